@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// commands are the maintenance subcommands of the server binary, run as
+// `server <command> [flags] [args]`. Without a command the binary serves HTTP.
+var commands = map[string]func(ctx context.Context, args []string) error{
+	"import": runImport,
+	"export": runExport,
+}
+
+// runCommand runs one subcommand against the configured database and exits.
+func runCommand(name string, args []string) {
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\nusage: server [import|export] ...\n", name)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := cmd(ctx, args); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		stop()
+		os.Exit(1)
+	}
+}
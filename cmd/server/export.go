@@ -0,0 +1,96 @@
+package main
+
+import (
+	"KaldalisCMS/internal/infra/exporter"
+	repository "KaldalisCMS/internal/infra/repository/postgres"
+	"KaldalisCMS/internal/router"
+	"KaldalisCMS/internal/service"
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// runExport implements `server export [-o FILE]`: it writes the whole site into a zip archive
+// (see docs/EXPORT_FORMAT.md). The archive is written to a temporary file that only takes
+// its final name once complete; "-o -" streams it to stdout instead.
+func runExport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	out := fs.String("o", "", "archive to write, or - for stdout (default: kaldalis-export-TIMESTAMP.zip)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: server export [-o FILE|-]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return errors.New("unexpected arguments")
+	}
+	if *out == "" {
+		*out = exporter.Filename(time.Now())
+	}
+
+	db, err := repository.InitDB(GetDatabaseDSN())
+	if err != nil {
+		return err
+	}
+	userRepo := repository.NewUserRepository(db)
+	systemService := service.NewSystemService(db, repository.NewSystemRepository(db), service.NewUserService(userRepo))
+	svc := service.NewExportService(
+		userRepo,
+		repository.NewCategoryRepository(db),
+		repository.NewTagRepository(db),
+		repository.NewSeriesRepository(db),
+		repository.NewPostRepository(db),
+		service.NewMediaService(repository.NewMediaRepository(db), router.MediaConfigFromEnv()),
+		systemService.ExportSettings,
+	)
+
+	if *out == "-" {
+		w := bufio.NewWriter(os.Stdout)
+		if _, err := svc.Export(ctx, exporter.NewZipWriter(w)); err != nil {
+			return err
+		}
+		return w.Flush()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(*out), ".export-*.zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	summary, err := svc.Export(ctx, exporter.NewZipWriter(tmp))
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), *out); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "wrote %s: %d users, %d categories, %d tags, %d posts, %d media files (%s)\n",
+		*out, summary.Users, summary.Categories, summary.Tags, summary.Posts, summary.MediaFiles, formatBytes(summary.MediaBytes))
+	return nil
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
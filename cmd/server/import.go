@@ -0,0 +1,132 @@
+package main
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/importer"
+	repository "KaldalisCMS/internal/infra/repository/postgres"
+	"KaldalisCMS/internal/router"
+	"KaldalisCMS/internal/service"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// runImport implements `server import [flags] FILE`: it imports a WordPress WXR export or a
+// zip of Markdown files and prints the report. It fails when any item failed, so scripts
+// can tell a partial import from a complete one; re-running it retries the failed items.
+func runImport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := fs.String("format", "", "export format, wxr or markdown (default: guessed from the file extension)")
+	dryRun := fs.Bool("dry-run", false, "report what would be imported without writing anything")
+	locale := fs.String("locale", "", "locale of posts that do not name one")
+	as := fs.String("as", "", "username of the user the import runs as (required)")
+	authorMap := make(map[string]string)
+	fs.Func("author", "map a source author to an existing user, as login=username (repeatable)", func(v string) error {
+		login, username, ok := strings.Cut(v, "=")
+		if !ok || login == "" || username == "" {
+			return errors.New("expected login=username")
+		}
+		authorMap[login] = username
+		return nil
+	})
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: server import -as USERNAME [-dry-run] [-format wxr|markdown] [-locale LOCALE] [-author login=username ...] FILE")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || *as == "" {
+		fs.Usage()
+		return errors.New("an export file and -as are required")
+	}
+	path := fs.Arg(0)
+
+	req := entity.ImportRequest{
+		Format:    entity.ImportFormat(*format),
+		DryRun:    *dryRun,
+		AuthorMap: authorMap,
+		Locale:    *locale,
+	}
+	if req.Format == "" {
+		req.Format = entity.ImportFormatFromFilename(path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	db, err := repository.InitDB(GetDatabaseDSN())
+	if err != nil {
+		return err
+	}
+	userRepo := repository.NewUserRepository(db)
+	actor, err := userRepo.GetByUsername(ctx, *as)
+	if err != nil {
+		return fmt.Errorf("look up user %q: %w", *as, err)
+	}
+	req.ActorUserID = actor.ID
+
+	svc := service.NewImportService(
+		importer.NewDecoder(nil),
+		userRepo,
+		repository.NewCategoryRepository(db),
+		repository.NewTagRepository(db),
+		repository.NewSeriesRepository(db),
+		repository.NewPostRepository(db),
+		service.NewMediaService(repository.NewMediaRepository(db), router.MediaConfigFromEnv()),
+	)
+	report, err := svc.Import(ctx, req, f, info.Size())
+	printImportReport(os.Stdout, report)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, item := range report.Items {
+		if item.Action == entity.ImportActionError {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d item(s) failed", failed)
+	}
+	return nil
+}
+
+func printImportReport(out *os.File, report entity.ImportReport) {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tACTION\tID\tSOURCE\tERROR")
+	for _, item := range report.Items {
+		id, msg := "-", ""
+		if item.ID != 0 {
+			id = fmt.Sprint(item.ID)
+		}
+		if item.Err != nil {
+			msg = item.Err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", item.Kind, item.Action, id, item.Source, msg)
+	}
+	_ = w.Flush()
+
+	if report.DryRun {
+		fmt.Fprintln(out, "\ndry run: nothing was written")
+	}
+	fmt.Fprintln(out)
+	for _, kind := range []entity.ImportItemKind{entity.ImportKindUser, entity.ImportKindCategory, entity.ImportKindTag, entity.ImportKindSeries, entity.ImportKindPost, entity.ImportKindMedia} {
+		fmt.Fprintf(out, "%-9s create=%d exists=%d error=%d\n", kind,
+			report.Count(kind, entity.ImportActionCreate),
+			report.Count(kind, entity.ImportActionExists),
+			report.Count(kind, entity.ImportActionError))
+	}
+}
@@ -5,10 +5,15 @@ import (
 	"KaldalisCMS/internal/infra/model"
 	repository "KaldalisCMS/internal/infra/repository/postgres"
 	"KaldalisCMS/internal/router"
+	"context"
 	"errors"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -24,10 +29,15 @@ import (
 // @in header
 // @name X-CSRF-Token
 
+// shutdownTimeout bounds draining in-flight requests and flushing buffered state on exit.
+const shutdownTimeout = 30 * time.Second
+
 // RouterManager acts as a dynamic proxy for the active http.Handler
 type RouterManager struct {
 	mu      sync.RWMutex
 	current http.Handler
+	// flush writes the active handler's buffered state; nil in setup mode.
+	flush func(ctx context.Context) error
 }
 
 func (rm *RouterManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -41,27 +51,65 @@ func (rm *RouterManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (rm *RouterManager) Switch(h http.Handler) {
+func (rm *RouterManager) Switch(h http.Handler, flush func(ctx context.Context) error) {
 	rm.mu.Lock()
 	rm.current = h
+	rm.flush = flush
 	rm.mu.Unlock()
 }
 
+// Flush writes the buffered state of the active handler.
+func (rm *RouterManager) Flush(ctx context.Context) error {
+	rm.mu.RLock()
+	flush := rm.flush
+	rm.mu.RUnlock()
+	if flush == nil {
+		return nil
+	}
+	return flush(ctx)
+}
+
 var routerManager = &RouterManager{}
 
 func main() {
 	// Initialize configuration
 	InitConfig()
 
+	// Maintenance commands (import, ...) run once and exit instead of serving.
+	if len(os.Args) > 1 {
+		runCommand(os.Args[1], os.Args[2:])
+		return
+	}
+
 	// Try to bootstrap the full application
 	if err := BootstrapApp(); err != nil {
 		log.Printf("系统启动检查未通过: %v. 切换到 [安装模式] (SETUP MODE).", err)
 		SwitchToSetupMode()
 	}
 
-	log.Println("服务器正在启动，监听端口: http://localhost:8080 ...")
-	if err := http.ListenAndServe(":8080", routerManager); err != nil {
-		log.Fatalf("服务器启动失败: %v", err)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{Addr: ":8080", Handler: routerManager}
+	go func() {
+		log.Println("服务器正在启动，监听端口: http://localhost:8080 ...")
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("服务器启动失败: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	log.Println("收到退出信号，正在关闭服务器...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("服务器关闭超时: %v", err)
+	}
+	// Requests have drained, so no view is recorded after this flush.
+	if err := routerManager.Flush(shutdownCtx); err != nil {
+		log.Printf("写入缓冲数据失败: %v", err)
 	}
 }
 
@@ -101,9 +149,9 @@ func BootstrapApp() error {
 	}
 
 	// --- 启动应用路由 ---
-	r := router.NewAppRouter(db, AppConfig.Auth, enforcer, swaggerOpts)
+	r, flush := router.NewAppRouter(db, AppConfig.Auth, enforcer, swaggerOpts)
 
-	routerManager.Switch(r)
+	routerManager.Switch(r, flush)
 	log.Printf("系统正常运行中 [业务模式] (APP MODE) - 站点名称: %s", setting.SiteName)
 	return nil
 }
@@ -131,6 +179,6 @@ func SwitchToSetupMode() {
 		swaggerOpts,
 	)
 
-	routerManager.Switch(r)
+	routerManager.Switch(r, nil)
 	log.Println("!!! 系统当前处于 [安装模式] (SETUP MODE) !!!")
 }
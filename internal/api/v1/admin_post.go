@@ -5,6 +5,7 @@ import (
 	"KaldalisCMS/internal/api/middleware"
 	"KaldalisCMS/internal/api/v1/dto"
 	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/service"
 	"context"
 	"errors"
 	"net/http"
@@ -22,10 +23,18 @@ import (
 // only needs to extract actor context and translate errors into responses.
 type AdminPostAPI struct {
 	service core.PostService
+	// requireIfMatch rejects post writes without If-Match.
+	requireIfMatch bool
 }
 
 func NewAdminPostAPI(service core.PostService) *AdminPostAPI {
-	return &AdminPostAPI{service: service}
+	return &AdminPostAPI{service: service, requireIfMatch: true}
+}
+
+// SetAllowUnconditionalWrites lets post writes omit If-Match, for clients that do not track
+// post versions yet. Such writes overwrite concurrent edits.
+func (api *AdminPostAPI) SetAllowUnconditionalWrites(allow bool) {
+	api.requireIfMatch = !allow
 }
 
 // GetPosts returns the management list for the current actor.
@@ -71,6 +80,7 @@ func (api *AdminPostAPI) GetPosts(c *gin.Context) {
 // @Produce json
 // @Param id path int true "post id"
 // @Success 200 {object} dto.PostResponse
+// @Header 200 {string} ETag "post version, send back as If-Match on writes"
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
@@ -104,6 +114,7 @@ func (api *AdminPostAPI) GetPostByID(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", postVersionETag(post.Version))
 	c.JSON(http.StatusOK, dto.ToPostResponse(&post))
 }
 
@@ -161,6 +172,7 @@ func (api *AdminPostAPI) CreatePost(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "post id"
+// @Param If-Match header string true "ETag from GET /admin/posts/{id}; the write is rejected with 409 if the post changed since"
 // @Param body body dto.UpdatePostRequest true "update post payload"
 // @Success 200 {object} dto.MessageResponse
 // @Failure 400 {object} dto.ErrorResponse
@@ -168,6 +180,7 @@ func (api *AdminPostAPI) CreatePost(c *gin.Context) {
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 409 {object} dto.ErrorResponse
+// @Failure 428 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Failure 504 {object} dto.ErrorResponse
 // @Security CookieAuth
@@ -184,6 +197,11 @@ func (api *AdminPostAPI) UpdatePost(c *gin.Context) {
 		return
 	}
 
+	ifVersion, ok := ifMatchVersion(c, api.requireIfMatch)
+	if !ok {
+		return
+	}
+
 	var req dto.UpdatePostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
@@ -193,7 +211,7 @@ func (api *AdminPostAPI) UpdatePost(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	if err := api.service.UpdateAdminPost(ctx, id, req.ToPatch(), actorUserID, actorRole); err != nil {
+	if err := api.service.UpdateAdminPost(ctx, id, req.ToPatch(), ifVersion, actorUserID, actorRole); err != nil {
 		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
 			errorx.RespondTimeoutError(c, "update post timed out")
 			return
@@ -211,12 +229,14 @@ func (api *AdminPostAPI) UpdatePost(c *gin.Context) {
 // @Tags admin-posts
 // @Produce json
 // @Param id path int true "post id"
+// @Param If-Match header string true "ETag from GET /admin/posts/{id}; the write is rejected with 409 if the post changed since"
 // @Success 200 {object} dto.MessageResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 409 {object} dto.ErrorResponse
+// @Failure 428 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Failure 504 {object} dto.ErrorResponse
 // @Security CookieAuth
@@ -233,10 +253,15 @@ func (api *AdminPostAPI) PublishPost(c *gin.Context) {
 		return
 	}
 
+	ifVersion, ok := ifMatchVersion(c, api.requireIfMatch)
+	if !ok {
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	if err := api.service.PublishAdminPost(ctx, id, actorUserID, actorRole); err != nil {
+	if err := api.service.PublishAdminPost(ctx, id, ifVersion, actorUserID, actorRole); err != nil {
 		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
 			errorx.RespondTimeoutError(c, "publish post timed out")
 			return
@@ -254,12 +279,14 @@ func (api *AdminPostAPI) PublishPost(c *gin.Context) {
 // @Tags admin-posts
 // @Produce json
 // @Param id path int true "post id"
+// @Param If-Match header string true "ETag from GET /admin/posts/{id}; the write is rejected with 409 if the post changed since"
 // @Success 200 {object} dto.MessageResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 409 {object} dto.ErrorResponse
+// @Failure 428 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Failure 504 {object} dto.ErrorResponse
 // @Security CookieAuth
@@ -276,10 +303,15 @@ func (api *AdminPostAPI) DraftPost(c *gin.Context) {
 		return
 	}
 
+	ifVersion, ok := ifMatchVersion(c, api.requireIfMatch)
+	if !ok {
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	if err := api.service.MovePostToDraft(ctx, id, actorUserID, actorRole); err != nil {
+	if err := api.service.MovePostToDraft(ctx, id, ifVersion, actorUserID, actorRole); err != nil {
 		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
 			errorx.RespondTimeoutError(c, "move post to draft timed out")
 			return
@@ -291,18 +323,79 @@ func (api *AdminPostAPI) DraftPost(c *gin.Context) {
 	errorx.RespondMessage(c, http.StatusOK, "post moved to draft successfully")
 }
 
+// SchedulePost sets a future publish time and/or an automatic unpublish time.
+// A pending publish time moves the post to Scheduled; the background scheduler flips it later.
+// @Summary Schedule post
+// @Description Schedule publication and/or automatic expiry of a post.
+// @Tags admin-posts
+// @Accept json
+// @Produce json
+// @Param id path int true "post id"
+// @Param If-Match header string true "ETag from GET /admin/posts/{id}; the write is rejected with 409 if the post changed since"
+// @Param body body dto.SchedulePostRequest true "schedule payload"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 428 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/schedule [post]
+func (api *AdminPostAPI) SchedulePost(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ifVersion, ok := ifMatchVersion(c, api.requireIfMatch)
+	if !ok {
+		return
+	}
+
+	var req dto.SchedulePostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := api.service.ScheduleAdminPost(ctx, id, req.ToSchedule(), ifVersion, actorUserID, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "schedule post timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "post scheduled successfully")
+}
+
 // DeletePost removes a post from the system.
 // @Summary Delete post
-// @Description Permanently delete one post under admin workflow authorization.
+// @Description Move one post to the trash under admin workflow authorization.
 // @Tags admin-posts
 // @Produce json
 // @Param id path int true "post id"
+// @Param If-Match header string true "ETag from GET /admin/posts/{id}; the write is rejected with 409 if the post changed since"
 // @Success 200 {object} dto.MessageResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 409 {object} dto.ErrorResponse
+// @Failure 428 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Failure 504 {object} dto.ErrorResponse
 // @Security CookieAuth
@@ -319,10 +412,15 @@ func (api *AdminPostAPI) DeletePost(c *gin.Context) {
 		return
 	}
 
+	ifVersion, ok := ifMatchVersion(c, api.requireIfMatch)
+	if !ok {
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
 	defer cancel()
 
-	if err := api.service.DeleteAdminPost(ctx, id, actorUserID, actorRole); err != nil {
+	if err := api.service.DeleteAdminPost(ctx, id, ifVersion, actorUserID, actorRole); err != nil {
 		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
 			errorx.RespondTimeoutError(c, "delete post timed out")
 			return
@@ -351,6 +449,11 @@ func getPostActor(c *gin.Context) (uint, string, bool) {
 }
 
 func respondPostWorkflowError(c *gin.Context, err error, defaultStatus int) {
+	var stale *service.PostVersionConflictError
+	if errors.As(err, &stale) {
+		errorx.RespondError(c, http.StatusConflict, core.CodeConflict, "post was changed by someone else", map[string]any{"resource": "post", "current_version": stale.CurrentVersion})
+		return
+	}
 	status := defaultStatus
 	if errors.Is(err, core.ErrInternalError) {
 		status = http.StatusInternalServerError
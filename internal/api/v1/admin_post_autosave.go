@@ -0,0 +1,199 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SavePostAutosave stores the current user's unsaved changes to a post.
+// @Summary Autosave post
+// @Description Stores unsaved title and content for the current user without modifying the post.
+// @Tags admin-posts
+// @Accept json
+// @Produce json
+// @Param id path int true "post id"
+// @Param body body dto.SavePostAutosaveRequest true "autosave payload"
+// @Success 200 {object} dto.PostAutosaveResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/autosave [put]
+func (api *AdminPostAPI) SavePostAutosave(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SavePostAutosaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	saved, err := api.service.SaveAdminPostAutosave(ctx, id, req.ToEntity(), actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "autosave post timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPostAutosaveResponse(saved))
+}
+
+// GetPostAutosave returns the current user's latest autosave of a post.
+// @Summary Get post autosave
+// @Description Returns the current user's unsaved changes to a post, or 404 when there are none.
+// @Tags admin-posts
+// @Produce json
+// @Param id path int true "post id"
+// @Success 200 {object} dto.PostAutosaveResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/autosave [get]
+func (api *AdminPostAPI) GetPostAutosave(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	autosave, err := api.service.GetAdminPostAutosave(ctx, id, actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "get post autosave timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPostAutosaveResponse(autosave))
+}
+
+// PromotePostAutosave applies the current user's autosave to the post as a regular update.
+// @Summary Promote post autosave
+// @Description Saves the autosaved title and content into the post and removes the autosave.
+// @Tags admin-posts
+// @Produce json
+// @Param id path int true "post id"
+// @Param If-Match header string true "ETag from GET /admin/posts/{id}; the write is rejected with 409 if the post changed since"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 428 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/autosave/promote [post]
+func (api *AdminPostAPI) PromotePostAutosave(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ifVersion, ok := ifMatchVersion(c, api.requireIfMatch)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := api.service.PromoteAdminPostAutosave(ctx, id, ifVersion, actorUserID, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "promote post autosave timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "updated")
+}
+
+// DiscardPostAutosave drops the current user's autosave of a post.
+// @Summary Discard post autosave
+// @Description Removes the current user's unsaved changes to a post.
+// @Tags admin-posts
+// @Produce json
+// @Param id path int true "post id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/autosave [delete]
+func (api *AdminPostAPI) DiscardPostAutosave(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := api.service.DiscardAdminPostAutosave(ctx, id, actorUserID, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "discard post autosave timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "discarded")
+}
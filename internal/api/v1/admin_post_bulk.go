@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BulkPosts applies one action to many posts.
+// Item failures do not fail the request: the response is 200 with each post's outcome.
+// @Summary Bulk post actions
+// @Description Publishes, unpublishes, deletes, retags or recategorizes many posts. Each post is checked like the single-post endpoint; with atomic set, one failure rolls back all posts.
+// @Tags admin-posts
+// @Accept json
+// @Produce json
+// @Param body body dto.BulkPostsRequest true "bulk action payload"
+// @Success 200 {object} dto.BulkPostsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/bulk [post]
+func (api *AdminPostAPI) BulkPosts(c *gin.Context) {
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	var req dto.BulkPostsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	result, err := api.service.BulkAdminPosts(ctx, req.ToEntity(), actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "bulk post action timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToBulkPostsResponse(result))
+}
@@ -0,0 +1,155 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePostPreview mints a shareable preview link for a post.
+// @Summary Create post preview link
+// @Description Mints a signed, time-limited link that shows the post read-only to anyone holding it. The token is only returned here.
+// @Tags admin-posts
+// @Accept json
+// @Produce json
+// @Param id path int true "post id"
+// @Param body body dto.CreatePostPreviewRequest false "preview link lifetime"
+// @Success 201 {object} dto.PostPreviewLinkResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/previews [post]
+func (api *AdminPostAPI) CreatePostPreview(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	// The body is optional: an empty request mints a link with the default lifetime.
+	var req dto.CreatePostPreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	record, token, err := api.service.CreatePreviewLink(ctx, id, req.TTL(), actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "create post preview timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToPostPreviewLinkResponse(record, token, time.Now()))
+}
+
+// GetPostPreviews lists the preview links minted for a post.
+// @Summary List post preview links
+// @Description Lists active, expired and revoked preview links of a post. Tokens are not included.
+// @Tags admin-posts
+// @Produce json
+// @Param id path int true "post id"
+// @Success 200 {array} dto.PostPreviewLinkResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Router /admin/posts/{id}/previews [get]
+func (api *AdminPostAPI) GetPostPreviews(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	tokens, err := api.service.ListPreviewLinks(ctx, id, actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list post previews timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPostPreviewLinkResponses(tokens, time.Now()))
+}
+
+// RevokePostPreview invalidates a preview link before it expires.
+// @Summary Revoke post preview link
+// @Tags admin-posts
+// @Produce json
+// @Param id path int true "post id"
+// @Param preview path int true "preview link id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/previews/{preview} [delete]
+func (api *AdminPostAPI) RevokePostPreview(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+	previewID, err := strconv.ParseUint(c.Param("preview"), 10, 32)
+	if err != nil || previewID == 0 {
+		errorx.RespondValidationError(c, "invalid preview id", map[string]any{"field": "preview"})
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := api.service.RevokePreviewLink(ctx, id, uint(previewID), actorUserID, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "revoke post preview timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "revoked")
+}
@@ -0,0 +1,198 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SubmitPostForReview hands the actor's own draft to the editors.
+// @Summary Submit draft for review
+// @Description Moves one of the actor's drafts to pending review; the author cannot edit it until it is approved or rejected.
+// @Tags admin-posts
+// @Produce json
+// @Param id path int true "post id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/submit [post]
+func (api *AdminPostAPI) SubmitPostForReview(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := api.service.SubmitAdminPostForReview(ctx, id, actorUserID, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "submit post for review timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "post submitted for review")
+}
+
+// GetReviewQueue lists every post awaiting review, longest-waiting first.
+// @Summary List review queue
+// @Description Returns posts in pending review status for editors.
+// @Tags admin-posts
+// @Produce json
+// @Success 200 {array} dto.PostResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/review [get]
+func (api *AdminPostAPI) GetReviewQueue(c *gin.Context) {
+	_, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	posts, err := api.service.ListReviewQueue(ctx, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list review queue timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPostListResponse(posts))
+}
+
+// ApprovePost publishes a post that is awaiting review.
+// @Summary Approve post
+// @Description Publishes one post in pending review status.
+// @Tags admin-posts
+// @Produce json
+// @Param id path int true "post id"
+// @Param If-Match header string true "ETag from GET /admin/posts/{id}; the write is rejected with 409 if the post changed since"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 428 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/approve [post]
+func (api *AdminPostAPI) ApprovePost(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ifVersion, ok := ifMatchVersion(c, api.requireIfMatch)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := api.service.ApproveAdminPost(ctx, id, ifVersion, actorUserID, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "approve post timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "post approved and published")
+}
+
+// RejectPost returns a post awaiting review to its author as a draft with a reason.
+// @Summary Reject post
+// @Description Moves one post in pending review back to draft and records the rejection reason.
+// @Tags admin-posts
+// @Accept json
+// @Produce json
+// @Param id path int true "post id"
+// @Param If-Match header string true "ETag from GET /admin/posts/{id}; the write is rejected with 409 if the post changed since"
+// @Param body body dto.RejectPostRequest true "rejection reason"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 428 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/reject [post]
+func (api *AdminPostAPI) RejectPost(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ifVersion, ok := ifMatchVersion(c, api.requireIfMatch)
+	if !ok {
+		return
+	}
+
+	var req dto.RejectPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := api.service.RejectAdminPost(ctx, id, req.Reason, ifVersion, actorUserID, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "reject post timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "post rejected and returned to draft")
+}
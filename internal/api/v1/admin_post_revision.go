@@ -0,0 +1,222 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPostRevisions returns the revision history of a manageable post, newest first.
+// @Summary List post revisions
+// @Description Returns revision summaries for one post visible to the current actor.
+// @Tags admin-posts
+// @Produce json
+// @Param id path int true "post id"
+// @Success 200 {array} dto.PostRevisionSummaryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/revisions [get]
+func (api *AdminPostAPI) GetPostRevisions(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	revisions, err := api.service.ListAdminPostRevisions(ctx, id, actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list post revisions timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPostRevisionListResponse(revisions))
+}
+
+// GetPostRevision returns one full revision snapshot.
+// @Summary Get post revision
+// @Description Returns one revision snapshot including content.
+// @Tags admin-posts
+// @Produce json
+// @Param id path int true "post id"
+// @Param rev path int true "revision id"
+// @Success 200 {object} dto.PostRevisionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/revisions/{rev} [get]
+func (api *AdminPostAPI) GetPostRevision(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+	revID, ok := parseRevisionID(c, c.Param("rev"), "rev")
+	if !ok {
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	revision, err := api.service.GetAdminPostRevision(ctx, id, revID, actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "get post revision timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPostRevisionResponse(revision))
+}
+
+// DiffPostRevisions compares two revisions of the same post.
+// @Summary Diff post revisions
+// @Description Returns changed fields and a line-level content diff between two revisions.
+// @Tags admin-posts
+// @Produce json
+// @Param id path int true "post id"
+// @Param from query int true "older revision id"
+// @Param to query int true "newer revision id"
+// @Success 200 {object} dto.PostRevisionDiffResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/revisions/diff [get]
+func (api *AdminPostAPI) DiffPostRevisions(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+	fromID, ok := parseRevisionID(c, c.Query("from"), "from")
+	if !ok {
+		return
+	}
+	toID, ok := parseRevisionID(c, c.Query("to"), "to")
+	if !ok {
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	diff, err := api.service.DiffAdminPostRevisions(ctx, id, fromID, toID, actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "diff post revisions timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPostRevisionDiffResponse(diff))
+}
+
+// RestorePostRevision re-applies a revision's content to the post.
+// Status is not restored; the restore is recorded as a new revision.
+// @Summary Restore post revision
+// @Description Re-apply the editable fields of a revision to the post.
+// @Tags admin-posts
+// @Produce json
+// @Param id path int true "post id"
+// @Param rev path int true "revision id"
+// @Param If-Match header string true "ETag from GET /admin/posts/{id}; the write is rejected with 409 if the post changed since"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 428 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/revisions/{rev}/restore [post]
+func (api *AdminPostAPI) RestorePostRevision(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+	revID, ok := parseRevisionID(c, c.Param("rev"), "rev")
+	if !ok {
+		return
+	}
+
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ifVersion, ok := ifMatchVersion(c, api.requireIfMatch)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := api.service.RestoreAdminPostRevision(ctx, id, revID, ifVersion, actorUserID, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "restore post revision timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "post revision restored successfully")
+}
+
+func parseRevisionID(c *gin.Context, raw string, field string) (uint, bool) {
+	id64, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil || id64 == 0 {
+		errorx.RespondValidationError(c, "invalid revision id", map[string]any{"field": field})
+		return 0, false
+	}
+	return uint(id64), true
+}
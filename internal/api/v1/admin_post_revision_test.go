@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+func TestAdminPostAPI_DiffPostRevisions_Success(t *testing.T) {
+	svc := &fakePostService{
+		diffRevisionsFn: func(ctx context.Context, postID, fromID, toID uint, uid uint, role string) (entity.PostRevisionDiff, error) {
+			if postID != 3 || fromID != 1 || toID != 2 {
+				t.Fatalf("ids not propagated: post=%d from=%d to=%d", postID, fromID, toID)
+			}
+			return entity.PostRevisionDiff{
+				From:    entity.PostRevision{ID: 1},
+				To:      entity.PostRevision{ID: 2},
+				Fields:  []entity.RevisionFieldChange{{Field: "title", From: "a", To: "b"}},
+				Content: []entity.DiffLine{{Op: entity.DiffOpInsert, Text: "x"}},
+			}, nil
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+	w := doJSON(r, http.MethodGet, "/admin/posts/3/revisions/diff?from=1&to=2", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got dto.PostRevisionDiffResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.To.ID != 2 || len(got.Fields) != 1 || got.Content[0].Op != "insert" {
+		t.Fatalf("unexpected body: %+v", got)
+	}
+}
+
+func TestAdminPostAPI_DiffPostRevisions_MissingQuery(t *testing.T) {
+	svc := &fakePostService{} // service must not be called on invalid ids
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+	w := doJSON(r, http.MethodGet, "/admin/posts/3/revisions/diff?from=1", nil)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestAdminPostAPI_RestorePostRevision_NotFound(t *testing.T) {
+	svc := &fakePostService{
+		restoreRevisionFn: func(ctx context.Context, postID, revID uint, ifVersion uint, uid uint, role string) error {
+			return core.ErrNotFound
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+	w := doJSONIfMatch(r, http.MethodPost, "/admin/posts/3/revisions/7/restore", `"v2"`, nil)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+}
@@ -10,10 +10,12 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"KaldalisCMS/internal/api/v1/dto"
 	"KaldalisCMS/internal/core"
 	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
@@ -43,12 +45,31 @@ func newAdminRouter(svc core.PostService, actor gin.HandlerFunc) *gin.Engine {
 	grp := r.Group("/admin/posts")
 	grp.Use(actor)
 	grp.GET("", api.GetPosts)
+	grp.GET("/review", api.GetReviewQueue)
+	grp.GET("/trash", api.GetTrashedPosts)
 	grp.GET("/:id", api.GetPostByID)
 	grp.POST("", api.CreatePost)
+	grp.POST("/bulk", api.BulkPosts)
 	grp.PUT("/:id", api.UpdatePost)
 	grp.DELETE("/:id", api.DeletePost)
+	grp.POST("/:id/restore", api.RestorePost)
+	grp.DELETE("/:id/purge", api.PurgePost)
+	grp.POST("/:id/translations", api.LinkPostTranslation)
+	grp.DELETE("/:id/translations", api.UnlinkPostTranslation)
 	grp.POST("/:id/publish", api.PublishPost)
 	grp.POST("/:id/draft", api.DraftPost)
+	grp.POST("/:id/schedule", api.SchedulePost)
+	grp.POST("/:id/submit", api.SubmitPostForReview)
+	grp.POST("/:id/approve", api.ApprovePost)
+	grp.POST("/:id/reject", api.RejectPost)
+	grp.GET("/:id/revisions", api.GetPostRevisions)
+	grp.GET("/:id/revisions/diff", api.DiffPostRevisions)
+	grp.GET("/:id/revisions/:rev", api.GetPostRevision)
+	grp.POST("/:id/revisions/:rev/restore", api.RestorePostRevision)
+	grp.GET("/:id/autosave", api.GetPostAutosave)
+	grp.PUT("/:id/autosave", api.SavePostAutosave)
+	grp.DELETE("/:id/autosave", api.DiscardPostAutosave)
+	grp.POST("/:id/autosave/promote", api.PromotePostAutosave)
 	return r
 }
 
@@ -67,6 +88,17 @@ func doJSON(r *gin.Engine, method, path string, body any) *httptest.ResponseReco
 	return w
 }
 
+// doJSONIfMatch is doJSON for the post writes that require an If-Match header.
+func doJSONIfMatch(r *gin.Engine, method, path string, ifMatch string, body any) *httptest.ResponseRecorder {
+	b, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, path, bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", ifMatch)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
 func TestAdminPostAPI_Unauthenticated(t *testing.T) {
 	// No actor injected → handler must return 401 without reaching the service.
 	svc := &fakePostService{} // any service call would nil-panic, proving the guard
@@ -161,12 +193,12 @@ func TestAdminPostAPI_CreatePost_Success(t *testing.T) {
 func TestAdminPostAPI_PublishPost_ConflictMapping(t *testing.T) {
 	// Service returns a wrapped ErrConflict → handler must map to 409.
 	svc := &fakePostService{
-		publishAdminFn: func(ctx context.Context, id uint, uid uint, role string) error {
+		publishAdminFn: func(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error {
 			return fmt.Errorf("publish: %w", core.ErrConflict)
 		},
 	}
 	r := newAdminRouter(svc, injectActor(9, "admin"))
-	w := doJSON(r, http.MethodPost, "/admin/posts/1/publish", nil)
+	w := doJSONIfMatch(r, http.MethodPost, "/admin/posts/1/publish", `"v1"`, nil)
 	if w.Code != http.StatusConflict {
 		t.Fatalf("status: %d", w.Code)
 	}
@@ -179,12 +211,12 @@ func TestAdminPostAPI_PublishPost_ConflictMapping(t *testing.T) {
 
 func TestAdminPostAPI_DeletePost_ForbiddenMapping(t *testing.T) {
 	svc := &fakePostService{
-		deleteAdminFn: func(ctx context.Context, id uint, uid uint, role string) error {
+		deleteAdminFn: func(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error {
 			return core.ErrPermission
 		},
 	}
 	r := newAdminRouter(svc, injectActor(9, "editor"))
-	w := doJSON(r, http.MethodDelete, "/admin/posts/5", nil)
+	w := doJSONIfMatch(r, http.MethodDelete, "/admin/posts/5", `"v1"`, nil)
 	if w.Code != http.StatusForbidden {
 		t.Fatalf("status: %d", w.Code)
 	}
@@ -192,15 +224,15 @@ func TestAdminPostAPI_DeletePost_ForbiddenMapping(t *testing.T) {
 
 func TestAdminPostAPI_DeletePost_Success(t *testing.T) {
 	svc := &fakePostService{
-		deleteAdminFn: func(ctx context.Context, id uint, uid uint, role string) error {
-			if id != 5 {
-				t.Fatalf("id not parsed: %d", id)
+		deleteAdminFn: func(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error {
+			if id != 5 || ifVersion != 3 {
+				t.Fatalf("id or If-Match not parsed: %d %d", id, ifVersion)
 			}
 			return nil
 		},
 	}
 	r := newAdminRouter(svc, injectActor(9, "admin"))
-	w := doJSON(r, http.MethodDelete, "/admin/posts/5", nil)
+	w := doJSONIfMatch(r, http.MethodDelete, "/admin/posts/5", `"v3"`, nil)
 	if w.Code != http.StatusOK {
 		t.Fatalf("status: %d", w.Code)
 	}
@@ -213,12 +245,12 @@ func TestAdminPostAPI_DeletePost_Success(t *testing.T) {
 
 func TestAdminPostAPI_DraftPost_Success(t *testing.T) {
 	svc := &fakePostService{
-		moveToDraftAdminFn: func(ctx context.Context, id uint, uid uint, role string) error {
+		moveToDraftAdminFn: func(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error {
 			return nil
 		},
 	}
 	r := newAdminRouter(svc, injectActor(9, "admin"))
-	w := doJSON(r, http.MethodPost, "/admin/posts/1/draft", nil)
+	w := doJSONIfMatch(r, http.MethodPost, "/admin/posts/1/draft", `"v1"`, nil)
 	if w.Code != http.StatusOK {
 		t.Fatalf("status: %d", w.Code)
 	}
@@ -227,14 +259,14 @@ func TestAdminPostAPI_DraftPost_Success(t *testing.T) {
 func TestAdminPostAPI_UpdatePost_PatchApplied(t *testing.T) {
 	var capturedPatch entity.PostPatch
 	svc := &fakePostService{
-		updateAdminFn: func(ctx context.Context, id uint, patch entity.PostPatch, uid uint, role string) error {
+		updateAdminFn: func(ctx context.Context, id uint, patch entity.PostPatch, ifVersion uint, uid uint, role string) error {
 			capturedPatch = patch
 			return nil
 		},
 	}
 	r := newAdminRouter(svc, injectActor(9, "admin"))
 
-	w := doJSON(r, http.MethodPut, "/admin/posts/3", dto.UpdatePostRequest{
+	w := doJSONIfMatch(r, http.MethodPut, "/admin/posts/3", `"v1"`, dto.UpdatePostRequest{
 		Title: strPtr("new title"),
 		Tags:  []uint{4, 5},
 	})
@@ -284,3 +316,381 @@ func TestAdminPostAPI_InternalErrorMapping(t *testing.T) {
 }
 
 func strPtr(s string) *string { return &s }
+
+func TestAdminPostAPI_SchedulePost_Success(t *testing.T) {
+	var got entity.PostSchedule
+	svc := &fakePostService{
+		scheduleAdminFn: func(ctx context.Context, id uint, schedule entity.PostSchedule, ifVersion uint, uid uint, role string) error {
+			got = schedule
+			return nil
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+	w := doJSONIfMatch(r, http.MethodPost, "/admin/posts/1/schedule", `"v1"`, map[string]any{"publish_at": "2030-01-02T03:04:05Z"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	if got.PublishAt == nil || got.PublishAt.Year() != 2030 || got.UnpublishAt != nil {
+		t.Fatalf("schedule not propagated: %+v", got)
+	}
+}
+
+func TestAdminPostAPI_SchedulePost_InvalidTime(t *testing.T) {
+	svc := &fakePostService{} // service must not be called on binding failure
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+	w := doJSONIfMatch(r, http.MethodPost, "/admin/posts/1/schedule", `"v1"`, map[string]any{"publish_at": "tomorrow"})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestAdminPostAPI_SubmitPostForReview_UnderReviewMapping(t *testing.T) {
+	svc := &fakePostService{
+		submitReviewFn: func(ctx context.Context, id uint, uid uint, role string) error {
+			return fmt.Errorf("%w: post is under review", core.ErrConflict)
+		},
+	}
+	r := newAdminRouter(svc, injectActor(5, "user"))
+	w := doJSON(r, http.MethodPost, "/admin/posts/1/submit", nil)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status: %d", w.Code)
+	}
+}
+
+func TestAdminPostAPI_GetReviewQueue(t *testing.T) {
+	svc := &fakePostService{
+		listReviewQueueFn: func(ctx context.Context, role string) ([]entity.Post, error) {
+			return []entity.Post{{ID: 3, Status: entity.StatusPendingReview}}, nil
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+	w := doJSON(r, http.MethodGet, "/admin/posts/review", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got []dto.PostResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if len(got) != 1 || got[0].ID != 3 || got[0].Status != entity.StatusPendingReview {
+		t.Fatalf("queue: %+v", got)
+	}
+}
+
+func TestAdminPostAPI_ApprovePost_Success(t *testing.T) {
+	svc := &fakePostService{
+		approveAdminFn: func(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error {
+			if id != 4 || ifVersion != 2 {
+				t.Fatalf("id or If-Match not parsed: %d %d", id, ifVersion)
+			}
+			return nil
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+	w := doJSONIfMatch(r, http.MethodPost, "/admin/posts/4/approve", `"v2"`, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d", w.Code)
+	}
+}
+
+func TestAdminPostAPI_RejectPost(t *testing.T) {
+	var reason string
+	svc := &fakePostService{
+		rejectAdminFn: func(ctx context.Context, id uint, r string, ifVersion uint, uid uint, role string) error {
+			reason = r
+			return nil
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+
+	w := doJSONIfMatch(r, http.MethodPost, "/admin/posts/4/reject", `"v2"`, map[string]any{})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("missing reason: status %d", w.Code)
+	}
+	w = doJSONIfMatch(r, http.MethodPost, "/admin/posts/4/reject", `"v2"`, map[string]any{"reason": "needs sources"})
+	if w.Code != http.StatusOK || reason != "needs sources" {
+		t.Fatalf("status: %d reason=%q", w.Code, reason)
+	}
+}
+
+func TestAdminPostAPI_GetTrashedPosts(t *testing.T) {
+	deletedAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	svc := &fakePostService{
+		listTrashedFn: func(ctx context.Context, role string) ([]entity.Post, error) {
+			return []entity.Post{{ID: 2, DeletedAt: &deletedAt}}, nil
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+	w := doJSON(r, http.MethodGet, "/admin/posts/trash", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got []dto.PostResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if len(got) != 1 || got[0].DeletedAt != "2026-03-01T00:00:00Z" {
+		t.Fatalf("trash: %+v", got)
+	}
+}
+
+func TestAdminPostAPI_RestorePost_NotFoundMapping(t *testing.T) {
+	svc := &fakePostService{
+		restoreAdminFn: func(ctx context.Context, id uint, role string) error {
+			return core.ErrNotFound
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+	w := doJSON(r, http.MethodPost, "/admin/posts/2/restore", nil)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status: %d", w.Code)
+	}
+}
+
+func TestAdminPostAPI_PurgePost_Success(t *testing.T) {
+	var purged uint
+	svc := &fakePostService{
+		purgeAdminFn: func(ctx context.Context, id uint, role string) error {
+			purged = id
+			return nil
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+	w := doJSON(r, http.MethodDelete, "/admin/posts/2/purge", nil)
+	if w.Code != http.StatusOK || purged != 2 {
+		t.Fatalf("status: %d purged=%d", w.Code, purged)
+	}
+}
+
+func TestAdminPostAPI_LinkPostTranslation(t *testing.T) {
+	svc := &fakePostService{
+		linkTranslationFn: func(ctx context.Context, id uint, translationID uint, role string) error {
+			if id != 1 || translationID != 2 {
+				t.Fatalf("unexpected args: %d %d", id, translationID)
+			}
+			return fmt.Errorf("%w: translation group already has a en post (ID 3)", core.ErrConflict)
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+	w := doJSON(r, http.MethodPost, "/admin/posts/1/translations", map[string]any{"post_id": 2})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+
+	w = doJSON(r, http.MethodPost, "/admin/posts/1/translations", map[string]any{})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("missing post_id: status %d", w.Code)
+	}
+}
+
+func TestAdminPostAPI_GetPostByID_SetsETag(t *testing.T) {
+	svc := &fakePostService{
+		getAdminByIDFn: func(ctx context.Context, id uint, uid uint, role string) (entity.Post, error) {
+			return entity.Post{ID: id, Version: 4}, nil
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+	w := doJSON(r, http.MethodGet, "/admin/posts/1", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d", w.Code)
+	}
+	if got := w.Header().Get("ETag"); got != `"v4"` {
+		t.Fatalf("ETag: %q", got)
+	}
+}
+
+func TestAdminPostAPI_UpdatePost_IfMatch(t *testing.T) {
+	var gotVersion uint
+	svc := &fakePostService{
+		updateAdminFn: func(ctx context.Context, id uint, patch entity.PostPatch, ifVersion uint, uid uint, role string) error {
+			gotVersion = ifVersion
+			if ifVersion != 4 {
+				return &service.PostVersionConflictError{CurrentVersion: 4}
+			}
+			return nil
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+	put := func(ifMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/admin/posts/1", strings.NewReader(`{"title":"t"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", ifMatch)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := put(`"v4"`); w.Code != http.StatusOK || gotVersion != 4 {
+		t.Fatalf("matching version: status=%d version=%d", w.Code, gotVersion)
+	}
+
+	w := put(`"v3"`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("stale version: status=%d body=%s", w.Code, w.Body.String())
+	}
+	var got dto.ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if got.Code != string(core.CodeConflict) || got.Details["current_version"] != float64(4) {
+		t.Fatalf("conflict body: %+v", got)
+	}
+
+	gotVersion = 0
+	if w := put("garbage"); w.Code != http.StatusBadRequest || gotVersion != 0 {
+		t.Fatalf("malformed If-Match: status=%d, service reached=%v", w.Code, gotVersion != 0)
+	}
+}
+
+func TestAdminPostAPI_WritesRequireIfMatch(t *testing.T) {
+	reached := false
+	svc := &fakePostService{
+		updateAdminFn: func(ctx context.Context, id uint, patch entity.PostPatch, ifVersion uint, uid uint, role string) error {
+			reached = true
+			return nil
+		},
+		publishAdminFn: func(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error {
+			reached = true
+			return nil
+		},
+		moveToDraftAdminFn: func(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error {
+			reached = true
+			return nil
+		},
+		promoteAutosaveFn: func(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error {
+			reached = true
+			return nil
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+	writes := []struct{ method, path string }{
+		{http.MethodPut, "/admin/posts/1"},
+		{http.MethodPost, "/admin/posts/1/publish"},
+		{http.MethodPost, "/admin/posts/1/draft"},
+		{http.MethodPost, "/admin/posts/1/autosave/promote"},
+		{http.MethodPost, "/admin/posts/1/schedule"},
+		{http.MethodDelete, "/admin/posts/1"},
+		{http.MethodPost, "/admin/posts/1/approve"},
+		{http.MethodPost, "/admin/posts/1/reject"},
+		{http.MethodPost, "/admin/posts/1/revisions/2/restore"},
+	}
+	for _, write := range writes {
+		w := doJSON(r, write.method, write.path, map[string]any{"title": "t"})
+		var got dto.ErrorResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &got)
+		if w.Code != http.StatusPreconditionRequired || got.Code != string(core.CodePreconditionRequired) || reached {
+			t.Fatalf("%s %s without If-Match: status=%d code=%s service reached=%v", write.method, write.path, w.Code, got.Code, reached)
+		}
+	}
+
+	// Opting out restores unconditional writes.
+	api := NewAdminPostAPI(svc)
+	api.SetAllowUnconditionalWrites(true)
+	open := gin.New()
+	open.Use(injectActor(9, "admin"))
+	open.POST("/admin/posts/:id/publish", api.PublishPost)
+	if w := doJSON(open, http.MethodPost, "/admin/posts/1/publish", nil); w.Code != http.StatusOK || !reached {
+		t.Fatalf("unconditional publish: status=%d service reached=%v", w.Code, reached)
+	}
+}
+
+func TestAdminPostAPI_SavePostAutosave(t *testing.T) {
+	var got entity.PostAutosave
+	svc := &fakePostService{
+		saveAutosaveFn: func(ctx context.Context, id uint, autosave entity.PostAutosave, uid uint, role string) (entity.PostAutosave, error) {
+			got = autosave
+			autosave.PostID, autosave.UserID = id, uid
+			autosave.UpdatedAt = time.Now()
+			return autosave, nil
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "user"))
+
+	w := doJSON(r, http.MethodPut, "/admin/posts/3/autosave", dto.SavePostAutosaveRequest{Content: "half a thought", BaseVersion: 2})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	if got.Content != "half a thought" || got.Title != "" || got.BaseVersion != 2 {
+		t.Fatalf("autosave not passed through: %+v", got)
+	}
+	var resp dto.PostAutosaveResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.PostID != 3 {
+		t.Fatalf("response: %+v %v", resp, err)
+	}
+}
+
+func TestAdminPostAPI_GetPostAutosave_NotFound(t *testing.T) {
+	svc := &fakePostService{
+		getAutosaveFn: func(ctx context.Context, id uint, uid uint, role string) (entity.PostAutosave, error) {
+			return entity.PostAutosave{}, core.ErrNotFound
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "user"))
+	if w := doJSON(r, http.MethodGet, "/admin/posts/3/autosave", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("status: %d", w.Code)
+	}
+}
+
+func TestAdminPostAPI_PromotePostAutosave_PassesIfMatch(t *testing.T) {
+	var gotVersion uint
+	svc := &fakePostService{
+		promoteAutosaveFn: func(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error {
+			gotVersion = ifVersion
+			return nil
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "user"))
+	req := httptest.NewRequest(http.MethodPost, "/admin/posts/3/autosave/promote", nil)
+	req.Header.Set("If-Match", `"v6"`)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || gotVersion != 6 {
+		t.Fatalf("status=%d version=%d", w.Code, gotVersion)
+	}
+}
+
+func TestAdminPostAPI_BulkPosts(t *testing.T) {
+	var got entity.PostBulkRequest
+	svc := &fakePostService{
+		bulkAdminFn: func(ctx context.Context, req entity.PostBulkRequest, uid uint, role string) (entity.PostBulkResult, error) {
+			got = req
+			return entity.PostBulkResult{Action: req.Action, Items: []entity.PostBulkItemResult{
+				{PostID: 1, Outcome: entity.PostBulkApplied},
+				{PostID: 2, Outcome: entity.PostBulkFailed, Err: fmt.Errorf("wrapped: %w", core.ErrPermission)},
+			}}, nil
+		},
+	}
+	r := newAdminRouter(svc, injectActor(9, "admin"))
+
+	w := doJSON(r, http.MethodPost, "/admin/posts/bulk", map[string]any{
+		"action": "retag", "post_ids": []uint{1, 2}, "tags": []uint{}, "tag_names": []string{"go"},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	if got.Action != entity.PostBulkRetag || len(got.PostIDs) != 2 || len(got.Tags) != 1 || got.Tags[0].Name != "go" {
+		t.Fatalf("unexpected request: %+v", got)
+	}
+	var resp dto.BulkPostsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Applied != 1 || resp.Failed != 1 || resp.Items[1].ErrorCode != core.CodeForbidden || resp.Items[0].ErrorCode != "" {
+		t.Fatalf("unexpected response: %s", w.Body.String())
+	}
+
+	w = doJSON(r, http.MethodPost, "/admin/posts/bulk", map[string]any{
+		"action": "delete", "post_ids": []uint{1}, "items": []map[string]uint{{"id": 2, "version": 5}, {"id": 3}},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("items: status %d body=%s", w.Code, w.Body.String())
+	}
+	if len(got.PostIDs) != 3 || got.PostIDs[1] != 2 || got.Versions[2] != 5 || len(got.Versions) != 1 {
+		t.Fatalf("items: unexpected request: %+v", got)
+	}
+
+	for _, body := range []map[string]any{
+		{"action": "archive", "post_ids": []uint{1}},
+		{"action": "publish", "post_ids": []uint{}},
+		{"action": "publish", "post_ids": []uint{0}},
+		{"action": "publish", "items": []map[string]uint{{"version": 3}}},
+	} {
+		if w := doJSON(r, http.MethodPost, "/admin/posts/bulk", body); w.Code != http.StatusBadRequest {
+			t.Fatalf("%v: want 400, got %d", body, w.Code)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LinkPostTranslation adds another post to the translation group of the target post.
+// @Summary Link post translation
+// @Description Links post_id as a translation of the post, starting a translation group when it has none. A group holds one post per locale.
+// @Tags admin-posts
+// @Accept json
+// @Produce json
+// @Param id path int true "post id"
+// @Param body body dto.LinkPostTranslationRequest true "post to link"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/translations [post]
+func (api *AdminPostAPI) LinkPostTranslation(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	_, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	var req dto.LinkPostTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := api.service.LinkAdminPostTranslation(ctx, id, req.PostID, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "link post translation timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "post translation linked successfully")
+}
+
+// UnlinkPostTranslation takes the post out of its translation group.
+// @Summary Unlink post translation
+// @Description Removes the post from its translation group; a group left with one post is dissolved.
+// @Tags admin-posts
+// @Produce json
+// @Param id path int true "post id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/translations [delete]
+func (api *AdminPostAPI) UnlinkPostTranslation(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	_, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := api.service.UnlinkAdminPostTranslation(ctx, id, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "unlink post translation timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "post translation unlinked successfully")
+}
@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTrashedPosts lists posts in the trash, most recently deleted first.
+// @Summary List trashed posts
+// @Description Returns soft-deleted posts that can still be restored or purged.
+// @Tags admin-posts
+// @Produce json
+// @Success 200 {array} dto.PostResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/trash [get]
+func (api *AdminPostAPI) GetTrashedPosts(c *gin.Context) {
+	_, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	posts, err := api.service.ListTrashedPosts(ctx, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list trashed posts timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPostListResponse(posts))
+}
+
+// RestorePost takes a post out of the trash.
+// @Summary Restore trashed post
+// @Description Restores one trashed post with the status it had when it was deleted.
+// @Tags admin-posts
+// @Produce json
+// @Param id path int true "post id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/restore [post]
+func (api *AdminPostAPI) RestorePost(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	_, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := api.service.RestoreAdminPost(ctx, id, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "restore post timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "post restored successfully")
+}
+
+// PurgePost permanently removes a trashed post.
+// @Summary Purge trashed post
+// @Description Permanently deletes one trashed post, freeing its slug and media references. This cannot be undone.
+// @Tags admin-posts
+// @Produce json
+// @Param id path int true "post id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/posts/{id}/purge [delete]
+func (api *AdminPostAPI) PurgePost(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	_, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := api.service.PurgeAdminPost(ctx, id, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "purge post timed out")
+			return
+		}
+		respondPostWorkflowError(c, err, http.StatusNotFound)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "post purged successfully")
+}
@@ -0,0 +1,173 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsAPI serves the admin view analytics under /api/v1/admin/analytics.
+// Ranges are whole UTC days given as from/to in YYYY-MM-DD, both optional and inclusive.
+type AnalyticsAPI struct {
+	service core.AnalyticsService
+}
+
+func NewAnalyticsAPI(service core.AnalyticsService) *AnalyticsAPI {
+	return &AnalyticsAPI{service: service}
+}
+
+// GetSiteViews returns the daily views of all posts.
+// @Summary Site-wide daily views
+// @Description Admin endpoint returning one point per day of the range, including days without views.
+// @Tags analytics
+// @Produce json
+// @Param from query string false "first day (YYYY-MM-DD); defaults to 30 days before to"
+// @Param to query string false "last day (YYYY-MM-DD); defaults to today"
+// @Success 200 {object} dto.ViewSeriesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Router /admin/analytics/views [get]
+func (api *AnalyticsAPI) GetSiteViews(c *gin.Context) {
+	r, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	points, err := api.service.SiteViews(ctx, r)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "load site views timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToViewSeriesResponse(nil, r, points))
+}
+
+// GetPostViews returns the daily views of one post.
+// @Summary Post daily views
+// @Tags analytics
+// @Produce json
+// @Param id path int true "post id"
+// @Param from query string false "first day (YYYY-MM-DD); defaults to 30 days before to"
+// @Param to query string false "last day (YYYY-MM-DD); defaults to today"
+// @Success 200 {object} dto.ViewSeriesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Router /admin/analytics/posts/{id}/views [get]
+func (api *AnalyticsAPI) GetPostViews(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+	r, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	points, err := api.service.PostViews(ctx, id, r)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "load post views timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToViewSeriesResponse(&id, r, points))
+}
+
+// GetTopPosts returns the most viewed posts.
+// @Summary Most viewed posts
+// @Tags analytics
+// @Produce json
+// @Param from query string false "first day (YYYY-MM-DD); defaults to 30 days before to"
+// @Param to query string false "last day (YYYY-MM-DD); defaults to today"
+// @Param limit query int false "number of posts (max 100)" default(10)
+// @Success 200 {object} dto.TopPostsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Router /admin/analytics/top-posts [get]
+func (api *AnalyticsAPI) GetTopPosts(c *gin.Context) {
+	r, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(entity.DefaultTopPostLimit)))
+	if err != nil || limit <= 0 {
+		errorx.RespondValidationError(c, "invalid limit", map[string]any{"field": "limit"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	posts, err := api.service.TopPosts(ctx, r, limit)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "load top posts timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTopPostsResponse(r, posts))
+}
+
+// parseAnalyticsRange reads from/to and resolves their defaults, so responses can echo the
+// range actually reported.
+func parseAnalyticsRange(c *gin.Context) (entity.AnalyticsRange, bool) {
+	var r entity.AnalyticsRange
+	for _, bound := range []struct {
+		field string
+		dst   *time.Time
+	}{{"from", &r.From}, {"to", &r.To}} {
+		raw := c.Query(bound.field)
+		if raw == "" {
+			continue
+		}
+		day, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			errorx.RespondValidationError(c, "invalid "+bound.field+" date", map[string]any{"field": bound.field})
+			return r, false
+		}
+		*bound.dst = day
+	}
+
+	r, ok := r.Normalized(time.Now())
+	if !ok {
+		errorx.RespondValidationError(c, "invalid analytics range", map[string]any{"max_days": entity.MaxAnalyticsDays})
+		return r, false
+	}
+	return r, true
+}
@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeAnalyticsService implements core.AnalyticsService for handler-layer tests.
+// Methods without a stub panic through the nil embedded interface.
+type fakeAnalyticsService struct {
+	core.AnalyticsService
+	recorded  []uint
+	visitors  []entity.Visitor
+	postViews func(ctx context.Context, postID uint, r entity.AnalyticsRange) ([]entity.DailyViews, error)
+}
+
+func (f *fakeAnalyticsService) RecordView(postID uint, visitor entity.Visitor) bool {
+	f.recorded = append(f.recorded, postID)
+	f.visitors = append(f.visitors, visitor)
+	return true
+}
+func (f *fakeAnalyticsService) PostViews(ctx context.Context, postID uint, r entity.AnalyticsRange) ([]entity.DailyViews, error) {
+	return f.postViews(ctx, postID, r)
+}
+
+func TestPublicPostAPI_GetPostByID_RecordsView(t *testing.T) {
+	svc := &fakePostService{
+		getPublicByIDFn: func(ctx context.Context, id uint, locales []string) (entity.Post, error) {
+			if id == 404 {
+				return entity.Post{}, core.ErrNotFound
+			}
+			// A locale fallback may serve a translation; the served post is counted.
+			return entity.Post{ID: 8, Title: "hello"}, nil
+		},
+	}
+	views := &fakeAnalyticsService{}
+	r := gin.New()
+	api := NewPublicPostAPI(svc)
+	api.SetViewTracker(views)
+	r.GET("/posts/:id", api.GetPostByID)
+
+	req := httptest.NewRequest(http.MethodGet, "/posts/7", nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	if len(views.recorded) != 1 || views.recorded[0] != 8 || views.visitors[0].UserAgent != "Mozilla/5.0" || views.visitors[0].IP == "" {
+		t.Fatalf("unexpected recorded views: %v %+v", views.recorded, views.visitors)
+	}
+
+	if w := doRequest(r, http.MethodGet, "/posts/404"); w.Code != http.StatusNotFound || len(views.recorded) != 1 {
+		t.Fatalf("missing post: status %d, recorded %v", w.Code, views.recorded)
+	}
+}
+
+func TestAnalyticsAPI_GetPostViews(t *testing.T) {
+	var gotRange entity.AnalyticsRange
+	views := &fakeAnalyticsService{
+		postViews: func(ctx context.Context, postID uint, r entity.AnalyticsRange) ([]entity.DailyViews, error) {
+			gotRange = r
+			return []entity.DailyViews{{Day: r.From, Views: 3}, {Day: r.To, Views: 2}}, nil
+		},
+	}
+	r := gin.New()
+	r.GET("/admin/analytics/posts/:id/views", NewAnalyticsAPI(views).GetPostViews)
+
+	w := doRequest(r, http.MethodGet, "/admin/analytics/posts/5/views?from=2026-03-01&to=2026-03-02")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got dto.ViewSeriesResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.PostID == nil || *got.PostID != 5 || got.From != "2026-03-01" || got.To != "2026-03-02" || got.Total != 5 || len(got.Points) != 2 {
+		t.Fatalf("unexpected response: %s", w.Body.String())
+	}
+	if !gotRange.From.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected range: %+v", gotRange)
+	}
+
+	for _, path := range []string{
+		"/admin/analytics/posts/5/views?from=March",
+		"/admin/analytics/posts/5/views?from=2026-03-02&to=2026-03-01",
+		"/admin/analytics/posts/5/views?from=2020-01-01&to=2026-01-01",
+		"/admin/analytics/posts/abc/views",
+	} {
+		if w := doRequest(r, http.MethodGet, path); w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: want 400, got %d", path, w.Code)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/service"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CategoryAPI serves category endpoints under /api/v1/categories.
+// Reads are public and address categories by slug; writes are admin-only and address them by ID.
+type CategoryAPI struct {
+	service core.CategoryService
+	posts   core.PostService
+}
+
+func NewCategoryAPI(service core.CategoryService, posts core.PostService) *CategoryAPI {
+	return &CategoryAPI{service: service, posts: posts}
+}
+
+// GetCategories returns all categories with their published post counts.
+// @Summary List categories
+// @Description Public endpoint listing categories with the number of published posts in each.
+// @Tags categories
+// @Produce json
+// @Success 200 {array} dto.CategoryWithCountResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /categories [get]
+func (api *CategoryAPI) GetCategories(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	categories, err := api.service.List(ctx)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list categories timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToCategoryListResponse(categories))
+}
+
+// GetCategoryBySlug returns one category.
+// @Summary Get category by slug
+// @Tags categories
+// @Produce json
+// @Param slug path string true "category slug"
+// @Success 200 {object} dto.CategoryResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /categories/slug/{slug} [get]
+func (api *CategoryAPI) GetCategoryBySlug(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	category, err := api.service.GetBySlug(ctx, c.Param("slug"))
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "get category timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusNotFound, map[string]any{"resource": "category"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToCategoryResponse(category))
+}
+
+// GetCategoryPosts returns one page of published posts in a category.
+// Accepts the same pagination, sort and filter parameters as GET /posts; category_id is implied.
+// @Summary List published posts in category
+// @Tags categories
+// @Produce json
+// @Param slug path string true "category slug"
+// @Param page query int false "page number" default(1)
+// @Param page_size query int false "page size (max 100)" default(20)
+// @Param sort query string false "sort key: created_at|updated_at" default(created_at)
+// @Param order query string false "sort order: asc|desc" default(desc)
+// @Success 200 {object} dto.CategoryPostsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /categories/slug/{slug}/posts [get]
+func (api *CategoryAPI) GetCategoryPosts(c *gin.Context) {
+	query, ok := parsePostListQuery(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	category, err := api.service.GetBySlug(ctx, c.Param("slug"))
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "get category timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusNotFound, map[string]any{"resource": "category"})
+		return
+	}
+
+	query.CategoryID = &category.ID
+	posts, total, err := api.posts.ListPublicPosts(ctx, query)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list category posts timed out")
+			return
+		}
+		errorx.RespondInternalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CategoryPostsResponse{
+		Category:         dto.ToCategoryResponse(category),
+		PostListResponse: dto.ToPostPageResponse(posts, total, query),
+	})
+}
+
+// CreateCategory creates a category.
+// @Summary Create category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateCategoryRequest true "create category payload"
+// @Success 201 {object} dto.CategoryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /categories [post]
+func (api *CategoryAPI) CreateCategory(c *gin.Context) {
+	var req dto.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	created, err := api.service.Create(ctx, req.ToEntity())
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "create category timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToCategoryResponse(created))
+}
+
+// UpdateCategory renames a category and/or changes its slug.
+// @Summary Update category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path int true "category id"
+// @Param body body dto.UpdateCategoryRequest true "update category payload"
+// @Success 200 {object} dto.CategoryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /categories/{id} [put]
+func (api *CategoryAPI) UpdateCategory(c *gin.Context) {
+	id, ok := parseCategoryID(c, c.Param("id"), "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	category := req.ToEntity()
+	category.ID = id
+	updated, err := api.service.Update(ctx, category)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "update category timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToCategoryResponse(updated))
+}
+
+// DeleteCategory removes a category.
+// Posts still in the category block the delete with 409 unless reassign_to names another category.
+// @Summary Delete category
+// @Tags categories
+// @Produce json
+// @Param id path int true "category id"
+// @Param reassign_to query int false "move the category's posts to this category before deleting"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /categories/{id} [delete]
+func (api *CategoryAPI) DeleteCategory(c *gin.Context) {
+	id, ok := parseCategoryID(c, c.Param("id"), "id")
+	if !ok {
+		return
+	}
+	var reassignTo *uint
+	if raw := c.Query("reassign_to"); raw != "" {
+		target, ok := parseCategoryID(c, raw, "reassign_to")
+		if !ok {
+			return
+		}
+		reassignTo = &target
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := api.service.Delete(ctx, id, reassignTo); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "delete category timed out")
+			return
+		}
+		var inUse *service.CategoryInUseError
+		if errors.As(err, &inUse) {
+			errorx.RespondError(c, http.StatusConflict, core.CodeConflict, "category is referenced by posts", map[string]any{"resource": "category", "references": inUse.Posts})
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "category deleted successfully")
+}
+
+func parseCategoryID(c *gin.Context, raw string, field string) (uint, bool) {
+	id64, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil || id64 == 0 {
+		errorx.RespondValidationError(c, "invalid category id", map[string]any{"field": field})
+		return 0, false
+	}
+	return uint(id64), true
+}
@@ -0,0 +1,139 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeCategoryService implements core.CategoryService for handler-layer tests.
+type fakeCategoryService struct {
+	createFn    func(ctx context.Context, c entity.Category) (entity.Category, error)
+	listFn      func(ctx context.Context) ([]entity.CategoryWithPostCount, error)
+	getByIDFn   func(ctx context.Context, id uint) (entity.Category, error)
+	getBySlugFn func(ctx context.Context, slug string) (entity.Category, error)
+	updateFn    func(ctx context.Context, c entity.Category) (entity.Category, error)
+	deleteFn    func(ctx context.Context, id uint, reassignTo *uint) error
+}
+
+func (f *fakeCategoryService) Create(ctx context.Context, c entity.Category) (entity.Category, error) {
+	return f.createFn(ctx, c)
+}
+func (f *fakeCategoryService) List(ctx context.Context) ([]entity.CategoryWithPostCount, error) {
+	return f.listFn(ctx)
+}
+func (f *fakeCategoryService) GetByID(ctx context.Context, id uint) (entity.Category, error) {
+	return f.getByIDFn(ctx, id)
+}
+func (f *fakeCategoryService) GetBySlug(ctx context.Context, slug string) (entity.Category, error) {
+	return f.getBySlugFn(ctx, slug)
+}
+func (f *fakeCategoryService) Update(ctx context.Context, c entity.Category) (entity.Category, error) {
+	return f.updateFn(ctx, c)
+}
+func (f *fakeCategoryService) Delete(ctx context.Context, id uint, reassignTo *uint) error {
+	return f.deleteFn(ctx, id, reassignTo)
+}
+
+func newCategoryRouter(svc core.CategoryService, posts core.PostService) *gin.Engine {
+	r := gin.New()
+	api := NewCategoryAPI(svc, posts)
+	r.GET("/categories", api.GetCategories)
+	r.GET("/categories/slug/:slug", api.GetCategoryBySlug)
+	r.GET("/categories/slug/:slug/posts", api.GetCategoryPosts)
+	r.POST("/categories", api.CreateCategory)
+	r.PUT("/categories/:id", api.UpdateCategory)
+	r.DELETE("/categories/:id", api.DeleteCategory)
+	return r
+}
+
+func TestCategoryAPI_GetCategories(t *testing.T) {
+	svc := &fakeCategoryService{
+		listFn: func(ctx context.Context) ([]entity.CategoryWithPostCount, error) {
+			return []entity.CategoryWithPostCount{{Category: entity.Category{ID: 1, Name: "Go", Slug: "go"}, PostCount: 3}}, nil
+		},
+	}
+	w := doRequest(newCategoryRouter(svc, &fakePostService{}), http.MethodGet, "/categories")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got []dto.CategoryWithCountResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Slug != "go" || got[0].PostCount != 3 {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestCategoryAPI_GetCategoryPosts(t *testing.T) {
+	svc := &fakeCategoryService{
+		getBySlugFn: func(ctx context.Context, slug string) (entity.Category, error) {
+			return entity.Category{ID: 5, Name: "Go", Slug: slug}, nil
+		},
+	}
+	posts := &fakePostService{
+		listPublicFn: func(ctx context.Context, q entity.PostListQuery) ([]entity.Post, int64, error) {
+			if q.CategoryID == nil || *q.CategoryID != 5 {
+				t.Fatalf("category filter not applied: %+v", q)
+			}
+			return []entity.Post{{ID: 1}}, 1, nil
+		},
+	}
+	w := doRequest(newCategoryRouter(svc, posts), http.MethodGet, "/categories/slug/go/posts")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got dto.CategoryPostsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Category.ID != 5 || got.Total != 1 || len(got.Items) != 1 {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestCategoryAPI_DeleteCategory_InUse(t *testing.T) {
+	svc := &fakeCategoryService{
+		deleteFn: func(ctx context.Context, id uint, reassignTo *uint) error {
+			return &service.CategoryInUseError{Posts: 2}
+		},
+	}
+	w := doRequest(newCategoryRouter(svc, &fakePostService{}), http.MethodDelete, "/categories/1")
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got dto.ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if got.Code != string(core.CodeConflict) || got.Details["references"] != float64(2) {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestCategoryAPI_DeleteCategory_Reassign(t *testing.T) {
+	svc := &fakeCategoryService{
+		deleteFn: func(ctx context.Context, id uint, reassignTo *uint) error {
+			if id != 1 || reassignTo == nil || *reassignTo != 2 {
+				t.Fatalf("unexpected args: id=%d reassign=%v", id, reassignTo)
+			}
+			return nil
+		},
+	}
+	w := doRequest(newCategoryRouter(svc, &fakePostService{}), http.MethodDelete, "/categories/1?reassign_to=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(newCategoryRouter(svc, &fakePostService{}), http.MethodDelete, "/categories/1?reassign_to=x")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("invalid reassign_to status: %d", w.Code)
+	}
+}
@@ -0,0 +1,248 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/middleware"
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CommentAPI serves public comment endpoints under /posts/:id/comments and the admin
+// moderation queue under /admin/comments.
+type CommentAPI struct {
+	service core.CommentService
+}
+
+func NewCommentAPI(service core.CommentService) *CommentAPI {
+	return &CommentAPI{service: service}
+}
+
+func parseCommentID(c *gin.Context) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id64 == 0 {
+		errorx.RespondValidationError(c, "invalid comment id", map[string]any{"id": c.Param("id")})
+		return 0, false
+	}
+	return uint(id64), true
+}
+
+// GetPostComments returns the approved comments of a published post as a reply tree.
+// @Summary List post comments
+// @Description Public endpoint returning approved comments, oldest first, with replies nested.
+// @Tags comments
+// @Produce json
+// @Param id path int true "post id"
+// @Success 200 {array} dto.CommentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /posts/{id}/comments [get]
+func (api *CommentAPI) GetPostComments(c *gin.Context) {
+	postID, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	comments, err := api.service.ListApproved(ctx, postID)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list comments timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, map[string]any{"resource": "post"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToCommentListResponse(comments))
+}
+
+// CreatePostComment submits a comment for moderation.
+// @Summary Comment on a post
+// @Description Adds a pending comment (Markdown, sanitized on render) to a published post. Guests must give author_name.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path int true "post id"
+// @Param request body dto.CreateCommentRequest true "comment"
+// @Success 201 {object} dto.CommentResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /posts/{id}/comments [post]
+func (api *CommentAPI) CreatePostComment(c *gin.Context) {
+	postID, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	// Guests comment too; a missing session just means actor 0.
+	actorUserID, _ := middleware.GetUserID(c)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	created, err := api.service.Create(ctx, req.ToEntity(postID), actorUserID)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "create comment timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusBadRequest, nil)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToCommentResponse(created))
+}
+
+// GetComments lists comments for moderation, pending ones by default.
+// @Summary List comments for moderation
+// @Tags admin-comments
+// @Produce json
+// @Param status query string false "pending|approved|rejected|spam|all" default(pending)
+// @Param post_id query int false "only comments on this post"
+// @Param page query int false "page number" default(1)
+// @Param page_size query int false "page size (max 100)" default(20)
+// @Success 200 {object} dto.AdminCommentListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Router /admin/comments [get]
+func (api *CommentAPI) GetComments(c *gin.Context) {
+	query := entity.CommentListQuery{Status: c.DefaultQuery("status", entity.CommentStatusPending)}
+	if query.Status == "all" {
+		query.Status = ""
+	}
+
+	var err error
+	if query.Page, err = strconv.Atoi(c.DefaultQuery("page", "1")); err != nil {
+		errorx.RespondValidationError(c, "invalid page", map[string]any{"field": "page"})
+		return
+	}
+	if query.PageSize, err = strconv.Atoi(c.DefaultQuery("page_size", strconv.Itoa(entity.DefaultPostPageSize))); err != nil {
+		errorx.RespondValidationError(c, "invalid page_size", map[string]any{"field": "page_size"})
+		return
+	}
+	if raw := c.Query("post_id"); raw != "" {
+		v, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil || v == 0 {
+			errorx.RespondValidationError(c, "invalid post_id", map[string]any{"field": "post_id"})
+			return
+		}
+		postID := uint(v)
+		query.PostID = &postID
+	}
+	query = query.Normalized()
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	comments, total, err := api.service.List(ctx, query)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list comments timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToAdminCommentPageResponse(comments, total, query))
+}
+
+// ApproveComment makes a comment public.
+// @Summary Approve comment
+// @Tags admin-comments
+// @Produce json
+// @Param id path int true "comment id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/comments/{id}/approve [post]
+func (api *CommentAPI) ApproveComment(c *gin.Context) {
+	api.moderate(c, entity.CommentStatusApproved)
+}
+
+// RejectComment hides a comment.
+// @Summary Reject comment
+// @Tags admin-comments
+// @Produce json
+// @Param id path int true "comment id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/comments/{id}/reject [post]
+func (api *CommentAPI) RejectComment(c *gin.Context) {
+	api.moderate(c, entity.CommentStatusRejected)
+}
+
+// SpamComment hides a comment and marks it as spam.
+// @Summary Mark comment as spam
+// @Tags admin-comments
+// @Produce json
+// @Param id path int true "comment id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/comments/{id}/spam [post]
+func (api *CommentAPI) SpamComment(c *gin.Context) {
+	api.moderate(c, entity.CommentStatusSpam)
+}
+
+func (api *CommentAPI) moderate(c *gin.Context, status string) {
+	id, ok := parseCommentID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := api.service.Moderate(ctx, id, status); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "moderate comment timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, map[string]any{"resource": "comment"})
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "comment "+status)
+}
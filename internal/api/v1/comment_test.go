@@ -0,0 +1,165 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeCommentService implements core.CommentService for handler-layer tests.
+type fakeCommentService struct {
+	createFn       func(ctx context.Context, c entity.Comment, actorUserID uint) (entity.Comment, error)
+	listApprovedFn func(ctx context.Context, postID uint) ([]entity.Comment, error)
+	listFn         func(ctx context.Context, q entity.CommentListQuery) ([]entity.Comment, int64, error)
+	moderateFn     func(ctx context.Context, id uint, status string) error
+}
+
+func (f *fakeCommentService) Create(ctx context.Context, c entity.Comment, actorUserID uint) (entity.Comment, error) {
+	return f.createFn(ctx, c, actorUserID)
+}
+func (f *fakeCommentService) ListApproved(ctx context.Context, postID uint) ([]entity.Comment, error) {
+	return f.listApprovedFn(ctx, postID)
+}
+func (f *fakeCommentService) List(ctx context.Context, q entity.CommentListQuery) ([]entity.Comment, int64, error) {
+	return f.listFn(ctx, q)
+}
+func (f *fakeCommentService) Moderate(ctx context.Context, id uint, status string) error {
+	return f.moderateFn(ctx, id, status)
+}
+
+func newCommentRouter(svc core.CommentService, actor gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.Use(actor)
+	api := NewCommentAPI(svc)
+	r.GET("/posts/:id/comments", api.GetPostComments)
+	r.POST("/posts/:id/comments", api.CreatePostComment)
+	r.GET("/admin/comments", api.GetComments)
+	r.POST("/admin/comments/:id/approve", api.ApproveComment)
+	r.POST("/admin/comments/:id/reject", api.RejectComment)
+	r.POST("/admin/comments/:id/spam", api.SpamComment)
+	return r
+}
+
+func TestCommentAPI_GetPostComments(t *testing.T) {
+	parent := uint(1)
+	svc := &fakeCommentService{listApprovedFn: func(ctx context.Context, postID uint) ([]entity.Comment, error) {
+		return []entity.Comment{{ID: 1, AuthorName: "ann", BodyHTML: "<p>hi</p>", AuthorEmail: "ann@example.com",
+			Replies: []entity.Comment{{ID: 2, ParentID: &parent, AuthorName: "bob"}}}}, nil
+	}}
+	w := doRequest(newCommentRouter(svc, injectActor(0, "")), http.MethodGet, "/posts/5/comments")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got []dto.CommentResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if len(got) != 1 || len(got[0].Replies) != 1 || got[0].Replies[0].AuthorName != "bob" {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "ann@example.com") {
+		t.Fatal("public listing must not expose commenter email")
+	}
+}
+
+func TestCommentAPI_CreatePostComment(t *testing.T) {
+	var gotActor uint
+	var gotComment entity.Comment
+	svc := &fakeCommentService{createFn: func(ctx context.Context, c entity.Comment, actorUserID uint) (entity.Comment, error) {
+		gotActor, gotComment = actorUserID, c
+		c.ID, c.Status = 9, entity.CommentStatusPending
+		return c, nil
+	}}
+
+	w := doJSON(newCommentRouter(svc, injectActor(0, "")), http.MethodPost, "/posts/5/comments",
+		map[string]any{"body": "hello", "author_name": "ann"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	if gotActor != 0 || gotComment.PostID != 5 || gotComment.AuthorName != "ann" {
+		t.Fatalf("unexpected call: actor=%d comment=%+v", gotActor, gotComment)
+	}
+
+	w = doJSON(newCommentRouter(svc, injectActor(3, "user")), http.MethodPost, "/posts/5/comments", map[string]any{"body": "hello"})
+	if w.Code != http.StatusCreated || gotActor != 3 {
+		t.Fatalf("status: %d actor=%d", w.Code, gotActor)
+	}
+
+	w = doJSON(newCommentRouter(svc, injectActor(0, "")), http.MethodPost, "/posts/5/comments",
+		map[string]any{"body": "hello", "author_name": "ann", "author_email": "not-an-email"})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("invalid email should be rejected, got %d", w.Code)
+	}
+}
+
+func TestCommentAPI_CreatePostComment_Closed(t *testing.T) {
+	svc := &fakeCommentService{createFn: func(ctx context.Context, c entity.Comment, actorUserID uint) (entity.Comment, error) {
+		return entity.Comment{}, service.ErrCommentsClosed
+	}}
+	w := doJSON(newCommentRouter(svc, injectActor(3, "user")), http.MethodPost, "/posts/5/comments", map[string]any{"body": "hello"})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestCommentAPI_GetComments(t *testing.T) {
+	var gotQuery entity.CommentListQuery
+	svc := &fakeCommentService{listFn: func(ctx context.Context, q entity.CommentListQuery) ([]entity.Comment, int64, error) {
+		gotQuery = q
+		return []entity.Comment{{ID: 1, AuthorEmail: "ann@example.com"}}, 1, nil
+	}}
+	r := newCommentRouter(svc, injectActor(1, "admin"))
+
+	w := doRequest(r, http.MethodGet, "/admin/comments")
+	if w.Code != http.StatusOK || gotQuery.Status != entity.CommentStatusPending {
+		t.Fatalf("status: %d query=%+v", w.Code, gotQuery)
+	}
+	var got dto.AdminCommentListResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if got.Total != 1 || got.Items[0].AuthorEmail != "ann@example.com" {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+
+	w = doRequest(r, http.MethodGet, "/admin/comments?status=all&post_id=4")
+	if w.Code != http.StatusOK || gotQuery.Status != "" || gotQuery.PostID == nil || *gotQuery.PostID != 4 {
+		t.Fatalf("status: %d query=%+v", w.Code, gotQuery)
+	}
+}
+
+func TestCommentAPI_Moderate(t *testing.T) {
+	var gotID uint
+	var gotStatus string
+	svc := &fakeCommentService{moderateFn: func(ctx context.Context, id uint, status string) error {
+		if id == 404 {
+			return core.ErrNotFound
+		}
+		gotID, gotStatus = id, status
+		return nil
+	}}
+	r := newCommentRouter(svc, injectActor(1, "admin"))
+
+	for path, want := range map[string]string{
+		"/admin/comments/7/approve": entity.CommentStatusApproved,
+		"/admin/comments/7/reject":  entity.CommentStatusRejected,
+		"/admin/comments/7/spam":    entity.CommentStatusSpam,
+	} {
+		w := doRequest(r, http.MethodPost, path)
+		if w.Code != http.StatusOK || gotID != 7 || gotStatus != want {
+			t.Fatalf("%s: status=%d id=%d moderation=%q", path, w.Code, gotID, gotStatus)
+		}
+	}
+
+	if w := doRequest(r, http.MethodPost, "/admin/comments/404/approve"); w.Code != http.StatusNotFound {
+		t.Fatalf("missing comment: status %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodPost, "/admin/comments/abc/approve"); w.Code != http.StatusBadRequest {
+		t.Fatalf("bad id: status %d", w.Code)
+	}
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/core"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// notModified sets ETag/Last-Modified validators and answers 304 when the request's
+// conditional headers show the client already has this version. If-None-Match takes
+// precedence over If-Modified-Since, as RFC 9110 requires. A zero lastModified is not sent.
+func notModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	c.Header("ETag", etag)
+	lastModified = lastModified.UTC().Truncate(time.Second)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+
+	if inm := c.GetHeader("If-None-Match"); inm != "" {
+		if etagListContains(inm, etag) {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+		return false
+	}
+	if ims := c.GetHeader("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(ims); err == nil && !lastModified.After(t) {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// etagListContains reports whether an If-None-Match value matches etag, using weak comparison.
+func etagListContains(header string, etag string) bool {
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// postVersionETag is the strong validator of a post's management state.
+func postVersionETag(version uint) string {
+	return fmt.Sprintf(`"v%d"`, version)
+}
+
+// ifMatchVersion reads the post version named by If-Match. A header that is absent or "*"
+// is answered with 428 and ok=false when required; otherwise it returns 0, meaning the write
+// is unconditional. A header that is not exactly one strong post ETag is answered with 400.
+func ifMatchVersion(c *gin.Context, required bool) (version uint, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		if required {
+			errorx.RespondError(c, http.StatusPreconditionRequired, core.CodePreconditionRequired, "If-Match header is required", map[string]any{"field": "If-Match", "reason": "send the ETag returned by GET /admin/posts/:id"})
+			return 0, false
+		}
+		return 0, true
+	}
+	raw := strings.TrimSuffix(strings.TrimPrefix(header, `"v`), `"`)
+	v, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil || v == 0 || postVersionETag(uint(v)) != header {
+		errorx.RespondValidationError(c, "invalid If-Match header", map[string]any{"field": "If-Match", "reason": "expected one ETag returned by GET /admin/posts/:id"})
+		return 0, false
+	}
+	return uint(v), true
+}
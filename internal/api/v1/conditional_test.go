@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newConditionalRouter(etag string, lastModified time.Time) *gin.Engine {
+	r := gin.New()
+	r.GET("/doc", func(c *gin.Context) {
+		if notModified(c, etag, lastModified) {
+			return
+		}
+		c.String(http.StatusOK, "body")
+	})
+	return r
+}
+
+func TestNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	modified := time.Date(2024, 5, 1, 12, 0, 0, 500, time.UTC)
+	r := newConditionalRouter(`"v1"`, modified)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{"no validators", nil, http.StatusOK},
+		{"matching etag", map[string]string{"If-None-Match": `"v0", "v1"`}, http.StatusNotModified},
+		{"weak etag matches", map[string]string{"If-None-Match": `W/"v1"`}, http.StatusNotModified},
+		{"stale etag", map[string]string{"If-None-Match": `"v0"`}, http.StatusOK},
+		{"not modified since", map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)}, http.StatusNotModified},
+		{"modified since", map[string]string{"If-Modified-Since": modified.Add(-time.Hour).Format(http.TimeFormat)}, http.StatusOK},
+		{"etag wins over date", map[string]string{
+			"If-None-Match":     `"v0"`,
+			"If-Modified-Since": modified.Format(http.TimeFormat),
+		}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/doc", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if w.Header().Get("ETag") != `"v1"` {
+				t.Fatalf("ETag = %q", w.Header().Get("ETag"))
+			}
+			if w.Header().Get("Last-Modified") != modified.Format(http.TimeFormat) {
+				t.Fatalf("Last-Modified = %q", w.Header().Get("Last-Modified"))
+			}
+		})
+	}
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name     string
+		header   string
+		required bool
+		want     uint
+		ok       bool
+		status   int
+	}{
+		{"absent", "", false, 0, true, 0},
+		{"wildcard", "*", false, 0, true, 0},
+		{"absent but required", "", true, 0, false, http.StatusPreconditionRequired},
+		{"wildcard but required", "*", true, 0, false, http.StatusPreconditionRequired},
+		{"strong etag", `"v7"`, true, 7, true, 0},
+		{"weak etag", `W/"v7"`, true, 0, false, http.StatusBadRequest},
+		{"list", `"v7", "v8"`, false, 0, false, http.StatusBadRequest},
+		{"zero version", `"v0"`, false, 0, false, http.StatusBadRequest},
+		{"unquoted", "v7", false, 0, false, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/admin/posts/1", nil)
+			if tt.header != "" {
+				c.Request.Header.Set("If-Match", tt.header)
+			}
+			got, ok := ifMatchVersion(c, tt.required)
+			if got != tt.want || ok != tt.ok {
+				t.Fatalf("got (%d, %v), want (%d, %v)", got, ok, tt.want, tt.ok)
+			}
+			if !ok && w.Code != tt.status {
+				t.Fatalf("status: %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
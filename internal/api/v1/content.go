@@ -0,0 +1,575 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/service"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentAPI serves custom content types and their entries.
+// Schemas are managed under /admin/content-types, entries under /admin/content/:type,
+// and published entries are readable under /content/:type.
+type ContentAPI struct {
+	service core.ContentService
+}
+
+func NewContentAPI(service core.ContentService) *ContentAPI {
+	return &ContentAPI{service: service}
+}
+
+// GetEntries returns one page of published entries of a content type.
+// @Summary List published entries
+// @Tags content
+// @Produce json
+// @Param type path string true "content type slug"
+// @Param page query int false "page number" default(1)
+// @Param page_size query int false "page size (max 100)" default(20)
+// @Success 200 {object} dto.ContentEntryListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /content/{type} [get]
+func (api *ContentAPI) GetEntries(c *gin.Context) {
+	query, ok := parseContentEntryQuery(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	entries, total, err := api.service.ListPublicEntries(ctx, c.Param("type"), query)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list entries timed out")
+			return
+		}
+		respondContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToContentEntryPageResponse(entries, total, query))
+}
+
+// GetEntryBySlug returns one published entry.
+// @Summary Get published entry by slug
+// @Tags content
+// @Produce json
+// @Param type path string true "content type slug"
+// @Param slug path string true "entry slug"
+// @Success 200 {object} dto.ContentEntryResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /content/{type}/{slug} [get]
+func (api *ContentAPI) GetEntryBySlug(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	entry, err := api.service.GetPublicEntryBySlug(ctx, c.Param("type"), c.Param("slug"))
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "get entry timed out")
+			return
+		}
+		respondContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToContentEntryResponse(entry))
+}
+
+// GetContentTypes returns every content type.
+// @Summary List content types
+// @Tags admin-content
+// @Produce json
+// @Success 200 {array} dto.ContentTypeResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Router /admin/content-types [get]
+func (api *ContentAPI) GetContentTypes(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	types, err := api.service.ListTypes(ctx)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list content types timed out")
+			return
+		}
+		respondContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToContentTypeListResponse(types))
+}
+
+// GetContentType returns one content type with its schema.
+// @Summary Get content type
+// @Tags admin-content
+// @Produce json
+// @Param type path string true "content type slug"
+// @Success 200 {object} dto.ContentTypeResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Router /admin/content-types/{type} [get]
+func (api *ContentAPI) GetContentType(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	contentType, err := api.service.GetType(ctx, c.Param("type"))
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "get content type timed out")
+			return
+		}
+		respondContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToContentTypeResponse(contentType))
+}
+
+// CreateContentType defines a new content type.
+// @Summary Create content type
+// @Tags admin-content
+// @Accept json
+// @Produce json
+// @Param body body dto.ContentTypeRequest true "content type schema"
+// @Success 201 {object} dto.ContentTypeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/content-types [post]
+func (api *ContentAPI) CreateContentType(c *gin.Context) {
+	var req dto.ContentTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	created, err := api.service.CreateType(ctx, req.ToEntity())
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "create content type timed out")
+			return
+		}
+		respondContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToContentTypeResponse(created))
+}
+
+// UpdateContentType replaces the name, description and fields of a content type.
+// Existing entries are validated against the new schema on their next save or publish.
+// @Summary Update content type
+// @Tags admin-content
+// @Accept json
+// @Produce json
+// @Param type path string true "content type slug"
+// @Param body body dto.ContentTypeRequest true "content type schema"
+// @Success 200 {object} dto.ContentTypeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/content-types/{type} [put]
+func (api *ContentAPI) UpdateContentType(c *gin.Context) {
+	var req dto.ContentTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	updated, err := api.service.UpdateType(ctx, c.Param("type"), req.ToEntity())
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "update content type timed out")
+			return
+		}
+		respondContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToContentTypeResponse(updated))
+}
+
+// DeleteContentType removes a content type. Types with entries, or referenced by another
+// type's fields, are refused with 409.
+// @Summary Delete content type
+// @Tags admin-content
+// @Produce json
+// @Param type path string true "content type slug"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/content-types/{type} [delete]
+func (api *ContentAPI) DeleteContentType(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := api.service.DeleteType(ctx, c.Param("type")); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "delete content type timed out")
+			return
+		}
+		respondContentError(c, err)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "content type deleted successfully")
+}
+
+// GetAdminEntries returns one page of manageable entries of a content type.
+// Editors see every entry; authors see their own drafts.
+// @Summary List manageable entries
+// @Tags admin-content
+// @Produce json
+// @Param type path string true "content type slug"
+// @Param page query int false "page number" default(1)
+// @Param page_size query int false "page size (max 100)" default(20)
+// @Success 200 {object} dto.ContentEntryListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Router /admin/content/{type} [get]
+func (api *ContentAPI) GetAdminEntries(c *gin.Context) {
+	query, ok := parseContentEntryQuery(c)
+	if !ok {
+		return
+	}
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	entries, total, err := api.service.ListAdminEntries(ctx, c.Param("type"), query, actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list entries timed out")
+			return
+		}
+		respondContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToContentEntryPageResponse(entries, total, query))
+}
+
+// GetAdminEntry returns one manageable entry.
+// @Summary Get manageable entry
+// @Tags admin-content
+// @Produce json
+// @Param type path string true "content type slug"
+// @Param id path int true "entry id"
+// @Success 200 {object} dto.ContentEntryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Router /admin/content/{type}/{id} [get]
+func (api *ContentAPI) GetAdminEntry(c *gin.Context) {
+	id, ok := parseContentEntryID(c)
+	if !ok {
+		return
+	}
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	entry, err := api.service.GetAdminEntry(ctx, c.Param("type"), id, actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "get entry timed out")
+			return
+		}
+		respondContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToContentEntryResponse(entry))
+}
+
+// CreateEntry creates a draft entry owned by the current user.
+// @Summary Create entry
+// @Tags admin-content
+// @Accept json
+// @Produce json
+// @Param type path string true "content type slug"
+// @Param body body dto.CreateContentEntryRequest true "entry payload"
+// @Success 201 {object} dto.ContentEntryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/content/{type} [post]
+func (api *ContentAPI) CreateEntry(c *gin.Context) {
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateContentEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	created, err := api.service.CreateAdminEntry(ctx, c.Param("type"), req.ToEntity(), actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "create entry timed out")
+			return
+		}
+		respondContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToContentEntryResponse(created))
+}
+
+// UpdateEntry updates an entry's title, slug or data.
+// @Summary Update entry
+// @Tags admin-content
+// @Accept json
+// @Produce json
+// @Param type path string true "content type slug"
+// @Param id path int true "entry id"
+// @Param body body dto.UpdateContentEntryRequest true "entry patch"
+// @Success 200 {object} dto.ContentEntryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/content/{type}/{id} [put]
+func (api *ContentAPI) UpdateEntry(c *gin.Context) {
+	id, ok := parseContentEntryID(c)
+	if !ok {
+		return
+	}
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateContentEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	updated, err := api.service.UpdateAdminEntry(ctx, c.Param("type"), id, req.ToPatch(), actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "update entry timed out")
+			return
+		}
+		respondContentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToContentEntryResponse(updated))
+}
+
+// PublishEntry transitions an entry from Draft to Published.
+// @Summary Publish entry
+// @Tags admin-content
+// @Produce json
+// @Param type path string true "content type slug"
+// @Param id path int true "entry id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/content/{type}/{id}/publish [post]
+func (api *ContentAPI) PublishEntry(c *gin.Context) {
+	api.transitionEntry(c, "publish entry timed out", "published", api.service.PublishAdminEntry)
+}
+
+// DraftEntry moves a published entry back to Draft.
+// @Summary Move entry to draft
+// @Tags admin-content
+// @Produce json
+// @Param type path string true "content type slug"
+// @Param id path int true "entry id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/content/{type}/{id}/draft [post]
+func (api *ContentAPI) DraftEntry(c *gin.Context) {
+	api.transitionEntry(c, "move entry to draft timed out", "moved to draft", api.service.MoveEntryToDraft)
+}
+
+// DeleteEntry permanently removes an entry. Entries that other entries reference are kept.
+// @Summary Delete entry
+// @Tags admin-content
+// @Produce json
+// @Param type path string true "content type slug"
+// @Param id path int true "entry id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/content/{type}/{id} [delete]
+func (api *ContentAPI) DeleteEntry(c *gin.Context) {
+	api.transitionEntry(c, "delete entry timed out", "entry deleted successfully", api.service.DeleteAdminEntry)
+}
+
+// transitionEntry runs a body-less entry action and answers with a plain message.
+func (api *ContentAPI) transitionEntry(c *gin.Context, timeoutMsg string, okMsg string, action func(ctx context.Context, typeSlug string, id uint, actorUserID uint, actorRole string) error) {
+	id, ok := parseContentEntryID(c)
+	if !ok {
+		return
+	}
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := action(ctx, c.Param("type"), id, actorUserID, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, timeoutMsg)
+			return
+		}
+		respondContentError(c, err)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, okMsg)
+}
+
+func parseContentEntryID(c *gin.Context) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id64 == 0 {
+		errorx.RespondValidationError(c, "invalid entry id", map[string]any{"field": "id"})
+		return 0, false
+	}
+	return uint(id64), true
+}
+
+func parseContentEntryQuery(c *gin.Context) (entity.ContentEntryQuery, bool) {
+	var query entity.ContentEntryQuery
+	var err error
+	if query.Page, err = strconv.Atoi(c.DefaultQuery("page", "1")); err != nil {
+		errorx.RespondValidationError(c, "invalid page", map[string]any{"field": "page"})
+		return entity.ContentEntryQuery{}, false
+	}
+	if query.PageSize, err = strconv.Atoi(c.DefaultQuery("page_size", strconv.Itoa(entity.DefaultPostPageSize))); err != nil {
+		errorx.RespondValidationError(c, "invalid page_size", map[string]any{"field": "page_size"})
+		return entity.ContentEntryQuery{}, false
+	}
+	return query.Normalized(), true
+}
+
+// respondContentError reports schema violations with the offending field, and in-use types
+// and entries with their reference count; everything else maps through the core error contract.
+func respondContentError(c *gin.Context, err error) {
+	var fieldErr *entity.ContentFieldError
+	if errors.As(err, &fieldErr) {
+		errorx.RespondValidationError(c, "content does not match its schema", map[string]any{"field": fieldErr.Field, "reason": fieldErr.Reason})
+		return
+	}
+	var inUse *service.ContentTypeInUseError
+	if errors.As(err, &inUse) {
+		errorx.RespondError(c, http.StatusConflict, core.CodeConflict, "content type is in use", map[string]any{"resource": "content_type", "references": inUse.Entries + int64(len(inUse.ReferencedBy))})
+		return
+	}
+	var entryInUse *service.ContentEntryInUseError
+	if errors.As(err, &entryInUse) {
+		errorx.RespondError(c, http.StatusConflict, core.CodeConflict, "content entry is referenced", map[string]any{"resource": "content_entry", "references": entryInUse.Entries})
+		return
+	}
+	errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+}
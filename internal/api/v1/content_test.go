@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeContentService implements core.ContentService for handler-layer tests.
+// Methods without a stub panic through the nil embedded interface.
+type fakeContentService struct {
+	core.ContentService
+	deleteTypeFn  func(ctx context.Context, slug string) error
+	listPublicFn  func(ctx context.Context, typeSlug string, q entity.ContentEntryQuery) ([]entity.ContentEntry, int64, error)
+	createEntryFn func(ctx context.Context, typeSlug string, e entity.ContentEntry, uid uint, role string) (entity.ContentEntry, error)
+	deleteEntryFn func(ctx context.Context, typeSlug string, id uint, uid uint, role string) error
+}
+
+func (f *fakeContentService) DeleteType(ctx context.Context, slug string) error {
+	return f.deleteTypeFn(ctx, slug)
+}
+func (f *fakeContentService) ListPublicEntries(ctx context.Context, typeSlug string, q entity.ContentEntryQuery) ([]entity.ContentEntry, int64, error) {
+	return f.listPublicFn(ctx, typeSlug, q)
+}
+func (f *fakeContentService) CreateAdminEntry(ctx context.Context, typeSlug string, e entity.ContentEntry, uid uint, role string) (entity.ContentEntry, error) {
+	return f.createEntryFn(ctx, typeSlug, e, uid, role)
+}
+
+func (f *fakeContentService) DeleteAdminEntry(ctx context.Context, typeSlug string, id uint, uid uint, role string) error {
+	return f.deleteEntryFn(ctx, typeSlug, id, uid, role)
+}
+
+func newContentRouter(svc core.ContentService, actor gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.Use(actor)
+	api := NewContentAPI(svc)
+	r.GET("/content/:type", api.GetEntries)
+	r.DELETE("/admin/content-types/:type", api.DeleteContentType)
+	r.POST("/admin/content/:type", api.CreateEntry)
+	r.DELETE("/admin/content/:type/:id", api.DeleteEntry)
+	return r
+}
+
+func TestContentAPI_GetEntries(t *testing.T) {
+	svc := &fakeContentService{
+		listPublicFn: func(ctx context.Context, typeSlug string, q entity.ContentEntryQuery) ([]entity.ContentEntry, int64, error) {
+			if typeSlug != "products" || q.Page != 2 || q.PageSize != 5 {
+				t.Fatalf("unexpected args: %q %+v", typeSlug, q)
+			}
+			return []entity.ContentEntry{{ID: 1, Title: "Anvil", Slug: "anvil", Data: map[string]any{"price": 10.0}}}, 6, nil
+		},
+	}
+	w := doRequest(newContentRouter(svc, injectActor(0, "")), http.MethodGet, "/content/products?page=2&page_size=5")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got dto.ContentEntryListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Total != 6 || len(got.Items) != 1 || got.Items[0].Data["price"] != 10.0 {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestContentAPI_CreateEntry_FieldError(t *testing.T) {
+	svc := &fakeContentService{
+		createEntryFn: func(ctx context.Context, typeSlug string, e entity.ContentEntry, uid uint, role string) (entity.ContentEntry, error) {
+			if uid != 5 || e.Data["price"] != "free" {
+				t.Fatalf("unexpected args: uid=%d entry=%+v", uid, e)
+			}
+			return entity.ContentEntry{}, fmt.Errorf("%w: %w", core.ErrInvalidInput, &entity.ContentFieldError{Field: "price", Reason: "expected a number"})
+		},
+	}
+	body := map[string]any{"title": "Anvil", "data": map[string]any{"price": "free"}}
+	w := doJSON(newContentRouter(svc, injectActor(5, "user")), http.MethodPost, "/admin/content/products", body)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got dto.ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if got.Details["field"] != "price" || got.Details["reason"] != "expected a number" {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestContentAPI_DeleteContentType_InUse(t *testing.T) {
+	svc := &fakeContentService{
+		deleteTypeFn: func(ctx context.Context, slug string) error {
+			return &service.ContentTypeInUseError{Entries: 3}
+		},
+	}
+	w := doRequest(newContentRouter(svc, injectActor(1, "admin")), http.MethodDelete, "/admin/content-types/products")
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got dto.ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if got.Code != string(core.CodeConflict) || got.Details["references"] != float64(3) {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestContentAPI_DeleteEntry_Referenced(t *testing.T) {
+	svc := &fakeContentService{
+		deleteEntryFn: func(ctx context.Context, typeSlug string, id uint, uid uint, role string) error {
+			return &service.ContentEntryInUseError{Entries: 2, ReferencedBy: []string{"products"}}
+		},
+	}
+	w := doRequest(newContentRouter(svc, injectActor(1, "admin")), http.MethodDelete, "/admin/content/brands/3")
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got dto.ErrorResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if got.Code != string(core.CodeConflict) || got.Details["resource"] != "content_entry" || got.Details["references"] != float64(2) {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+}
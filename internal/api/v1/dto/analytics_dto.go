@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"KaldalisCMS/internal/core/entity"
+)
+
+// analyticsDayLayout formats analytics days, which are whole UTC days.
+const analyticsDayLayout = "2006-01-02"
+
+// DailyViewsResponse is the DTO for the views of one day.
+type DailyViewsResponse struct {
+	Day   string `json:"day"`
+	Views int64  `json:"views"`
+}
+
+// ViewSeriesResponse is a daily views time series with one point per day of the range.
+type ViewSeriesResponse struct {
+	PostID *uint                `json:"post_id,omitempty"`
+	From   string               `json:"from"`
+	To     string               `json:"to"`
+	Total  int64                `json:"total"`
+	Points []DailyViewsResponse `json:"points"`
+}
+
+// TopPostResponse is the DTO for one entry of the most viewed posts.
+type TopPostResponse struct {
+	PostID uint   `json:"post_id"`
+	Title  string `json:"title"`
+	Slug   string `json:"slug"`
+	Locale string `json:"locale"`
+	Views  int64  `json:"views"`
+}
+
+// TopPostsResponse lists the most viewed posts of a range.
+type TopPostsResponse struct {
+	From  string            `json:"from"`
+	To    string            `json:"to"`
+	Items []TopPostResponse `json:"items"`
+}
+
+func ToViewSeriesResponse(postID *uint, r entity.AnalyticsRange, points []entity.DailyViews) ViewSeriesResponse {
+	resp := ViewSeriesResponse{
+		PostID: postID,
+		From:   r.From.Format(analyticsDayLayout),
+		To:     r.To.Format(analyticsDayLayout),
+		Points: make([]DailyViewsResponse, len(points)),
+	}
+	for i, p := range points {
+		resp.Points[i] = DailyViewsResponse{Day: p.Day.Format(analyticsDayLayout), Views: p.Views}
+		resp.Total += p.Views
+	}
+	return resp
+}
+
+func ToTopPostsResponse(r entity.AnalyticsRange, posts []entity.TopPost) TopPostsResponse {
+	resp := TopPostsResponse{
+		From:  r.From.Format(analyticsDayLayout),
+		To:    r.To.Format(analyticsDayLayout),
+		Items: make([]TopPostResponse, len(posts)),
+	}
+	for i, p := range posts {
+		resp.Items[i] = TopPostResponse{PostID: p.PostID, Title: p.Title, Slug: p.Slug, Locale: p.Locale, Views: p.Views}
+	}
+	return resp
+}
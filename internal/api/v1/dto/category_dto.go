@@ -0,0 +1,55 @@
+package dto
+
+import "KaldalisCMS/internal/core/entity"
+
+// CreateCategoryRequest defines the request body for creating a category.
+// Slug is optional and derived from Name when omitted.
+type CreateCategoryRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=50"`
+	Slug string `json:"slug" binding:"omitempty,max=100"`
+}
+
+func (r *CreateCategoryRequest) ToEntity() entity.Category {
+	return entity.Category{Name: r.Name, Slug: r.Slug}
+}
+
+// UpdateCategoryRequest defines the request body for updating a category.
+type UpdateCategoryRequest struct {
+	Name *string `json:"name" binding:"omitempty,min=1,max=50"`
+	Slug *string `json:"slug" binding:"omitempty,min=1,max=100"`
+}
+
+func (r *UpdateCategoryRequest) ToEntity() entity.Category {
+	c := entity.Category{}
+	if r.Name != nil {
+		c.Name = *r.Name
+	}
+	if r.Slug != nil {
+		c.Slug = *r.Slug
+	}
+	return c
+}
+
+// CategoryWithCountResponse is a category plus its number of published posts.
+type CategoryWithCountResponse struct {
+	CategoryResponse
+	PostCount int64 `json:"post_count"`
+}
+
+// CategoryPostsResponse is one page of published posts within a category.
+type CategoryPostsResponse struct {
+	Category CategoryResponse `json:"category"`
+	PostListResponse
+}
+
+func ToCategoryResponse(category entity.Category) CategoryResponse {
+	return CategoryResponse{ID: category.ID, Name: category.Name, Slug: category.Slug}
+}
+
+func ToCategoryListResponse(categories []entity.CategoryWithPostCount) []CategoryWithCountResponse {
+	res := make([]CategoryWithCountResponse, len(categories))
+	for i, c := range categories {
+		res[i] = CategoryWithCountResponse{CategoryResponse: ToCategoryResponse(c.Category), PostCount: c.PostCount}
+	}
+	return res
+}
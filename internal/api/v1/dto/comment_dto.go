@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"time"
+)
+
+// CreateCommentRequest is the payload for commenting on a post. Guests must send
+// author_name; for logged-in readers the account username is used and both author fields are ignored.
+type CreateCommentRequest struct {
+	Body        string `json:"body" binding:"required,min=1,max=5000"`
+	ParentID    *uint  `json:"parent_id"`
+	AuthorName  string `json:"author_name" binding:"max=100"`
+	AuthorEmail string `json:"author_email" binding:"omitempty,email,max=255"`
+}
+
+// ToEntity converts the request into a comment on postID.
+func (r *CreateCommentRequest) ToEntity(postID uint) entity.Comment {
+	return entity.Comment{
+		PostID:      postID,
+		ParentID:    r.ParentID,
+		AuthorName:  r.AuthorName,
+		AuthorEmail: r.AuthorEmail,
+		Body:        r.Body,
+	}
+}
+
+// CommentResponse is the public view of a comment. BodyHTML is sanitized and safe to embed.
+type CommentResponse struct {
+	ID         uint              `json:"id"`
+	ParentID   *uint             `json:"parent_id,omitempty"`
+	AuthorName string            `json:"author_name"`
+	BodyHTML   string            `json:"body_html"`
+	Status     string            `json:"status"`
+	CreatedAt  string            `json:"created_at"`
+	Replies    []CommentResponse `json:"replies,omitempty"`
+}
+
+// ToCommentResponse converts a comment and its replies to the public view.
+func ToCommentResponse(c entity.Comment) CommentResponse {
+	res := CommentResponse{
+		ID:         c.ID,
+		ParentID:   c.ParentID,
+		AuthorName: c.AuthorName,
+		BodyHTML:   c.BodyHTML,
+		Status:     c.Status,
+		CreatedAt:  c.CreatedAt.Format(time.RFC3339),
+	}
+	if len(c.Replies) > 0 {
+		res.Replies = ToCommentListResponse(c.Replies)
+	}
+	return res
+}
+
+func ToCommentListResponse(comments []entity.Comment) []CommentResponse {
+	out := make([]CommentResponse, len(comments))
+	for i, c := range comments {
+		out[i] = ToCommentResponse(c)
+	}
+	return out
+}
+
+// AdminCommentResponse is the moderation view of a comment, including the raw Markdown
+// and the guest email address.
+type AdminCommentResponse struct {
+	ID          uint   `json:"id"`
+	PostID      uint   `json:"post_id"`
+	ParentID    *uint  `json:"parent_id,omitempty"`
+	AuthorID    *uint  `json:"author_id,omitempty"`
+	AuthorName  string `json:"author_name"`
+	AuthorEmail string `json:"author_email,omitempty"`
+	Body        string `json:"body"`
+	BodyHTML    string `json:"body_html"`
+	Status      string `json:"status"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// AdminCommentListResponse is one page of the moderation queue.
+type AdminCommentListResponse struct {
+	Items    []AdminCommentResponse `json:"items"`
+	Total    int64                  `json:"total"`
+	Page     int                    `json:"page"`
+	PageSize int                    `json:"page_size"`
+}
+
+// ToAdminCommentPageResponse wraps one page of comments with its pagination metadata.
+func ToAdminCommentPageResponse(comments []entity.Comment, total int64, query entity.CommentListQuery) AdminCommentListResponse {
+	items := make([]AdminCommentResponse, len(comments))
+	for i, c := range comments {
+		items[i] = AdminCommentResponse{
+			ID:          c.ID,
+			PostID:      c.PostID,
+			ParentID:    c.ParentID,
+			AuthorID:    c.AuthorID,
+			AuthorName:  c.AuthorName,
+			AuthorEmail: c.AuthorEmail,
+			Body:        c.Body,
+			BodyHTML:    c.BodyHTML,
+			Status:      c.Status,
+			CreatedAt:   c.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:   c.UpdatedAt.Format(time.RFC3339),
+		}
+	}
+	return AdminCommentListResponse{Items: items, Total: total, Page: query.Page, PageSize: query.PageSize}
+}
@@ -0,0 +1,159 @@
+package dto
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"time"
+)
+
+// ContentFieldRequest defines one field of a content type schema.
+// Type is one of text, rich_text, number, date, boolean, media, reference;
+// reference fields name the slug of the referenced type in Target.
+type ContentFieldRequest struct {
+	Key      string `json:"key" binding:"required,max=50"`
+	Label    string `json:"label" binding:"max=100"`
+	Type     string `json:"type" binding:"required"`
+	Required bool   `json:"required"`
+	Target   string `json:"target" binding:"max=100"`
+}
+
+// ContentTypeRequest defines the request body for creating or replacing a content type.
+// Slug is optional on create and ignored on update: a type keeps the slug it was created with.
+type ContentTypeRequest struct {
+	Name        string                `json:"name" binding:"required,min=1,max=100"`
+	Slug        string                `json:"slug" binding:"omitempty,max=100"`
+	Description string                `json:"description" binding:"max=1000"`
+	Fields      []ContentFieldRequest `json:"fields" binding:"dive"`
+}
+
+func (r *ContentTypeRequest) ToEntity() entity.ContentType {
+	fields := make([]entity.ContentField, len(r.Fields))
+	for i, f := range r.Fields {
+		fields[i] = entity.ContentField{
+			Key:      f.Key,
+			Label:    f.Label,
+			Type:     entity.ContentFieldType(f.Type),
+			Required: f.Required,
+			Target:   f.Target,
+		}
+	}
+	return entity.ContentType{Name: r.Name, Slug: r.Slug, Description: r.Description, Fields: fields}
+}
+
+// ContentFieldResponse is the DTO for one field of a content type schema.
+type ContentFieldResponse struct {
+	Key      string `json:"key"`
+	Label    string `json:"label,omitempty"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+	Target   string `json:"target,omitempty"`
+}
+
+// ContentTypeResponse is the DTO for a content type.
+type ContentTypeResponse struct {
+	ID          uint                   `json:"id"`
+	Name        string                 `json:"name"`
+	Slug        string                 `json:"slug"`
+	Description string                 `json:"description"`
+	Fields      []ContentFieldResponse `json:"fields"`
+	CreatedAt   string                 `json:"created_at"`
+	UpdatedAt   string                 `json:"updated_at"`
+}
+
+func ToContentTypeResponse(contentType entity.ContentType) ContentTypeResponse {
+	fields := make([]ContentFieldResponse, len(contentType.Fields))
+	for i, f := range contentType.Fields {
+		fields[i] = ContentFieldResponse{
+			Key:      f.Key,
+			Label:    f.Label,
+			Type:     string(f.Type),
+			Required: f.Required,
+			Target:   f.Target,
+		}
+	}
+	return ContentTypeResponse{
+		ID:          contentType.ID,
+		Name:        contentType.Name,
+		Slug:        contentType.Slug,
+		Description: contentType.Description,
+		Fields:      fields,
+		CreatedAt:   contentType.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   contentType.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToContentTypeListResponse(types []entity.ContentType) []ContentTypeResponse {
+	res := make([]ContentTypeResponse, len(types))
+	for i, t := range types {
+		res[i] = ToContentTypeResponse(t)
+	}
+	return res
+}
+
+// CreateContentEntryRequest defines the request body for creating an entry.
+// Data holds the field values keyed by field key and is validated against the type's schema.
+type CreateContentEntryRequest struct {
+	Title string         `json:"title" binding:"required,min=1,max=200"`
+	Slug  string         `json:"slug" binding:"omitempty,max=200"`
+	Data  map[string]any `json:"data"`
+}
+
+func (r *CreateContentEntryRequest) ToEntity() entity.ContentEntry {
+	return entity.ContentEntry{Title: r.Title, Slug: r.Slug, Data: r.Data}
+}
+
+// UpdateContentEntryRequest defines the request body for updating an entry.
+// Omitted fields are left unchanged; data, when present, replaces all field values.
+type UpdateContentEntryRequest struct {
+	Title *string        `json:"title" binding:"omitempty,min=1,max=200"`
+	Slug  *string        `json:"slug" binding:"omitempty,min=1,max=200"`
+	Data  map[string]any `json:"data"`
+}
+
+func (r *UpdateContentEntryRequest) ToPatch() entity.ContentEntryPatch {
+	return entity.ContentEntryPatch{Title: r.Title, Slug: r.Slug, Data: r.Data}
+}
+
+// ContentEntryResponse is the DTO for one content entry.
+type ContentEntryResponse struct {
+	ID        uint           `json:"id"`
+	Title     string         `json:"title"`
+	Slug      string         `json:"slug"`
+	Data      map[string]any `json:"data"`
+	Status    int            `json:"status"`
+	AuthorID  uint           `json:"author_id"`
+	CreatedAt string         `json:"created_at"`
+	UpdatedAt string         `json:"updated_at"`
+}
+
+func ToContentEntryResponse(entry entity.ContentEntry) ContentEntryResponse {
+	data := entry.Data
+	if data == nil {
+		data = map[string]any{}
+	}
+	return ContentEntryResponse{
+		ID:        entry.ID,
+		Title:     entry.Title,
+		Slug:      entry.Slug,
+		Data:      data,
+		Status:    entry.Status,
+		AuthorID:  entry.AuthorID,
+		CreatedAt: entry.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: entry.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// ContentEntryListResponse is one page of entries with its pagination metadata.
+type ContentEntryListResponse struct {
+	Items    []ContentEntryResponse `json:"items"`
+	Total    int64                  `json:"total"`
+	Page     int                    `json:"page"`
+	PageSize int                    `json:"page_size"`
+}
+
+func ToContentEntryPageResponse(entries []entity.ContentEntry, total int64, query entity.ContentEntryQuery) ContentEntryListResponse {
+	items := make([]ContentEntryResponse, len(entries))
+	for i, e := range entries {
+		items[i] = ToContentEntryResponse(e)
+	}
+	return ContentEntryListResponse{Items: items, Total: total, Page: query.Page, PageSize: query.PageSize}
+}
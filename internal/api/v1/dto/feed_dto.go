@@ -0,0 +1,284 @@
+package dto
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"encoding/xml"
+	"mime"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+)
+
+// RSSFeed is an RSS 2.0 document. Full content goes in content:encoded, the excerpt in description.
+type RSSFeed struct {
+	XMLName      xml.Name   `xml:"rss"`
+	Version      string     `xml:"version,attr"`
+	XMLNSAtom    string     `xml:"xmlns:atom,attr"`
+	XMLNSContent string     `xml:"xmlns:content,attr"`
+	XMLNSDC      string     `xml:"xmlns:dc,attr"`
+	Channel      RSSChannel `xml:"channel"`
+}
+
+type RSSChannel struct {
+	Title         string      `xml:"title"`
+	Link          string      `xml:"link"`
+	Description   string      `xml:"description"`
+	Language      string      `xml:"language,omitempty"`
+	LastBuildDate string      `xml:"lastBuildDate,omitempty"`
+	SelfLink      RSSAtomLink `xml:"atom:link"`
+	Items         []RSSItem   `xml:"item"`
+}
+
+type RSSAtomLink struct {
+	Href     string `xml:"href,attr"`
+	Rel      string `xml:"rel,attr"`
+	Type     string `xml:"type,attr,omitempty"`
+	Hreflang string `xml:"hreflang,attr,omitempty"`
+}
+
+type RSSItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        RSSGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Creator     string        `xml:"dc:creator,omitempty"`
+	Categories  []string      `xml:"category"`
+	Description string        `xml:"description"`
+	Content     string        `xml:"content:encoded,omitempty"`
+	Enclosure   *RSSEnclosure `xml:"enclosure"`
+	// Translations are atom:link rel="alternate" elements carrying hreflang.
+	Translations []RSSAtomLink `xml:"atom:link"`
+}
+
+type RSSGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type RSSEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length string `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// ToRSSFeed encodes feed as RSS 2.0; selfURL is the absolute URL the feed was fetched from.
+func ToRSSFeed(feed entity.Feed, selfURL string) RSSFeed {
+	channel := RSSChannel{
+		Title:       feed.Title,
+		Link:        feed.Link,
+		Description: feed.Description,
+		Language:    feed.Language,
+		SelfLink:    RSSAtomLink{Href: selfURL, Rel: "self", Type: "application/rss+xml"},
+		Items:       make([]RSSItem, len(feed.Items)),
+	}
+	if !feed.Updated.IsZero() {
+		channel.LastBuildDate = feed.Updated.UTC().Format(time.RFC1123Z)
+	}
+	for i, it := range feed.Items {
+		item := RSSItem{
+			Title:       it.Title,
+			Link:        it.URL,
+			GUID:        RSSGUID{IsPermaLink: true, Value: it.URL},
+			PubDate:     it.Published.UTC().Format(time.RFC1123Z),
+			Creator:     it.AuthorName,
+			Categories:  it.Categories,
+			Description: it.Summary,
+			Content:     it.ContentHTML,
+		}
+		if it.ImageURL != "" {
+			// RSS requires a length; 0 is the accepted value when it is unknown.
+			item.Enclosure = &RSSEnclosure{URL: it.ImageURL, Length: "0", Type: imageMimeType(it.ImageURL)}
+		}
+		for _, alt := range it.Alternates {
+			item.Translations = append(item.Translations, RSSAtomLink{Href: alt.URL, Rel: "alternate", Type: "text/html", Hreflang: alt.Locale})
+		}
+		channel.Items[i] = item
+	}
+	return RSSFeed{
+		Version:      "2.0",
+		XMLNSAtom:    "http://www.w3.org/2005/Atom",
+		XMLNSContent: "http://purl.org/rss/1.0/modules/content/",
+		XMLNSDC:      "http://purl.org/dc/elements/1.1/",
+		Channel:      channel,
+	}
+}
+
+// AtomFeed is an Atom 1.0 (RFC 4287) document.
+type AtomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Lang     string      `xml:"xml:lang,attr,omitempty"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	ID       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Links    []AtomLink  `xml:"link"`
+	Entries  []AtomEntry `xml:"entry"`
+}
+
+type AtomLink struct {
+	Href     string `xml:"href,attr"`
+	Rel      string `xml:"rel,attr,omitempty"`
+	Type     string `xml:"type,attr,omitempty"`
+	Hreflang string `xml:"hreflang,attr,omitempty"`
+}
+
+type AtomEntry struct {
+	Lang       string         `xml:"xml:lang,attr,omitempty"`
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Links      []AtomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     AtomAuthor     `xml:"author"`
+	Categories []AtomCategory `xml:"category"`
+	Summary    AtomText       `xml:"summary"`
+	Content    *AtomText      `xml:"content"`
+}
+
+type AtomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type AtomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// ToAtomFeed encodes feed as Atom; selfURL is the absolute URL the feed was fetched from.
+func ToAtomFeed(feed entity.Feed, selfURL string) AtomFeed {
+	updated := feed.Updated
+	if updated.IsZero() {
+		// <updated> is mandatory; an empty feed has never changed.
+		updated = time.Unix(0, 0)
+	}
+	out := AtomFeed{
+		Lang:     feed.Language,
+		Title:    feed.Title,
+		Subtitle: feed.Description,
+		ID:       feed.Link,
+		Updated:  updated.UTC().Format(time.RFC3339),
+		Links: []AtomLink{
+			{Href: selfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: feed.Link, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: make([]AtomEntry, len(feed.Items)),
+	}
+	for i, it := range feed.Items {
+		entry := AtomEntry{
+			Lang:      it.Language,
+			Title:     it.Title,
+			ID:        it.URL,
+			Links:     []AtomLink{{Href: it.URL, Rel: "alternate", Type: "text/html"}},
+			Published: it.Published.UTC().Format(time.RFC3339),
+			Updated:   it.Updated.UTC().Format(time.RFC3339),
+			Author:    AtomAuthor{Name: it.AuthorName},
+			Summary:   AtomText{Type: "text", Value: it.Summary},
+		}
+		for _, c := range it.Categories {
+			entry.Categories = append(entry.Categories, AtomCategory{Term: c})
+		}
+		if it.ContentHTML != "" {
+			entry.Content = &AtomText{Type: "html", Value: it.ContentHTML}
+		}
+		if it.ImageURL != "" {
+			entry.Links = append(entry.Links, AtomLink{Href: it.ImageURL, Rel: "enclosure", Type: imageMimeType(it.ImageURL)})
+		}
+		for _, alt := range it.Alternates {
+			entry.Links = append(entry.Links, AtomLink{Href: alt.URL, Rel: "alternate", Type: "text/html", Hreflang: alt.Locale})
+		}
+		out.Entries[i] = entry
+	}
+	return out
+}
+
+// JSONFeed is a JSON Feed 1.1 document (https://jsonfeed.org/version/1.1).
+type JSONFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url"`
+	Description string         `json:"description,omitempty"`
+	Language    string         `json:"language,omitempty"`
+	Items       []JSONFeedItem `json:"items"`
+}
+
+type JSONFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html,omitempty"`
+	ContentText   string           `json:"content_text,omitempty"`
+	Summary       string           `json:"summary,omitempty"`
+	Image         string           `json:"image,omitempty"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Authors       []JSONFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	Language      string           `json:"language,omitempty"`
+	// Translations is a custom extension (JSON Feed extensions start with "_").
+	Translations []JSONFeedTranslation `json:"_translations,omitempty"`
+}
+
+// JSONFeedTranslation points at another language version of an item.
+type JSONFeedTranslation struct {
+	Language string `json:"language"`
+	URL      string `json:"url"`
+}
+
+type JSONFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// ToJSONFeed encodes feed as JSON Feed; selfURL is the absolute URL the feed was fetched from.
+func ToJSONFeed(feed entity.Feed, selfURL string) JSONFeed {
+	out := JSONFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       feed.Title,
+		HomePageURL: feed.Link,
+		FeedURL:     selfURL,
+		Description: feed.Description,
+		Language:    feed.Language,
+		Items:       make([]JSONFeedItem, len(feed.Items)),
+	}
+	for i, it := range feed.Items {
+		item := JSONFeedItem{
+			ID:            strconv.FormatUint(uint64(it.ID), 10),
+			URL:           it.URL,
+			Title:         it.Title,
+			ContentHTML:   it.ContentHTML,
+			Summary:       it.Summary,
+			Image:         it.ImageURL,
+			DatePublished: it.Published.UTC().Format(time.RFC3339),
+			DateModified:  it.Updated.UTC().Format(time.RFC3339),
+			Tags:          it.Categories,
+			Language:      it.Language,
+		}
+		for _, alt := range it.Alternates {
+			item.Translations = append(item.Translations, JSONFeedTranslation{Language: alt.Locale, URL: alt.URL})
+		}
+		if item.ContentHTML == "" {
+			// Every item needs content_html or content_text.
+			item.ContentText = it.Summary
+		}
+		if it.AuthorName != "" {
+			item.Authors = []JSONFeedAuthor{{Name: it.AuthorName}}
+		}
+		out.Items[i] = item
+	}
+	return out
+}
+
+// imageMimeType guesses a cover's media type from its URL path extension.
+func imageMimeType(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		if t := mime.TypeByExtension(path.Ext(u.Path)); t != "" {
+			return t
+		}
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,150 @@
+package dto
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/core/entity"
+)
+
+func sampleFeed() entity.Feed {
+	return entity.Feed{
+		Title:   "Blog",
+		Link:    "https://blog.example.com/",
+		Updated: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Items: []entity.FeedItem{{
+			ID:          9,
+			Title:       "Hello & welcome",
+			URL:         "https://blog.example.com/posts/hello",
+			Summary:     "Intro",
+			ContentHTML: "<p>Intro</p>",
+			AuthorName:  "alice",
+			ImageURL:    "https://media.example.com/media/a/cover.png",
+			Categories:  []string{"Go"},
+			Published:   time.Date(2024, 4, 30, 8, 0, 0, 0, time.UTC),
+			Updated:     time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		}},
+	}
+}
+
+func TestToRSSFeed(t *testing.T) {
+	body, err := xml.Marshal(ToRSSFeed(sampleFeed(), "https://blog.example.com/feed.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := string(body)
+	for _, want := range []string{
+		`<rss version="2.0"`,
+		`<atom:link href="https://blog.example.com/feed.xml" rel="self" type="application/rss+xml">`,
+		`<title>Hello &amp; welcome</title>`,
+		`<guid isPermaLink="true">https://blog.example.com/posts/hello</guid>`,
+		`<pubDate>Tue, 30 Apr 2024 08:00:00 +0000</pubDate>`,
+		`<content:encoded>&lt;p&gt;Intro&lt;/p&gt;</content:encoded>`,
+		`<enclosure url="https://media.example.com/media/a/cover.png" length="0" type="image/png">`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Fatalf("RSS missing %q in:\n%s", want, doc)
+		}
+	}
+}
+
+func TestToAtomFeed(t *testing.T) {
+	body, err := xml.Marshal(ToAtomFeed(sampleFeed(), "https://blog.example.com/atom.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := string(body)
+	for _, want := range []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom">`,
+		`<updated>2024-05-01T12:00:00Z</updated>`,
+		`<link href="https://blog.example.com/atom.xml" rel="self" type="application/atom+xml">`,
+		`<content type="html">&lt;p&gt;Intro&lt;/p&gt;</content>`,
+		`<link href="https://media.example.com/media/a/cover.png" rel="enclosure" type="image/png">`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Fatalf("Atom missing %q in:\n%s", want, doc)
+		}
+	}
+
+	empty := ToAtomFeed(entity.Feed{Title: "Blog"}, "https://blog.example.com/atom.xml")
+	if empty.Updated != "1970-01-01T00:00:00Z" {
+		t.Fatalf("empty feed updated = %q", empty.Updated)
+	}
+}
+
+func TestToJSONFeed(t *testing.T) {
+	feed := sampleFeed()
+	feed.Items = append(feed.Items, entity.FeedItem{ID: 10, Title: "Plain", Summary: "just text"})
+
+	body, err := json.Marshal(ToJSONFeed(feed, "https://blog.example.com/feed.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["version"] != "https://jsonfeed.org/version/1.1" || got["feed_url"] != "https://blog.example.com/feed.json" {
+		t.Fatalf("unexpected header: %v", got)
+	}
+	items := got["items"].([]any)
+	first := items[0].(map[string]any)
+	if first["id"] != "9" || first["content_html"] != "<p>Intro</p>" || first["image"] == nil {
+		t.Fatalf("unexpected first item: %v", first)
+	}
+	second := items[1].(map[string]any)
+	if second["content_text"] != "just text" {
+		t.Fatalf("item without HTML must carry content_text: %v", second)
+	}
+}
+
+func TestFeedTranslations(t *testing.T) {
+	feed := sampleFeed()
+	feed.Language = "en"
+	feed.Items[0].Language = "en"
+	feed.Items[0].Alternates = []entity.LocaleAlternate{{Locale: "zh-CN", URL: "https://blog.example.com/posts/ni-hao"}}
+
+	rss, err := xml.Marshal(ToRSSFeed(feed, "https://blog.example.com/feed.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	atom, err := xml.Marshal(ToAtomFeed(feed, "https://blog.example.com/atom.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, check := range []struct{ doc, want string }{
+		{string(rss), `<language>en</language>`},
+		{string(rss), `<atom:link href="https://blog.example.com/posts/ni-hao" rel="alternate" type="text/html" hreflang="zh-CN"></atom:link>`},
+		{string(atom), `xml:lang="en"`},
+		{string(atom), `<link href="https://blog.example.com/posts/ni-hao" rel="alternate" type="text/html" hreflang="zh-CN"></link>`},
+	} {
+		if !strings.Contains(check.doc, check.want) {
+			t.Fatalf("missing %q in:\n%s", check.want, check.doc)
+		}
+	}
+
+	body, err := json.Marshal(ToJSONFeed(feed, "https://blog.example.com/feed.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Language string `json:"language"`
+		Items    []struct {
+			Language     string `json:"language"`
+			Translations []struct {
+				Language string `json:"language"`
+				URL      string `json:"url"`
+			} `json:"_translations"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Language != "en" || got.Items[0].Language != "en" || len(got.Items[0].Translations) != 1 ||
+		got.Items[0].Translations[0].Language != "zh-CN" {
+		t.Fatalf("unexpected JSON Feed: %s", body)
+	}
+}
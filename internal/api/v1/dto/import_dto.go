@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// ImportItemResponse is one line of the import report. Action is create, exists or error;
+// failed items carry an error code and message.
+type ImportItemResponse struct {
+	Kind      string         `json:"kind"`
+	Source    string         `json:"source"`
+	Action    string         `json:"action"`
+	ID        uint           `json:"id,omitempty"`
+	ErrorCode core.ErrorCode `json:"error_code,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// ImportCounts sums the report items of one kind by action.
+type ImportCounts struct {
+	Create int `json:"create"`
+	Exists int `json:"exists"`
+	Error  int `json:"error"`
+}
+
+// ImportResponse is the import report. In a dry run nothing was written and created items
+// have no id.
+type ImportResponse struct {
+	Format  string                  `json:"format"`
+	DryRun  bool                    `json:"dry_run"`
+	Summary map[string]ImportCounts `json:"summary"`
+	Items   []ImportItemResponse    `json:"items"`
+}
+
+func ToImportResponse(report entity.ImportReport) ImportResponse {
+	resp := ImportResponse{
+		Format:  string(report.Format),
+		DryRun:  report.DryRun,
+		Summary: make(map[string]ImportCounts),
+		Items:   make([]ImportItemResponse, len(report.Items)),
+	}
+	for _, kind := range []entity.ImportItemKind{entity.ImportKindUser, entity.ImportKindCategory, entity.ImportKindTag, entity.ImportKindSeries, entity.ImportKindPost, entity.ImportKindMedia} {
+		resp.Summary[string(kind)] = ImportCounts{
+			Create: report.Count(kind, entity.ImportActionCreate),
+			Exists: report.Count(kind, entity.ImportActionExists),
+			Error:  report.Count(kind, entity.ImportActionError),
+		}
+	}
+	for i, item := range report.Items {
+		resp.Items[i] = ImportItemResponse{Kind: string(item.Kind), Source: item.Source, Action: string(item.Action), ID: item.ID}
+		if item.Err != nil {
+			resp.Items[i].ErrorCode = core.ErrorCodeOf(item.Err)
+			resp.Items[i].Error = item.Err.Error()
+			if resp.Items[i].ErrorCode == core.CodeInternalError {
+				resp.Items[i].Error = "internal error"
+			}
+		}
+	}
+	return resp
+}
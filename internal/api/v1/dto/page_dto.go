@@ -0,0 +1,149 @@
+package dto
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"time"
+)
+
+// CreatePageRequest defines the request body for creating a page.
+// The slug is derived from the title when omitted; parent_id places the page under another page.
+type CreatePageRequest struct {
+	Title    string `json:"title" binding:"required,min=1,max=200"`
+	Slug     string `json:"slug" binding:"omitempty,max=200"`
+	Content  string `json:"content"`
+	Cover    string `json:"cover" binding:"omitempty,max=500"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+func (r *CreatePageRequest) ToEntity() entity.Page {
+	return entity.Page{Title: r.Title, Slug: r.Slug, Content: r.Content, Cover: r.Cover, ParentID: r.ParentID}
+}
+
+// UpdatePageRequest defines the request body for updating a page.
+// Omitted fields are left unchanged; parent_id 0 moves the page to the top level.
+type UpdatePageRequest struct {
+	Title    *string `json:"title" binding:"omitempty,min=1,max=200"`
+	Slug     *string `json:"slug" binding:"omitempty,min=1,max=200"`
+	Content  *string `json:"content"`
+	Cover    *string `json:"cover" binding:"omitempty,max=500"`
+	ParentID *uint   `json:"parent_id"`
+}
+
+func (r *UpdatePageRequest) ToPatch() entity.PagePatch {
+	return entity.PagePatch{Title: r.Title, Slug: r.Slug, Content: r.Content, Cover: r.Cover, ParentID: r.ParentID}
+}
+
+// ReorderPagesRequest sets the order of all children of one parent; parent_id null or 0
+// addresses the top-level pages.
+type ReorderPagesRequest struct {
+	ParentID *uint  `json:"parent_id"`
+	PageIDs  []uint `json:"page_ids" binding:"required,min=1"`
+}
+
+// PageRefResponse is a lightweight reference to a page, used for breadcrumbs and children.
+type PageRefResponse struct {
+	ID     uint   `json:"id"`
+	Title  string `json:"title"`
+	Slug   string `json:"slug"`
+	Path   string `json:"path"`
+	Status int    `json:"status"`
+}
+
+// PageResponse is the DTO for one page.
+type PageResponse struct {
+	ID        uint   `json:"id"`
+	ParentID  *uint  `json:"parent_id"`
+	Title     string `json:"title"`
+	Slug      string `json:"slug"`
+	Path      string `json:"path"`
+	Content   string `json:"content"`
+	Cover     string `json:"cover"`
+	Status    int    `json:"status"`
+	SortOrder int    `json:"sort_order"`
+	AuthorID  uint   `json:"author_id"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	// Breadcrumbs lists the ancestors from the top level down; Children the direct children
+	// in sibling order. Both are only filled on single-page reads and empty in listings.
+	Breadcrumbs []PageRefResponse `json:"breadcrumbs"`
+	Children    []PageRefResponse `json:"children"`
+	// ContentHTML and TOC are only present on public page reads.
+	ContentHTML string             `json:"content_html,omitempty"`
+	TOC         []TOCEntryResponse `json:"toc,omitempty"`
+}
+
+func ToPageResponse(page entity.Page) PageResponse {
+	res := PageResponse{
+		ID:        page.ID,
+		ParentID:  page.ParentID,
+		Title:     page.Title,
+		Slug:      page.Slug,
+		Path:      page.Path,
+		Content:   page.Content,
+		Cover:     page.Cover,
+		Status:    page.Status,
+		SortOrder: page.SortOrder,
+		AuthorID:  page.AuthorID,
+		CreatedAt: page.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: page.UpdatedAt.Format(time.RFC3339),
+	}
+	res.Breadcrumbs = toPageRefResponses(page.Breadcrumbs)
+	res.Children = toPageRefResponses(page.Children)
+	if page.Rendered != nil {
+		res.ContentHTML = page.Rendered.HTML
+		if len(page.Rendered.TOC) > 0 {
+			res.TOC = make([]TOCEntryResponse, len(page.Rendered.TOC))
+			for i, e := range page.Rendered.TOC {
+				res.TOC[i] = TOCEntryResponse{Level: e.Level, Text: e.Text, Anchor: e.Anchor}
+			}
+		}
+	}
+	return res
+}
+
+func toPageRefResponses(refs []entity.PageRef) []PageRefResponse {
+	out := make([]PageRefResponse, len(refs))
+	for i, r := range refs {
+		out[i] = PageRefResponse{ID: r.ID, Title: r.Title, Slug: r.Slug, Path: r.Path, Status: r.Status}
+	}
+	return out
+}
+
+// PageTreeNode is one page of the public navigation tree.
+type PageTreeNode struct {
+	ID       uint           `json:"id"`
+	Title    string         `json:"title"`
+	Slug     string         `json:"slug"`
+	Path     string         `json:"path"`
+	Children []PageTreeNode `json:"children"`
+}
+
+// ToPageTree nests pages under their parents, keeping the order they are given in.
+// Pages whose parent is not in the list become roots.
+func ToPageTree(pages []entity.Page) []PageTreeNode {
+	present := make(map[uint]struct{}, len(pages))
+	for _, p := range pages {
+		present[p.ID] = struct{}{}
+	}
+	byParent := make(map[uint][]entity.Page, len(pages))
+	var roots []entity.Page
+	for _, p := range pages {
+		if p.ParentID != nil {
+			if _, ok := present[*p.ParentID]; ok {
+				byParent[*p.ParentID] = append(byParent[*p.ParentID], p)
+				continue
+			}
+		}
+		roots = append(roots, p)
+	}
+
+	var build func([]entity.Page) []PageTreeNode
+	build = func(level []entity.Page) []PageTreeNode {
+		nodes := make([]PageTreeNode, len(level))
+		for i, p := range level {
+			nodes[i] = PageTreeNode{ID: p.ID, Title: p.Title, Slug: p.Slug, Path: p.Path, Children: build(byParent[p.ID])}
+		}
+		return nodes
+	}
+	return build(roots)
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"time"
+)
+
+// SavePostAutosaveRequest carries an editor's unsaved title and content.
+// Fields are not required to be complete: validity is only enforced when the autosave is promoted.
+type SavePostAutosaveRequest struct {
+	Title   string `json:"title" binding:"max=100"`
+	Content string `json:"content"`
+	// BaseVersion is the post version the editor loaded; omit it to use the current version.
+	BaseVersion uint `json:"base_version"`
+}
+
+// ToEntity converts the request to an entity.PostAutosave; post and user are set by the service.
+func (r *SavePostAutosaveRequest) ToEntity() entity.PostAutosave {
+	return entity.PostAutosave{Title: r.Title, Content: r.Content, BaseVersion: r.BaseVersion}
+}
+
+// PostAutosaveResponse is the DTO for the current user's autosave of a post.
+type PostAutosaveResponse struct {
+	PostID      uint   `json:"post_id"`
+	Title       string `json:"title"`
+	Content     string `json:"content"`
+	BaseVersion uint   `json:"base_version"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// ToPostAutosaveResponse converts an entity.PostAutosave to its DTO.
+func ToPostAutosaveResponse(autosave entity.PostAutosave) PostAutosaveResponse {
+	return PostAutosaveResponse{
+		PostID:      autosave.PostID,
+		Title:       autosave.Title,
+		Content:     autosave.Content,
+		BaseVersion: autosave.BaseVersion,
+		UpdatedAt:   autosave.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// BulkPostsRequest applies one action to many posts.
+type BulkPostsRequest struct {
+	Action  string `json:"action" binding:"required,oneof=publish unpublish delete retag set_category"`
+	PostIDs []uint `json:"post_ids" binding:"required_without=Items,omitempty,min=1,max=100,dive,min=1"`
+	// Items lists posts together with the version the client last saw, like If-Match on the
+	// single-post endpoints. It may be combined with PostIDs, whose posts are written unconditionally.
+	Items []BulkPostItemRequest `json:"items" binding:"omitempty,max=100,dive"`
+	// Tags and TagNames replace the tags of every post for retag; send an empty list to clear them.
+	Tags     []uint   `json:"tags"`
+	TagNames []string `json:"tag_names" binding:"omitempty,dive,min=1,max=50"`
+	// CategoryID is the new category for set_category.
+	CategoryID *uint `json:"category_id" binding:"omitempty,min=1"`
+	// Atomic applies all posts or none: the first failure rolls back the whole request.
+	Atomic bool `json:"atomic"`
+}
+
+// BulkPostItemRequest names one post and, optionally, the version it must still be at.
+type BulkPostItemRequest struct {
+	ID      uint `json:"id" binding:"required,min=1"`
+	Version uint `json:"version"`
+}
+
+func (r *BulkPostsRequest) ToEntity() entity.PostBulkRequest {
+	ids := append([]uint(nil), r.PostIDs...)
+	var versions map[uint]uint
+	for _, item := range r.Items {
+		ids = append(ids, item.ID)
+		if item.Version == 0 {
+			continue
+		}
+		if versions == nil {
+			versions = make(map[uint]uint, len(r.Items))
+		}
+		if _, seen := versions[item.ID]; !seen {
+			versions[item.ID] = item.Version
+		}
+	}
+	return entity.PostBulkRequest{
+		Action:     entity.PostBulkAction(r.Action),
+		PostIDs:    ids,
+		Versions:   versions,
+		Tags:       tagsFromRequest(r.Tags, r.TagNames),
+		CategoryID: r.CategoryID,
+		Atomic:     r.Atomic,
+	}
+}
+
+// BulkPostItemResponse reports the outcome for one post: applied, failed, rolled_back or
+// skipped. Failed items carry the error code the single-post endpoint would have returned.
+type BulkPostItemResponse struct {
+	PostID    uint           `json:"post_id"`
+	Outcome   string         `json:"outcome"`
+	ErrorCode core.ErrorCode `json:"error_code,omitempty"`
+}
+
+// BulkPostsResponse reports a bulk action item by item, in request order.
+type BulkPostsResponse struct {
+	Action     string                 `json:"action"`
+	Atomic     bool                   `json:"atomic"`
+	RolledBack bool                   `json:"rolled_back"`
+	Applied    int                    `json:"applied"`
+	Failed     int                    `json:"failed"`
+	Items      []BulkPostItemResponse `json:"items"`
+}
+
+func ToBulkPostsResponse(result entity.PostBulkResult) BulkPostsResponse {
+	resp := BulkPostsResponse{
+		Action:     string(result.Action),
+		Atomic:     result.Atomic,
+		RolledBack: result.RolledBack,
+		Items:      make([]BulkPostItemResponse, len(result.Items)),
+	}
+	for i, item := range result.Items {
+		resp.Items[i] = BulkPostItemResponse{PostID: item.PostID, Outcome: item.Outcome}
+		switch item.Outcome {
+		case entity.PostBulkApplied:
+			resp.Applied++
+		case entity.PostBulkFailed:
+			resp.Failed++
+			resp.Items[i].ErrorCode = core.ErrorCodeOf(item.Err)
+		}
+	}
+	return resp
+}
@@ -2,6 +2,8 @@ package dto
 
 import (
 	"KaldalisCMS/internal/core/entity"
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -12,6 +14,17 @@ type CreatePostRequest struct {
 	Cover      string `json:"cover" binding:"max=255"`
 	CategoryID *uint  `json:"category_id"`
 	Tags       []uint `json:"tags"`
+	// TagNames references tags by name; unknown names are created.
+	TagNames []string `json:"tag_names" binding:"omitempty,dive,min=1,max=50"`
+	// NoIndex keeps the post out of the sitemap and search engine indexes.
+	NoIndex bool `json:"no_index"`
+	// CommentsDisabled closes the post for new comments.
+	CommentsDisabled bool `json:"comments_disabled"`
+	// Locale is the language tag of the post, e.g. "en"; it defaults to zh-CN.
+	Locale string `json:"locale" binding:"omitempty,max=35"`
+	// SeriesID adds the post to a series at SeriesPosition; position 0 appends it at the end.
+	SeriesID       *uint `json:"series_id"`
+	SeriesPosition int   `json:"series_position" binding:"omitempty,min=0"`
 }
 
 // ToEntity converts a CreatePostRequest DTO to an entity.Post.
@@ -19,28 +32,40 @@ type CreatePostRequest struct {
 // not trusted from the request binding layer.
 func (r *CreatePostRequest) ToEntity() *entity.Post {
 	post := &entity.Post{
-		Title:      r.Title,
-		Content:    r.Content,
-		Cover:      r.Cover,
-		CategoryID: r.CategoryID,
-		Status:     entity.StatusDraft, // 默认创建为草稿
-	}
-	if r.Tags != nil {
-		post.Tags = make([]entity.Tag, 0, len(r.Tags))
-		for _, tagID := range r.Tags {
-			post.Tags = append(post.Tags, entity.Tag{ID: tagID})
-		}
+		Title:            r.Title,
+		Content:          r.Content,
+		Cover:            r.Cover,
+		CategoryID:       r.CategoryID,
+		Status:           entity.StatusDraft, // 默认创建为草稿
+		Tags:             tagsFromRequest(r.Tags, r.TagNames),
+		NoIndex:          r.NoIndex,
+		CommentsDisabled: r.CommentsDisabled,
+		Locale:           r.Locale,
 	}
+	post.SeriesID, post.SeriesPosition = r.SeriesID, r.SeriesPosition
 	return post
 }
 
 // UpdatePostRequest defines the structure for updating an existing post.
 type UpdatePostRequest struct {
 	Title      *string `json:"title" binding:"omitempty,min=1,max=100"`
+	Slug       *string `json:"slug" binding:"omitempty,min=1,max=200"`
 	Content    *string `json:"content"`
 	Cover      *string `json:"cover" binding:"omitempty,max=255"`
 	CategoryID *uint   `json:"category_id"`
 	Tags       []uint  `json:"tags"`
+	// TagNames references tags by name; unknown names are created. When either Tags or
+	// TagNames is present, the post's tags are replaced with the union of both.
+	TagNames []string `json:"tag_names" binding:"omitempty,dive,min=1,max=50"`
+	NoIndex  *bool    `json:"no_index"`
+	// CommentsDisabled closes (true) or reopens (false) the post for new comments.
+	CommentsDisabled *bool `json:"comments_disabled"`
+	// Locale moves the post to another language; its slug must be free in that locale.
+	Locale *string `json:"locale" binding:"omitempty,min=2,max=35"`
+	// SeriesID moves the post into a series, or out of its series when 0.
+	// SeriesPosition moves it within the series; 0 moves it to the end.
+	SeriesID       *uint `json:"series_id"`
+	SeriesPosition *int  `json:"series_position" binding:"omitempty,min=0"`
 	// Status 由专用发布工作流接口管理：
 	// POST /admin/posts/:id/publish 与 POST /admin/posts/:id/draft。
 	// 这里保留字段兼容旧调用方，但 ToEntity 会显式忽略它。
@@ -55,6 +80,9 @@ func (r *UpdatePostRequest) ToEntity() entity.Post {
 	if r.Title != nil {
 		post.Title = *r.Title
 	}
+	if r.Slug != nil {
+		post.Slug = *r.Slug
+	}
 	if r.Content != nil {
 		post.Content = *r.Content
 	}
@@ -64,12 +92,16 @@ func (r *UpdatePostRequest) ToEntity() entity.Post {
 	if r.CategoryID != nil {
 		post.CategoryID = r.CategoryID
 	}
-	if r.Tags != nil {
-		post.Tags = make([]entity.Tag, 0, len(r.Tags))
-		for _, tagID := range r.Tags {
-			post.Tags = append(post.Tags, entity.Tag{ID: tagID})
-		}
+	if r.NoIndex != nil {
+		post.NoIndex = *r.NoIndex
+	}
+	if r.CommentsDisabled != nil {
+		post.CommentsDisabled = *r.CommentsDisabled
+	}
+	if r.Locale != nil {
+		post.Locale = *r.Locale
 	}
+	post.Tags = tagsFromRequest(r.Tags, r.TagNames)
 	// 状态切换必须走专用后台工作流接口，避免普通更新绕过业务约束。
 	return post
 }
@@ -79,14 +111,17 @@ func (r *UpdatePostRequest) ToEntity() entity.Post {
 // the distinction between omitted and explicitly provided scalar fields.
 func (r *UpdatePostRequest) ToPatch() entity.PostPatch {
 	patch := entity.PostPatch{
-		Title:      r.Title,
-		Content:    r.Content,
-		Cover:      r.Cover,
-		CategoryID: r.CategoryID,
-	}
-	if r.Tags != nil {
-		patch.Tags = tagsFromIDs(r.Tags)
+		Title:            r.Title,
+		Slug:             r.Slug,
+		Content:          r.Content,
+		Cover:            r.Cover,
+		CategoryID:       r.CategoryID,
+		NoIndex:          r.NoIndex,
+		CommentsDisabled: r.CommentsDisabled,
+		Locale:           r.Locale,
 	}
+	patch.Tags = tagsFromRequest(r.Tags, r.TagNames)
+	patch.SeriesID, patch.SeriesPosition = r.SeriesID, r.SeriesPosition
 	return patch
 }
 
@@ -103,21 +138,107 @@ func tagsFromIDs(ids []uint) []entity.Tag {
 	return tags
 }
 
+// tagsFromRequest merges tag IDs and tag names into one slice; name-only entries carry ID 0
+// and are resolved by the service. It returns nil when neither list was sent.
+func tagsFromRequest(ids []uint, names []string) []entity.Tag {
+	if ids == nil && names == nil {
+		return nil
+	}
+	tags := tagsFromIDs(ids)
+	if tags == nil {
+		tags = make([]entity.Tag, 0, len(names))
+	}
+	for _, name := range names {
+		tags = append(tags, entity.Tag{Name: name})
+	}
+	return tags
+}
+
 // --- 以下是建议新增和修改的部分 ---
 
 // PostResponse is the DTO for a single post.
 type PostResponse struct {
-	ID        uint              `json:"id"`
-	Title     string            `json:"title"`
-	Slug      string            `json:"slug"`
-	Content   string            `json:"content"`
-	Cover     string            `json:"cover"`
-	Status    int               `json:"status"`
-	Author    AuthorResponse    `json:"author"`
-	Category  *CategoryResponse `json:"category,omitempty"`
-	Tags      []TagResponse     `json:"tags,omitempty"`
-	CreatedAt string            `json:"created_at"`
-	UpdatedAt string            `json:"updated_at"`
+	ID      uint   `json:"id"`
+	Title   string `json:"title"`
+	Slug    string `json:"slug"`
+	Content string `json:"content"`
+	// ContentHTML and TOC are only present on public single-post reads.
+	// ContentHTML is sanitized and safe to embed; TOC anchors match heading ids in it.
+	ContentHTML      string             `json:"content_html,omitempty"`
+	TOC              []TOCEntryResponse `json:"toc,omitempty"`
+	Cover            string             `json:"cover"`
+	Status           int                `json:"status"`
+	Version          uint               `json:"version"`
+	NoIndex          bool               `json:"no_index"`
+	CommentsDisabled bool               `json:"comments_disabled"`
+	Locale           string             `json:"locale"`
+	Author           AuthorResponse     `json:"author"`
+	Category         *CategoryResponse  `json:"category,omitempty"`
+	Tags             []TagResponse      `json:"tags,omitempty"`
+	CreatedAt        string             `json:"created_at"`
+	UpdatedAt        string             `json:"updated_at"`
+	// PublishAt and UnpublishAt are only present while a schedule is pending.
+	PublishAt   string `json:"publish_at,omitempty"`
+	UnpublishAt string `json:"unpublish_at,omitempty"`
+	// ReviewNote carries the editor's reason after a review was rejected.
+	ReviewNote string `json:"review_note,omitempty"`
+	// DeletedAt is only present on trashed posts.
+	DeletedAt string `json:"deleted_at,omitempty"`
+	// TranslationGroupID is shared by all translations of the post; absent when it has none.
+	TranslationGroupID *uint `json:"translation_group_id,omitempty"`
+	// Translations lists the other language versions; public reads only list published ones.
+	Translations []PostTranslationResponse `json:"translations"`
+	// SeriesID and SeriesPosition place the post in a series; absent outside any series.
+	SeriesID       *uint `json:"series_id,omitempty"`
+	SeriesPosition int   `json:"series_position,omitempty"`
+	// Series carries the series and the previous/next published parts on public reads.
+	Series *PostSeriesResponse `json:"series,omitempty"`
+}
+
+// SchedulePostRequest sets a future publish time and/or an automatic unpublish time.
+// Times are RFC 3339; at least one of the three fields must be provided. A stored unpublish
+// time is kept when only publish_at is sent; clear_unpublish_at removes it.
+type SchedulePostRequest struct {
+	PublishAt        *time.Time `json:"publish_at"`
+	UnpublishAt      *time.Time `json:"unpublish_at"`
+	ClearUnpublishAt bool       `json:"clear_unpublish_at"`
+}
+
+// ToSchedule converts the request into a domain schedule.
+func (r *SchedulePostRequest) ToSchedule() entity.PostSchedule {
+	return entity.PostSchedule{PublishAt: r.PublishAt, UnpublishAt: r.UnpublishAt, ClearUnpublishAt: r.ClearUnpublishAt}
+}
+
+// RejectPostRequest carries the reason an editor sends a post back to its author.
+type RejectPostRequest struct {
+	Reason string `json:"reason" binding:"required,min=1,max=1000"`
+}
+
+// PostTranslationResponse references another language version of a post.
+type PostTranslationResponse struct {
+	ID     uint   `json:"id"`
+	Locale string `json:"locale"`
+	Slug   string `json:"slug"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+}
+
+// LinkPostTranslationRequest adds an existing post to the translation group of another.
+type LinkPostTranslationRequest struct {
+	PostID uint `json:"post_id" binding:"required,min=1"`
+}
+
+// TOCEntryResponse is one heading in a post's table of contents.
+type TOCEntryResponse struct {
+	Level  int    `json:"level"`
+	Text   string `json:"text"`
+	Anchor string `json:"anchor"`
+}
+
+// PostSlugRedirectResponse points a client at the current slug when an old slug was requested.
+type PostSlugRedirectResponse struct {
+	Slug     string `json:"slug"`
+	Location string `json:"location"`
 }
 
 // AuthorResponse is the DTO for post author.
@@ -130,6 +251,7 @@ type AuthorResponse struct {
 type CategoryResponse struct {
 	ID   uint   `json:"id"`
 	Name string `json:"name"`
+	Slug string `json:"slug"`
 }
 
 // ToPostResponse converts an entity.Post to a PostResponse DTO.
@@ -139,40 +261,195 @@ func ToPostResponse(post *entity.Post) *PostResponse {
 	}
 
 	res := &PostResponse{
-		ID:        post.ID,
-		Title:     post.Title,
-		Slug:      post.Slug,
-		Content:   post.Content,
-		Cover:     post.Cover,
-		Status:    post.Status,
-		CreatedAt: post.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: post.UpdatedAt.Format(time.RFC3339),
+		ID:               post.ID,
+		Title:            post.Title,
+		Slug:             post.Slug,
+		Content:          post.Content,
+		Cover:            post.Cover,
+		Status:           post.Status,
+		Version:          post.Version,
+		NoIndex:          post.NoIndex,
+		CommentsDisabled: post.CommentsDisabled,
+		ReviewNote:       post.ReviewNote,
+		Locale:           post.Locale,
+		CreatedAt:        post.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        post.UpdatedAt.Format(time.RFC3339),
 		Author: AuthorResponse{
 			ID:       post.Author.ID,
 			Username: post.Author.Username,
 		},
 	}
 
-	if post.CategoryID != nil {
-		res.Category = &CategoryResponse{
-			ID:   post.Category.ID,
-			Name: post.Category.Name,
+	res.TranslationGroupID = post.TranslationGroupID
+	res.Translations = make([]PostTranslationResponse, len(post.Translations))
+	for i, t := range post.Translations {
+		res.Translations[i] = PostTranslationResponse{ID: t.ID, Locale: t.Locale, Slug: t.Slug, Title: t.Title, Status: t.Status}
+	}
+
+	res.SeriesID, res.SeriesPosition = post.SeriesID, post.SeriesPosition
+	if post.Series != nil {
+		res.Series = ToPostSeriesResponse(*post.Series)
+	}
+
+	if post.Rendered != nil {
+		res.ContentHTML = post.Rendered.HTML
+		if len(post.Rendered.TOC) > 0 {
+			res.TOC = make([]TOCEntryResponse, len(post.Rendered.TOC))
+			for i, e := range post.Rendered.TOC {
+				res.TOC[i] = TOCEntryResponse{Level: e.Level, Text: e.Text, Anchor: e.Anchor}
+			}
 		}
 	}
 
+	if post.PublishAt != nil {
+		res.PublishAt = post.PublishAt.Format(time.RFC3339)
+	}
+	if post.UnpublishAt != nil {
+		res.UnpublishAt = post.UnpublishAt.Format(time.RFC3339)
+	}
+	if post.DeletedAt != nil {
+		res.DeletedAt = post.DeletedAt.Format(time.RFC3339)
+	}
+
+	if post.CategoryID != nil {
+		category := ToCategoryResponse(post.Category)
+		res.Category = &category
+	}
+
 	if len(post.Tags) > 0 {
 		res.Tags = make([]TagResponse, len(post.Tags))
 		for i, tag := range post.Tags {
-			res.Tags[i] = TagResponse{
-				ID:   tag.ID,
-				Name: tag.Name,
-			}
+			res.Tags[i] = ToTagResponse(tag)
 		}
 	}
 
 	return res
 }
 
+// postExcerptRunes bounds the plain-text excerpt carried by list items.
+const postExcerptRunes = 200
+
+// PostSummaryResponse is one post in a listing or search result. It carries a short plain-text
+// excerpt instead of the body; the full content is only returned by single-post reads.
+type PostSummaryResponse struct {
+	ID               uint              `json:"id"`
+	Title            string            `json:"title"`
+	Slug             string            `json:"slug"`
+	Excerpt          string            `json:"excerpt"`
+	Cover            string            `json:"cover"`
+	Status           int               `json:"status"`
+	Version          uint              `json:"version"`
+	NoIndex          bool              `json:"no_index"`
+	CommentsDisabled bool              `json:"comments_disabled"`
+	Locale           string            `json:"locale"`
+	Author           AuthorResponse    `json:"author"`
+	Category         *CategoryResponse `json:"category,omitempty"`
+	Tags             []TagResponse     `json:"tags,omitempty"`
+	CreatedAt        string            `json:"created_at"`
+	UpdatedAt        string            `json:"updated_at"`
+	// PublishAt and UnpublishAt are only present while a schedule is pending.
+	PublishAt   string `json:"publish_at,omitempty"`
+	UnpublishAt string `json:"unpublish_at,omitempty"`
+	// TranslationGroupID is shared by all translations of the post; absent when it has none.
+	TranslationGroupID *uint                     `json:"translation_group_id,omitempty"`
+	Translations       []PostTranslationResponse `json:"translations"`
+	// SeriesID and SeriesPosition place the post in a series; absent outside any series.
+	SeriesID       *uint `json:"series_id,omitempty"`
+	SeriesPosition int   `json:"series_position,omitempty"`
+}
+
+// ToPostSummaryResponse converts an entity.Post to a PostSummaryResponse DTO.
+func ToPostSummaryResponse(post *entity.Post) *PostSummaryResponse {
+	if post == nil {
+		return nil
+	}
+	full := ToPostResponse(post)
+	return &PostSummaryResponse{
+		ID:                 full.ID,
+		Title:              full.Title,
+		Slug:               full.Slug,
+		Excerpt:            postExcerpt(post),
+		Cover:              full.Cover,
+		Status:             full.Status,
+		Version:            full.Version,
+		NoIndex:            full.NoIndex,
+		CommentsDisabled:   full.CommentsDisabled,
+		Locale:             full.Locale,
+		Author:             full.Author,
+		Category:           full.Category,
+		Tags:               full.Tags,
+		CreatedAt:          full.CreatedAt,
+		UpdatedAt:          full.UpdatedAt,
+		PublishAt:          full.PublishAt,
+		UnpublishAt:        full.UnpublishAt,
+		TranslationGroupID: full.TranslationGroupID,
+		Translations:       full.Translations,
+		SeriesID:           full.SeriesID,
+		SeriesPosition:     full.SeriesPosition,
+	}
+}
+
+// postExcerpt returns the start of the post's visible text. Public listings are rendered by
+// the service, so Rendered.Text is used; without it, Markdown syntax is stripped from Content.
+func postExcerpt(post *entity.Post) string {
+	var text string
+	if post.Rendered != nil {
+		text = post.Rendered.Text
+	} else {
+		text = stripMarkdown(post.Content)
+	}
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	if len(runes) <= postExcerptRunes {
+		return string(runes)
+	}
+	return strings.TrimSpace(string(runes[:postExcerptRunes])) + "…"
+}
+
+var (
+	reMarkdownImage  = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	reMarkdownLink   = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	reMarkdownPrefix = regexp.MustCompile(`(?m)^[ \t]*(#{1,6}[ \t]+|>[ \t]?|[-*+][ \t]+|\d+\.[ \t]+)`)
+	reMarkdownMarks  = regexp.MustCompile("\\*+|__|~~|`+")
+	reHTMLTag        = regexp.MustCompile(`<[^>]+>`)
+)
+
+// stripMarkdown approximates the visible text of Markdown: images and tags are dropped,
+// links keep their label, and heading, quote, list and emphasis markers are removed.
+func stripMarkdown(content string) string {
+	text := reMarkdownImage.ReplaceAllString(content, "")
+	text = reMarkdownLink.ReplaceAllString(text, "$1")
+	text = reHTMLTag.ReplaceAllString(text, "")
+	text = reMarkdownPrefix.ReplaceAllString(text, "")
+	return reMarkdownMarks.ReplaceAllString(text, "")
+}
+
+// ToPostSummaryListResponse converts a slice of entity.Post to a slice of PostSummaryResponse DTOs.
+func ToPostSummaryListResponse(posts []entity.Post) []*PostSummaryResponse {
+	res := make([]*PostSummaryResponse, len(posts))
+	for i := range posts {
+		res[i] = ToPostSummaryResponse(&posts[i])
+	}
+	return res
+}
+
+// PostListResponse is the paginated envelope for post listings.
+type PostListResponse struct {
+	Items    []*PostSummaryResponse `json:"items"`
+	Total    int64                  `json:"total"`
+	Page     int                    `json:"page"`
+	PageSize int                    `json:"page_size"`
+}
+
+// ToPostPageResponse wraps one page of posts with the pagination metadata used to fetch it.
+func ToPostPageResponse(posts []entity.Post, total int64, query entity.PostListQuery) PostListResponse {
+	return PostListResponse{
+		Items:    ToPostSummaryListResponse(posts),
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}
+}
+
 // ToPostListResponse converts a slice of entity.Post to a slice of PostResponse DTOs.
 func ToPostListResponse(posts []entity.Post) []*PostResponse {
 	if len(posts) == 0 {
@@ -185,3 +462,37 @@ func ToPostListResponse(posts []entity.Post) []*PostResponse {
 	}
 	return res
 }
+
+// PostSearchHitResponse is one search result. TitleHighlight and Snippet are HTML-escaped
+// with matched terms wrapped in <mark>, so clients can render them as HTML directly.
+type PostSearchHitResponse struct {
+	*PostSummaryResponse
+	TitleHighlight string `json:"title_highlight"`
+	Snippet        string `json:"snippet"`
+}
+
+// PostSearchResponse is the paginated envelope for search results.
+type PostSearchResponse struct {
+	Items    []PostSearchHitResponse `json:"items"`
+	Total    int64                   `json:"total"`
+	Page     int                     `json:"page"`
+	PageSize int                     `json:"page_size"`
+}
+
+// ToPostSearchResponse wraps one page of search hits with its pagination metadata.
+func ToPostSearchResponse(hits []entity.PostSearchHit, total int64, query entity.PostSearchQuery) PostSearchResponse {
+	items := make([]PostSearchHitResponse, len(hits))
+	for i := range hits {
+		items[i] = PostSearchHitResponse{
+			PostSummaryResponse: ToPostSummaryResponse(&hits[i].Post),
+			TitleHighlight:      hits[i].TitleHighlight,
+			Snippet:             hits[i].Snippet,
+		}
+	}
+	return PostSearchResponse{
+		Items:    items,
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}
+}
@@ -1,8 +1,11 @@
 package dto
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"KaldalisCMS/internal/core/entity"
 )
@@ -54,6 +57,17 @@ func TestUpdatePostRequest_ToPatch_OnlySetFields(t *testing.T) {
 	}
 }
 
+func TestUpdatePostRequest_ToPatch_NoIndex(t *testing.T) {
+	off := false
+	patch := (&UpdatePostRequest{NoIndex: &off}).ToPatch()
+	if patch.NoIndex == nil || *patch.NoIndex {
+		t.Fatalf("explicit no_index=false must reach the patch: %+v", patch.NoIndex)
+	}
+	if (&UpdatePostRequest{}).ToPatch().NoIndex != nil {
+		t.Fatal("omitted no_index should leave the flag untouched")
+	}
+}
+
 func TestUpdatePostRequest_ToPatch_EmptyTagsMeansClear(t *testing.T) {
 	// Per comment in PostPatch: nil = ignore, empty slice = replace with empty.
 	req := &UpdatePostRequest{Tags: []uint{}}
@@ -66,6 +80,61 @@ func TestUpdatePostRequest_ToPatch_EmptyTagsMeansClear(t *testing.T) {
 	}
 }
 
+func TestUpdatePostRequest_ToPatch_TagNames(t *testing.T) {
+	req := &UpdatePostRequest{Tags: []uint{4}, TagNames: []string{"go"}}
+	patch := req.ToPatch()
+	if len(patch.Tags) != 2 || patch.Tags[0].ID != 4 || patch.Tags[1].ID != 0 || patch.Tags[1].Name != "go" {
+		t.Fatalf("tags: %+v", patch.Tags)
+	}
+
+	// Names alone still replace the tag set.
+	patch = (&UpdatePostRequest{TagNames: []string{}}).ToPatch()
+	if patch.Tags == nil {
+		t.Fatal("empty tag_names must clear tags")
+	}
+}
+
+func TestToPostResponse_Rendered(t *testing.T) {
+	post := &entity.Post{ID: 1, Content: "# Hi"}
+	if got := ToPostResponse(post); got.ContentHTML != "" || got.TOC != nil {
+		t.Fatalf("content_html must be absent when not rendered: %+v", got)
+	}
+
+	post.Rendered = &entity.RenderedContent{
+		HTML: `<h1 id="hi">Hi</h1>`,
+		TOC:  []entity.TOCEntry{{Level: 1, Text: "Hi", Anchor: "hi"}},
+	}
+	got := ToPostResponse(post)
+	if got.ContentHTML != post.Rendered.HTML {
+		t.Fatalf("content_html: %q", got.ContentHTML)
+	}
+	if len(got.TOC) != 1 || got.TOC[0] != (TOCEntryResponse{Level: 1, Text: "Hi", Anchor: "hi"}) {
+		t.Fatalf("toc: %+v", got.TOC)
+	}
+}
+
+func TestToPostResponse_Series(t *testing.T) {
+	seriesID := uint(3)
+	post := &entity.Post{ID: 1, SeriesID: &seriesID, SeriesPosition: 1}
+	if got := ToPostResponse(post); got.Series != nil || got.SeriesPosition != 1 {
+		t.Fatalf("series navigation must be absent when not attached: %+v", got)
+	}
+
+	post.Series = &entity.PostSeries{
+		Series:   entity.Series{ID: 3, Title: "Go", Slug: "go"},
+		Position: 1,
+		Total:    2,
+		Next:     &entity.SeriesPart{ID: 2, Title: "Types", Slug: "types", Position: 2},
+	}
+	got := ToPostResponse(post).Series
+	if got == nil || got.Slug != "go" || got.Total != 2 || got.Prev != nil {
+		t.Fatalf("series: %+v", got)
+	}
+	if got.Next == nil || got.Next.ID != 2 || got.Next.Position != 2 {
+		t.Fatalf("next: %+v", got.Next)
+	}
+}
+
 func TestToPostResponse_Nil(t *testing.T) {
 	if got := ToPostResponse(nil); got != nil {
 		t.Fatalf("want nil, got %+v", got)
@@ -133,3 +202,42 @@ func TestToPostListResponse_Many(t *testing.T) {
 		t.Fatalf("unexpected: %+v", got)
 	}
 }
+
+func TestToPostPageResponse_ItemsCarryExcerptOnly(t *testing.T) {
+	long := "# Title\n\n" + strings.Repeat("word ", 100)
+	got := ToPostPageResponse([]entity.Post{{ID: 1, Content: long}, {ID: 2, Content: "short\n\nbody"}}, 2, entity.PostListQuery{Page: 1, PageSize: 10})
+
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"content"`) {
+		t.Fatalf("list response carries full content: %s", data)
+	}
+	if n := utf8.RuneCountInString(got.Items[0].Excerpt); n != postExcerptRunes+1 || !strings.HasSuffix(got.Items[0].Excerpt, "…") {
+		t.Fatalf("excerpt not truncated: %q", got.Items[0].Excerpt)
+	}
+	if got.Items[1].Excerpt != "short body" {
+		t.Fatalf("excerpt = %q", got.Items[1].Excerpt)
+	}
+}
+
+func TestToPostSummaryResponse_ExcerptStripsMarkdown(t *testing.T) {
+	content := "## Intro\n\nSome **bold** and `code`, see [the docs](/docs).\n\n![cover](/media/a/3/x.png)\n\n- one\n> quoted"
+	got := ToPostSummaryResponse(&entity.Post{ID: 1, Content: content})
+	if want := "Intro Some bold and code, see the docs. one quoted"; got.Excerpt != want {
+		t.Fatalf("excerpt = %q, want %q", got.Excerpt, want)
+	}
+
+	got = ToPostSummaryResponse(&entity.Post{ID: 1, Content: content, Rendered: &entity.RenderedContent{Text: "rendered text"}})
+	if got.Excerpt != "rendered text" {
+		t.Fatalf("rendered excerpt = %q", got.Excerpt)
+	}
+}
+
+func TestToPostPageResponse_Empty(t *testing.T) {
+	got := ToPostPageResponse(nil, 0, entity.PostListQuery{})
+	if got.Items == nil {
+		t.Fatal("want empty slice, got nil (breaks JSON contract)")
+	}
+}
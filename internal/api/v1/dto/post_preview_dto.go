@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"time"
+)
+
+// CreatePostPreviewRequest defines the optional lifetime of a new preview link.
+type CreatePostPreviewRequest struct {
+	// ExpiresInHours defaults to 72 when omitted; at most 720 (30 days).
+	ExpiresInHours int `json:"expires_in_hours" binding:"omitempty,min=1,max=720"`
+}
+
+// TTL converts the request lifetime; zero asks the service for the default.
+func (r *CreatePostPreviewRequest) TTL() time.Duration {
+	return time.Duration(r.ExpiresInHours) * time.Hour
+}
+
+// PostPreviewLinkResponse is the DTO for a preview link. Token and Path are only returned
+// when the link is created; afterwards the link can be revoked but not shown again.
+type PostPreviewLinkResponse struct {
+	ID        uint    `json:"id"`
+	PostID    uint    `json:"post_id"`
+	CreatedBy uint    `json:"created_by"`
+	Token     string  `json:"token,omitempty"`
+	Path      string  `json:"path,omitempty"`
+	Active    bool    `json:"active"`
+	ExpiresAt string  `json:"expires_at"`
+	RevokedAt *string `json:"revoked_at,omitempty"`
+	CreatedAt string  `json:"created_at"`
+}
+
+// PostPreviewResponse is the read-only view of a post served through a preview link.
+type PostPreviewResponse struct {
+	Post      *PostResponse        `json:"post"`
+	Media     []MediaAssetResponse `json:"media"`
+	ExpiresAt string               `json:"expires_at"`
+}
+
+// ToPostPreviewLinkResponse converts a preview token record; token is empty except on creation.
+func ToPostPreviewLinkResponse(t entity.PostPreviewToken, token string, now time.Time) PostPreviewLinkResponse {
+	resp := PostPreviewLinkResponse{
+		ID:        t.ID,
+		PostID:    t.PostID,
+		CreatedBy: t.CreatedBy,
+		Active:    t.Active(now),
+		ExpiresAt: t.ExpiresAt.Format(time.RFC3339),
+		CreatedAt: t.CreatedAt.Format(time.RFC3339),
+	}
+	if token != "" {
+		resp.Token = token
+		resp.Path = "/api/v1/preview/" + token
+	}
+	if t.RevokedAt != nil {
+		revoked := t.RevokedAt.Format(time.RFC3339)
+		resp.RevokedAt = &revoked
+	}
+	return resp
+}
+
+func ToPostPreviewLinkResponses(tokens []entity.PostPreviewToken, now time.Time) []PostPreviewLinkResponse {
+	out := make([]PostPreviewLinkResponse, len(tokens))
+	for i, t := range tokens {
+		out[i] = ToPostPreviewLinkResponse(t, "", now)
+	}
+	return out
+}
+
+func ToPostPreviewResponse(preview *entity.PostPreview) PostPreviewResponse {
+	return PostPreviewResponse{
+		Post:      ToPostResponse(&preview.Post),
+		Media:     ToMediaAssetResponses(preview.Media),
+		ExpiresAt: preview.ExpiresAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"time"
+)
+
+// PostRevisionResponse is the DTO for one post revision snapshot.
+type PostRevisionResponse struct {
+	ID           uint   `json:"id"`
+	PostID       uint   `json:"post_id"`
+	ActorID      uint   `json:"actor_id"`
+	Action       string `json:"action"`
+	RestoredFrom *uint  `json:"restored_from,omitempty"`
+	Title        string `json:"title"`
+	Slug         string `json:"slug"`
+	Content      string `json:"content"`
+	Cover        string `json:"cover"`
+	CategoryID   *uint  `json:"category_id,omitempty"`
+	Tags         []uint `json:"tags"`
+	Status       int    `json:"status"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// PostRevisionSummaryResponse is the list-view DTO; content is omitted to keep history listings small.
+type PostRevisionSummaryResponse struct {
+	ID           uint   `json:"id"`
+	ActorID      uint   `json:"actor_id"`
+	Action       string `json:"action"`
+	RestoredFrom *uint  `json:"restored_from,omitempty"`
+	Title        string `json:"title"`
+	Status       int    `json:"status"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// RevisionFieldChangeResponse describes one changed field between two revisions.
+type RevisionFieldChangeResponse struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// DiffLineResponse is one line of a content diff.
+type DiffLineResponse struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// PostRevisionDiffResponse is the DTO for comparing two revisions.
+type PostRevisionDiffResponse struct {
+	From    PostRevisionSummaryResponse   `json:"from"`
+	To      PostRevisionSummaryResponse   `json:"to"`
+	Fields  []RevisionFieldChangeResponse `json:"fields"`
+	Content []DiffLineResponse            `json:"content"`
+}
+
+// ToPostRevisionResponse converts an entity.PostRevision to its full DTO.
+func ToPostRevisionResponse(rev entity.PostRevision) PostRevisionResponse {
+	tags := rev.TagIDs
+	if tags == nil {
+		tags = []uint{}
+	}
+	return PostRevisionResponse{
+		ID:           rev.ID,
+		PostID:       rev.PostID,
+		ActorID:      rev.ActorID,
+		Action:       rev.Action,
+		RestoredFrom: rev.RestoredFrom,
+		Title:        rev.Title,
+		Slug:         rev.Slug,
+		Content:      rev.Content,
+		Cover:        rev.Cover,
+		CategoryID:   rev.CategoryID,
+		Tags:         tags,
+		Status:       rev.Status,
+		CreatedAt:    rev.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// ToPostRevisionSummaryResponse converts an entity.PostRevision to its list-view DTO.
+func ToPostRevisionSummaryResponse(rev entity.PostRevision) PostRevisionSummaryResponse {
+	return PostRevisionSummaryResponse{
+		ID:           rev.ID,
+		ActorID:      rev.ActorID,
+		Action:       rev.Action,
+		RestoredFrom: rev.RestoredFrom,
+		Title:        rev.Title,
+		Status:       rev.Status,
+		CreatedAt:    rev.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// ToPostRevisionListResponse converts a revision history to list-view DTOs.
+func ToPostRevisionListResponse(revs []entity.PostRevision) []PostRevisionSummaryResponse {
+	res := make([]PostRevisionSummaryResponse, len(revs))
+	for i, rev := range revs {
+		res[i] = ToPostRevisionSummaryResponse(rev)
+	}
+	return res
+}
+
+// ToPostRevisionDiffResponse converts a revision diff to its DTO.
+func ToPostRevisionDiffResponse(diff entity.PostRevisionDiff) PostRevisionDiffResponse {
+	res := PostRevisionDiffResponse{
+		From:    ToPostRevisionSummaryResponse(diff.From),
+		To:      ToPostRevisionSummaryResponse(diff.To),
+		Fields:  make([]RevisionFieldChangeResponse, len(diff.Fields)),
+		Content: make([]DiffLineResponse, len(diff.Content)),
+	}
+	for i, f := range diff.Fields {
+		res.Fields[i] = RevisionFieldChangeResponse{Field: f.Field, From: f.From, To: f.To}
+	}
+	for i, l := range diff.Content {
+		res.Content[i] = DiffLineResponse{Op: string(l.Op), Text: l.Text}
+	}
+	return res
+}
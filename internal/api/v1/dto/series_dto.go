@@ -0,0 +1,113 @@
+package dto
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"time"
+)
+
+// CreateSeriesRequest defines the request body for creating a series.
+// Slug is optional and derived from Title when omitted.
+type CreateSeriesRequest struct {
+	Title       string `json:"title" binding:"required,min=1,max=200"`
+	Slug        string `json:"slug" binding:"omitempty,max=200"`
+	Description string `json:"description" binding:"max=2000"`
+}
+
+func (r *CreateSeriesRequest) ToEntity() entity.Series {
+	return entity.Series{Title: r.Title, Slug: r.Slug, Description: r.Description}
+}
+
+// UpdateSeriesRequest defines the request body for updating a series; omitted fields are unchanged.
+type UpdateSeriesRequest struct {
+	Title       *string `json:"title" binding:"omitempty,min=1,max=200"`
+	Slug        *string `json:"slug" binding:"omitempty,min=1,max=200"`
+	Description *string `json:"description" binding:"omitempty,max=2000"`
+}
+
+func (r *UpdateSeriesRequest) ToPatch() entity.SeriesPatch {
+	return entity.SeriesPatch{Title: r.Title, Slug: r.Slug, Description: r.Description}
+}
+
+// SeriesResponse is the DTO for one series.
+type SeriesResponse struct {
+	ID          uint   `json:"id"`
+	Title       string `json:"title"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// SeriesWithCountResponse is a series plus its number of published parts.
+type SeriesWithCountResponse struct {
+	SeriesResponse
+	PartCount int64 `json:"part_count"`
+}
+
+// SeriesPartResponse is one published post of a series.
+type SeriesPartResponse struct {
+	ID       uint   `json:"id"`
+	Title    string `json:"title"`
+	Slug     string `json:"slug"`
+	Locale   string `json:"locale"`
+	Position int    `json:"position"`
+}
+
+// SeriesPartsResponse lists the published parts of a series in order.
+type SeriesPartsResponse struct {
+	Series SeriesResponse       `json:"series"`
+	Parts  []SeriesPartResponse `json:"parts"`
+}
+
+// PostSeriesResponse places a post within its series; prev and next are null at the ends.
+type PostSeriesResponse struct {
+	SeriesResponse
+	Position int                 `json:"position"`
+	Total    int                 `json:"total"`
+	Prev     *SeriesPartResponse `json:"prev"`
+	Next     *SeriesPartResponse `json:"next"`
+}
+
+func ToSeriesResponse(series entity.Series) SeriesResponse {
+	return SeriesResponse{
+		ID:          series.ID,
+		Title:       series.Title,
+		Slug:        series.Slug,
+		Description: series.Description,
+		CreatedAt:   series.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   series.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToSeriesListResponse(series []entity.SeriesWithPartCount) []SeriesWithCountResponse {
+	res := make([]SeriesWithCountResponse, len(series))
+	for i, s := range series {
+		res[i] = SeriesWithCountResponse{SeriesResponse: ToSeriesResponse(s.Series), PartCount: s.PartCount}
+	}
+	return res
+}
+
+func ToSeriesPartResponse(part entity.SeriesPart) SeriesPartResponse {
+	return SeriesPartResponse{ID: part.ID, Title: part.Title, Slug: part.Slug, Locale: part.Locale, Position: part.Position}
+}
+
+func ToSeriesPartsResponse(series entity.Series, parts []entity.SeriesPart) SeriesPartsResponse {
+	res := SeriesPartsResponse{Series: ToSeriesResponse(series), Parts: make([]SeriesPartResponse, len(parts))}
+	for i, p := range parts {
+		res.Parts[i] = ToSeriesPartResponse(p)
+	}
+	return res
+}
+
+func ToPostSeriesResponse(nav entity.PostSeries) *PostSeriesResponse {
+	res := &PostSeriesResponse{SeriesResponse: ToSeriesResponse(nav.Series), Position: nav.Position, Total: nav.Total}
+	if nav.Prev != nil {
+		prev := ToSeriesPartResponse(*nav.Prev)
+		res.Prev = &prev
+	}
+	if nav.Next != nil {
+		next := ToSeriesPartResponse(*nav.Next)
+		res.Next = &next
+	}
+	return res
+}
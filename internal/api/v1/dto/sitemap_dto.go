@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"encoding/xml"
+	"time"
+)
+
+const (
+	sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	// xhtmlNamespace carries the hreflang <xhtml:link> elements of translated pages.
+	xhtmlNamespace = "http://www.w3.org/1999/xhtml"
+)
+
+// SitemapURLSet is a sitemaps.org <urlset> document.
+type SitemapURLSet struct {
+	XMLName    xml.Name     `xml:"urlset"`
+	XMLNS      string       `xml:"xmlns,attr"`
+	XMLNSXHTML string       `xml:"xmlns:xhtml,attr,omitempty"`
+	URLs       []SitemapLoc `xml:"url"`
+}
+
+// SitemapIndex is a sitemaps.org <sitemapindex> document.
+type SitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []SitemapLoc `xml:"sitemap"`
+}
+
+// SitemapLoc is a <url> or <sitemap> element.
+type SitemapLoc struct {
+	Loc        string                 `xml:"loc"`
+	LastMod    string                 `xml:"lastmod,omitempty"`
+	Alternates []SitemapAlternateLink `xml:"xhtml:link"`
+}
+
+// SitemapAlternateLink is an <xhtml:link rel="alternate" hreflang="..."> element.
+type SitemapAlternateLink struct {
+	Rel      string `xml:"rel,attr"`
+	Hreflang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+// ToSitemapDocument encodes a sitemap as either a <urlset> or a <sitemapindex>.
+func ToSitemapDocument(sitemap entity.Sitemap) any {
+	if sitemap.IsIndex() {
+		return SitemapIndex{XMLNS: sitemapNamespace, Sitemaps: toSitemapLocs(sitemap.Pages)}
+	}
+	urlset := SitemapURLSet{XMLNS: sitemapNamespace, URLs: toSitemapLocs(sitemap.URLs)}
+	// The xhtml namespace is only declared when some URL carries hreflang alternates.
+	for _, u := range urlset.URLs {
+		if len(u.Alternates) > 0 {
+			urlset.XMLNSXHTML = xhtmlNamespace
+			break
+		}
+	}
+	return urlset
+}
+
+func toSitemapLocs(urls []entity.SitemapURL) []SitemapLoc {
+	out := make([]SitemapLoc, len(urls))
+	for i, u := range urls {
+		out[i] = SitemapLoc{Loc: u.Loc}
+		if !u.LastMod.IsZero() {
+			out[i].LastMod = u.LastMod.UTC().Format(time.RFC3339)
+		}
+		for _, alt := range u.Alternates {
+			out[i].Alternates = append(out[i].Alternates, SitemapAlternateLink{Rel: "alternate", Hreflang: alt.Locale, Href: alt.URL})
+		}
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/core/entity"
+)
+
+func TestToSitemapDocument(t *testing.T) {
+	urlset, err := xml.Marshal(ToSitemapDocument(entity.Sitemap{URLs: []entity.SitemapURL{
+		{Loc: "https://blog.example.com/posts/a?x=1&y=2", LastMod: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
+	}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>https://blog.example.com/posts/a?x=1&amp;y=2</loc><lastmod>2024-05-01T12:00:00Z</lastmod></url></urlset>`
+	if string(urlset) != want {
+		t.Fatalf("urlset:\n got %s\nwant %s", urlset, want)
+	}
+
+	index, err := xml.Marshal(ToSitemapDocument(entity.Sitemap{Pages: []entity.SitemapURL{
+		{Loc: "https://blog.example.com/sitemap.xml?page=1"},
+	}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(index), `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><sitemap><loc>`) ||
+		strings.Contains(string(index), "lastmod") {
+		t.Fatalf("unexpected index: %s", index)
+	}
+}
+
+func TestToSitemapDocument_Alternates(t *testing.T) {
+	body, err := xml.Marshal(ToSitemapDocument(entity.Sitemap{URLs: []entity.SitemapURL{{
+		Loc: "https://blog.example.com/en/posts/hello",
+		Alternates: []entity.LocaleAlternate{
+			{Locale: "en", URL: "https://blog.example.com/en/posts/hello"},
+			{Locale: "x-default", URL: "https://blog.example.com/posts/ni-hao"},
+		},
+	}}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := string(body)
+	for _, want := range []string{
+		`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:xhtml="http://www.w3.org/1999/xhtml">`,
+		`<xhtml:link rel="alternate" hreflang="en" href="https://blog.example.com/en/posts/hello"></xhtml:link>`,
+		`<xhtml:link rel="alternate" hreflang="x-default" href="https://blog.example.com/posts/ni-hao"></xhtml:link>`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Fatalf("sitemap missing %q in:\n%s", want, doc)
+		}
+	}
+}
@@ -3,17 +3,20 @@ package dto
 import "KaldalisCMS/internal/core/entity"
 
 // CreateTagRequest defines the request body for creating a tag.
+// Slug is optional and derived from Name when omitted.
 type CreateTagRequest struct {
 	Name string `json:"name" binding:"required,min=1,max=50"`
+	Slug string `json:"slug" binding:"omitempty,max=100"`
 }
 
 func (r *CreateTagRequest) ToEntity() entity.Tag {
-	return entity.Tag{Name: r.Name}
+	return entity.Tag{Name: r.Name, Slug: r.Slug}
 }
 
 // UpdateTagRequest defines the request body for updating a tag.
 type UpdateTagRequest struct {
 	Name *string `json:"name" binding:"omitempty,min=1,max=50"`
+	Slug *string `json:"slug" binding:"omitempty,min=1,max=100"`
 }
 
 func (r *UpdateTagRequest) ToEntity() entity.Tag {
@@ -21,6 +24,9 @@ func (r *UpdateTagRequest) ToEntity() entity.Tag {
 	if r.Name != nil {
 		t.Name = *r.Name
 	}
+	if r.Slug != nil {
+		t.Slug = *r.Slug
+	}
 	return t
 }
 
@@ -28,10 +34,31 @@ func (r *UpdateTagRequest) ToEntity() entity.Tag {
 type TagResponse struct {
 	ID   uint   `json:"id"`
 	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// TagWithCountResponse is a tag plus its number of published posts.
+type TagWithCountResponse struct {
+	TagResponse
+	PostCount int64 `json:"post_count"`
+}
+
+// TagPostsResponse is one page of published posts carrying a tag.
+type TagPostsResponse struct {
+	Tag TagResponse `json:"tag"`
+	PostListResponse
 }
 
 func ToTagResponse(tag entity.Tag) TagResponse {
-	return TagResponse{ID: tag.ID, Name: tag.Name}
+	return TagResponse{ID: tag.ID, Name: tag.Name, Slug: tag.Slug}
+}
+
+func ToTagWithCountListResponse(tags []entity.TagWithPostCount) []TagWithCountResponse {
+	res := make([]TagWithCountResponse, len(tags))
+	for i, t := range tags {
+		res[i] = TagWithCountResponse{TagResponse: ToTagResponse(t.Tag), PostCount: t.PostCount}
+	}
+	return res
 }
 
 func ToTagListResponse(tags []entity.Tag) []TagResponse {
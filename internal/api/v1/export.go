@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/infra/exporter"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportAPI serves the site export under /api/v1/admin/export.
+type ExportAPI struct {
+	service core.ExportService
+}
+
+func NewExportAPI(service core.ExportService) *ExportAPI {
+	return &ExportAPI{service: service}
+}
+
+// Export streams the whole site as a zip archive.
+// The archive is written while it is read, so there is no size limit and no timeout beyond
+// the client's. An export that fails before anything was sent gets an error response; one
+// that fails later ends without manifest.json and the zip directory, which readers reject.
+// @Summary Export the site
+// @Description Exports all posts as Markdown with front matter, categories, tags, users (without password hashes), settings and the media tree into a zip archive with a manifest. The format is described in docs/EXPORT_FORMAT.md and can be imported again with format=markdown.
+// @Tags export
+// @Produce application/zip
+// @Success 200 {file} file
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Router /admin/export [get]
+func (api *ExportAPI) Export(c *gin.Context) {
+	header := c.Writer.Header()
+	header.Set("Content-Type", "application/zip")
+	header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, exporter.Filename(time.Now())))
+	header.Set("Cache-Control", "no-store")
+
+	if _, err := api.service.Export(c.Request.Context(), exporter.NewZipWriter(c.Writer)); err != nil {
+		if !c.Writer.Written() {
+			header.Del("Content-Type")
+			header.Del("Content-Disposition")
+			errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+			return
+		}
+		log.Printf("[WARN] Site export aborted after the archive was partly sent: %v", err)
+	}
+}
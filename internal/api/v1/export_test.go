@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeExportService implements core.ExportService for handler-layer tests.
+type fakeExportService struct {
+	exportFn func(ctx context.Context, w core.ExportWriter) (entity.ExportSummary, error)
+}
+
+func (f *fakeExportService) Export(ctx context.Context, w core.ExportWriter) (entity.ExportSummary, error) {
+	return f.exportFn(ctx, w)
+}
+
+func TestExportAPI_Export(t *testing.T) {
+	svc := &fakeExportService{
+		exportFn: func(ctx context.Context, w core.ExportWriter) (entity.ExportSummary, error) {
+			if err := w.WritePost(entity.Post{ID: 1, Slug: "hello", Locale: "en", Content: "Hi"}); err != nil {
+				return entity.ExportSummary{}, err
+			}
+			summary := entity.ExportSummary{Posts: 1}
+			return summary, w.Close(summary)
+		},
+	}
+	r := gin.New()
+	r.GET("/admin/export", NewExportAPI(svc).Export)
+
+	w := doRequest(r, http.MethodGet, "/admin/export")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/zip" {
+		t.Fatalf("content type = %q", ct)
+	}
+	if cd := w.Header().Get("Content-Disposition"); !strings.HasPrefix(cd, `attachment; filename="kaldalis-export-`) {
+		t.Fatalf("content disposition = %q", cd)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zr.File) != 2 || zr.File[0].Name != "posts/en/hello.md" || zr.File[1].Name != "manifest.json" {
+		t.Fatalf("unexpected archive entries: %v", zr.File)
+	}
+}
+
+func TestExportAPI_Export_ErrorBeforeStreaming(t *testing.T) {
+	svc := &fakeExportService{
+		exportFn: func(ctx context.Context, w core.ExportWriter) (entity.ExportSummary, error) {
+			return entity.ExportSummary{}, core.ErrInternalError
+		},
+	}
+	r := gin.New()
+	r.GET("/admin/export", NewExportAPI(svc).Export)
+
+	w := doRequest(r, http.MethodGet, "/admin/export")
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") || w.Header().Get("Content-Disposition") != "" {
+		t.Fatalf("headers = %v", w.Header())
+	}
+}
@@ -0,0 +1,189 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/service"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	feedFormatRSS  = "rss"
+	feedFormatAtom = "atom"
+	feedFormatJSON = "json"
+)
+
+// FeedAPI serves RSS 2.0, Atom and JSON Feed documents at the site root, for the whole
+// site and per tag or category. Feeds only ever contain published posts.
+type FeedAPI struct {
+	service *service.FeedService
+}
+
+func NewFeedAPI(svc *service.FeedService) *FeedAPI {
+	return &FeedAPI{service: svc}
+}
+
+// RegisterRootRoutes mounts the feed documents. Authorization is applied by the caller's group.
+func (api *FeedAPI) RegisterRootRoutes(r gin.IRoutes) {
+	for _, prefix := range []string{"", "/tags/:slug", "/categories/:slug"} {
+		r.GET(prefix+"/feed.xml", api.RSS)
+		r.GET(prefix+"/atom.xml", api.Atom)
+		r.GET(prefix+"/feed.json", api.JSONFeed)
+	}
+}
+
+// RSS returns the RSS 2.0 feed.
+// @Summary RSS feed
+// @Description Newest published posts as RSS 2.0; also served per tag and per category. Supports conditional GET.
+// @Tags feeds
+// @Produce xml
+// @Param slug path string false "tag or category slug for scoped feeds"
+// @Param locale query string false "locale fallback chain, e.g. en,zh-CN; lists each translated post once"
+// @Success 200 {string} string "RSS document"
+// @Success 304 "not modified"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /feed.xml [get]
+// @Router /tags/{slug}/feed.xml [get]
+// @Router /categories/{slug}/feed.xml [get]
+func (api *FeedAPI) RSS(c *gin.Context) {
+	api.serveFeed(c, feedFormatRSS)
+}
+
+// Atom returns the Atom feed.
+// @Summary Atom feed
+// @Description Newest published posts as Atom 1.0; also served per tag and per category. Supports conditional GET.
+// @Tags feeds
+// @Produce xml
+// @Param slug path string false "tag or category slug for scoped feeds"
+// @Param locale query string false "locale fallback chain, e.g. en,zh-CN; lists each translated post once"
+// @Success 200 {string} string "Atom document"
+// @Success 304 "not modified"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /atom.xml [get]
+// @Router /tags/{slug}/atom.xml [get]
+// @Router /categories/{slug}/atom.xml [get]
+func (api *FeedAPI) Atom(c *gin.Context) {
+	api.serveFeed(c, feedFormatAtom)
+}
+
+// JSONFeed returns the JSON Feed.
+// @Summary JSON Feed
+// @Description Newest published posts as JSON Feed 1.1; also served per tag and per category. Supports conditional GET.
+// @Tags feeds
+// @Produce json
+// @Param slug path string false "tag or category slug for scoped feeds"
+// @Param locale query string false "locale fallback chain, e.g. en,zh-CN; lists each translated post once"
+// @Success 200 {object} dto.JSONFeed
+// @Success 304 "not modified"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /feed.json [get]
+// @Router /tags/{slug}/feed.json [get]
+// @Router /categories/{slug}/feed.json [get]
+func (api *FeedAPI) JSONFeed(c *gin.Context) {
+	api.serveFeed(c, feedFormatJSON)
+}
+
+func (api *FeedAPI) serveFeed(c *gin.Context, format string) {
+	scope, resource := feedScopeOf(c)
+	locales, ok := parseLocaleChain(c)
+	if !ok {
+		return
+	}
+	scope.Locales = locales
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	feed, err := api.service.Build(ctx, scope)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "build feed timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, map[string]any{"resource": resource})
+		return
+	}
+
+	if notModified(c, feedETag(format, feed), feed.Updated) {
+		return
+	}
+
+	selfURL := api.service.SiteURL() + c.Request.URL.RequestURI()
+	var (
+		body        []byte
+		contentType string
+	)
+	switch format {
+	case feedFormatRSS:
+		body, err = marshalXMLDocument(dto.ToRSSFeed(feed, selfURL))
+		contentType = "application/rss+xml; charset=utf-8"
+	case feedFormatAtom:
+		body, err = marshalXMLDocument(dto.ToAtomFeed(feed, selfURL))
+		contentType = "application/atom+xml; charset=utf-8"
+	default:
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false) // content_html is meant to be HTML
+		err = enc.Encode(dto.ToJSONFeed(feed, selfURL))
+		body, contentType = buf.Bytes(), "application/feed+json; charset=utf-8"
+	}
+	if err != nil {
+		errorx.RespondInternalError(c)
+		return
+	}
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// feedScopeOf derives the feed scope from the matched route, returning the scope and the
+// resource name used in not-found errors.
+func feedScopeOf(c *gin.Context) (service.FeedScope, string) {
+	switch {
+	case strings.HasPrefix(c.FullPath(), "/tags/"):
+		return service.FeedScope{TagSlug: c.Param("slug")}, "tag"
+	case strings.HasPrefix(c.FullPath(), "/categories/"):
+		return service.FeedScope{CategorySlug: c.Param("slug")}, "category"
+	default:
+		return service.FeedScope{}, "feed"
+	}
+}
+
+// feedETag identifies a feed version by its newest update time and the exact set of items,
+// so posts dropping out of the window also produce a new tag. Language and translation links
+// are hashed too, since linking a translation does not touch the post's update time.
+func feedETag(format string, feed entity.Feed) string {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s;", feed.Language)
+	for _, it := range feed.Items {
+		fmt.Fprintf(h, "%d:%d;", it.ID, it.Updated.UnixNano())
+		for _, alt := range it.Alternates {
+			fmt.Fprintf(h, "%s=%s;", alt.Locale, alt.URL)
+		}
+	}
+	return fmt.Sprintf(`"%s-%x-%x"`, format, feed.Updated.UnixNano(), h.Sum64())
+}
+
+func marshalXMLDocument(v any) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/middleware"
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// importTimeout bounds one import request. Imports copy every referenced image, often from
+// the old site, so they run far longer than other admin requests.
+const importTimeout = 10 * time.Minute
+
+// defaultImportMaxBytes bounds the request body of an import. Site exports carry their media,
+// so it is well above the media upload limit.
+const defaultImportMaxBytes = 512 << 20
+
+// ImportAPI serves the admin content import under /api/v1/admin/import.
+type ImportAPI struct {
+	service  core.ImportService
+	maxBytes int64
+}
+
+func NewImportAPI(service core.ImportService) *ImportAPI {
+	return &ImportAPI{service: service, maxBytes: defaultImportMaxBytes}
+}
+
+// SetMaxUploadBytes overrides the request body limit; values <= 0 keep the default.
+func (api *ImportAPI) SetMaxUploadBytes(n int64) {
+	if n > 0 {
+		api.maxBytes = n
+	}
+}
+
+// Import migrates content from a WordPress WXR export or a zip of Markdown files.
+// Item failures do not fail the request: the response is 200 with the report.
+// @Summary Import content
+// @Description Imports authors, categories, tags, posts and their images, keeping original dates and slugs. Existing items are reused, so re-running an import creates nothing new. With dry_run set nothing is written.
+// @Tags import
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "WXR file (.xml) or zip of Markdown files (.zip)"
+// @Param format formData string false "wxr or markdown; guessed from the file extension when empty"
+// @Param dry_run formData bool false "report what would be imported without writing"
+// @Param locale formData string false "locale of posts that do not name one"
+// @Param author_map formData string false "JSON object mapping source author logins to existing usernames"
+// @Success 200 {object} dto.ImportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/import [post]
+func (api *ImportAPI) Import(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		errorx.RespondError(c, http.StatusUnauthorized, core.CodeUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, api.maxBytes)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			errorx.RespondError(c, http.StatusRequestEntityTooLarge, core.CodeValidationFailed, "upload too large", nil)
+			return
+		}
+		errorx.RespondValidationError(c, "missing file", nil)
+		return
+	}
+
+	req := entity.ImportRequest{
+		Format:      entity.ImportFormat(c.PostForm("format")),
+		Locale:      c.PostForm("locale"),
+		ActorUserID: userID,
+	}
+	if req.Format == "" {
+		req.Format = entity.ImportFormatFromFilename(fileHeader.Filename)
+	}
+	if !req.Format.Valid() {
+		errorx.RespondValidationError(c, "format must be wxr or markdown", nil)
+		return
+	}
+	if raw := c.PostForm("dry_run"); raw != "" {
+		if req.DryRun, err = strconv.ParseBool(raw); err != nil {
+			errorx.RespondValidationError(c, "invalid dry_run", nil)
+			return
+		}
+	}
+	if raw := c.PostForm("author_map"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &req.AuthorMap); err != nil {
+			errorx.RespondValidationError(c, "author_map must be a JSON object of strings", nil)
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		errorx.RespondInternalError(c)
+		return
+	}
+	defer file.Close()
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), importTimeout)
+	defer cancel()
+
+	report, err := api.service.Import(ctx, req, file, fileHeader.Size)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "import timed out")
+			return
+		}
+		if errors.Is(err, core.ErrInvalidInput) {
+			errorx.RespondValidationError(c, "invalid import file", map[string]any{"reason": err.Error()})
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToImportResponse(report))
+}
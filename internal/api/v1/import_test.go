@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeImportService implements core.ImportService for handler-layer tests.
+type fakeImportService struct {
+	importFn func(ctx context.Context, req entity.ImportRequest, r io.ReaderAt, size int64) (entity.ImportReport, error)
+}
+
+func (f *fakeImportService) Import(ctx context.Context, req entity.ImportRequest, r io.ReaderAt, size int64) (entity.ImportReport, error) {
+	return f.importFn(ctx, req, r, size)
+}
+
+func newImportRouter(svc core.ImportService, actor gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.POST("/admin/import", actor, NewImportAPI(svc).Import)
+	return r
+}
+
+func doMultipart(r *gin.Engine, path, filename, content string, fields map[string]string) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for k, v := range fields {
+		_ = mw.WriteField(k, v)
+	}
+	if filename != "" {
+		fw, _ := mw.CreateFormFile("file", filename)
+		_, _ = io.WriteString(fw, content)
+	}
+	_ = mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, path, &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestImportAPI_Import_DryRun(t *testing.T) {
+	var got entity.ImportRequest
+	var gotBody string
+	svc := &fakeImportService{
+		importFn: func(ctx context.Context, req entity.ImportRequest, r io.ReaderAt, size int64) (entity.ImportReport, error) {
+			got = req
+			buf := make([]byte, size)
+			_, _ = r.ReadAt(buf, 0)
+			gotBody = string(buf)
+			report := entity.ImportReport{Format: req.Format, DryRun: req.DryRun}
+			report.Add(entity.ImportKindPost, "post 1", entity.ImportActionCreate, 0, nil)
+			report.Add(entity.ImportKindMedia, "https://old/a.png", entity.ImportActionError, 0, fmt.Errorf("%w: gone", core.ErrNotFound))
+			report.Add(entity.ImportKindPost, "post 2", entity.ImportActionError, 0, fmt.Errorf("boom"))
+			return report, nil
+		},
+	}
+	r := newImportRouter(svc, injectActor(7, "admin"))
+
+	w := doMultipart(r, "/admin/import", "export.xml", "<rss/>", map[string]string{
+		"dry_run":    "true",
+		"locale":     "en",
+		"author_map": `{"wpadmin":"alice"}`,
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	if got.Format != entity.ImportFormatWXR || !got.DryRun || got.Locale != "en" || got.ActorUserID != 7 || got.AuthorMap["wpadmin"] != "alice" {
+		t.Fatalf("request = %+v", got)
+	}
+	if gotBody != "<rss/>" {
+		t.Fatalf("body = %q", gotBody)
+	}
+
+	var resp dto.ImportResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.DryRun || resp.Summary["post"] != (dto.ImportCounts{Create: 1, Error: 1}) || resp.Summary["media"].Error != 1 {
+		t.Fatalf("response = %+v", resp)
+	}
+	if resp.Items[1].ErrorCode != core.CodeNotFound || resp.Items[2].Error != "internal error" {
+		t.Fatalf("item errors = %+v", resp.Items)
+	}
+}
+
+func TestImportAPI_Import_BadRequests(t *testing.T) {
+	svc := &fakeImportService{
+		importFn: func(ctx context.Context, req entity.ImportRequest, r io.ReaderAt, size int64) (entity.ImportReport, error) {
+			return entity.ImportReport{}, fmt.Errorf("%w: invalid zip archive", core.ErrInvalidInput)
+		},
+	}
+	r := newImportRouter(svc, injectActor(7, "admin"))
+
+	cases := []struct {
+		name     string
+		filename string
+		fields   map[string]string
+	}{
+		{"missing file", "", nil},
+		{"unknown extension", "export.csv", nil},
+		{"unknown format", "export.xml", map[string]string{"format": "csv"}},
+		{"bad dry_run", "export.xml", map[string]string{"dry_run": "maybe"}},
+		{"bad author_map", "export.xml", map[string]string{"author_map": `["alice"]`}},
+		{"undecodable file", "posts.zip", nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if w := doMultipart(r, "/admin/import", tc.filename, "data", tc.fields); w.Code != http.StatusBadRequest {
+				t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+			}
+		})
+	}
+
+	unauth := newImportRouter(svc, injectActor(0, ""))
+	if w := doMultipart(unauth, "/admin/import", "export.xml", "data", nil); w.Code != http.StatusUnauthorized {
+		t.Fatalf("unauthenticated: %d", w.Code)
+	}
+}
+
+func TestImportAPI_Import_TooLarge(t *testing.T) {
+	svc := &fakeImportService{
+		importFn: func(ctx context.Context, req entity.ImportRequest, r io.ReaderAt, size int64) (entity.ImportReport, error) {
+			t.Fatal("service reached with an oversized upload")
+			return entity.ImportReport{}, nil
+		},
+	}
+	api := NewImportAPI(svc)
+	api.SetMaxUploadBytes(1 << 10)
+	r := gin.New()
+	r.POST("/admin/import", injectActor(7, "admin"), api.Import)
+
+	w := doMultipart(r, "/admin/import", "export.xml", string(bytes.Repeat([]byte("x"), 4<<10)), nil)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,392 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PageAPI serves static pages. Pages are managed under /admin/pages and published pages
+// are readable under /pages by their full path.
+type PageAPI struct {
+	service core.PageService
+}
+
+func NewPageAPI(service core.PageService) *PageAPI {
+	return &PageAPI{service: service}
+}
+
+// GetPageTree returns the public pages nested under their parents.
+// @Summary Get page tree
+// @Description Returns every public page in sibling order, nested under its parent. A page is public while it and all its ancestors are published.
+// @Tags pages
+// @Produce json
+// @Success 200 {array} dto.PageTreeNode
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /pages [get]
+func (api *PageAPI) GetPageTree(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	pages, err := api.service.ListPublicPages(ctx)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list pages timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPageTree(pages))
+}
+
+// GetPageByPath resolves a published page by its full path.
+// @Summary Get page by path
+// @Description Resolves a page by its full slug path, e.g. /pages/docs/install/linux, and returns it with its breadcrumbs and published children.
+// @Tags pages
+// @Produce json
+// @Param path path string true "full page path"
+// @Success 200 {object} dto.PageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /pages/{path} [get]
+func (api *PageAPI) GetPageByPath(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	page, err := api.service.GetPublicPageByPath(ctx, c.Param("path"))
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "get page timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPageResponse(page))
+}
+
+// GetAdminPages lists the pages the current user can manage.
+// @Summary List manageable pages
+// @Description Admins see every page; other roles only see their own drafts. Pages are listed flat in sibling order.
+// @Tags admin-pages
+// @Produce json
+// @Success 200 {array} dto.PageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Router /admin/pages [get]
+func (api *PageAPI) GetAdminPages(c *gin.Context) {
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	pages, err := api.service.ListAdminPages(ctx, actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list pages timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	res := make([]dto.PageResponse, len(pages))
+	for i, p := range pages {
+		res[i] = dto.ToPageResponse(p)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// GetAdminPage returns one manageable page with its breadcrumbs and children.
+// @Summary Get manageable page
+// @Tags admin-pages
+// @Produce json
+// @Param id path int true "page id"
+// @Success 200 {object} dto.PageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Router /admin/pages/{id} [get]
+func (api *PageAPI) GetAdminPage(c *gin.Context) {
+	id, ok := parsePageID(c)
+	if !ok {
+		return
+	}
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	page, err := api.service.GetAdminPage(ctx, id, actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "get page timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPageResponse(page))
+}
+
+// CreatePage creates a draft page owned by the current user.
+// @Summary Create page
+// @Description Creates a draft page placed after its siblings. The slug is made unique among the siblings.
+// @Tags admin-pages
+// @Accept json
+// @Produce json
+// @Param body body dto.CreatePageRequest true "page payload"
+// @Success 201 {object} dto.PageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/pages [post]
+func (api *PageAPI) CreatePage(c *gin.Context) {
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreatePageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	created, err := api.service.CreateAdminPage(ctx, req.ToEntity(), actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "create page timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToPageResponse(created))
+}
+
+// UpdatePage updates a page; changing its slug or parent moves its whole subtree.
+// @Summary Update page
+// @Description Omitted fields are left unchanged. parent_id moves the page (0 for the top level); the paths of all descendants follow.
+// @Tags admin-pages
+// @Accept json
+// @Produce json
+// @Param id path int true "page id"
+// @Param body body dto.UpdatePageRequest true "page patch"
+// @Success 200 {object} dto.PageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/pages/{id} [put]
+func (api *PageAPI) UpdatePage(c *gin.Context) {
+	id, ok := parsePageID(c)
+	if !ok {
+		return
+	}
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdatePageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	updated, err := api.service.UpdateAdminPage(ctx, id, req.ToPatch(), actorUserID, actorRole)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "update page timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPageResponse(updated))
+}
+
+// PublishPage transitions a page from Draft to Published.
+// @Summary Publish page
+// @Tags admin-pages
+// @Produce json
+// @Param id path int true "page id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/pages/{id}/publish [post]
+func (api *PageAPI) PublishPage(c *gin.Context) {
+	api.transitionPage(c, 5*time.Second, "publish page timed out", "published", api.service.PublishAdminPage)
+}
+
+// DraftPage moves a published page, and with it its subtree, back to Draft.
+// @Summary Move page to draft
+// @Tags admin-pages
+// @Produce json
+// @Param id path int true "page id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/pages/{id}/draft [post]
+func (api *PageAPI) DraftPage(c *gin.Context) {
+	api.transitionPage(c, 5*time.Second, "move page to draft timed out", "moved to draft", api.service.MovePageToDraft)
+}
+
+// DeletePage permanently removes a page without children.
+// @Summary Delete page
+// @Tags admin-pages
+// @Produce json
+// @Param id path int true "page id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/pages/{id} [delete]
+func (api *PageAPI) DeletePage(c *gin.Context) {
+	api.transitionPage(c, 10*time.Second, "delete page timed out", "page deleted successfully", api.service.DeleteAdminPage)
+}
+
+// ReorderPages sets the sibling order of all children of one parent.
+// @Summary Reorder pages
+// @Description page_ids must list every child of parent_id (null or 0 for the top level) exactly once, in the new order.
+// @Tags admin-pages
+// @Accept json
+// @Produce json
+// @Param body body dto.ReorderPagesRequest true "new sibling order"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /admin/pages/reorder [post]
+func (api *PageAPI) ReorderPages(c *gin.Context) {
+	_, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReorderPagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := api.service.ReorderAdminPages(ctx, req.ParentID, req.PageIDs, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "reorder pages timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "pages reordered successfully")
+}
+
+// transitionPage runs a body-less page action and answers with a plain message.
+func (api *PageAPI) transitionPage(c *gin.Context, timeout time.Duration, timeoutMsg string, okMsg string, action func(ctx context.Context, id uint, actorUserID uint, actorRole string) error) {
+	id, ok := parsePageID(c)
+	if !ok {
+		return
+	}
+	actorUserID, actorRole, ok := getPostActor(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+
+	if err := action(ctx, id, actorUserID, actorRole); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, timeoutMsg)
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, okMsg)
+}
+
+func parsePageID(c *gin.Context) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id64 == 0 {
+		errorx.RespondValidationError(c, "invalid page id", map[string]any{"field": "id"})
+		return 0, false
+	}
+	return uint(id64), true
+}
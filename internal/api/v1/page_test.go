@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakePageService implements core.PageService for handler-layer tests.
+// Methods without a stub panic through the nil embedded interface.
+type fakePageService struct {
+	core.PageService
+	listPublicFn func(ctx context.Context) ([]entity.Page, error)
+	getByPathFn  func(ctx context.Context, path string) (entity.Page, error)
+	reorderFn    func(ctx context.Context, parentID *uint, ids []uint, role string) error
+	deleteFn     func(ctx context.Context, id uint, uid uint, role string) error
+}
+
+func (f *fakePageService) ListPublicPages(ctx context.Context) ([]entity.Page, error) {
+	return f.listPublicFn(ctx)
+}
+func (f *fakePageService) GetPublicPageByPath(ctx context.Context, path string) (entity.Page, error) {
+	return f.getByPathFn(ctx, path)
+}
+func (f *fakePageService) ReorderAdminPages(ctx context.Context, parentID *uint, ids []uint, role string) error {
+	return f.reorderFn(ctx, parentID, ids, role)
+}
+func (f *fakePageService) DeleteAdminPage(ctx context.Context, id uint, uid uint, role string) error {
+	return f.deleteFn(ctx, id, uid, role)
+}
+
+func newPageRouter(svc core.PageService, actor gin.HandlerFunc) *gin.Engine {
+	r := gin.New()
+	r.Use(actor)
+	api := NewPageAPI(svc)
+	r.GET("/pages", api.GetPageTree)
+	r.GET("/pages/*path", api.GetPageByPath)
+	r.POST("/admin/pages/reorder", api.ReorderPages)
+	r.POST("/admin/pages/:id/publish", api.PublishPage)
+	r.DELETE("/admin/pages/:id", api.DeletePage)
+	return r
+}
+
+func TestPageAPI_GetPageTree(t *testing.T) {
+	docs := uint(1)
+	svc := &fakePageService{
+		listPublicFn: func(ctx context.Context) ([]entity.Page, error) {
+			return []entity.Page{
+				{ID: 1, Title: "Docs", Slug: "docs", Path: "docs"},
+				{ID: 2, Title: "About", Slug: "about", Path: "about"},
+				{ID: 3, ParentID: &docs, Title: "Install", Slug: "install", Path: "docs/install"},
+			}, nil
+		},
+	}
+	w := doRequest(newPageRouter(svc, injectActor(0, "")), http.MethodGet, "/pages")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got []dto.PageTreeNode
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Path != "docs" || got[1].Path != "about" {
+		t.Fatalf("unexpected roots: %s", w.Body.String())
+	}
+	if len(got[0].Children) != 1 || got[0].Children[0].Path != "docs/install" || got[1].Children == nil {
+		t.Fatalf("unexpected children: %s", w.Body.String())
+	}
+}
+
+func TestPageAPI_GetPageByPath(t *testing.T) {
+	svc := &fakePageService{
+		getByPathFn: func(ctx context.Context, path string) (entity.Page, error) {
+			if path != "/docs/install/linux" {
+				return entity.Page{}, core.ErrNotFound
+			}
+			return entity.Page{
+				ID: 3, Title: "Linux", Slug: "linux", Path: "docs/install/linux",
+				Breadcrumbs: []entity.PageRef{{ID: 1, Title: "Docs", Path: "docs"}, {ID: 2, Title: "Install", Path: "docs/install"}},
+				Rendered:    &entity.RenderedContent{HTML: "<p>hi</p>"},
+			}, nil
+		},
+	}
+	r := newPageRouter(svc, injectActor(0, ""))
+
+	w := doRequest(r, http.MethodGet, "/pages/docs/install/linux")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got dto.PageResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Breadcrumbs) != 2 || got.Breadcrumbs[1].Path != "docs/install" || got.ContentHTML != "<p>hi</p>" {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+	if got.Children == nil {
+		t.Fatal("children should be reported as an empty list")
+	}
+
+	if w := doRequest(r, http.MethodGet, "/pages/docs/missing"); w.Code != http.StatusNotFound {
+		t.Fatalf("want 404, got %d", w.Code)
+	}
+}
+
+func TestPageAPI_ReorderPages(t *testing.T) {
+	var gotParent *uint
+	var gotIDs []uint
+	svc := &fakePageService{
+		reorderFn: func(ctx context.Context, parentID *uint, ids []uint, role string) error {
+			gotParent, gotIDs = parentID, ids
+			return nil
+		},
+	}
+	body := map[string]any{"parent_id": 4, "page_ids": []uint{7, 5, 6}}
+	w := doJSON(newPageRouter(svc, injectActor(1, "admin")), http.MethodPost, "/admin/pages/reorder", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	if gotParent == nil || *gotParent != 4 || !slices.Equal(gotIDs, []uint{7, 5, 6}) {
+		t.Fatalf("unexpected args: %v %v", gotParent, gotIDs)
+	}
+
+	w = doJSON(newPageRouter(svc, injectActor(1, "admin")), http.MethodPost, "/admin/pages/reorder", map[string]any{"page_ids": []uint{}})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("empty order: want 400, got %d", w.Code)
+	}
+}
+
+func TestPageAPI_DeletePage_HasChildren(t *testing.T) {
+	svc := &fakePageService{
+		deleteFn: func(ctx context.Context, id uint, uid uint, role string) error {
+			return service.ErrPageHasChildren
+		},
+	}
+	w := doRequest(newPageRouter(svc, injectActor(1, "admin")), http.MethodDelete, "/admin/pages/3")
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+}
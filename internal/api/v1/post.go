@@ -4,10 +4,13 @@ import (
 	"KaldalisCMS/internal/api/errorx"
 	"KaldalisCMS/internal/api/v1/dto"
 	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
 	"context"
 	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -18,12 +21,18 @@ import (
 // stable regardless of caller identity.
 type PublicPostAPI struct {
 	service core.PostService
+	views   core.AnalyticsService
 }
 
 func NewPublicPostAPI(service core.PostService) *PublicPostAPI {
 	return &PublicPostAPI{service: service}
 }
 
+// SetViewTracker enables view counting on GetPostByID.
+func (api *PublicPostAPI) SetViewTracker(views core.AnalyticsService) {
+	api.views = views
+}
+
 func parsePostID(c *gin.Context) (uint, bool) {
 	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -33,20 +42,100 @@ func parsePostID(c *gin.Context) (uint, bool) {
 	return uint(id64), true
 }
 
-// GetPosts returns only published posts for public consumers.
+// parseLocaleChain reads the optional ?locale= fallback chain, e.g. "en,zh-CN".
+func parseLocaleChain(c *gin.Context) ([]string, bool) {
+	locales, err := entity.ParseLocaleChain(c.Query("locale"))
+	if err != nil {
+		errorx.RespondValidationError(c, "invalid locale", map[string]any{"field": "locale", "reason": err.Error()})
+		return nil, false
+	}
+	return locales, true
+}
+
+// parsePostListQuery reads pagination, filter and sort parameters shared by post listings.
+// Unknown sort keys are rejected instead of silently falling back, so clients notice typos.
+func parsePostListQuery(c *gin.Context) (entity.PostListQuery, bool) {
+	query := entity.PostListQuery{
+		SortBy: c.DefaultQuery("sort", entity.PostSortCreatedAt),
+		Order:  c.DefaultQuery("order", entity.SortOrderDesc),
+	}
+
+	var (
+		err error
+		ok  bool
+	)
+	if query.Page, err = strconv.Atoi(c.DefaultQuery("page", "1")); err != nil {
+		errorx.RespondValidationError(c, "invalid page", map[string]any{"field": "page"})
+		return entity.PostListQuery{}, false
+	}
+	if query.PageSize, err = strconv.Atoi(c.DefaultQuery("page_size", strconv.Itoa(entity.DefaultPostPageSize))); err != nil {
+		errorx.RespondValidationError(c, "invalid page_size", map[string]any{"field": "page_size"})
+		return entity.PostListQuery{}, false
+	}
+	if query.SortBy != entity.PostSortCreatedAt && query.SortBy != entity.PostSortUpdatedAt {
+		errorx.RespondValidationError(c, "invalid sort", map[string]any{"field": "sort"})
+		return entity.PostListQuery{}, false
+	}
+	if query.Order != entity.SortOrderAsc && query.Order != entity.SortOrderDesc {
+		errorx.RespondValidationError(c, "invalid order", map[string]any{"field": "order"})
+		return entity.PostListQuery{}, false
+	}
+
+	for _, f := range []struct {
+		name string
+		dst  **uint
+	}{
+		{"tag_id", &query.TagID},
+		{"category_id", &query.CategoryID},
+		{"author_id", &query.AuthorID},
+	} {
+		raw := c.Query(f.name)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil || v == 0 {
+			errorx.RespondValidationError(c, "invalid "+f.name, map[string]any{"field": f.name})
+			return entity.PostListQuery{}, false
+		}
+		id := uint(v)
+		*f.dst = &id
+	}
+
+	if query.Locales, ok = parseLocaleChain(c); !ok {
+		return entity.PostListQuery{}, false
+	}
+	return query.Normalized(), true
+}
+
+// GetPosts returns one page of published posts for public consumers.
 // @Summary List published posts
-// @Description Public read-only endpoint for published content.
+// @Description Public read-only endpoint for published content with pagination, filters and sorting.
 // @Tags posts
 // @Produce json
-// @Success 200 {array} dto.PostResponse
+// @Param page query int false "page number" default(1)
+// @Param page_size query int false "page size (max 100)" default(20)
+// @Param tag_id query int false "only posts carrying this tag"
+// @Param category_id query int false "only posts in this category"
+// @Param author_id query int false "only posts by this author"
+// @Param sort query string false "sort key: created_at|updated_at" default(created_at)
+// @Param order query string false "sort order: asc|desc" default(desc)
+// @Param locale query string false "locale fallback chain, e.g. en,zh-CN; lists each translated post once"
+// @Success 200 {object} dto.PostListResponse
+// @Failure 400 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Failure 504 {object} dto.ErrorResponse
 // @Router /posts [get]
 func (api *PublicPostAPI) GetPosts(c *gin.Context) {
+	query, ok := parsePostListQuery(c)
+	if !ok {
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	posts, err := api.service.ListPublicPosts(ctx)
+	posts, total, err := api.service.ListPublicPosts(ctx, query)
 	if err != nil {
 		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
 			errorx.RespondTimeoutError(c, "list posts timed out")
@@ -56,16 +145,18 @@ func (api *PublicPostAPI) GetPosts(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ToPostListResponse(posts))
+	c.JSON(http.StatusOK, dto.ToPostPageResponse(posts, total, query))
 }
 
 // GetPostByID returns a single published post.
 // Drafts are intentionally invisible on this endpoint to avoid leaking unpublished content.
+// When a view tracker is set, each successful read counts as a view of the returned post.
 // @Summary Get published post
 // @Description Public endpoint that returns one published post by numeric ID.
 // @Tags posts
 // @Produce json
 // @Param id path int true "post id"
+// @Param locale query string false "locale fallback chain; returns the best published translation"
 // @Success 200 {object} dto.PostResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
@@ -77,11 +168,15 @@ func (api *PublicPostAPI) GetPostByID(c *gin.Context) {
 	if !ok {
 		return
 	}
+	locales, ok := parseLocaleChain(c)
+	if !ok {
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	post, err := api.service.GetPublicPostByID(ctx, id)
+	post, err := api.service.GetPublicPostByID(ctx, id, locales)
 	if err != nil {
 		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
 			errorx.RespondTimeoutError(c, "get post timed out")
@@ -91,5 +186,198 @@ func (api *PublicPostAPI) GetPostByID(c *gin.Context) {
 		return
 	}
 
+	if api.views != nil {
+		api.views.RecordView(post.ID, entity.Visitor{IP: c.ClientIP(), UserAgent: c.Request.UserAgent()})
+	}
 	c.JSON(http.StatusOK, dto.ToPostResponse(&post))
 }
+
+// GetPostBySlug returns a single published post by slug.
+// Requests for a slug the post used previously receive 301 with the current slug, so shared
+// links keep working after an editor renames a post. Likewise, when the locale chain prefers
+// a translation with another slug, the client is redirected to that slug.
+// @Summary Get published post by slug
+// @Description Public endpoint that resolves a published post by current or historical slug.
+// @Tags posts
+// @Produce json
+// @Param slug path string true "post slug"
+// @Param locale query string false "locale fallback chain; picks the slug's locale and preferred translation"
+// @Success 200 {object} dto.PostResponse
+// @Success 301 {object} dto.PostSlugRedirectResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /posts/slug/{slug} [get]
+func (api *PublicPostAPI) GetPostBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		errorx.RespondValidationError(c, "invalid post slug", map[string]any{"field": "slug"})
+		return
+	}
+	locales, ok := parseLocaleChain(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	post, err := api.service.GetPublicPostBySlug(ctx, slug, locales)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "get post timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusNotFound, nil)
+		return
+	}
+
+	if post.Slug != slug {
+		location := postSlugLocation(c, post.Slug)
+		c.Header("Location", location)
+		c.JSON(http.StatusMovedPermanently, dto.PostSlugRedirectResponse{Slug: post.Slug, Location: location})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPostResponse(&post))
+}
+
+// SearchPosts runs a full-text search over published posts, ordered by relevance.
+// Every whitespace-separated term must match; Chinese terms match as consecutive characters.
+// @Summary Search published posts
+// @Description Public full-text search over published post titles and content with highlighted excerpts.
+// @Tags posts
+// @Produce json
+// @Param q query string true "search terms"
+// @Param page query int false "page number" default(1)
+// @Param page_size query int false "page size (max 100)" default(20)
+// @Success 200 {object} dto.PostSearchResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /posts/search [get]
+func (api *PublicPostAPI) SearchPosts(c *gin.Context) {
+	query := entity.PostSearchQuery{Q: c.Query("q")}
+
+	var err error
+	if query.Page, err = strconv.Atoi(c.DefaultQuery("page", "1")); err != nil {
+		errorx.RespondValidationError(c, "invalid page", map[string]any{"field": "page"})
+		return
+	}
+	if query.PageSize, err = strconv.Atoi(c.DefaultQuery("page_size", strconv.Itoa(entity.DefaultPostPageSize))); err != nil {
+		errorx.RespondValidationError(c, "invalid page_size", map[string]any{"field": "page_size"})
+		return
+	}
+	query = query.Normalized()
+	if query.Q == "" {
+		errorx.RespondValidationError(c, "search query is required", map[string]any{"field": "q"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	hits, total, err := api.service.SearchPublicPosts(ctx, query)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "search posts timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPostSearchResponse(hits, total, query))
+}
+
+// GetRelatedPosts returns the published posts most related to a published post.
+// Relatedness is scored by shared tags and the same category, optionally plus text similarity.
+// @Summary List related posts
+// @Description Public endpoint returning the published posts most related to one published post, most related first.
+// @Tags posts
+// @Produce json
+// @Param id path int true "post id"
+// @Param limit query int false "number of posts (max 20)" default(5)
+// @Success 200 {array} dto.PostSummaryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /posts/{id}/related [get]
+func (api *PublicPostAPI) GetRelatedPosts(c *gin.Context) {
+	id, ok := parsePostID(c)
+	if !ok {
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(entity.DefaultRelatedPostLimit)))
+	if err != nil || limit <= 0 {
+		errorx.RespondValidationError(c, "invalid limit", map[string]any{"field": "limit"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	posts, err := api.service.ListRelatedPosts(ctx, id, limit)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list related posts timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusNotFound, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPostSummaryListResponse(posts))
+}
+
+// postSlugLocation rebuilds the slug route for a new slug, keeping whatever prefix
+// (e.g. /api/v1) the handler was mounted under and the query string, so a locale chain
+// survives the redirect.
+func postSlugLocation(c *gin.Context, slug string) string {
+	location := url.PathEscape(slug)
+	path := c.Request.URL.Path
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		location = path[:i+1] + location
+	}
+	if q := c.Request.URL.RawQuery; q != "" {
+		location += "?" + q
+	}
+	return location
+}
+
+// GetPostPreview serves the post behind a signed preview link, whatever its status.
+// The token is the only credential, so this route sits outside the Casbin read policies.
+// Responses must never be indexed, cached by shared caches or leak the link as a referrer.
+// @Summary Get post preview
+// @Description Public endpoint serving a draft read-only, with its media, to holders of a valid preview link.
+// @Tags posts
+// @Produce json
+// @Param token path string true "preview token"
+// @Success 200 {object} dto.PostPreviewResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /preview/{token} [get]
+func (api *PublicPostAPI) GetPostPreview(c *gin.Context) {
+	c.Header("X-Robots-Tag", "noindex, nofollow, noarchive")
+	c.Header("Cache-Control", "private, no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	preview, err := api.service.GetPreviewPost(ctx, c.Param("token"))
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "get post preview timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusForbidden, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToPostPreviewResponse(&preview))
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"KaldalisCMS/internal/api/v1/dto"
 	"KaldalisCMS/internal/core"
@@ -23,6 +25,10 @@ func newPublicRouter(svc core.PostService) *gin.Engine {
 	api := NewPublicPostAPI(svc)
 	r.GET("/posts", api.GetPosts)
 	r.GET("/posts/:id", api.GetPostByID)
+	r.GET("/posts/slug/:slug", api.GetPostBySlug)
+	r.GET("/posts/search", api.SearchPosts)
+	r.GET("/posts/:id/related", api.GetRelatedPosts)
+	r.GET("/preview/:token", api.GetPostPreview)
 	return r
 }
 
@@ -35,8 +41,8 @@ func doRequest(r *gin.Engine, method, path string) *httptest.ResponseRecorder {
 
 func TestPublicPostAPI_GetPosts_Success(t *testing.T) {
 	svc := &fakePostService{
-		listPublicFn: func(ctx context.Context) ([]entity.Post, error) {
-			return []entity.Post{{ID: 1, Title: "hello"}}, nil
+		listPublicFn: func(ctx context.Context, q entity.PostListQuery) ([]entity.Post, int64, error) {
+			return []entity.Post{{ID: 1, Title: "hello"}}, 1, nil
 		},
 	}
 	w := doRequest(newPublicRouter(svc), http.MethodGet, "/posts")
@@ -44,19 +50,64 @@ func TestPublicPostAPI_GetPosts_Success(t *testing.T) {
 	if w.Code != http.StatusOK {
 		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
 	}
-	var got []dto.PostResponse
+	var got dto.PostListResponse
 	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
 		t.Fatal(err)
 	}
-	if len(got) != 1 || got[0].ID != 1 {
+	if len(got.Items) != 1 || got.Items[0].ID != 1 || got.Total != 1 {
 		t.Fatalf("unexpected body: %+v", got)
 	}
+	if got.Page != 1 || got.PageSize != entity.DefaultPostPageSize {
+		t.Fatalf("pagination defaults: %+v", got)
+	}
+}
+
+func TestPublicPostAPI_GetPosts_QueryParams(t *testing.T) {
+	var seen entity.PostListQuery
+	svc := &fakePostService{
+		listPublicFn: func(ctx context.Context, q entity.PostListQuery) ([]entity.Post, int64, error) {
+			seen = q
+			return nil, 0, nil
+		},
+	}
+	w := doRequest(newPublicRouter(svc), http.MethodGet, "/posts?page=3&page_size=5&tag_id=2&category_id=4&author_id=6&sort=updated_at&order=asc")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	if seen.Page != 3 || seen.PageSize != 5 || seen.SortBy != entity.PostSortUpdatedAt || seen.Order != entity.SortOrderAsc {
+		t.Fatalf("paging/sort not propagated: %+v", seen)
+	}
+	if seen.TagID == nil || *seen.TagID != 2 || seen.CategoryID == nil || *seen.CategoryID != 4 || seen.AuthorID == nil || *seen.AuthorID != 6 {
+		t.Fatalf("filters not propagated: %+v", seen)
+	}
+	var got dto.PostListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Items == nil || len(got.Items) != 0 {
+		t.Fatalf("empty page should serialize as []: %s", w.Body.String())
+	}
+}
+
+func TestPublicPostAPI_GetPosts_InvalidQuery(t *testing.T) {
+	for _, path := range []string{
+		"/posts?sort=title",
+		"/posts?order=sideways",
+		"/posts?page=x",
+		"/posts?tag_id=abc",
+		"/posts?category_id=0",
+	} {
+		w := doRequest(newPublicRouter(&fakePostService{}), http.MethodGet, path)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: status %d", path, w.Code)
+		}
+	}
 }
 
 func TestPublicPostAPI_GetPosts_ServiceError(t *testing.T) {
 	svc := &fakePostService{
-		listPublicFn: func(ctx context.Context) ([]entity.Post, error) {
-			return nil, core.ErrInternalError
+		listPublicFn: func(ctx context.Context, q entity.PostListQuery) ([]entity.Post, int64, error) {
+			return nil, 0, core.ErrInternalError
 		},
 	}
 	w := doRequest(newPublicRouter(svc), http.MethodGet, "/posts")
@@ -87,7 +138,7 @@ func TestPublicPostAPI_GetPostByID_InvalidID(t *testing.T) {
 
 func TestPublicPostAPI_GetPostByID_NotFound(t *testing.T) {
 	svc := &fakePostService{
-		getPublicByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+		getPublicByIDFn: func(ctx context.Context, id uint, locales []string) (entity.Post, error) {
 			return entity.Post{}, core.ErrNotFound
 		},
 	}
@@ -106,7 +157,7 @@ func TestPublicPostAPI_GetPostByID_NotFound(t *testing.T) {
 
 func TestPublicPostAPI_GetPostByID_Success(t *testing.T) {
 	svc := &fakePostService{
-		getPublicByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+		getPublicByIDFn: func(ctx context.Context, id uint, locales []string) (entity.Post, error) {
 			return entity.Post{ID: id, Title: "hi"}, nil
 		},
 	}
@@ -122,3 +173,185 @@ func TestPublicPostAPI_GetPostByID_Success(t *testing.T) {
 		t.Fatalf("body: %+v", got)
 	}
 }
+
+func TestPublicPostAPI_GetPostBySlug(t *testing.T) {
+	svc := &fakePostService{
+		getPublicBySlugFn: func(ctx context.Context, slug string, locales []string) (entity.Post, error) {
+			switch slug {
+			case "current":
+				return entity.Post{ID: 1, Slug: "current"}, nil
+			case "old":
+				return entity.Post{ID: 1, Slug: "current"}, nil
+			default:
+				return entity.Post{}, core.ErrNotFound
+			}
+		},
+	}
+	r := newPublicRouter(svc)
+
+	w := doRequest(r, http.MethodGet, "/posts/slug/current")
+	if w.Code != http.StatusOK {
+		t.Fatalf("current: status %d", w.Code)
+	}
+
+	w = doRequest(r, http.MethodGet, "/posts/slug/old")
+	if w.Code != http.StatusMovedPermanently {
+		t.Fatalf("old: status %d", w.Code)
+	}
+	if loc := w.Header().Get("Location"); loc != "/posts/slug/current" {
+		t.Fatalf("location: %q", loc)
+	}
+	var redirect dto.PostSlugRedirectResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &redirect); err != nil {
+		t.Fatal(err)
+	}
+	if redirect.Slug != "current" {
+		t.Fatalf("redirect body: %+v", redirect)
+	}
+
+	w = doRequest(r, http.MethodGet, "/posts/slug/missing")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("missing: status %d", w.Code)
+	}
+}
+
+func TestPublicPostAPI_GetPostBySlug_LocaleChain(t *testing.T) {
+	svc := &fakePostService{
+		getPublicBySlugFn: func(ctx context.Context, slug string, locales []string) (entity.Post, error) {
+			if len(locales) != 2 || locales[0] != "en" || locales[1] != "zh-CN" {
+				t.Fatalf("locale chain not parsed: %v", locales)
+			}
+			// The English translation of "ni-hao" lives under another slug.
+			return entity.Post{ID: 2, Slug: "hello", Locale: "en", Translations: []entity.PostTranslation{{ID: 1, Locale: "zh-CN", Slug: "ni-hao"}}}, nil
+		},
+	}
+	r := newPublicRouter(svc)
+
+	w := doRequest(r, http.MethodGet, "/posts/slug/ni-hao?locale=en,zh-cn")
+	if w.Code != http.StatusMovedPermanently {
+		t.Fatalf("status: %d", w.Code)
+	}
+	if loc := w.Header().Get("Location"); loc != "/posts/slug/hello?locale=en,zh-cn" {
+		t.Fatalf("location should keep the locale chain: %q", loc)
+	}
+
+	w = doRequest(r, http.MethodGet, "/posts/slug/hello?locale=en,zh-cn")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d", w.Code)
+	}
+	var got dto.PostResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Locale != "en" || len(got.Translations) != 1 || got.Translations[0].Slug != "ni-hao" {
+		t.Fatalf("body: %s", w.Body.String())
+	}
+
+	w = doRequest(r, http.MethodGet, "/posts/slug/hello?locale=english")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("invalid locale: status %d", w.Code)
+	}
+}
+
+func TestPublicPostAPI_SearchPosts(t *testing.T) {
+	svc := &fakePostService{
+		searchPublicFn: func(ctx context.Context, q entity.PostSearchQuery) ([]entity.PostSearchHit, int64, error) {
+			if q.Q != "编程" || q.Page != 2 {
+				t.Fatalf("query not propagated: %+v", q)
+			}
+			return []entity.PostSearchHit{{Post: entity.Post{ID: 7, Title: "t"}, Snippet: "<mark>编程</mark>"}}, 11, nil
+		},
+	}
+	w := doRequest(newPublicRouter(svc), http.MethodGet, "/posts/search?q=%E7%BC%96%E7%A8%8B&page=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got dto.PostSearchResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Total != 11 || len(got.Items) != 1 || got.Items[0].ID != 7 || got.Items[0].Snippet != "<mark>编程</mark>" {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestPublicPostAPI_SearchPosts_MissingQuery(t *testing.T) {
+	svc := &fakePostService{} // service must not be called without q
+	w := doRequest(newPublicRouter(svc), http.MethodGet, "/posts/search?q=%20")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestPublicPostAPI_GetRelatedPosts(t *testing.T) {
+	var gotID uint
+	var gotLimit int
+	svc := &fakePostService{
+		listRelatedFn: func(ctx context.Context, id uint, limit int) ([]entity.Post, error) {
+			if id == 404 {
+				return nil, core.ErrNotFound
+			}
+			gotID, gotLimit = id, limit
+			return []entity.Post{{ID: 2, Title: "next"}}, nil
+		},
+	}
+	r := newPublicRouter(svc)
+
+	w := doRequest(r, http.MethodGet, "/posts/1/related")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got []dto.PostResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != 2 || gotID != 1 || gotLimit != entity.DefaultRelatedPostLimit {
+		t.Fatalf("unexpected result %s for id=%d limit=%d", w.Body.String(), gotID, gotLimit)
+	}
+
+	if w := doRequest(r, http.MethodGet, "/posts/1/related?limit=3"); w.Code != http.StatusOK || gotLimit != 3 {
+		t.Fatalf("limit: status %d, got %d", w.Code, gotLimit)
+	}
+	if w := doRequest(r, http.MethodGet, "/posts/1/related?limit=0"); w.Code != http.StatusBadRequest {
+		t.Fatalf("invalid limit: want 400, got %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodGet, "/posts/404/related"); w.Code != http.StatusNotFound {
+		t.Fatalf("unknown post: want 404, got %d", w.Code)
+	}
+}
+
+func TestPublicPostAPI_GetPostPreview(t *testing.T) {
+	svc := &fakePostService{
+		getPreviewFn: func(ctx context.Context, token string) (entity.PostPreview, error) {
+			if token != "good" {
+				return entity.PostPreview{}, core.ErrPermission
+			}
+			return entity.PostPreview{
+				Post:      entity.Post{ID: 3, Title: "draft", Status: entity.StatusDraft},
+				Media:     []entity.MediaAsset{{ID: 7, OriginalName: "cover.png"}},
+				ExpiresAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			}, nil
+		},
+	}
+	r := newPublicRouter(svc)
+
+	w := doRequest(r, http.MethodGet, "/preview/good")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("X-Robots-Tag"); !strings.Contains(got, "noindex") {
+		t.Fatalf("missing noindex header: %q", got)
+	}
+	var got dto.PostPreviewResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Post == nil || got.Post.ID != 3 || len(got.Media) != 1 || got.Media[0].ID != 7 {
+		t.Fatalf("unexpected preview: %s", w.Body.String())
+	}
+
+	w = doRequest(r, http.MethodGet, "/preview/bad")
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Header().Get("X-Robots-Tag"), "noindex") {
+		t.Fatalf("invalid token: status %d, headers %v", w.Code, w.Header())
+	}
+}
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"context"
+	"time"
 
 	"KaldalisCMS/internal/core/entity"
 )
@@ -10,22 +11,57 @@ import (
 // Each field is an optional override; nil fields cause the test to panic if hit,
 // which surfaces accidentally-exercised branches instead of silently passing.
 type fakePostService struct {
-	listPublicFn       func(ctx context.Context) ([]entity.Post, error)
-	getPublicByIDFn    func(ctx context.Context, id uint) (entity.Post, error)
-	listAdminFn        func(ctx context.Context, uid uint, role string) ([]entity.Post, error)
-	getAdminByIDFn     func(ctx context.Context, id uint, uid uint, role string) (entity.Post, error)
-	createAdminFn      func(ctx context.Context, uid uint, role string, p entity.Post) (entity.Post, error)
-	updateAdminFn      func(ctx context.Context, id uint, patch entity.PostPatch, uid uint, role string) error
-	deleteAdminFn      func(ctx context.Context, id uint, uid uint, role string) error
-	publishAdminFn     func(ctx context.Context, id uint, uid uint, role string) error
-	moveToDraftAdminFn func(ctx context.Context, id uint, uid uint, role string) error
+	listPublicFn        func(ctx context.Context, q entity.PostListQuery) ([]entity.Post, int64, error)
+	getPublicByIDFn     func(ctx context.Context, id uint, locales []string) (entity.Post, error)
+	getPublicBySlugFn   func(ctx context.Context, slug string, locales []string) (entity.Post, error)
+	searchPublicFn      func(ctx context.Context, q entity.PostSearchQuery) ([]entity.PostSearchHit, int64, error)
+	listRelatedFn       func(ctx context.Context, id uint, limit int) ([]entity.Post, error)
+	listAdminFn         func(ctx context.Context, uid uint, role string) ([]entity.Post, error)
+	getAdminByIDFn      func(ctx context.Context, id uint, uid uint, role string) (entity.Post, error)
+	createAdminFn       func(ctx context.Context, uid uint, role string, p entity.Post) (entity.Post, error)
+	updateAdminFn       func(ctx context.Context, id uint, patch entity.PostPatch, ifVersion uint, uid uint, role string) error
+	deleteAdminFn       func(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error
+	publishAdminFn      func(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error
+	moveToDraftAdminFn  func(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error
+	listRevisionsFn     func(ctx context.Context, postID uint, uid uint, role string) ([]entity.PostRevision, error)
+	getRevisionFn       func(ctx context.Context, postID uint, revID uint, uid uint, role string) (entity.PostRevision, error)
+	diffRevisionsFn     func(ctx context.Context, postID uint, fromID uint, toID uint, uid uint, role string) (entity.PostRevisionDiff, error)
+	restoreRevisionFn   func(ctx context.Context, postID uint, revID uint, ifVersion uint, uid uint, role string) error
+	scheduleAdminFn     func(ctx context.Context, id uint, schedule entity.PostSchedule, ifVersion uint, uid uint, role string) error
+	submitReviewFn      func(ctx context.Context, id uint, uid uint, role string) error
+	listReviewQueueFn   func(ctx context.Context, role string) ([]entity.Post, error)
+	approveAdminFn      func(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error
+	rejectAdminFn       func(ctx context.Context, id uint, reason string, ifVersion uint, uid uint, role string) error
+	listTrashedFn       func(ctx context.Context, role string) ([]entity.Post, error)
+	restoreAdminFn      func(ctx context.Context, id uint, role string) error
+	purgeAdminFn        func(ctx context.Context, id uint, role string) error
+	saveAutosaveFn      func(ctx context.Context, id uint, autosave entity.PostAutosave, uid uint, role string) (entity.PostAutosave, error)
+	getAutosaveFn       func(ctx context.Context, id uint, uid uint, role string) (entity.PostAutosave, error)
+	promoteAutosaveFn   func(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error
+	discardAutosaveFn   func(ctx context.Context, id uint, uid uint, role string) error
+	linkTranslationFn   func(ctx context.Context, id uint, translationID uint, role string) error
+	unlinkTranslationFn func(ctx context.Context, id uint, role string) error
+	createPreviewFn     func(ctx context.Context, postID uint, ttl time.Duration, uid uint, role string) (entity.PostPreviewToken, string, error)
+	listPreviewsFn      func(ctx context.Context, postID uint, uid uint, role string) ([]entity.PostPreviewToken, error)
+	revokePreviewFn     func(ctx context.Context, postID uint, tokenID uint, uid uint, role string) error
+	getPreviewFn        func(ctx context.Context, token string) (entity.PostPreview, error)
+	bulkAdminFn         func(ctx context.Context, req entity.PostBulkRequest, uid uint, role string) (entity.PostBulkResult, error)
 }
 
-func (f *fakePostService) ListPublicPosts(ctx context.Context) ([]entity.Post, error) {
-	return f.listPublicFn(ctx)
+func (f *fakePostService) ListPublicPosts(ctx context.Context, q entity.PostListQuery) ([]entity.Post, int64, error) {
+	return f.listPublicFn(ctx, q)
 }
-func (f *fakePostService) GetPublicPostByID(ctx context.Context, id uint) (entity.Post, error) {
-	return f.getPublicByIDFn(ctx, id)
+func (f *fakePostService) GetPublicPostByID(ctx context.Context, id uint, locales []string) (entity.Post, error) {
+	return f.getPublicByIDFn(ctx, id, locales)
+}
+func (f *fakePostService) GetPublicPostBySlug(ctx context.Context, slug string, locales []string) (entity.Post, error) {
+	return f.getPublicBySlugFn(ctx, slug, locales)
+}
+func (f *fakePostService) SearchPublicPosts(ctx context.Context, q entity.PostSearchQuery) ([]entity.PostSearchHit, int64, error) {
+	return f.searchPublicFn(ctx, q)
+}
+func (f *fakePostService) ListRelatedPosts(ctx context.Context, id uint, limit int) ([]entity.Post, error) {
+	return f.listRelatedFn(ctx, id, limit)
 }
 func (f *fakePostService) ListAdminPosts(ctx context.Context, uid uint, role string) ([]entity.Post, error) {
 	return f.listAdminFn(ctx, uid, role)
@@ -36,15 +72,87 @@ func (f *fakePostService) GetAdminPostByID(ctx context.Context, id uint, uid uin
 func (f *fakePostService) CreateAdminPost(ctx context.Context, uid uint, role string, p entity.Post) (entity.Post, error) {
 	return f.createAdminFn(ctx, uid, role, p)
 }
-func (f *fakePostService) UpdateAdminPost(ctx context.Context, id uint, patch entity.PostPatch, uid uint, role string) error {
-	return f.updateAdminFn(ctx, id, patch, uid, role)
+func (f *fakePostService) UpdateAdminPost(ctx context.Context, id uint, patch entity.PostPatch, ifVersion uint, uid uint, role string) error {
+	return f.updateAdminFn(ctx, id, patch, ifVersion, uid, role)
+}
+func (f *fakePostService) DeleteAdminPost(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error {
+	return f.deleteAdminFn(ctx, id, ifVersion, uid, role)
+}
+func (f *fakePostService) PublishAdminPost(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error {
+	return f.publishAdminFn(ctx, id, ifVersion, uid, role)
+}
+func (f *fakePostService) MovePostToDraft(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error {
+	return f.moveToDraftAdminFn(ctx, id, ifVersion, uid, role)
+}
+func (f *fakePostService) ScheduleAdminPost(ctx context.Context, id uint, schedule entity.PostSchedule, ifVersion uint, uid uint, role string) error {
+	return f.scheduleAdminFn(ctx, id, schedule, ifVersion, uid, role)
+}
+
+func (f *fakePostService) ListAdminPostRevisions(ctx context.Context, postID uint, uid uint, role string) ([]entity.PostRevision, error) {
+	return f.listRevisionsFn(ctx, postID, uid, role)
+}
+func (f *fakePostService) GetAdminPostRevision(ctx context.Context, postID uint, revID uint, uid uint, role string) (entity.PostRevision, error) {
+	return f.getRevisionFn(ctx, postID, revID, uid, role)
+}
+func (f *fakePostService) DiffAdminPostRevisions(ctx context.Context, postID uint, fromID uint, toID uint, uid uint, role string) (entity.PostRevisionDiff, error) {
+	return f.diffRevisionsFn(ctx, postID, fromID, toID, uid, role)
+}
+func (f *fakePostService) RestoreAdminPostRevision(ctx context.Context, postID uint, revID uint, ifVersion uint, uid uint, role string) error {
+	return f.restoreRevisionFn(ctx, postID, revID, ifVersion, uid, role)
+}
+
+func (f *fakePostService) SubmitAdminPostForReview(ctx context.Context, id uint, uid uint, role string) error {
+	return f.submitReviewFn(ctx, id, uid, role)
+}
+func (f *fakePostService) ListReviewQueue(ctx context.Context, role string) ([]entity.Post, error) {
+	return f.listReviewQueueFn(ctx, role)
+}
+func (f *fakePostService) ApproveAdminPost(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error {
+	return f.approveAdminFn(ctx, id, ifVersion, uid, role)
+}
+func (f *fakePostService) RejectAdminPost(ctx context.Context, id uint, reason string, ifVersion uint, uid uint, role string) error {
+	return f.rejectAdminFn(ctx, id, reason, ifVersion, uid, role)
+}
+
+func (f *fakePostService) ListTrashedPosts(ctx context.Context, role string) ([]entity.Post, error) {
+	return f.listTrashedFn(ctx, role)
+}
+func (f *fakePostService) RestoreAdminPost(ctx context.Context, id uint, role string) error {
+	return f.restoreAdminFn(ctx, id, role)
+}
+func (f *fakePostService) PurgeAdminPost(ctx context.Context, id uint, role string) error {
+	return f.purgeAdminFn(ctx, id, role)
+}
+func (f *fakePostService) SaveAdminPostAutosave(ctx context.Context, id uint, autosave entity.PostAutosave, uid uint, role string) (entity.PostAutosave, error) {
+	return f.saveAutosaveFn(ctx, id, autosave, uid, role)
+}
+func (f *fakePostService) GetAdminPostAutosave(ctx context.Context, id uint, uid uint, role string) (entity.PostAutosave, error) {
+	return f.getAutosaveFn(ctx, id, uid, role)
+}
+func (f *fakePostService) PromoteAdminPostAutosave(ctx context.Context, id uint, ifVersion uint, uid uint, role string) error {
+	return f.promoteAutosaveFn(ctx, id, ifVersion, uid, role)
+}
+func (f *fakePostService) DiscardAdminPostAutosave(ctx context.Context, id uint, uid uint, role string) error {
+	return f.discardAutosaveFn(ctx, id, uid, role)
+}
+func (f *fakePostService) LinkAdminPostTranslation(ctx context.Context, id uint, translationID uint, role string) error {
+	return f.linkTranslationFn(ctx, id, translationID, role)
+}
+func (f *fakePostService) UnlinkAdminPostTranslation(ctx context.Context, id uint, role string) error {
+	return f.unlinkTranslationFn(ctx, id, role)
+}
+func (f *fakePostService) CreatePreviewLink(ctx context.Context, postID uint, ttl time.Duration, uid uint, role string) (entity.PostPreviewToken, string, error) {
+	return f.createPreviewFn(ctx, postID, ttl, uid, role)
+}
+func (f *fakePostService) ListPreviewLinks(ctx context.Context, postID uint, uid uint, role string) ([]entity.PostPreviewToken, error) {
+	return f.listPreviewsFn(ctx, postID, uid, role)
 }
-func (f *fakePostService) DeleteAdminPost(ctx context.Context, id uint, uid uint, role string) error {
-	return f.deleteAdminFn(ctx, id, uid, role)
+func (f *fakePostService) RevokePreviewLink(ctx context.Context, postID uint, tokenID uint, uid uint, role string) error {
+	return f.revokePreviewFn(ctx, postID, tokenID, uid, role)
 }
-func (f *fakePostService) PublishAdminPost(ctx context.Context, id uint, uid uint, role string) error {
-	return f.publishAdminFn(ctx, id, uid, role)
+func (f *fakePostService) GetPreviewPost(ctx context.Context, token string) (entity.PostPreview, error) {
+	return f.getPreviewFn(ctx, token)
 }
-func (f *fakePostService) MovePostToDraft(ctx context.Context, id uint, uid uint, role string) error {
-	return f.moveToDraftAdminFn(ctx, id, uid, role)
+func (f *fakePostService) BulkAdminPosts(ctx context.Context, req entity.PostBulkRequest, uid uint, role string) (entity.PostBulkResult, error) {
+	return f.bulkAdminFn(ctx, req, uid, role)
 }
@@ -0,0 +1,234 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SeriesAPI serves post series endpoints under /api/v1/series.
+// Reads are public and address series by slug; writes are admin-only and address them by ID.
+type SeriesAPI struct {
+	service core.SeriesService
+}
+
+func NewSeriesAPI(service core.SeriesService) *SeriesAPI {
+	return &SeriesAPI{service: service}
+}
+
+// GetSeries returns all series with their published part counts.
+// @Summary List series
+// @Description Public endpoint listing post series with the number of published parts in each.
+// @Tags series
+// @Produce json
+// @Success 200 {array} dto.SeriesWithCountResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /series [get]
+func (api *SeriesAPI) GetSeries(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	series, err := api.service.List(ctx)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list series timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToSeriesListResponse(series))
+}
+
+// GetSeriesBySlug returns one series.
+// @Summary Get series by slug
+// @Tags series
+// @Produce json
+// @Param slug path string true "series slug"
+// @Success 200 {object} dto.SeriesResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /series/{slug} [get]
+func (api *SeriesAPI) GetSeriesBySlug(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	series, err := api.service.GetBySlug(ctx, c.Param("slug"))
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "get series timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusNotFound, map[string]any{"resource": "series"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToSeriesResponse(series))
+}
+
+// GetSeriesPosts returns the published parts of a series in order.
+// @Summary List published parts of a series
+// @Tags series
+// @Produce json
+// @Param slug path string true "series slug"
+// @Success 200 {object} dto.SeriesPartsResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /series/{slug}/posts [get]
+func (api *SeriesAPI) GetSeriesPosts(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	series, parts, err := api.service.ListPublishedParts(ctx, c.Param("slug"))
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list series posts timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusNotFound, map[string]any{"resource": "series"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToSeriesPartsResponse(series, parts))
+}
+
+// CreateSeries creates a series.
+// @Summary Create series
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateSeriesRequest true "create series payload"
+// @Success 201 {object} dto.SeriesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /series [post]
+func (api *SeriesAPI) CreateSeries(c *gin.Context) {
+	var req dto.CreateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	created, err := api.service.Create(ctx, req.ToEntity())
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "create series timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToSeriesResponse(created))
+}
+
+// UpdateSeries changes the title, slug and/or description of a series.
+// @Summary Update series
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path int true "series id"
+// @Param body body dto.UpdateSeriesRequest true "update series payload"
+// @Success 200 {object} dto.SeriesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /series/{id} [put]
+func (api *SeriesAPI) UpdateSeries(c *gin.Context) {
+	id, ok := parseSeriesID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	updated, err := api.service.Update(ctx, id, req.ToPatch())
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "update series timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToSeriesResponse(updated))
+}
+
+// DeleteSeries removes a series. Its posts are kept and simply leave the series.
+// @Summary Delete series
+// @Tags series
+// @Produce json
+// @Param id path int true "series id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /series/{id} [delete]
+func (api *SeriesAPI) DeleteSeries(c *gin.Context) {
+	id, ok := parseSeriesID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := api.service.Delete(ctx, id); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "delete series timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "series deleted successfully")
+}
+
+func parseSeriesID(c *gin.Context) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id64 == 0 {
+		errorx.RespondValidationError(c, "invalid series id", map[string]any{"field": "id"})
+		return 0, false
+	}
+	return uint(id64), true
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeSeriesService implements core.SeriesService for handler-layer tests.
+// Methods without a stub panic through the nil embedded interface.
+type fakeSeriesService struct {
+	core.SeriesService
+	partsFn  func(ctx context.Context, slug string) (entity.Series, []entity.SeriesPart, error)
+	updateFn func(ctx context.Context, id uint, patch entity.SeriesPatch) (entity.Series, error)
+}
+
+func (f *fakeSeriesService) ListPublishedParts(ctx context.Context, slug string) (entity.Series, []entity.SeriesPart, error) {
+	return f.partsFn(ctx, slug)
+}
+func (f *fakeSeriesService) Update(ctx context.Context, id uint, patch entity.SeriesPatch) (entity.Series, error) {
+	return f.updateFn(ctx, id, patch)
+}
+
+func newSeriesRouter(svc core.SeriesService) *gin.Engine {
+	r := gin.New()
+	api := NewSeriesAPI(svc)
+	r.GET("/series/:slug/posts", api.GetSeriesPosts)
+	r.PUT("/series/:id", api.UpdateSeries)
+	return r
+}
+
+func TestSeriesAPI_GetSeriesPosts(t *testing.T) {
+	svc := &fakeSeriesService{
+		partsFn: func(ctx context.Context, slug string) (entity.Series, []entity.SeriesPart, error) {
+			if slug != "go" {
+				return entity.Series{}, nil, core.ErrNotFound
+			}
+			return entity.Series{ID: 1, Title: "Go", Slug: "go"}, []entity.SeriesPart{
+				{ID: 10, Title: "Intro", Slug: "intro", Position: 1},
+				{ID: 12, Title: "Types", Slug: "types", Position: 3},
+			}, nil
+		},
+	}
+	r := newSeriesRouter(svc)
+
+	w := doRequest(r, http.MethodGet, "/series/go/posts")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got dto.SeriesPartsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Series.Slug != "go" || len(got.Parts) != 2 || got.Parts[1].Position != 3 {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+
+	if w := doRequest(r, http.MethodGet, "/series/missing/posts"); w.Code != http.StatusNotFound {
+		t.Fatalf("want 404, got %d", w.Code)
+	}
+}
+
+func TestSeriesAPI_UpdateSeries(t *testing.T) {
+	var gotID uint
+	var gotPatch entity.SeriesPatch
+	svc := &fakeSeriesService{
+		updateFn: func(ctx context.Context, id uint, patch entity.SeriesPatch) (entity.Series, error) {
+			gotID, gotPatch = id, patch
+			return entity.Series{ID: id, Title: "Go", Slug: "go"}, nil
+		},
+	}
+	r := newSeriesRouter(svc)
+
+	w := doJSON(r, http.MethodPut, "/series/4", map[string]any{"description": ""})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	if gotID != 4 || gotPatch.Description == nil || *gotPatch.Description != "" || gotPatch.Title != nil {
+		t.Fatalf("unexpected args: %d %+v", gotID, gotPatch)
+	}
+
+	if w := doJSON(r, http.MethodPut, "/series/abc", map[string]any{}); w.Code != http.StatusBadRequest {
+		t.Fatalf("invalid id: want 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/service"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SitemapAPI serves /sitemap.xml and /robots.txt at the site root.
+type SitemapAPI struct {
+	service *service.SitemapService
+}
+
+func NewSitemapAPI(svc *service.SitemapService) *SitemapAPI {
+	return &SitemapAPI{service: svc}
+}
+
+// Sitemap returns the XML sitemap, or a sitemap index once the site outgrows one file.
+// @Summary XML sitemap
+// @Description Published posts (except noindex ones), categories and tags with lastmod. Above 50,000 URLs this returns a sitemap index whose entries are ?page=N.
+// @Tags seo
+// @Produce xml
+// @Param page query int false "sitemap page listed in the index"
+// @Success 200 {string} string "urlset or sitemapindex document"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /sitemap.xml [get]
+func (api *SitemapAPI) Sitemap(c *gin.Context) {
+	page := 0
+	if raw := c.Query("page"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 {
+			errorx.RespondValidationError(c, "invalid page", map[string]any{"field": "page"})
+			return
+		}
+		page = v
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	sitemap, err := api.service.Build(ctx, page)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "build sitemap timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	body, err := marshalXMLDocument(dto.ToSitemapDocument(sitemap))
+	if err != nil {
+		errorx.RespondInternalError(c)
+		return
+	}
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", body)
+}
+
+// RobotsTxt returns the crawler rules, including the sitemap location.
+// @Summary robots.txt
+// @Tags seo
+// @Produce plain
+// @Success 200 {string} string "robots.txt"
+// @Router /robots.txt [get]
+func (api *SitemapAPI) RobotsTxt(c *gin.Context) {
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(api.service.RobotsTxt()))
+}
@@ -0,0 +1,262 @@
+package v1
+
+import (
+	"KaldalisCMS/internal/api/errorx"
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagAPI serves tag endpoints under /api/v1/tags.
+// Reads are public and address tags by slug; writes are admin-only and address them by ID.
+type TagAPI struct {
+	service core.TagService
+	posts   core.PostService
+}
+
+func NewTagAPI(service core.TagService, posts core.PostService) *TagAPI {
+	return &TagAPI{service: service, posts: posts}
+}
+
+// GetTags returns all tags with their published post counts.
+// @Summary List tags
+// @Description Public endpoint listing tags with the number of published posts carrying each.
+// @Tags tags
+// @Produce json
+// @Success 200 {array} dto.TagWithCountResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /tags [get]
+func (api *TagAPI) GetTags(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	tags, err := api.service.List(ctx)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list tags timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTagWithCountListResponse(tags))
+}
+
+// GetTagBySlug returns one tag.
+// @Summary Get tag by slug
+// @Tags tags
+// @Produce json
+// @Param slug path string true "tag slug"
+// @Success 200 {object} dto.TagResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /tags/{slug} [get]
+func (api *TagAPI) GetTagBySlug(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	tag, err := api.service.GetBySlug(ctx, c.Param("slug"))
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "get tag timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusNotFound, map[string]any{"resource": "tag"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTagResponse(tag))
+}
+
+// GetTagPosts returns one page of published posts carrying a tag.
+// Accepts the same pagination, sort and filter parameters as GET /posts; tag_id is implied.
+// @Summary List published posts with tag
+// @Tags tags
+// @Produce json
+// @Param slug path string true "tag slug"
+// @Param page query int false "page number" default(1)
+// @Param page_size query int false "page size (max 100)" default(20)
+// @Param sort query string false "sort key: created_at|updated_at" default(created_at)
+// @Param order query string false "sort order: asc|desc" default(desc)
+// @Success 200 {object} dto.TagPostsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Router /tags/{slug}/posts [get]
+func (api *TagAPI) GetTagPosts(c *gin.Context) {
+	query, ok := parsePostListQuery(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	tag, err := api.service.GetBySlug(ctx, c.Param("slug"))
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "get tag timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusNotFound, map[string]any{"resource": "tag"})
+		return
+	}
+
+	query.TagID = &tag.ID
+	posts, total, err := api.posts.ListPublicPosts(ctx, query)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "list tag posts timed out")
+			return
+		}
+		errorx.RespondInternalError(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.TagPostsResponse{
+		Tag:              dto.ToTagResponse(tag),
+		PostListResponse: dto.ToPostPageResponse(posts, total, query),
+	})
+}
+
+// CreateTag creates a tag.
+// @Summary Create tag
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param body body dto.CreateTagRequest true "create tag payload"
+// @Success 201 {object} dto.TagResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /tags [post]
+func (api *TagAPI) CreateTag(c *gin.Context) {
+	var req dto.CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	created, err := api.service.Create(ctx, req.ToEntity())
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "create tag timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToTagResponse(created))
+}
+
+// UpdateTag renames a tag and/or changes its slug.
+// @Summary Update tag
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path int true "tag id"
+// @Param body body dto.UpdateTagRequest true "update tag payload"
+// @Success 200 {object} dto.TagResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /tags/{id} [put]
+func (api *TagAPI) UpdateTag(c *gin.Context) {
+	id, ok := parseTagID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorx.RespondValidationError(c, "invalid request body", map[string]any{"reason": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	tag := req.ToEntity()
+	tag.ID = id
+	updated, err := api.service.Update(ctx, tag)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "update tag timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToTagResponse(updated))
+}
+
+// DeleteTag removes a tag and detaches it from every post.
+// @Summary Delete tag
+// @Tags tags
+// @Produce json
+// @Param id path int true "tag id"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Failure 504 {object} dto.ErrorResponse
+// @Security CookieAuth
+// @Security CSRFToken
+// @Router /tags/{id} [delete]
+func (api *TagAPI) DeleteTag(c *gin.Context) {
+	id, ok := parseTagID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := api.service.Delete(ctx, id); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			errorx.RespondTimeoutError(c, "delete tag timed out")
+			return
+		}
+		errorx.RespondErrorByCore(c, err, http.StatusInternalServerError, nil)
+		return
+	}
+
+	errorx.RespondMessage(c, http.StatusOK, "tag deleted successfully")
+}
+
+func parseTagID(c *gin.Context) (uint, bool) {
+	id64, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id64 == 0 {
+		errorx.RespondValidationError(c, "invalid tag id", map[string]any{"field": "id"})
+		return 0, false
+	}
+	return uint(id64), true
+}
@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"KaldalisCMS/internal/api/v1/dto"
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeTagService implements core.TagService for handler-layer tests.
+type fakeTagService struct {
+	createFn    func(ctx context.Context, tag entity.Tag) (entity.Tag, error)
+	listFn      func(ctx context.Context) ([]entity.TagWithPostCount, error)
+	getBySlugFn func(ctx context.Context, slug string) (entity.Tag, error)
+	updateFn    func(ctx context.Context, tag entity.Tag) (entity.Tag, error)
+	deleteFn    func(ctx context.Context, id uint) error
+}
+
+func (f *fakeTagService) Create(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
+	return f.createFn(ctx, tag)
+}
+func (f *fakeTagService) GetAll(ctx context.Context) ([]entity.Tag, error) {
+	panic("not used by handlers")
+}
+func (f *fakeTagService) GetByID(ctx context.Context, id uint) (entity.Tag, error) {
+	panic("not used by handlers")
+}
+func (f *fakeTagService) GetByName(ctx context.Context, name string) (entity.Tag, error) {
+	panic("not used by handlers")
+}
+func (f *fakeTagService) GetBySlug(ctx context.Context, slug string) (entity.Tag, error) {
+	return f.getBySlugFn(ctx, slug)
+}
+func (f *fakeTagService) List(ctx context.Context) ([]entity.TagWithPostCount, error) {
+	return f.listFn(ctx)
+}
+func (f *fakeTagService) ResolveNames(ctx context.Context, names []string) ([]entity.Tag, error) {
+	panic("not used by handlers")
+}
+func (f *fakeTagService) Update(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
+	return f.updateFn(ctx, tag)
+}
+func (f *fakeTagService) Delete(ctx context.Context, id uint) error {
+	return f.deleteFn(ctx, id)
+}
+
+func newTagRouter(svc core.TagService, posts core.PostService) *gin.Engine {
+	r := gin.New()
+	api := NewTagAPI(svc, posts)
+	r.GET("/tags", api.GetTags)
+	r.GET("/tags/:slug", api.GetTagBySlug)
+	r.GET("/tags/:slug/posts", api.GetTagPosts)
+	r.POST("/tags", api.CreateTag)
+	r.PUT("/tags/:id", api.UpdateTag)
+	r.DELETE("/tags/:id", api.DeleteTag)
+	return r
+}
+
+func TestTagAPI_GetTags(t *testing.T) {
+	svc := &fakeTagService{
+		listFn: func(ctx context.Context) ([]entity.TagWithPostCount, error) {
+			return []entity.TagWithPostCount{{Tag: entity.Tag{ID: 1, Name: "Go", Slug: "go"}, PostCount: 2}}, nil
+		},
+	}
+	w := doRequest(newTagRouter(svc, &fakePostService{}), http.MethodGet, "/tags")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got []dto.TagWithCountResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Slug != "go" || got[0].PostCount != 2 {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestTagAPI_GetTagPosts(t *testing.T) {
+	svc := &fakeTagService{
+		getBySlugFn: func(ctx context.Context, slug string) (entity.Tag, error) {
+			return entity.Tag{ID: 4, Name: "Go", Slug: slug}, nil
+		},
+	}
+	posts := &fakePostService{
+		listPublicFn: func(ctx context.Context, q entity.PostListQuery) ([]entity.Post, int64, error) {
+			if q.TagID == nil || *q.TagID != 4 {
+				t.Fatalf("tag filter not applied: %+v", q)
+			}
+			return []entity.Post{{ID: 1}}, 1, nil
+		},
+	}
+	w := doRequest(newTagRouter(svc, posts), http.MethodGet, "/tags/go/posts")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+	var got dto.TagPostsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Tag.ID != 4 || got.Total != 1 || len(got.Items) != 1 {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestTagAPI_GetTagPosts_UnknownTag(t *testing.T) {
+	svc := &fakeTagService{
+		getBySlugFn: func(ctx context.Context, slug string) (entity.Tag, error) {
+			return entity.Tag{}, core.ErrNotFound
+		},
+	}
+	w := doRequest(newTagRouter(svc, &fakePostService{}), http.MethodGet, "/tags/nope/posts")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestTagAPI_CreateTag_Duplicate(t *testing.T) {
+	svc := &fakeTagService{
+		createFn: func(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
+			return entity.Tag{}, core.ErrDuplicate
+		},
+	}
+	w := doJSON(newTagRouter(svc, &fakePostService{}), http.MethodPost, "/tags", map[string]any{"name": "go"})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestTagAPI_DeleteTag_InvalidID(t *testing.T) {
+	w := doRequest(newTagRouter(&fakeTagService{}, &fakePostService{}), http.MethodDelete, "/tags/abc")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status: %d body=%s", w.Code, w.Body.String())
+	}
+}
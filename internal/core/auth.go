@@ -1,6 +1,7 @@
 package core
 
 import (
+	"KaldalisCMS/internal/core/entity"
 	"KaldalisCMS/pkg/auth"
 	"net/http"
 	"time"
@@ -14,3 +15,10 @@ type SessionManager interface {
 	ValidateCSRF(r *http.Request, expectedHash string) error
 	GetTTL() time.Duration
 }
+
+// PreviewTokenSigner signs preview link grants with the server secret and verifies them.
+// Verify fails with ErrPermission for tampered, foreign or expired tokens.
+type PreviewTokenSigner interface {
+	SignPreview(grant entity.PreviewGrant) (string, error)
+	VerifyPreview(token string) (entity.PreviewGrant, error)
+}
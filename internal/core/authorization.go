@@ -11,12 +11,15 @@ const (
 	PostPermissionListOwnDrafts  PostPermission = "post:list_own_drafts"
 	PostPermissionReadOwnDraft   PostPermission = "post:read_own_draft"
 	PostPermissionUpdateOwnDraft PostPermission = "post:update_own_draft"
+	PostPermissionSubmitOwnDraft PostPermission = "post:submit_own_draft"
 	PostPermissionListAnyPost    PostPermission = "post:list_any"
 	PostPermissionReadAnyPost    PostPermission = "post:read_any"
 	PostPermissionUpdateAnyPost  PostPermission = "post:update_any"
 	PostPermissionPublishPost    PostPermission = "post:publish"
 	PostPermissionUnpublishPost  PostPermission = "post:unpublish"
 	PostPermissionDeletePost     PostPermission = "post:delete"
+	PostPermissionReviewPost     PostPermission = "post:review"
+	PostPermissionPurgePost      PostPermission = "post:purge"
 )
 
 // PostAuthorizer decides whether a role currently has a given post-management capability.
@@ -0,0 +1,14 @@
+package entity
+
+// Category groups posts under one public, slug-addressable section.
+type Category struct {
+	ID   uint
+	Name string
+	Slug string
+}
+
+// CategoryWithPostCount is a category together with the number of published posts in it.
+type CategoryWithPostCount struct {
+	Category
+	PostCount int64
+}
@@ -0,0 +1,66 @@
+package entity
+
+import "time"
+
+// Comment moderation states. New comments start Pending; only Approved ones are public.
+const (
+	CommentStatusPending  = "pending"
+	CommentStatusApproved = "approved"
+	CommentStatusRejected = "rejected"
+	CommentStatusSpam     = "spam"
+)
+
+// IsValidCommentStatus reports whether status is one of the moderation states.
+func IsValidCommentStatus(status string) bool {
+	switch status {
+	case CommentStatusPending, CommentStatusApproved, CommentStatusRejected, CommentStatusSpam:
+		return true
+	}
+	return false
+}
+
+// Comment is a reader comment on a published post. ParentID makes it a reply.
+// Logged-in commenters carry AuthorID and their username as AuthorName; guests only a name.
+type Comment struct {
+	ID          uint
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	PostID      uint
+	ParentID    *uint
+	AuthorID    *uint
+	AuthorName  string
+	AuthorEmail string
+	// Body is the Markdown as written; BodyHTML is its sanitized rendering.
+	Body     string
+	BodyHTML string
+	Status   string
+	// Replies is only filled by threaded public listings.
+	Replies []Comment
+}
+
+// CommentListQuery filters the moderation listing. Empty Status and nil PostID match everything.
+type CommentListQuery struct {
+	Status   string
+	PostID   *uint
+	Page     int
+	PageSize int
+}
+
+// Normalized applies the same paging defaults and bounds as post listings.
+func (q CommentListQuery) Normalized() CommentListQuery {
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	if q.PageSize <= 0 {
+		q.PageSize = DefaultPostPageSize
+	}
+	if q.PageSize > MaxPostPageSize {
+		q.PageSize = MaxPostPageSize
+	}
+	return q
+}
+
+// Offset returns the row offset for the current page.
+func (q CommentListQuery) Offset() int {
+	return (q.Page - 1) * q.PageSize
+}
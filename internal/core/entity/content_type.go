@@ -0,0 +1,285 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ContentFieldType is the value type of one field in a content type schema.
+type ContentFieldType string
+
+const (
+	FieldTypeText ContentFieldType = "text"
+	// FieldTypeRichText holds Markdown, like post content.
+	FieldTypeRichText ContentFieldType = "rich_text"
+	FieldTypeNumber   ContentFieldType = "number"
+	// FieldTypeDate accepts YYYY-MM-DD or an RFC 3339 timestamp.
+	FieldTypeDate    ContentFieldType = "date"
+	FieldTypeBoolean ContentFieldType = "boolean"
+	// FieldTypeMedia holds the ID of a media asset.
+	FieldTypeMedia ContentFieldType = "media"
+	// FieldTypeReference holds the ID of an entry of the content type named by ContentField.Target.
+	FieldTypeReference ContentFieldType = "reference"
+)
+
+// IsValidContentFieldType reports whether t is a supported field type.
+func IsValidContentFieldType(t ContentFieldType) bool {
+	switch t {
+	case FieldTypeText, FieldTypeRichText, FieldTypeNumber, FieldTypeDate, FieldTypeBoolean, FieldTypeMedia, FieldTypeReference:
+		return true
+	}
+	return false
+}
+
+// MaxContentFields bounds the size of a content type schema.
+const MaxContentFields = 50
+
+var contentFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// ContentField describes one field of a content type.
+type ContentField struct {
+	// Key is the name of the field in entry data.
+	Key      string
+	Label    string
+	Type     ContentFieldType
+	Required bool
+	// Target is the slug of the referenced content type; only used by reference fields.
+	Target string
+}
+
+// ContentType is an admin-defined schema for structured content such as products or events.
+// Entries of the type store their field values as JSON validated against Fields.
+type ContentType struct {
+	ID          uint
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Name        string
+	Slug        string
+	Description string
+	Fields      []ContentField
+}
+
+// CheckValidity verifies the schema itself: field keys are unique identifiers and every field
+// has a supported type. Whether reference targets exist is checked by the service.
+func (t *ContentType) CheckValidity() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return &ContentFieldError{Field: "name", Reason: "name is required"}
+	}
+	if len(t.Fields) > MaxContentFields {
+		return &ContentFieldError{Field: "fields", Reason: fmt.Sprintf("at most %d fields are allowed", MaxContentFields)}
+	}
+	seen := make(map[string]struct{}, len(t.Fields))
+	for _, f := range t.Fields {
+		if !contentFieldKeyPattern.MatchString(f.Key) {
+			return &ContentFieldError{Field: f.Key, Reason: "key must be lowercase letters, digits and underscores, starting with a letter"}
+		}
+		if _, dup := seen[f.Key]; dup {
+			return &ContentFieldError{Field: f.Key, Reason: "duplicate field key"}
+		}
+		seen[f.Key] = struct{}{}
+		if !IsValidContentFieldType(f.Type) {
+			return &ContentFieldError{Field: f.Key, Reason: fmt.Sprintf("unsupported field type %q", f.Type)}
+		}
+		if f.Type == FieldTypeReference && f.Target == "" {
+			return &ContentFieldError{Field: f.Key, Reason: "reference fields need a target content type"}
+		}
+		if f.Type != FieldTypeReference && f.Target != "" {
+			return &ContentFieldError{Field: f.Key, Reason: "only reference fields have a target"}
+		}
+	}
+	return nil
+}
+
+// ValidateData checks entry data against the schema and returns a normalized copy:
+// unknown keys are rejected, missing optional fields and nulls are dropped, dates are
+// stored in a canonical form and media/reference IDs as unsigned integers.
+func (t *ContentType) ValidateData(data map[string]any) (map[string]any, error) {
+	fields := make(map[string]ContentField, len(t.Fields))
+	for _, f := range t.Fields {
+		fields[f.Key] = f
+	}
+	for key := range data {
+		if _, ok := fields[key]; !ok {
+			return nil, &ContentFieldError{Field: key, Reason: "unknown field"}
+		}
+	}
+
+	out := make(map[string]any, len(data))
+	for _, f := range t.Fields {
+		raw, present := data[f.Key]
+		if !present || raw == nil {
+			if f.Required {
+				return nil, &ContentFieldError{Field: f.Key, Reason: "field is required"}
+			}
+			continue
+		}
+		value, err := normalizeFieldValue(f, raw)
+		if err != nil {
+			return nil, err
+		}
+		out[f.Key] = value
+	}
+	return out, nil
+}
+
+// References returns the entry IDs held by reference fields in already validated data, keyed by field.
+func (t *ContentType) References(data map[string]any) map[string]uint {
+	return t.idsOfType(data, FieldTypeReference)
+}
+
+// MediaIDs returns the media asset IDs held by media fields in already validated data, keyed by field.
+func (t *ContentType) MediaIDs(data map[string]any) map[string]uint {
+	return t.idsOfType(data, FieldTypeMedia)
+}
+
+func (t *ContentType) idsOfType(data map[string]any, fieldType ContentFieldType) map[string]uint {
+	out := map[string]uint{}
+	for _, f := range t.Fields {
+		if f.Type != fieldType {
+			continue
+		}
+		if id, ok := contentIDValue(data[f.Key]); ok {
+			out[f.Key] = id
+		}
+	}
+	return out
+}
+
+func normalizeFieldValue(f ContentField, raw any) (any, error) {
+	invalid := func(reason string) error { return &ContentFieldError{Field: f.Key, Reason: reason} }
+
+	switch f.Type {
+	case FieldTypeText, FieldTypeRichText:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, invalid("expected a string")
+		}
+		if f.Required && strings.TrimSpace(s) == "" {
+			return nil, invalid("field is required")
+		}
+		return s, nil
+	case FieldTypeNumber:
+		n, ok := contentNumberValue(raw)
+		if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, invalid("expected a number")
+		}
+		return n, nil
+	case FieldTypeBoolean:
+		b, ok := raw.(bool)
+		if !ok {
+			return nil, invalid("expected true or false")
+		}
+		return b, nil
+	case FieldTypeDate:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, invalid("expected a date string")
+		}
+		if d, err := time.Parse(time.DateOnly, s); err == nil {
+			return d.Format(time.DateOnly), nil
+		}
+		ts, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, invalid("expected YYYY-MM-DD or an RFC 3339 timestamp")
+		}
+		return ts.UTC().Format(time.RFC3339), nil
+	case FieldTypeMedia, FieldTypeReference:
+		id, ok := contentIDValue(raw)
+		if !ok {
+			return nil, invalid("expected a positive integer id")
+		}
+		return id, nil
+	}
+	return nil, invalid(fmt.Sprintf("unsupported field type %q", f.Type))
+}
+
+// contentNumberValue accepts the numeric types produced by JSON decoding and by Go callers.
+func contentNumberValue(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func contentIDValue(v any) (uint, bool) {
+	n, ok := contentNumberValue(v)
+	if !ok || n < 1 || n > math.MaxUint32 || n != math.Trunc(n) {
+		return 0, false
+	}
+	return uint(n), true
+}
+
+// ContentFieldError reports which field of a schema or entry failed validation.
+type ContentFieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ContentFieldError) Error() string {
+	return fmt.Sprintf("field %q: %s", e.Field, e.Reason)
+}
+
+// ContentEntry is one item of a content type. It follows the same draft/publish lifecycle as
+// posts: only StatusPublished entries are visible on public endpoints.
+type ContentEntry struct {
+	ID        uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	TypeID    uint
+	Title     string
+	Slug      string
+	Data      map[string]any
+	Status    int // StatusDraft or StatusPublished
+	AuthorID  uint
+}
+
+// ContentEntryPatch models the editable subset of an entry. Nil fields are left unchanged;
+// a non-nil Data replaces the entry's data as a whole.
+type ContentEntryPatch struct {
+	Title *string
+	Slug  *string
+	Data  map[string]any
+}
+
+// ContentEntryQuery selects one page of a content type's entries.
+// Nil AuthorID and Status match every author and every status.
+type ContentEntryQuery struct {
+	TypeID   uint
+	AuthorID *uint
+	Status   *int
+	Page     int
+	PageSize int
+}
+
+// Normalized applies the same paging defaults and bounds as post listings.
+func (q ContentEntryQuery) Normalized() ContentEntryQuery {
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	if q.PageSize <= 0 {
+		q.PageSize = DefaultPostPageSize
+	}
+	if q.PageSize > MaxPostPageSize {
+		q.PageSize = MaxPostPageSize
+	}
+	return q
+}
+
+// Offset returns the row offset for the current page.
+func (q ContentEntryQuery) Offset() int {
+	return (q.Page - 1) * q.PageSize
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestContentType_CheckValidity(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  []ContentField
+		wantErr string // offending field, empty when valid
+	}{
+		{"valid", []ContentField{{Key: "price", Type: FieldTypeNumber}, {Key: "maker", Type: FieldTypeReference, Target: "brands"}}, ""},
+		{"bad key", []ContentField{{Key: "Price", Type: FieldTypeNumber}}, "Price"},
+		{"duplicate key", []ContentField{{Key: "a", Type: FieldTypeText}, {Key: "a", Type: FieldTypeDate}}, "a"},
+		{"unknown type", []ContentField{{Key: "a", Type: "color"}}, "a"},
+		{"reference without target", []ContentField{{Key: "maker", Type: FieldTypeReference}}, "maker"},
+		{"target on non-reference", []ContentField{{Key: "a", Type: FieldTypeText, Target: "brands"}}, "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct := &ContentType{Name: "Products", Fields: tt.fields}
+			err := ct.CheckValidity()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected: %v", err)
+				}
+				return
+			}
+			var fieldErr *ContentFieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Field != tt.wantErr {
+				t.Fatalf("want field error on %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestContentType_ValidateData(t *testing.T) {
+	ct := &ContentType{Name: "Events", Fields: []ContentField{
+		{Key: "name", Type: FieldTypeText, Required: true},
+		{Key: "seats", Type: FieldTypeNumber},
+		{Key: "starts", Type: FieldTypeDate},
+		{Key: "online", Type: FieldTypeBoolean},
+		{Key: "poster", Type: FieldTypeMedia},
+		{Key: "venue", Type: FieldTypeReference, Target: "venues"},
+	}}
+
+	t.Run("normalizes values", func(t *testing.T) {
+		got, err := ct.ValidateData(map[string]any{
+			"name":   "GopherCon",
+			"seats":  float64(300),
+			"starts": "2026-07-01T09:00:00+02:00",
+			"online": false,
+			"poster": float64(12),
+			"venue":  float64(3),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got["starts"] != "2026-07-01T07:00:00Z" {
+			t.Fatalf("date not normalized: %v", got["starts"])
+		}
+		if got["poster"] != uint(12) || got["venue"] != uint(3) {
+			t.Fatalf("ids not normalized: %v %v", got["poster"], got["venue"])
+		}
+		if refs := ct.References(got); refs["venue"] != 3 || len(refs) != 1 {
+			t.Fatalf("references: %v", refs)
+		}
+		if media := ct.MediaIDs(got); media["poster"] != 12 || len(media) != 1 {
+			t.Fatalf("media: %v", media)
+		}
+	})
+
+	t.Run("drops nulls of optional fields", func(t *testing.T) {
+		got, err := ct.ValidateData(map[string]any{"name": "x", "seats": nil})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := got["seats"]; ok || len(got) != 1 {
+			t.Fatalf("unexpected data: %v", got)
+		}
+	})
+
+	rejected := []struct {
+		name  string
+		data  map[string]any
+		field string
+	}{
+		{"missing required", map[string]any{"seats": float64(1)}, "name"},
+		{"blank required text", map[string]any{"name": "  "}, "name"},
+		{"unknown field", map[string]any{"name": "x", "color": "red"}, "color"},
+		{"wrong type", map[string]any{"name": "x", "seats": "many"}, "seats"},
+		{"bad date", map[string]any{"name": "x", "starts": "next week"}, "starts"},
+		{"fractional id", map[string]any{"name": "x", "venue": 1.5}, "venue"},
+		{"non-positive id", map[string]any{"name": "x", "poster": float64(0)}, "poster"},
+	}
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ct.ValidateData(tt.data)
+			var fieldErr *ContentFieldError
+			if !errors.As(err, &fieldErr) || fieldErr.Field != tt.field {
+				t.Fatalf("want field error on %q, got %v", tt.field, err)
+			}
+		})
+	}
+}
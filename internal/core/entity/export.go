@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// ExportSettings are the site settings carried in an export.
+type ExportSettings struct {
+	SiteName    string
+	InstalledAt *time.Time
+}
+
+// ExportSummary counts what an export wrote. It becomes the manifest of the archive.
+type ExportSummary struct {
+	CreatedAt  time.Time
+	SiteName   string
+	Users      int
+	Categories int
+	Tags       int
+	Series     int
+	Posts      int
+	MediaFiles int
+	MediaBytes int64
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// Feed is a format-neutral syndication feed of published posts.
+// API handlers encode it as RSS 2.0, Atom or JSON Feed.
+type Feed struct {
+	Title       string
+	Description string
+	// Link is the absolute URL of the page the feed mirrors (site home, tag or category page).
+	Link string
+	// Updated is the newest UpdatedAt among the items; zero for an empty feed.
+	Updated time.Time
+	// Language is the preferred locale of a locale-scoped feed; empty for a mixed feed.
+	Language string
+	Items    []FeedItem
+}
+
+// FeedItem is one post in a feed. All URLs are absolute.
+type FeedItem struct {
+	ID          uint
+	Title       string
+	URL         string
+	Summary     string
+	ContentHTML string
+	AuthorName  string
+	ImageURL    string
+	Categories  []string
+	Published   time.Time
+	Updated     time.Time
+	// Language is the locale of the post.
+	Language string
+	// Alternates are the published translations of the post, for hreflang links.
+	Alternates []LocaleAlternate
+}
@@ -0,0 +1,177 @@
+package entity
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// ImportFormat names a supported export format of another system.
+type ImportFormat string
+
+const (
+	// ImportFormatWXR is a WordPress eXtended RSS export.
+	ImportFormatWXR ImportFormat = "wxr"
+	// ImportFormatMarkdown is a zip of Markdown files with YAML front matter (Hugo, Jekyll, ...).
+	ImportFormatMarkdown ImportFormat = "markdown"
+)
+
+// Valid reports whether f is a supported format.
+func (f ImportFormat) Valid() bool {
+	return f == ImportFormatWXR || f == ImportFormatMarkdown
+}
+
+// ImportFormatFromFilename guesses the format from the export's file name: .xml is a WXR
+// export and .zip a Markdown archive. It returns "" for anything else.
+func ImportFormatFromFilename(name string) ImportFormat {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".xml":
+		return ImportFormatWXR
+	case ".zip":
+		return ImportFormatMarkdown
+	default:
+		return ""
+	}
+}
+
+// ImportAuthor is an author as named by the source system.
+type ImportAuthor struct {
+	Login       string
+	Email       string
+	DisplayName string
+}
+
+// ImportTerm is a category or tag as named by the source system.
+type ImportTerm struct {
+	Name string
+	Slug string
+}
+
+// ImportSeries is a post series as named by the source system.
+type ImportSeries struct {
+	Title       string
+	Slug        string
+	Description string
+}
+
+// ImportPost is a post as exported by the source system. Status is one of the post
+// statuses; Scheduled posts carry PublishAt.
+type ImportPost struct {
+	// Source identifies the post in the export, e.g. its path in the archive; used in reports
+	// and to resolve relative asset references.
+	Source     string
+	Title      string
+	Slug       string
+	Content    string
+	Cover      string
+	Author     string
+	Locale     string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Status     int
+	PublishAt  *time.Time
+	Categories []ImportTerm
+	Tags       []ImportTerm
+	// UnpublishAt, NoIndex and CommentsDisabled are only known for site exports.
+	UnpublishAt      *time.Time
+	NoIndex          bool
+	CommentsDisabled bool
+	// Series places the post in a series at SeriesPosition; 0 appends it after the last part.
+	Series         *ImportSeries
+	SeriesPosition int
+	// TranslationKey links the posts of one export that are translations of one another.
+	TranslationKey string
+}
+
+// ImportBundle is the decoded content of one export.
+type ImportBundle struct {
+	Authors    []ImportAuthor
+	Categories []ImportTerm
+	Tags       []ImportTerm
+	Series     []ImportSeries
+	Posts      []ImportPost
+}
+
+// ImportRequest configures one import run.
+type ImportRequest struct {
+	Format ImportFormat
+	// DryRun reports what the import would do without writing anything.
+	DryRun bool
+	// AuthorMap maps source author logins to existing usernames. Unmapped authors are matched
+	// by username and created when missing.
+	AuthorMap map[string]string
+	// Locale is used for posts that do not name their own.
+	Locale string
+	// ActorUserID owns imported media and authors posts whose author is unknown.
+	ActorUserID uint
+}
+
+// Validate checks the request before the export is decoded.
+func (r ImportRequest) Validate() error {
+	if !r.Format.Valid() {
+		return fmt.Errorf("unsupported import format %q", r.Format)
+	}
+	if r.ActorUserID == 0 {
+		return fmt.Errorf("import requires an acting user")
+	}
+	return nil
+}
+
+// ImportItemKind names what an import report item is about.
+type ImportItemKind string
+
+const (
+	ImportKindUser     ImportItemKind = "user"
+	ImportKindCategory ImportItemKind = "category"
+	ImportKindTag      ImportItemKind = "tag"
+	ImportKindSeries   ImportItemKind = "series"
+	ImportKindPost     ImportItemKind = "post"
+	ImportKindMedia    ImportItemKind = "media"
+)
+
+// ImportAction is what the import did, or in a dry run would do, with one item.
+type ImportAction string
+
+const (
+	// ImportActionCreate means the item is new and was (or would be) created.
+	ImportActionCreate ImportAction = "create"
+	// ImportActionExists means a matching item already exists and was reused; re-running an
+	// import reports every item it created before as existing.
+	ImportActionExists ImportAction = "exists"
+	// ImportActionError means the item could not be imported; the rest of the import goes on.
+	ImportActionError ImportAction = "error"
+)
+
+// ImportItem is one line of an import report. ID is the local ID of the created or reused
+// item; it is 0 for items a dry run would create and for posts that already exist.
+type ImportItem struct {
+	Kind   ImportItemKind
+	Source string
+	Action ImportAction
+	ID     uint
+	Err    error
+}
+
+// ImportReport lists what an import run did, in the order it did it.
+type ImportReport struct {
+	Format ImportFormat
+	DryRun bool
+	Items  []ImportItem
+}
+
+// Add appends one item to the report.
+func (r *ImportReport) Add(kind ImportItemKind, source string, action ImportAction, id uint, err error) {
+	r.Items = append(r.Items, ImportItem{Kind: kind, Source: source, Action: action, ID: id, Err: err})
+}
+
+// Count returns how many items of kind ended with action.
+func (r ImportReport) Count(kind ImportItemKind, action ImportAction) int {
+	n := 0
+	for _, item := range r.Items {
+		if item.Kind == kind && item.Action == action {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultLocale is the locale of posts created without one. It matches the default locale of
+// the web frontend, whose URLs carry no locale prefix for it.
+const DefaultLocale = "zh-CN"
+
+// MaxLocaleChain bounds how many locales a reader may list in a fallback chain.
+const MaxLocaleChain = 5
+
+// localePattern accepts the BCP 47 subset the site uses: a language, an optional script and
+// an optional region, e.g. "en", "zh-CN", "zh-Hant-TW" or "es-419".
+var localePattern = regexp.MustCompile(`^([A-Za-z]{2,3})(-[A-Za-z]{4})?(-(?:[A-Za-z]{2}|[0-9]{3}))?$`)
+
+// NormalizeLocale validates a language tag and returns it in canonical case:
+// lower-case language, title-case script and upper-case region.
+func NormalizeLocale(tag string) (string, error) {
+	m := localePattern.FindStringSubmatch(strings.TrimSpace(tag))
+	if m == nil {
+		return "", fmt.Errorf("invalid locale %q", tag)
+	}
+	out := strings.ToLower(m[1])
+	if m[2] != "" {
+		script := strings.ToLower(m[2][1:])
+		out += "-" + strings.ToUpper(script[:1]) + script[1:]
+	}
+	if m[3] != "" {
+		out += "-" + strings.ToUpper(m[3][1:])
+	}
+	return out, nil
+}
+
+// ParseLocaleChain parses a comma-separated list of locales in order of preference,
+// e.g. "zh-CN,en". Duplicates are dropped; an empty string yields a nil chain.
+func ParseLocaleChain(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	parts := strings.Split(raw, ",")
+	if len(parts) > MaxLocaleChain {
+		return nil, fmt.Errorf("at most %d locales are allowed", MaxLocaleChain)
+	}
+	chain := make([]string, 0, len(parts))
+	seen := make(map[string]struct{}, len(parts))
+	for _, p := range parts {
+		locale, err := NormalizeLocale(p)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := seen[locale]; dup {
+			continue
+		}
+		seen[locale] = struct{}{}
+		chain = append(chain, locale)
+	}
+	return chain, nil
+}
+
+// PostTranslation is a lightweight reference to another post of the same translation group.
+type PostTranslation struct {
+	ID     uint
+	Locale string
+	Slug   string
+	Title  string
+	Status int
+}
+
+// LocaleAlternate is the URL of one language version of a page, used for hreflang links.
+type LocaleAlternate struct {
+	Locale string
+	URL    string
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{"en", "en", false},
+		{"ZH-cn", "zh-CN", false},
+		{" zh-hant-tw ", "zh-Hant-TW", false},
+		{"es-419", "es-419", false},
+		{"", "", true},
+		{"english", "", true},
+		{"en_US", "", true},
+		{"en-USA", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeLocale(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeLocale(%q) = %q, %v; want %q, err=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseLocaleChain(t *testing.T) {
+	got, err := ParseLocaleChain("en, zh-cn,EN")
+	if err != nil || !slices.Equal(got, []string{"en", "zh-CN"}) {
+		t.Fatalf("unexpected chain: %v %v", got, err)
+	}
+	if got, err := ParseLocaleChain(" "); err != nil || got != nil {
+		t.Fatalf("blank chain: %v %v", got, err)
+	}
+	if _, err := ParseLocaleChain("en,,fr"); err == nil {
+		t.Fatal("empty element should be rejected")
+	}
+	if _, err := ParseLocaleChain("a1,b2,c3,d4,e5,f6"); err == nil {
+		t.Fatal("overlong chain should be rejected")
+	}
+}
@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxPageDepth bounds how deeply pages may nest, e.g. "docs/install/linux" has depth 3.
+const MaxPageDepth = 8
+
+// Page is a static page such as About, Contact or a documentation chapter. Pages are kept
+// apart from posts: they form a tree instead of a feed and never appear in post listings.
+type Page struct {
+	ID        uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// ParentID is nil for a top-level page.
+	ParentID *uint
+	Title    string
+	Slug     string
+	// Path joins the slugs from the top-level page down, e.g. "docs/install/linux".
+	// It is unique and rewritten for the whole subtree when a page is renamed or moved.
+	Path    string
+	Content string
+	Cover   string
+	Status  int // StatusDraft or StatusPublished
+	// SortOrder positions the page among its siblings, lowest first.
+	SortOrder int
+	AuthorID  uint
+	// Breadcrumbs lists the ancestors from the top level down; filled on single-page reads.
+	Breadcrumbs []PageRef
+	// Children lists the direct children in sibling order; filled on single-page reads.
+	// Public reads only include published children.
+	Children []PageRef
+	// Rendered is filled by the service on public single-page reads; nil everywhere else.
+	Rendered *RenderedContent
+}
+
+// PageRef is a lightweight reference to a page, used for breadcrumbs and child lists.
+type PageRef struct {
+	ID     uint
+	Title  string
+	Slug   string
+	Path   string
+	Status int
+}
+
+// Ref returns the lightweight reference to p.
+func (p Page) Ref() PageRef {
+	return PageRef{ID: p.ID, Title: p.Title, Slug: p.Slug, Path: p.Path, Status: p.Status}
+}
+
+// PagePatch models the editable subset of a page. Nil fields are left unchanged.
+type PagePatch struct {
+	Title   *string
+	Slug    *string
+	Content *string
+	Cover   *string
+	// ParentID moves the page under another parent; a pointer to 0 moves it to the top level.
+	// A moved page is placed after its new siblings.
+	ParentID *uint
+}
+
+// PageQuery filters page listings. Nil fields match every author and every status.
+type PageQuery struct {
+	AuthorID *uint
+	Status   *int
+}
+
+// NormalizePagePath trims surrounding slashes from a requested page path, e.g.
+// "/docs/install/" becomes "docs/install". Empty paths and empty segments are rejected.
+func NormalizePagePath(raw string) (string, error) {
+	path := strings.Trim(strings.TrimSpace(raw), "/")
+	if path == "" {
+		return "", fmt.Errorf("page path is empty")
+	}
+	segments := strings.Split(path, "/")
+	if len(segments) > MaxPageDepth {
+		return "", fmt.Errorf("page path is deeper than %d levels", MaxPageDepth)
+	}
+	for _, s := range segments {
+		if s == "" || s == "." || s == ".." {
+			return "", fmt.Errorf("invalid page path %q", raw)
+		}
+	}
+	return path, nil
+}
+
+// PageDepth returns the number of segments of a page path.
+func PageDepth(path string) int {
+	if path == "" {
+		return 0
+	}
+	return strings.Count(path, "/") + 1
+}
+
+// PageAncestorPaths returns the paths of every ancestor of path, from the top level down.
+func PageAncestorPaths(path string) []string {
+	var out []string
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' {
+			out = append(out, path[:i])
+		}
+	}
+	return out
+}
+
+// IsPageDescendantPath reports whether path lies in the subtree rooted at root, root included.
+func IsPageDescendantPath(path string, root string) bool {
+	return path == root || strings.HasPrefix(path, root+"/")
+}
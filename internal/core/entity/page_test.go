@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizePagePath(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{"docs", "docs", false},
+		{"/docs/install/linux/", "docs/install/linux", false},
+		{" about ", "about", false},
+		{"", "", true},
+		{"/", "", true},
+		{"docs//install", "", true},
+		{"docs/../admin", "", true},
+		{strings.Repeat("a/", MaxPageDepth) + "a", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizePagePath(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizePagePath(%q) = %q, %v; want %q, err=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestPageAncestorPaths(t *testing.T) {
+	if got := PageAncestorPaths("docs/install/linux"); !slices.Equal(got, []string{"docs", "docs/install"}) {
+		t.Fatalf("unexpected ancestors: %v", got)
+	}
+	if got := PageAncestorPaths("about"); got != nil {
+		t.Fatalf("top-level page has no ancestors, got %v", got)
+	}
+	if PageDepth("docs/install/linux") != 3 || PageDepth("about") != 1 {
+		t.Fatal("unexpected page depth")
+	}
+}
+
+func TestIsPageDescendantPath(t *testing.T) {
+	if !IsPageDescendantPath("docs", "docs") || !IsPageDescendantPath("docs/install", "docs") {
+		t.Fatal("subtree paths should match")
+	}
+	if IsPageDescendantPath("docs-old/install", "docs") || IsPageDescendantPath("about", "docs") {
+		t.Fatal("sibling paths sharing a prefix should not match")
+	}
+}
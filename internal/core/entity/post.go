@@ -12,6 +12,12 @@ const (
 	StatusDraft = 0
 	// StatusPublished marks content that is safe to expose on public read endpoints.
 	StatusPublished = 1
+	// StatusScheduled marks content waiting for its PublishAt time. It is not public yet;
+	// the scheduler promotes it to Published once PublishAt has passed.
+	StatusScheduled = 2
+	// StatusPendingReview marks a draft its author has submitted for editorial review.
+	// It stays private and locked for the author until an editor approves or rejects it.
+	StatusPendingReview = 3
 )
 
 // Post is the core publishing aggregate shared across service, repository, and API layers.
@@ -29,26 +35,130 @@ type Post struct {
 	CategoryID *uint
 	Category   Category
 	Tags       []Tag
-	Status     int // Draft or Published
+	Status     int // Draft, Published, Scheduled or PendingReview
+	// Version increases on every saved change; updates based on an older version are rejected.
+	Version uint
+	// PublishAt is the pending go-live time of a Scheduled post.
+	PublishAt *time.Time
+	// UnpublishAt optionally takes a Published (or Scheduled) post offline automatically.
+	UnpublishAt *time.Time
+	// NoIndex asks search engines not to index the post; it is left out of the sitemap.
+	NoIndex bool
+	// CommentsDisabled stops new comments; already approved ones stay visible.
+	CommentsDisabled bool
+	// ReviewNote is the reason given by the editor who last rejected the post; cleared on resubmission.
+	ReviewNote string
+	// DeletedAt is set while the post sits in the trash.
+	DeletedAt *time.Time
+	// Locale is the language tag of the post, e.g. "zh-CN" or "en".
+	Locale string
+	// TranslationGroupID links posts that are translations of one another; it is the ID of the
+	// post the group was started from. Nil for a post without translations.
+	TranslationGroupID *uint
+	// Translations lists the other posts of the translation group; filled on reads only.
+	// Public reads only include published translations.
+	Translations []PostTranslation
+	// SeriesID places the post in a series at SeriesPosition (1-based); nil outside any series.
+	SeriesID       *uint
+	SeriesPosition int
+	// Series is filled on public single-post reads of a post in a series; nil everywhere else.
+	Series *PostSeries
+	// Rendered is filled by the service on public single-post reads; nil everywhere else.
+	Rendered *RenderedContent
+}
+
+// PostSchedule carries the requested publish/expiry times for a scheduling action.
+// A nil field means "do not change this side of the schedule".
+// A stored unpublish time is kept unless UnpublishAt replaces it or ClearUnpublishAt is set.
+type PostSchedule struct {
+	PublishAt        *time.Time
+	UnpublishAt      *time.Time
+	ClearUnpublishAt bool
 }
 
 // PostPatch models the editable subset of a post for management updates.
 // Nil pointer fields mean "leave the current value unchanged".
 // For Tags, nil means "do not touch tags", while an empty slice means "replace with empty".
 type PostPatch struct {
-	Title      *string
-	Content    *string
-	Cover      *string
+	Title   *string
+	Slug    *string
+	Content *string
+	Cover   *string
+	// CategoryID moves the post into a category; a pointer to 0 removes its category.
 	CategoryID *uint
 	Tags       []Tag
+	NoIndex    *bool
+	// CommentsDisabled toggles whether readers may add comments.
+	CommentsDisabled *bool
+	Locale           *string
+	// SeriesID moves the post into a series; a pointer to 0 takes it out of its series.
+	// SeriesPosition picks its position there; 0 (or nil when joining) appends it at the end.
+	SeriesID       *uint
+	SeriesPosition *int
+}
+
+const (
+	// PostSortCreatedAt orders listings by creation time.
+	PostSortCreatedAt = "created_at"
+	// PostSortUpdatedAt orders listings by last modification time.
+	PostSortUpdatedAt = "updated_at"
+
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+
+	DefaultPostPageSize = 20
+	MaxPostPageSize     = 100
+)
+
+// PostListQuery describes pagination, filtering and ordering for post listings.
+// Nil filter pointers mean "do not filter on this dimension".
+type PostListQuery struct {
+	Page       int
+	PageSize   int
+	TagID      *uint
+	CategoryID *uint
+	AuthorID   *uint
+	// Locales is a fallback chain in order of preference. When set, each translation group is
+	// listed once, in the first locale of the chain it is available in; posts in none of the
+	// locales are left out.
+	Locales []string
+	SortBy  string
+	Order   string
+}
+
+// Normalized returns a copy with defaults applied and out-of-range values clamped,
+// so repositories can trust Page/PageSize/SortBy/Order without re-validating them.
+func (q PostListQuery) Normalized() PostListQuery {
+	if q.Page <= 0 {
+		q.Page = 1
+	}
+	if q.PageSize <= 0 {
+		q.PageSize = DefaultPostPageSize
+	}
+	if q.PageSize > MaxPostPageSize {
+		q.PageSize = MaxPostPageSize
+	}
+	if q.SortBy != PostSortUpdatedAt {
+		q.SortBy = PostSortCreatedAt
+	}
+	if q.Order != SortOrderAsc {
+		q.Order = SortOrderDesc
+	}
+	return q
 }
 
-// Category 结构体，简化版
-type Category struct {
-	ID   uint
-	Name string
+// Offset returns the row offset for the current page.
+func (q PostListQuery) Offset() int {
+	if q.Page <= 1 {
+		return 0
+	}
+	return (q.Page - 1) * q.PageSize
 }
 
+// Category 结构体
+//
+// NOTE: Category 已迁移到 entity/category.go。
+
 // Tag 结构体，简化版
 //
 // NOTE: Tag 已迁移到 entity/tag.go（避免在同一 package 内重复声明）。
@@ -83,17 +193,88 @@ func (p *Post) Publish() error {
 	}
 
 	p.Status = StatusPublished
+	p.PublishAt = nil
 	p.UpdatedAt = time.Now()
 
 	return nil
 }
 
-// Draft 将文章切回草稿状态，用于“下线”已发布内容。
+// Schedule 将文章设为定时发布，发布前需通过与 Publish 相同的校验。
+func (p *Post) Schedule(publishAt time.Time, now time.Time) error {
+	if p.Status == StatusPublished {
+		return errors.New("文章已是发布状态，无法定时发布")
+	}
+	if !publishAt.After(now) {
+		return errors.New("定时发布时间必须晚于当前时间")
+	}
+	if p.UnpublishAt != nil && !p.UnpublishAt.After(publishAt) {
+		return errors.New("自动下线时间必须晚于发布时间")
+	}
+	if err := p.CheckValidity(); err != nil {
+		return fmt.Errorf("文章定时发布失败，校验未通过: %w", err)
+	}
+
+	p.Status = StatusScheduled
+	p.PublishAt = &publishAt
+	p.UpdatedAt = now
+	return nil
+}
+
+// SubmitForReview 将草稿提交审核，提交后作者在审核结束前不能再编辑。
+func (p *Post) SubmitForReview(now time.Time) error {
+	if p.Status != StatusDraft {
+		return errors.New("只有草稿可以提交审核")
+	}
+	if err := p.CheckValidity(); err != nil {
+		return fmt.Errorf("文章提交审核失败，校验未通过: %w", err)
+	}
+
+	p.Status = StatusPendingReview
+	p.ReviewNote = ""
+	p.UpdatedAt = now
+	return nil
+}
+
+// RejectReview 驳回待审核的文章，退回草稿并记录驳回理由。
+func (p *Post) RejectReview(reason string, now time.Time) error {
+	if p.Status != StatusPendingReview {
+		return errors.New("文章不在审核中")
+	}
+	if reason == "" {
+		return errors.New("驳回理由不能为空")
+	}
+
+	p.Status = StatusDraft
+	p.ReviewNote = reason
+	p.UpdatedAt = now
+	return nil
+}
+
+// SetExpiry 设置自动下线时间，仅适用于已发布或待发布的文章。
+func (p *Post) SetExpiry(unpublishAt time.Time, now time.Time) error {
+	if p.Status == StatusDraft {
+		return errors.New("草稿无法设置自动下线时间")
+	}
+	if !unpublishAt.After(now) {
+		return errors.New("自动下线时间必须晚于当前时间")
+	}
+	if p.PublishAt != nil && !unpublishAt.After(*p.PublishAt) {
+		return errors.New("自动下线时间必须晚于发布时间")
+	}
+
+	p.UnpublishAt = &unpublishAt
+	p.UpdatedAt = now
+	return nil
+}
+
+// Draft 将文章切回草稿状态，用于“下线”已发布内容或取消定时发布，同时清除排期。
 func (p *Post) Draft() error {
 	if p.Status == StatusDraft {
 		return errors.New("文章已是草稿状态")
 	}
 	p.Status = StatusDraft
+	p.PublishAt = nil
+	p.UnpublishAt = nil
 	p.UpdatedAt = time.Now()
 	return nil
 }
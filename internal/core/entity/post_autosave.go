@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// PostAutosave is an editor's unsaved title and content for one post.
+// Each user keeps at most one per post, and it never touches the post itself until promoted.
+type PostAutosave struct {
+	PostID uint
+	UserID uint
+
+	Title   string
+	Content string
+	// BaseVersion is the post version the editor started from; promoting fails once the post
+	// has moved past it, the same way a stale If-Match does.
+	BaseVersion uint
+
+	UpdatedAt time.Time
+}
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PostBulkAction names an action applied to many posts at once.
+type PostBulkAction string
+
+const (
+	PostBulkPublish     PostBulkAction = "publish"
+	PostBulkUnpublish   PostBulkAction = "unpublish"
+	PostBulkDelete      PostBulkAction = "delete"
+	PostBulkRetag       PostBulkAction = "retag"
+	PostBulkSetCategory PostBulkAction = "set_category"
+)
+
+// MaxPostBulkItems bounds the number of posts one bulk request may touch.
+const MaxPostBulkItems = 100
+
+// PostBulkRequest applies Action to every post in PostIDs.
+type PostBulkRequest struct {
+	Action  PostBulkAction
+	PostIDs []uint
+	// Versions holds the version the caller last saw, keyed by post ID. A post whose version
+	// no longer matches fails with a conflict; posts without an entry are written unconditionally.
+	Versions map[uint]uint
+	// Tags replaces the tags of every post for PostBulkRetag; an empty slice clears them.
+	Tags []Tag
+	// CategoryID is the new category for PostBulkSetCategory.
+	CategoryID *uint
+	// Atomic applies all items in one transaction: if any item fails, none is applied.
+	Atomic bool
+}
+
+// Validate checks the request shape; per-post permissions are checked when items run.
+func (r PostBulkRequest) Validate() error {
+	switch r.Action {
+	case PostBulkPublish, PostBulkUnpublish, PostBulkDelete:
+	case PostBulkRetag:
+		if r.Tags == nil {
+			return errors.New("retag requires tags")
+		}
+	case PostBulkSetCategory:
+		if r.CategoryID == nil || *r.CategoryID == 0 {
+			return errors.New("set_category requires a category")
+		}
+	default:
+		return fmt.Errorf("unknown bulk action %q", r.Action)
+	}
+	if len(r.PostIDs) == 0 || len(r.PostIDs) > MaxPostBulkItems {
+		return fmt.Errorf("between 1 and %d posts are required", MaxPostBulkItems)
+	}
+	for _, id := range r.PostIDs {
+		if id == 0 {
+			return errors.New("post ids must be positive")
+		}
+	}
+	return nil
+}
+
+// Outcomes of one bulk item.
+const (
+	PostBulkApplied    = "applied"
+	PostBulkFailed     = "failed"
+	PostBulkRolledBack = "rolled_back"
+	PostBulkSkipped    = "skipped"
+)
+
+// PostBulkItemResult reports what happened to one post. Err is set for failed items only.
+// In atomic mode, items applied before a failure are rolled back and later ones skipped.
+type PostBulkItemResult struct {
+	PostID  uint
+	Outcome string
+	Err     error
+}
+
+// PostBulkResult reports a bulk request item by item, in request order.
+type PostBulkResult struct {
+	Action     PostBulkAction
+	Atomic     bool
+	RolledBack bool
+	Items      []PostBulkItemResult
+}
@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+const (
+	// DefaultPreviewTTL is the lifetime of a preview link minted without an explicit one.
+	DefaultPreviewTTL = 72 * time.Hour
+	// MaxPreviewTTL bounds how long a preview link may stay valid.
+	MaxPreviewTTL = 30 * 24 * time.Hour
+)
+
+// PostPreviewToken records a preview link minted for one post. The link itself is a signed
+// token naming this record, so revoking the record invalidates the link before it expires.
+type PostPreviewToken struct {
+	ID        uint
+	PostID    uint
+	CreatedBy uint
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+// Active reports whether links for the token are still honoured at now.
+func (t PostPreviewToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// PreviewGrant is the signed content of a preview link.
+type PreviewGrant struct {
+	TokenID   uint
+	PostID    uint
+	ExpiresAt time.Time
+}
+
+// PostPreview is a post of any status served read-only through a preview link,
+// together with the media it references.
+type PostPreview struct {
+	Post      Post
+	Media     []MediaAsset
+	ExpiresAt time.Time
+}
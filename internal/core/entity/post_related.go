@@ -0,0 +1,34 @@
+package entity
+
+const (
+	// DefaultRelatedPostLimit and MaxRelatedPostLimit bound how many related posts are returned.
+	DefaultRelatedPostLimit = 5
+	MaxRelatedPostLimit     = 20
+
+	// Relatedness weights: every shared tag scores RelatedTagWeight, the same category scores
+	// RelatedCategoryWeight, and the optional text similarity adds up to RelatedTextWeight.
+	RelatedTagWeight      = 3
+	RelatedCategoryWeight = 2
+	RelatedTextWeight     = 1
+)
+
+// RelatedPostQuery asks for the published posts most related to PostID.
+// Only posts in the same locale that share at least some relatedness are considered.
+type RelatedPostQuery struct {
+	PostID uint
+	Limit  int
+	// TextSimilarity adds full-text similarity between the candidate's text and the source
+	// post's title to the score.
+	TextSimilarity bool
+}
+
+// Normalized returns a copy with the limit clamped to [1, MaxRelatedPostLimit].
+func (q RelatedPostQuery) Normalized() RelatedPostQuery {
+	if q.Limit <= 0 {
+		q.Limit = DefaultRelatedPostLimit
+	}
+	if q.Limit > MaxRelatedPostLimit {
+		q.Limit = MaxRelatedPostLimit
+	}
+	return q
+}
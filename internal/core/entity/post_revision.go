@@ -0,0 +1,136 @@
+package entity
+
+import "time"
+
+const (
+	RevisionActionCreate  = "create"
+	RevisionActionUpdate  = "update"
+	RevisionActionPublish = "publish"
+	RevisionActionDraft   = "draft"
+	RevisionActionRestore = "restore"
+	// RevisionActionSchedule records a change to a post's publish/unpublish schedule.
+	RevisionActionSchedule = "schedule"
+	// RevisionActionSubmitReview and RevisionActionReject record the editorial review hand-offs.
+	RevisionActionSubmitReview = "submit_review"
+	RevisionActionReject       = "reject"
+)
+
+// PostRevision is an immutable snapshot of a post taken right after a change was persisted.
+// It records who made the change and why, so any earlier state can be inspected or restored.
+type PostRevision struct {
+	ID        uint
+	CreatedAt time.Time
+
+	PostID  uint
+	ActorID uint
+	Action  string
+	// RestoredFrom is set on restore revisions and points at the revision that was re-applied.
+	RestoredFrom *uint
+
+	Title      string
+	Slug       string
+	Content    string
+	Cover      string
+	CategoryID *uint
+	TagIDs     []uint
+	Status     int
+	// NoIndex, CommentsDisabled and SeriesID are nil on revisions recorded before they were
+	// snapshotted; restoring such a revision leaves them as they are. SeriesID is 0 when the
+	// post was in no series.
+	NoIndex          *bool
+	CommentsDisabled *bool
+	SeriesID         *uint
+	SeriesPosition   int
+}
+
+// NewPostRevision snapshots the given post state.
+func NewPostRevision(post Post, actorID uint, action string) PostRevision {
+	tagIDs := make([]uint, 0, len(post.Tags))
+	for _, t := range post.Tags {
+		tagIDs = append(tagIDs, t.ID)
+	}
+	noIndex, commentsDisabled := post.NoIndex, post.CommentsDisabled
+	var seriesID uint
+	if post.SeriesID != nil {
+		seriesID = *post.SeriesID
+	}
+	return PostRevision{
+		PostID:           post.ID,
+		ActorID:          actorID,
+		Action:           action,
+		Title:            post.Title,
+		Slug:             post.Slug,
+		Content:          post.Content,
+		Cover:            post.Cover,
+		CategoryID:       post.CategoryID,
+		TagIDs:           tagIDs,
+		Status:           post.Status,
+		NoIndex:          &noIndex,
+		CommentsDisabled: &commentsDisabled,
+		SeriesID:         &seriesID,
+		SeriesPosition:   post.SeriesPosition,
+	}
+}
+
+// ToPatch converts the snapshot back into an update that re-applies its editable fields.
+// Status is deliberately excluded: restoring content never bypasses the publish workflow.
+// Series membership is restored at the recorded position, which fails if another post
+// has taken it since. Locale and translation group are left alone.
+func (r PostRevision) ToPatch() PostPatch {
+	title, slug, content, cover := r.Title, r.Slug, r.Content, r.Cover
+	tags := make([]Tag, 0, len(r.TagIDs))
+	for _, id := range r.TagIDs {
+		tags = append(tags, Tag{ID: id})
+	}
+	// A pointer to 0 clears the category; nil would keep the current one.
+	var categoryID uint
+	if r.CategoryID != nil {
+		categoryID = *r.CategoryID
+	}
+	patch := PostPatch{
+		Title:            &title,
+		Slug:             &slug,
+		Content:          &content,
+		Cover:            &cover,
+		CategoryID:       &categoryID,
+		Tags:             tags,
+		NoIndex:          r.NoIndex,
+		CommentsDisabled: r.CommentsDisabled,
+		SeriesID:         r.SeriesID,
+	}
+	if r.SeriesID != nil && *r.SeriesID != 0 {
+		position := r.SeriesPosition
+		patch.SeriesPosition = &position
+	}
+	return patch
+}
+
+// RevisionFieldChange describes one scalar field that differs between two revisions.
+type RevisionFieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// DiffOp marks how a line participates in a line-level diff.
+type DiffOp string
+
+const (
+	DiffOpEqual  DiffOp = "equal"
+	DiffOpInsert DiffOp = "insert"
+	DiffOpDelete DiffOp = "delete"
+)
+
+// DiffLine is one line of a line-level content diff.
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// PostRevisionDiff compares two revisions of the same post.
+type PostRevisionDiff struct {
+	From    PostRevision
+	To      PostRevision
+	Fields  []RevisionFieldChange
+	Content []DiffLine
+}
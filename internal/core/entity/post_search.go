@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxPostSearchQueryRunes bounds the raw search input accepted from clients.
+	MaxPostSearchQueryRunes = 200
+	// MaxPostSearchTerms bounds how many whitespace-separated terms are combined into one query.
+	MaxPostSearchTerms = 8
+)
+
+// PostSearchQuery describes a full-text search over published posts.
+type PostSearchQuery struct {
+	Q        string
+	Page     int
+	PageSize int
+}
+
+// Normalized returns a copy with defaults applied and out-of-range values clamped.
+func (q PostSearchQuery) Normalized() PostSearchQuery {
+	list := PostListQuery{Page: q.Page, PageSize: q.PageSize}.Normalized()
+	q.Page, q.PageSize = list.Page, list.PageSize
+	q.Q = strings.TrimSpace(q.Q)
+	return q
+}
+
+// Offset returns the row offset for the current page.
+func (q PostSearchQuery) Offset() int {
+	return PostListQuery{Page: q.Page, PageSize: q.PageSize}.Offset()
+}
+
+// Terms splits the query on whitespace. Every term must match; terms without any
+// letter or digit are dropped because they can never match an indexed token.
+func (q PostSearchQuery) Terms() []string {
+	terms := make([]string, 0, MaxPostSearchTerms)
+	for _, field := range strings.Fields(q.Q) {
+		if !strings.ContainsFunc(field, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+			continue
+		}
+		terms = append(terms, field)
+		if len(terms) == MaxPostSearchTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// PostSearchHit is one search result: the post plus HTML-escaped excerpts where the
+// matched terms are wrapped in <mark> tags.
+type PostSearchHit struct {
+	Post           Post
+	TitleHighlight string
+	Snippet        string
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPostSearchQuery_Terms(t *testing.T) {
+	cases := []struct {
+		q    string
+		want []string
+	}{
+		{"  go   gin ", []string{"go", "gin"}},
+		{"编程 - 入门", []string{"编程", "入门"}},
+		{"!!! ...", []string{}},
+		{strings.Repeat("a ", 20), []string{"a", "a", "a", "a", "a", "a", "a", "a"}},
+	}
+	for _, tc := range cases {
+		got := PostSearchQuery{Q: tc.q}.Terms()
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Terms(%q) = %v, want %v", tc.q, got, tc.want)
+		}
+	}
+}
@@ -69,3 +69,86 @@ func TestPost_Draft(t *testing.T) {
 		}
 	})
 }
+
+func TestPostListQuery_Normalized(t *testing.T) {
+	t.Run("defaults applied", func(t *testing.T) {
+		q := PostListQuery{}.Normalized()
+		if q.Page != 1 || q.PageSize != DefaultPostPageSize || q.SortBy != PostSortCreatedAt || q.Order != SortOrderDesc {
+			t.Fatalf("defaults: %+v", q)
+		}
+		if q.Offset() != 0 {
+			t.Fatalf("offset: %d", q.Offset())
+		}
+	})
+	t.Run("page size clamped and unknown sort reset", func(t *testing.T) {
+		q := PostListQuery{Page: 3, PageSize: 1000, SortBy: "title; drop table", Order: "up"}.Normalized()
+		if q.PageSize != MaxPostPageSize || q.SortBy != PostSortCreatedAt || q.Order != SortOrderDesc {
+			t.Fatalf("clamp: %+v", q)
+		}
+		if q.Offset() != 2*MaxPostPageSize {
+			t.Fatalf("offset: %d", q.Offset())
+		}
+	})
+}
+
+func TestPost_Schedule(t *testing.T) {
+	now := time.Now()
+	t.Run("future time moves draft to scheduled", func(t *testing.T) {
+		p := &Post{Title: "x", Status: StatusDraft}
+		at := now.Add(time.Hour)
+		if err := p.Schedule(at, now); err != nil {
+			t.Fatal(err)
+		}
+		if p.Status != StatusScheduled || p.PublishAt == nil || !p.PublishAt.Equal(at) {
+			t.Fatalf("unexpected state: status=%d publish_at=%v", p.Status, p.PublishAt)
+		}
+	})
+	t.Run("past time rejected", func(t *testing.T) {
+		p := &Post{Title: "x", Status: StatusDraft}
+		if err := p.Schedule(now.Add(-time.Minute), now); err == nil {
+			t.Fatal("want error for past publish time")
+		}
+	})
+	t.Run("published post rejected", func(t *testing.T) {
+		p := &Post{Title: "x", Status: StatusPublished}
+		if err := p.Schedule(now.Add(time.Hour), now); err == nil {
+			t.Fatal("want error for already published")
+		}
+	})
+	t.Run("validity failure propagates", func(t *testing.T) {
+		p := &Post{Status: StatusDraft}
+		if err := p.Schedule(now.Add(time.Hour), now); err == nil {
+			t.Fatal("want error from empty title")
+		}
+	})
+}
+
+func TestPost_SetExpiry(t *testing.T) {
+	now := time.Now()
+	t.Run("draft rejected", func(t *testing.T) {
+		p := &Post{Title: "x", Status: StatusDraft}
+		if err := p.SetExpiry(now.Add(time.Hour), now); err == nil {
+			t.Fatal("want error for draft")
+		}
+	})
+	t.Run("must follow publish time", func(t *testing.T) {
+		publishAt := now.Add(2 * time.Hour)
+		p := &Post{Title: "x", Status: StatusScheduled, PublishAt: &publishAt}
+		if err := p.SetExpiry(now.Add(time.Hour), now); err == nil {
+			t.Fatal("want error for expiry before publish time")
+		}
+		if err := p.SetExpiry(now.Add(3*time.Hour), now); err != nil {
+			t.Fatalf("unexpected: %v", err)
+		}
+	})
+	t.Run("draft clears schedule", func(t *testing.T) {
+		at := now.Add(time.Hour)
+		p := &Post{Title: "x", Status: StatusScheduled, PublishAt: &at, UnpublishAt: &at}
+		if err := p.Draft(); err != nil {
+			t.Fatal(err)
+		}
+		if p.PublishAt != nil || p.UnpublishAt != nil {
+			t.Fatal("schedule not cleared")
+		}
+	})
+}
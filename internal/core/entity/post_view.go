@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultAnalyticsDays is the span of an analytics range without explicit bounds.
+	DefaultAnalyticsDays = 30
+	// MaxAnalyticsDays bounds the span of one analytics query.
+	MaxAnalyticsDays = 366
+
+	DefaultTopPostLimit = 10
+	MaxTopPostLimit     = 100
+)
+
+// Visitor identifies a reader for view de-duplication only; it is hashed and never stored.
+type Visitor struct {
+	IP        string
+	UserAgent string
+}
+
+// botUserAgentMarkers are lower-case fragments of crawler, monitor and tool user agents.
+var botUserAgentMarkers = []string{
+	"bot", "crawl", "spider", "slurp", "archiver", "facebookexternalhit", "embedly",
+	"preview", "monitor", "pingdom", "uptime", "lighthouse", "headless", "phantomjs",
+	"curl", "wget", "python-requests", "python-urllib", "go-http-client", "java/",
+	"okhttp", "axios", "node-fetch", "httpclient", "libwww",
+}
+
+// IsBot reports whether the visitor looks automated. A missing user agent counts as a bot,
+// since browsers always send one.
+func (v Visitor) IsBot() bool {
+	ua := strings.ToLower(strings.TrimSpace(v.UserAgent))
+	if ua == "" {
+		return true
+	}
+	for _, marker := range botUserAgentMarkers {
+		if strings.Contains(ua, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// DailyViews is the number of counted views on one UTC day.
+type DailyViews struct {
+	Day   time.Time
+	Views int64
+}
+
+// PostDailyViews is the number of counted views of one post on one UTC day.
+type PostDailyViews struct {
+	PostID uint
+	DailyViews
+}
+
+// TopPost is a post together with its views over an analytics range.
+type TopPost struct {
+	PostID uint
+	Title  string
+	Slug   string
+	Locale string
+	Views  int64
+}
+
+// AnalyticsRange is an inclusive range of UTC days.
+type AnalyticsRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// ViewDay truncates t to the start of its UTC day, the granularity views are counted at.
+func ViewDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Normalized truncates both bounds to UTC days. A missing To means today and a missing From
+// means DefaultAnalyticsDays back from To. It reports false when From is after To or the
+// range spans more than MaxAnalyticsDays.
+func (r AnalyticsRange) Normalized(now time.Time) (AnalyticsRange, bool) {
+	if r.To.IsZero() {
+		r.To = now
+	}
+	r.To = ViewDay(r.To)
+	if r.From.IsZero() {
+		r.From = r.To.AddDate(0, 0, -(DefaultAnalyticsDays - 1))
+	}
+	r.From = ViewDay(r.From)
+	if r.From.After(r.To) || r.Days() > MaxAnalyticsDays {
+		return r, false
+	}
+	return r, true
+}
+
+// Days returns the number of days in the range, both ends included.
+func (r AnalyticsRange) Days() int {
+	return int(r.To.Sub(r.From).Hours()/24) + 1
+}
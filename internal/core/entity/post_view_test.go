@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVisitor_IsBot(t *testing.T) {
+	cases := []struct {
+		ua   string
+		want bool
+	}{
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0 Safari/537.36", false},
+		{"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", true},
+		{"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/126.0", true},
+		{"curl/8.5.0", true},
+		{"", true},
+	}
+	for _, tc := range cases {
+		if got := (Visitor{UserAgent: tc.ua}).IsBot(); got != tc.want {
+			t.Errorf("IsBot(%q) = %v, want %v", tc.ua, got, tc.want)
+		}
+	}
+}
+
+func TestAnalyticsRange_Normalized(t *testing.T) {
+	now := time.Date(2026, 3, 31, 22, 30, 0, 0, time.UTC)
+
+	r, ok := AnalyticsRange{}.Normalized(now)
+	if !ok || !r.To.Equal(time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)) || r.Days() != DefaultAnalyticsDays {
+		t.Fatalf("unexpected default range: %+v ok=%v", r, ok)
+	}
+
+	if _, ok := (AnalyticsRange{From: now.AddDate(-2, 0, 0)}).Normalized(now); ok {
+		t.Fatal("range longer than MaxAnalyticsDays accepted")
+	}
+	if _, ok := (AnalyticsRange{From: now, To: now.AddDate(0, 0, -1)}).Normalized(now); ok {
+		t.Fatal("inverted range accepted")
+	}
+}
@@ -0,0 +1,17 @@
+package entity
+
+// TOCEntry is one heading in a rendered post's table of contents.
+// Anchor is the id attribute carried by the heading in the rendered HTML.
+type TOCEntry struct {
+	Level  int
+	Text   string
+	Anchor string
+}
+
+// RenderedContent is post content converted to sanitized HTML for delivery.
+type RenderedContent struct {
+	HTML string
+	TOC  []TOCEntry
+	// Text is the visible text of HTML with whitespace collapsed, used for excerpts.
+	Text string
+}
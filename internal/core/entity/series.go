@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// Series groups posts into an ordered, multi-part sequence such as a tutorial.
+// A post belongs to at most one series, at a position that is unique within it.
+type Series struct {
+	ID          uint
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Title       string
+	Slug        string
+	Description string
+}
+
+// SeriesWithPartCount is a series together with the number of published posts in it.
+type SeriesWithPartCount struct {
+	Series
+	PartCount int64
+}
+
+// SeriesPart is one post of a series as seen from the series: enough to list and link it.
+type SeriesPart struct {
+	ID       uint
+	Title    string
+	Slug     string
+	Locale   string
+	Status   int
+	Position int
+}
+
+// PostSeries places a post within its series for public reads: the series itself, the
+// post's position, and the neighbouring published parts. Prev and Next are nil at the ends.
+type PostSeries struct {
+	Series   Series
+	Position int
+	// Total counts the published parts of the series.
+	Total int
+	Prev  *SeriesPart
+	Next  *SeriesPart
+}
+
+// SeriesPatch models the editable fields of a series; nil means "leave unchanged".
+type SeriesPatch struct {
+	Title       *string
+	Slug        *string
+	Description *string
+}
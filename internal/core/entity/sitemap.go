@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// Kinds of public pages listed in the sitemap.
+const (
+	SitemapEntryPost     = "post"
+	SitemapEntryCategory = "category"
+	SitemapEntryTag      = "tag"
+)
+
+// SitemapEntry is one public page as stored: its kind, slug and last modification time.
+// Posts also carry their locale and translation group.
+type SitemapEntry struct {
+	Kind    string
+	Slug    string
+	LastMod time.Time
+	Locale  string
+	GroupID *uint
+}
+
+// SitemapURL is an absolute URL in a sitemap or sitemap index. LastMod may be zero.
+// Alternates lists every language version of the page, itself included, for hreflang links.
+type SitemapURL struct {
+	Loc        string
+	LastMod    time.Time
+	Alternates []LocaleAlternate
+}
+
+// Sitemap is either a URL set or, when the site has too many URLs for one file,
+// an index of URL-set pages. Exactly one of URLs and Pages is used.
+type Sitemap struct {
+	URLs  []SitemapURL
+	Pages []SitemapURL
+}
+
+// IsIndex reports whether the sitemap is a sitemap index.
+func (s Sitemap) IsIndex() bool {
+	return len(s.Pages) > 0
+}
@@ -6,4 +6,11 @@ package entity
 type Tag struct {
 	ID   uint
 	Name string
+	Slug string
+}
+
+// TagWithPostCount is a tag together with the number of published posts carrying it.
+type TagWithPostCount struct {
+	Tag
+	PostCount int64
 }
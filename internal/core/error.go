@@ -16,8 +16,10 @@ const (
 	CodeNotFound          ErrorCode = "NOT_FOUND"
 	CodeDuplicateResource ErrorCode = "DUPLICATE_RESOURCE"
 	CodeConflict          ErrorCode = "CONFLICT"
-	CodeTimeout           ErrorCode = "TIMEOUT"
-	CodeInternalError     ErrorCode = "INTERNAL_ERROR"
+	// CodePreconditionRequired is answered to writes that must name the version they replace.
+	CodePreconditionRequired ErrorCode = "PRECONDITION_REQUIRED"
+	CodeTimeout              ErrorCode = "TIMEOUT"
+	CodeInternalError        ErrorCode = "INTERNAL_ERROR"
 )
 
 // ErrorPolicy defines how an error code is exposed over HTTP.
@@ -73,8 +75,18 @@ var errorPolicyCatalog = map[ErrorCode]ErrorPolicy{
 		HTTPStatus: http.StatusConflict,
 		Message:    "request conflict",
 		AllowDetailsKey: map[string]struct{}{
-			"resource":   {},
-			"references": {},
+			"resource":        {},
+			"references":      {},
+			"current_version": {},
+			"request_id":      {},
+		},
+	},
+	CodePreconditionRequired: {
+		HTTPStatus: http.StatusPreconditionRequired,
+		Message:    "precondition required",
+		AllowDetailsKey: map[string]struct{}{
+			"field":      {},
+			"reason":     {},
 			"request_id": {},
 		},
 	},
@@ -39,3 +39,13 @@ func TestErrorCodeOf(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorPolicyOf_PreconditionRequired(t *testing.T) {
+	if got := HTTPStatusOf(CodePreconditionRequired); got != 428 {
+		t.Fatalf("status = %d, want 428", got)
+	}
+	details := SanitizeDetails(CodePreconditionRequired, map[string]any{"field": "If-Match", "current_version": 3})
+	if _, ok := details["current_version"]; ok || details["field"] != "If-Match" {
+		t.Fatalf("details = %v", details)
+	}
+}
@@ -0,0 +1,27 @@
+package core
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"io"
+	"time"
+)
+
+// ExportWriter encodes one site export archive as it is written. Entries go straight to the
+// underlying writer, so an archive is never held in memory. The archive is only valid once
+// Close has written the manifest.
+type ExportWriter interface {
+	WriteSettings(settings entity.ExportSettings) error
+	// WriteUsers writes the user accounts; password hashes are never part of an export.
+	WriteUsers(users []entity.User) error
+	WriteCategories(categories []entity.Category) error
+	WriteTags(tags []entity.Tag) error
+	// WriteSeries writes the post series; it comes before the posts that name them.
+	WriteSeries(series []entity.Series) error
+	// WritePost writes one post; Author, Category and Tags must be loaded.
+	WritePost(post entity.Post) error
+	// WriteMedia copies one file of the media tree; objectKey is its path below the upload
+	// directory, e.g. "a/12/photo.jpg".
+	WriteMedia(objectKey string, modified time.Time, r io.Reader) error
+	// Close writes the manifest and finishes the archive.
+	Close(summary entity.ExportSummary) error
+}
@@ -0,0 +1,20 @@
+package core
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"context"
+	"io"
+)
+
+// ImportDecoder parses an export of another system into a bundle. Malformed exports fail
+// with ErrInvalidInput.
+type ImportDecoder interface {
+	Decode(format entity.ImportFormat, r io.ReaderAt, size int64) (entity.ImportBundle, ImportAssets, error)
+}
+
+// ImportAssets opens the files referenced by the posts of a decoded bundle: paths inside the
+// archive, or URLs for exports that only link to their media.
+type ImportAssets interface {
+	// Open resolves ref relative to post and returns the file's content and base name.
+	Open(ctx context.Context, post entity.ImportPost, ref string) (io.ReadCloser, string, error)
+}
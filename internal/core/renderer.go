@@ -0,0 +1,9 @@
+package core
+
+import "KaldalisCMS/internal/core/entity"
+
+// ContentRenderer converts stored post content (Markdown) into HTML that is safe to embed.
+// Implementations must be deterministic: the same input always yields the same HTML and anchors.
+type ContentRenderer interface {
+	Render(content string) (entity.RenderedContent, error)
+}
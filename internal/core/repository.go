@@ -9,14 +9,138 @@ import (
 type PostRepository interface {
 	GetByID(ctx context.Context, id uint) (entity.Post, error)
 	GetPublishedByID(ctx context.Context, id uint) (entity.Post, error)
+	// GetPublishedBySlug prefers the earliest locale of the chain when several locales share the slug.
+	GetPublishedBySlug(ctx context.Context, slug string, locales []string) (entity.Post, error)
+	// GetPostIDBySlugHistory only matches slugs used in one of the locales, preferring the earliest.
+	GetPostIDBySlugHistory(ctx context.Context, slug string, locales []string) (uint, error)
+	// GetDraftByIDAndAuthor and GetDraftsByAuthor also match drafts that are pending review.
 	GetDraftByIDAndAuthor(ctx context.Context, id uint, authorID uint) (entity.Post, error)
 	Create(ctx context.Context, post entity.Post) (entity.Post, error)
 	Update(ctx context.Context, post entity.Post) error
 	Delete(ctx context.Context, id uint) error
 	GetAll(ctx context.Context) ([]entity.Post, error)
-	GetPublished(ctx context.Context) ([]entity.Post, error)
+	// GetAllAfter pages through all non-trashed posts in ID order: it returns up to limit
+	// posts with an ID above afterID.
+	GetAllAfter(ctx context.Context, afterID uint, limit int) ([]entity.Post, error)
+	GetPublished(ctx context.Context, query entity.PostListQuery) ([]entity.Post, int64, error)
 	GetDraftsByAuthor(ctx context.Context, authorID uint) ([]entity.Post, error)
-	IsSlugExists(ctx context.Context, slug string) (bool, error)
+	GetPendingReview(ctx context.Context) ([]entity.Post, error)
+	IsSlugExists(ctx context.Context, locale string, slug string) (bool, error)
+	GetDueScheduled(ctx context.Context, now time.Time, limit int) ([]entity.Post, error)
+	GetDueExpired(ctx context.Context, now time.Time, limit int) ([]entity.Post, error)
+	SearchPublished(ctx context.Context, query entity.PostSearchQuery) ([]entity.Post, int64, error)
+	// GetRelatedPublished returns published posts ordered by relatedness to query.PostID, most related first.
+	GetRelatedPublished(ctx context.Context, query entity.RelatedPostQuery) ([]entity.Post, error)
+	// Delete moves a post to the trash; GetTrashed, Restore and Purge operate on trashed posts only.
+	GetTrashed(ctx context.Context) ([]entity.Post, error)
+	GetTrashedBefore(ctx context.Context, cutoff time.Time, limit int) ([]entity.Post, error)
+	Restore(ctx context.Context, id uint) error
+	Purge(ctx context.Context, id uint) error
+	// GetTranslations returns the non-trashed members of translation groups; SetTranslationGroup
+	// moves posts into a group, or out of their group when groupID is nil.
+	GetTranslations(ctx context.Context, groupIDs []uint, publishedOnly bool) ([]entity.Post, error)
+	SetTranslationGroup(ctx context.Context, ids []uint, groupID *uint) error
+}
+
+// PostRevisionRepository persists immutable post snapshots.
+// It deliberately has no update or delete operations.
+type PostRevisionRepository interface {
+	Create(ctx context.Context, revision entity.PostRevision) (entity.PostRevision, error)
+	ListByPost(ctx context.Context, postID uint) ([]entity.PostRevision, error)
+	GetByID(ctx context.Context, postID uint, revisionID uint) (entity.PostRevision, error)
+}
+
+// PostAutosaveRepository stores at most one unsaved draft of a post per user.
+// Get returns ErrNotFound when the user has no autosave for the post.
+type PostAutosaveRepository interface {
+	Save(ctx context.Context, autosave entity.PostAutosave) (entity.PostAutosave, error)
+	Get(ctx context.Context, postID uint, userID uint) (entity.PostAutosave, error)
+	Delete(ctx context.Context, postID uint, userID uint) error
+	DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// Transactor runs fn in one database transaction. Repository calls made with the ctx passed
+// to fn take part in it; an error from fn rolls all of them back.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// PostPreviewTokenRepository stores the records behind preview links.
+// GetByID returns ErrNotFound for unknown tokens; Revoke returns it when the token does not
+// belong to the post or is already revoked.
+type PostPreviewTokenRepository interface {
+	Create(ctx context.Context, token entity.PostPreviewToken) (entity.PostPreviewToken, error)
+	GetByID(ctx context.Context, id uint) (entity.PostPreviewToken, error)
+	ListByPost(ctx context.Context, postID uint) ([]entity.PostPreviewToken, error)
+	Revoke(ctx context.Context, postID uint, id uint, at time.Time) error
+}
+
+// ContentTypeRepository persists admin-defined content type schemas.
+type ContentTypeRepository interface {
+	Create(ctx context.Context, contentType entity.ContentType) (entity.ContentType, error)
+	GetBySlug(ctx context.Context, slug string) (entity.ContentType, error)
+	List(ctx context.Context) ([]entity.ContentType, error)
+	Update(ctx context.Context, contentType entity.ContentType) (entity.ContentType, error)
+	Delete(ctx context.Context, id uint) error
+}
+
+// ContentEntryRepository persists entries of content types. Entries are always addressed
+// together with their type, so an ID from one type never resolves inside another.
+type ContentEntryRepository interface {
+	Create(ctx context.Context, entry entity.ContentEntry) (entity.ContentEntry, error)
+	GetByID(ctx context.Context, typeID uint, id uint) (entity.ContentEntry, error)
+	GetBySlug(ctx context.Context, typeID uint, slug string) (entity.ContentEntry, error)
+	List(ctx context.Context, query entity.ContentEntryQuery) ([]entity.ContentEntry, int64, error)
+	Update(ctx context.Context, entry entity.ContentEntry) error
+	Delete(ctx context.Context, typeID uint, id uint) error
+	IsSlugExists(ctx context.Context, typeID uint, slug string) (bool, error)
+	CountByType(ctx context.Context, typeID uint) (int64, error)
+	// CountReferencing counts the entries of typeID whose reference field holds targetID.
+	CountReferencing(ctx context.Context, typeID uint, field string, targetID uint) (int64, error)
+}
+
+// PageRepository persists static pages. Paths are unique; Update rewrites the paths of the
+// page's descendants in the same transaction when the page's own path changes.
+type PageRepository interface {
+	Create(ctx context.Context, page entity.Page) (entity.Page, error)
+	GetByID(ctx context.Context, id uint) (entity.Page, error)
+	GetByPath(ctx context.Context, path string) (entity.Page, error)
+	// ListByPaths returns the pages at the given paths, in no particular order.
+	ListByPaths(ctx context.Context, paths []string) ([]entity.Page, error)
+	// List returns the matching pages in sibling order (sort order, then ID).
+	List(ctx context.Context, query entity.PageQuery) ([]entity.Page, error)
+	// ListChildren returns the direct children of parentID (nil for top-level pages) in sibling order.
+	ListChildren(ctx context.Context, parentID *uint, query entity.PageQuery) ([]entity.Page, error)
+	IsPathExists(ctx context.Context, path string) (bool, error)
+	Update(ctx context.Context, page entity.Page) error
+	// Reorder sets the sort order of the given children of parentID to their position in ids.
+	Reorder(ctx context.Context, parentID *uint, ids []uint) error
+	Delete(ctx context.Context, id uint) error
+}
+
+// SeriesRepository persists post series. Deleting a series detaches its posts instead of
+// deleting them.
+type SeriesRepository interface {
+	Create(ctx context.Context, series entity.Series) (entity.Series, error)
+	GetByID(ctx context.Context, id uint) (entity.Series, error)
+	GetBySlug(ctx context.Context, slug string) (entity.Series, error)
+	// ListWithPartCounts returns every series ordered by title, each with its number of published parts.
+	ListWithPartCounts(ctx context.Context) ([]entity.SeriesWithPartCount, error)
+	Update(ctx context.Context, series entity.Series) (entity.Series, error)
+	Delete(ctx context.Context, id uint) error
+	// ListParts returns the live posts of a series ordered by position, then ID.
+	ListParts(ctx context.Context, seriesID uint, publishedOnly bool) ([]entity.SeriesPart, error)
+}
+
+// PostViewRepository stores daily view aggregates. Days are UTC dates.
+type PostViewRepository interface {
+	// AddDailyViews adds the given counts to the stored aggregates in one batch.
+	AddDailyViews(ctx context.Context, counts []entity.PostDailyViews) error
+	// DailyViews sums views per day within the range, for one post or, with a nil postID, the whole site.
+	// Days without views are omitted.
+	DailyViews(ctx context.Context, postID *uint, r entity.AnalyticsRange) ([]entity.DailyViews, error)
+	// TopPosts returns the live posts with the most views within the range.
+	TopPosts(ctx context.Context, r entity.AnalyticsRange, limit int) ([]entity.TopPost, error)
 }
 
 // MediaRepository defines persistence operations for media assets and post-media relations.
@@ -24,10 +148,15 @@ type PostRepository interface {
 type MediaRepository interface {
 	Create(ctx context.Context, asset *entity.MediaAsset) error
 	GetByID(ctx context.Context, id uint) (entity.MediaAsset, error)
+	// GetBySHA256 returns an uploaded asset with the given content hash, or ErrNotFound.
+	GetBySHA256(ctx context.Context, sum string) (entity.MediaAsset, error)
 	List(ctx context.Context, ownerUserID *uint, offset, limit int, q string) ([]entity.MediaAsset, int64, error)
 	Delete(ctx context.Context, id uint) error
 	CountReferences(ctx context.Context, assetID uint) (int64, error)
 	UpsertPostReferences(ctx context.Context, postID uint, purpose string, assetIDs []uint) error
+	UpsertPageReferences(ctx context.Context, pageID uint, purpose string, assetIDs []uint) error
+	// UpsertEntryReferences replaces the assets recorded for a content entry's media fields.
+	UpsertEntryReferences(ctx context.Context, entryID uint, assetIDs []uint) error
 	ListPostMedia(ctx context.Context, postID uint, purpose *string) ([]entity.MediaAsset, error)
 	UpdateAssetFields(ctx context.Context, assetID uint, fields map[string]any) error
 	UpdateStatus(ctx context.Context, id uint, status entity.MediaStatus) error
@@ -52,6 +181,39 @@ type TagRepository interface {
 	GetAll(ctx context.Context) ([]entity.Tag, error)
 	GetByID(ctx context.Context, id uint) (entity.Tag, error)
 	GetByName(ctx context.Context, name string) (entity.Tag, error)
+	GetBySlug(ctx context.Context, slug string) (entity.Tag, error)
+	ListWithPostCounts(ctx context.Context) ([]entity.TagWithPostCount, error)
 	Update(ctx context.Context, tag entity.Tag) (entity.Tag, error)
 	Delete(ctx context.Context, id uint) error
 }
+
+// CategoryRepository defines the interface for category persistence.
+type CategoryRepository interface {
+	Create(ctx context.Context, category entity.Category) (entity.Category, error)
+	ListWithPostCounts(ctx context.Context) ([]entity.CategoryWithPostCount, error)
+	GetByID(ctx context.Context, id uint) (entity.Category, error)
+	GetBySlug(ctx context.Context, slug string) (entity.Category, error)
+	GetByName(ctx context.Context, name string) (entity.Category, error)
+	Update(ctx context.Context, category entity.Category) (entity.Category, error)
+	CountPosts(ctx context.Context, id uint) (int64, error)
+	Delete(ctx context.Context, id uint, reassignTo *uint) error
+}
+
+// SitemapRepository lists the public pages that belong in the sitemap, in a stable order:
+// indexable published posts, then categories, then tags.
+type SitemapRepository interface {
+	CountEntries(ctx context.Context) (int64, error)
+	ListEntries(ctx context.Context, offset int, limit int) ([]entity.SitemapEntry, error)
+	ListPostAlternates(ctx context.Context, groupIDs []uint) ([]entity.SitemapEntry, error)
+}
+
+// CommentRepository defines the interface for comment persistence.
+type CommentRepository interface {
+	Create(ctx context.Context, comment entity.Comment) (entity.Comment, error)
+	GetByID(ctx context.Context, id uint) (entity.Comment, error)
+	// ListApprovedByPost returns every approved comment of a post, oldest first.
+	ListApprovedByPost(ctx context.Context, postID uint) ([]entity.Comment, error)
+	// List returns one page of comments for moderation, newest first.
+	List(ctx context.Context, query entity.CommentListQuery) ([]entity.Comment, int64, error)
+	UpdateStatus(ctx context.Context, id uint, status string) error
+}
@@ -3,22 +3,55 @@ package core
 import (
 	"KaldalisCMS/internal/core/entity"
 	"context"
+	"io"
+	"time"
 )
 
 // PostService describes the article publishing use cases exposed to delivery layers.
 // Public and management concerns are split explicitly so HTTP handlers do not need to infer
 // visibility rules from route naming alone.
 type PostService interface {
-	ListPublicPosts(ctx context.Context) ([]entity.Post, error)
-	GetPublicPostByID(ctx context.Context, id uint) (entity.Post, error)
+	ListPublicPosts(ctx context.Context, query entity.PostListQuery) ([]entity.Post, int64, error)
+	// GetPublicPostByID and GetPublicPostBySlug return the best translation for the locale
+	// fallback chain; a nil chain returns the requested post as is.
+	GetPublicPostByID(ctx context.Context, id uint, locales []string) (entity.Post, error)
+	GetPublicPostBySlug(ctx context.Context, slug string, locales []string) (entity.Post, error)
+	// ListRelatedPosts returns up to limit published posts related to the published post id.
+	ListRelatedPosts(ctx context.Context, id uint, limit int) ([]entity.Post, error)
+	SearchPublicPosts(ctx context.Context, query entity.PostSearchQuery) ([]entity.PostSearchHit, int64, error)
 
 	ListAdminPosts(ctx context.Context, actorUserID uint, actorRole string) ([]entity.Post, error)
 	GetAdminPostByID(ctx context.Context, id uint, actorUserID uint, actorRole string) (entity.Post, error)
 	CreateAdminPost(ctx context.Context, actorUserID uint, actorRole string, post entity.Post) (entity.Post, error)
-	UpdateAdminPost(ctx context.Context, id uint, patch entity.PostPatch, actorUserID uint, actorRole string) error
-	DeleteAdminPost(ctx context.Context, id uint, actorUserID uint, actorRole string) error
-	PublishAdminPost(ctx context.Context, id uint, actorUserID uint, actorRole string) error
-	MovePostToDraft(ctx context.Context, id uint, actorUserID uint, actorRole string) error
+	UpdateAdminPost(ctx context.Context, id uint, patch entity.PostPatch, ifVersion uint, actorUserID uint, actorRole string) error
+	DeleteAdminPost(ctx context.Context, id uint, ifVersion uint, actorUserID uint, actorRole string) error
+	PublishAdminPost(ctx context.Context, id uint, ifVersion uint, actorUserID uint, actorRole string) error
+	MovePostToDraft(ctx context.Context, id uint, ifVersion uint, actorUserID uint, actorRole string) error
+	ScheduleAdminPost(ctx context.Context, id uint, schedule entity.PostSchedule, ifVersion uint, actorUserID uint, actorRole string) error
+	ListAdminPostRevisions(ctx context.Context, postID uint, actorUserID uint, actorRole string) ([]entity.PostRevision, error)
+	GetAdminPostRevision(ctx context.Context, postID uint, revisionID uint, actorUserID uint, actorRole string) (entity.PostRevision, error)
+	DiffAdminPostRevisions(ctx context.Context, postID uint, fromID uint, toID uint, actorUserID uint, actorRole string) (entity.PostRevisionDiff, error)
+	RestoreAdminPostRevision(ctx context.Context, postID uint, revisionID uint, ifVersion uint, actorUserID uint, actorRole string) error
+	SaveAdminPostAutosave(ctx context.Context, id uint, autosave entity.PostAutosave, actorUserID uint, actorRole string) (entity.PostAutosave, error)
+	GetAdminPostAutosave(ctx context.Context, id uint, actorUserID uint, actorRole string) (entity.PostAutosave, error)
+	PromoteAdminPostAutosave(ctx context.Context, id uint, ifVersion uint, actorUserID uint, actorRole string) error
+	DiscardAdminPostAutosave(ctx context.Context, id uint, actorUserID uint, actorRole string) error
+	SubmitAdminPostForReview(ctx context.Context, id uint, actorUserID uint, actorRole string) error
+	ListReviewQueue(ctx context.Context, actorRole string) ([]entity.Post, error)
+	ApproveAdminPost(ctx context.Context, id uint, ifVersion uint, actorUserID uint, actorRole string) error
+	RejectAdminPost(ctx context.Context, id uint, reason string, ifVersion uint, actorUserID uint, actorRole string) error
+	ListTrashedPosts(ctx context.Context, actorRole string) ([]entity.Post, error)
+	RestoreAdminPost(ctx context.Context, id uint, actorRole string) error
+	PurgeAdminPost(ctx context.Context, id uint, actorRole string) error
+	LinkAdminPostTranslation(ctx context.Context, id uint, translationID uint, actorRole string) error
+	UnlinkAdminPostTranslation(ctx context.Context, id uint, actorRole string) error
+	CreatePreviewLink(ctx context.Context, postID uint, ttl time.Duration, actorUserID uint, actorRole string) (entity.PostPreviewToken, string, error)
+	ListPreviewLinks(ctx context.Context, postID uint, actorUserID uint, actorRole string) ([]entity.PostPreviewToken, error)
+	RevokePreviewLink(ctx context.Context, postID uint, tokenID uint, actorUserID uint, actorRole string) error
+	// GetPreviewPost serves the post behind a signed preview link regardless of its status.
+	GetPreviewPost(ctx context.Context, token string) (entity.PostPreview, error)
+	// BulkAdminPosts applies one action to many posts and reports the outcome per post.
+	BulkAdminPosts(ctx context.Context, req entity.PostBulkRequest, actorUserID uint, actorRole string) (entity.PostBulkResult, error)
 }
 
 type UserService interface {
@@ -36,6 +69,123 @@ type TagService interface {
 	GetAll(ctx context.Context) ([]entity.Tag, error)
 	GetByID(ctx context.Context, id uint) (entity.Tag, error)
 	GetByName(ctx context.Context, name string) (entity.Tag, error)
+	GetBySlug(ctx context.Context, slug string) (entity.Tag, error)
+	// List returns every tag with its number of published posts.
+	List(ctx context.Context) ([]entity.TagWithPostCount, error)
+	// ResolveNames maps tag names to tags, creating the ones that do not exist yet.
+	ResolveNames(ctx context.Context, names []string) ([]entity.Tag, error)
 	Update(ctx context.Context, tag entity.Tag) (entity.Tag, error)
 	Delete(ctx context.Context, id uint) error
 }
+
+// CategoryService defines category-related business operations.
+type CategoryService interface {
+	Create(ctx context.Context, category entity.Category) (entity.Category, error)
+	List(ctx context.Context) ([]entity.CategoryWithPostCount, error)
+	GetByID(ctx context.Context, id uint) (entity.Category, error)
+	GetBySlug(ctx context.Context, slug string) (entity.Category, error)
+	Update(ctx context.Context, category entity.Category) (entity.Category, error)
+	// Delete refuses while posts reference the category unless reassignTo names another category.
+	Delete(ctx context.Context, id uint, reassignTo *uint) error
+}
+
+// SeriesService manages post series. Reads are public and only ever expose published parts;
+// posts join a series through the post management API.
+type SeriesService interface {
+	Create(ctx context.Context, series entity.Series) (entity.Series, error)
+	List(ctx context.Context) ([]entity.SeriesWithPartCount, error)
+	GetBySlug(ctx context.Context, slug string) (entity.Series, error)
+	// ListPublishedParts returns the series and its published posts ordered by position.
+	ListPublishedParts(ctx context.Context, slug string) (entity.Series, []entity.SeriesPart, error)
+	Update(ctx context.Context, id uint, patch entity.SeriesPatch) (entity.Series, error)
+	// Delete removes the series; its posts are kept and leave the series.
+	Delete(ctx context.Context, id uint) error
+}
+
+// AnalyticsService counts post views and reports them. Views are de-duplicated per visitor,
+// post and UTC day through a salted hash; neither addresses nor hashes are ever persisted.
+type AnalyticsService interface {
+	// RecordView buffers one view and reports whether it was counted; it never touches storage.
+	RecordView(postID uint, visitor entity.Visitor) bool
+	// Flush writes the buffered counts to the daily aggregates.
+	Flush(ctx context.Context) error
+	// PostViews and SiteViews return one point per day of the range, including days without views.
+	PostViews(ctx context.Context, postID uint, r entity.AnalyticsRange) ([]entity.DailyViews, error)
+	SiteViews(ctx context.Context, r entity.AnalyticsRange) ([]entity.DailyViews, error)
+	TopPosts(ctx context.Context, r entity.AnalyticsRange, limit int) ([]entity.TopPost, error)
+}
+
+// ImportService migrates users, categories, tags, posts and their images from an export of
+// another system. Items that already exist are reused rather than duplicated, so running the
+// same import again creates nothing new.
+type ImportService interface {
+	// Import decodes the export and imports it. Failures of single items are recorded in the
+	// report and do not stop the import; malformed exports fail with ErrInvalidInput.
+	Import(ctx context.Context, req entity.ImportRequest, r io.ReaderAt, size int64) (entity.ImportReport, error)
+}
+
+// ExportService writes the whole site (settings, users, categories, tags, posts and the
+// media tree) into a portable archive that a fresh install can import again.
+type ExportService interface {
+	// Export streams the site into w and closes it. On error the archive is incomplete and
+	// must be discarded.
+	Export(ctx context.Context, w ExportWriter) (entity.ExportSummary, error)
+}
+
+// ContentService manages custom content types and their entries.
+// Types are addressed by slug. Entries reuse the post draft/publish lifecycle and post
+// capabilities, so a role manages entries exactly as far as it may manage posts.
+type ContentService interface {
+	CreateType(ctx context.Context, contentType entity.ContentType) (entity.ContentType, error)
+	ListTypes(ctx context.Context) ([]entity.ContentType, error)
+	GetType(ctx context.Context, slug string) (entity.ContentType, error)
+	// UpdateType replaces name, description and fields; the slug is fixed at creation.
+	UpdateType(ctx context.Context, slug string, contentType entity.ContentType) (entity.ContentType, error)
+	// DeleteType refuses while the type has entries or is the target of another type's reference field.
+	DeleteType(ctx context.Context, slug string) error
+
+	ListPublicEntries(ctx context.Context, typeSlug string, query entity.ContentEntryQuery) ([]entity.ContentEntry, int64, error)
+	GetPublicEntryBySlug(ctx context.Context, typeSlug string, slug string) (entity.ContentEntry, error)
+
+	ListAdminEntries(ctx context.Context, typeSlug string, query entity.ContentEntryQuery, actorUserID uint, actorRole string) ([]entity.ContentEntry, int64, error)
+	GetAdminEntry(ctx context.Context, typeSlug string, id uint, actorUserID uint, actorRole string) (entity.ContentEntry, error)
+	CreateAdminEntry(ctx context.Context, typeSlug string, entry entity.ContentEntry, actorUserID uint, actorRole string) (entity.ContentEntry, error)
+	UpdateAdminEntry(ctx context.Context, typeSlug string, id uint, patch entity.ContentEntryPatch, actorUserID uint, actorRole string) (entity.ContentEntry, error)
+	PublishAdminEntry(ctx context.Context, typeSlug string, id uint, actorUserID uint, actorRole string) error
+	MoveEntryToDraft(ctx context.Context, typeSlug string, id uint, actorUserID uint, actorRole string) error
+	DeleteAdminEntry(ctx context.Context, typeSlug string, id uint, actorUserID uint, actorRole string) error
+}
+
+// PageService manages static pages. Pages reuse the post draft/publish lifecycle and post
+// capabilities; a page is public only while it and all its ancestors are published.
+type PageService interface {
+	// ListPublicPages returns every public page in sibling order, for building the navigation tree.
+	ListPublicPages(ctx context.Context) ([]entity.Page, error)
+	// GetPublicPageByPath resolves a page by its full path, e.g. "docs/install/linux".
+	GetPublicPageByPath(ctx context.Context, path string) (entity.Page, error)
+
+	ListAdminPages(ctx context.Context, actorUserID uint, actorRole string) ([]entity.Page, error)
+	GetAdminPage(ctx context.Context, id uint, actorUserID uint, actorRole string) (entity.Page, error)
+	CreateAdminPage(ctx context.Context, page entity.Page, actorUserID uint, actorRole string) (entity.Page, error)
+	UpdateAdminPage(ctx context.Context, id uint, patch entity.PagePatch, actorUserID uint, actorRole string) (entity.Page, error)
+	PublishAdminPage(ctx context.Context, id uint, actorUserID uint, actorRole string) error
+	MovePageToDraft(ctx context.Context, id uint, actorUserID uint, actorRole string) error
+	// DeleteAdminPage refuses while the page has children.
+	DeleteAdminPage(ctx context.Context, id uint, actorUserID uint, actorRole string) error
+	// ReorderAdminPages sets the sibling order of the children of parentID (nil for top-level
+	// pages); ids must list every one of those children exactly once.
+	ReorderAdminPages(ctx context.Context, parentID *uint, ids []uint, actorRole string) error
+}
+
+// CommentService defines reader comment and moderation operations.
+type CommentService interface {
+	// Create adds a pending comment to a published post. actorUserID is 0 for guests,
+	// who must then provide AuthorName.
+	Create(ctx context.Context, comment entity.Comment, actorUserID uint) (entity.Comment, error)
+	// ListApproved returns a published post's approved comments as a reply tree.
+	ListApproved(ctx context.Context, postID uint) ([]entity.Comment, error)
+	// List returns one page of the moderation queue.
+	List(ctx context.Context, query entity.CommentListQuery) ([]entity.Comment, int64, error)
+	// Moderate sets a comment to approved, rejected or spam.
+	Moderate(ctx context.Context, id uint, status string) error
+}
@@ -68,6 +68,38 @@ func setupTestEnforcer(t *testing.T, opts setupOpts) *casbin.Enforcer {
 		{"admin", "/api/v1/admin/posts/:id", "PUT"},
 		{"admin", "/api/v1/admin/posts/:id/publish", "POST"},
 		{"admin", "/api/v1/admin/posts/:id/draft", "POST"},
+		{"admin", "/api/v1/admin/posts/:id/schedule", "POST"},
+		{"admin", "/api/v1/admin/posts/review", "GET"},
+		{"admin", "/api/v1/admin/posts/:id/approve", "POST"},
+		{"admin", "/api/v1/admin/posts/:id/reject", "POST"},
+		{"admin", "/api/v1/admin/posts/trash", "GET"},
+		{"admin", "/api/v1/admin/posts/:id/restore", "POST"},
+		{"admin", "/api/v1/admin/posts/:id/translations", "POST"},
+		{"admin", "/api/v1/admin/posts/:id/translations", "DELETE"},
+		{"admin", "/api/v1/admin/posts/:id/previews", "GET"},
+		{"admin", "/api/v1/admin/posts/:id/previews", "POST"},
+		{"admin", "/api/v1/admin/posts/:id/previews/:preview", "DELETE"},
+		{"admin", "/api/v1/admin/posts/bulk", "POST"},
+		{"admin", "/api/v1/admin/comments", "GET"},
+		{"admin", "/api/v1/admin/comments/:id/approve", "POST"},
+		{"admin", "/api/v1/admin/comments/:id/reject", "POST"},
+		{"admin", "/api/v1/admin/comments/:id/spam", "POST"},
+		{"admin", "/api/v1/admin/content-types", "GET"},
+		{"admin", "/api/v1/admin/content-types", "POST"},
+		{"admin", "/api/v1/admin/content-types/:type", "GET"},
+		{"admin", "/api/v1/admin/content-types/:type", "PUT"},
+		{"admin", "/api/v1/admin/content/:type/:id/publish", "POST"},
+		{"admin", "/api/v1/admin/content/:type/:id/draft", "POST"},
+		{"admin", "/api/v1/admin/pages/:id/publish", "POST"},
+		{"admin", "/api/v1/admin/pages/:id/draft", "POST"},
+		{"admin", "/api/v1/admin/pages/reorder", "POST"},
+		{"admin", "/api/v1/series", "POST"},
+		{"admin", "/api/v1/series/:id", "PUT"},
+		{"admin", "/api/v1/admin/analytics/views", "GET"},
+		{"admin", "/api/v1/admin/analytics/posts/:id/views", "GET"},
+		{"admin", "/api/v1/admin/analytics/top-posts", "GET"},
+		{"admin", "/api/v1/admin/import", "POST"},
+		{"admin", "/api/v1/admin/export", "GET"},
 		// capability policies
 		{"admin", "post", "list:any"},
 		{"admin", "post", "read:any"},
@@ -75,6 +107,8 @@ func setupTestEnforcer(t *testing.T, opts setupOpts) *casbin.Enforcer {
 		{"admin", "post", "publish"},
 		{"admin", "post", "unpublish"},
 		{"admin", "post", "delete"},
+		{"admin", "post", "review"},
+		{"admin", "post", "purge"},
 		// media / tags / categories
 		{"admin", "/api/v1/media", "POST"},
 		{"admin", "/api/v1/tags", "POST"},
@@ -86,24 +120,79 @@ func setupTestEnforcer(t *testing.T, opts setupOpts) *casbin.Enforcer {
 
 	if opts.AdminCanDelete {
 		_, _ = e.AddPolicy("admin", "/api/v1/admin/posts/:id", "DELETE")
+		_, _ = e.AddPolicy("admin", "/api/v1/admin/posts/:id/purge", "DELETE")
 		_, _ = e.AddPolicy("admin", "/api/v1/media/:id", "DELETE")
 		_, _ = e.AddPolicy("admin", "/api/v1/tags/:id", "DELETE")
 		_, _ = e.AddPolicy("admin", "/api/v1/categories/:id", "DELETE")
+		_, _ = e.AddPolicy("admin", "/api/v1/admin/content-types/:type", "DELETE")
+		_, _ = e.AddPolicy("admin", "/api/v1/admin/content/:type/:id", "DELETE")
+		_, _ = e.AddPolicy("admin", "/api/v1/admin/pages/:id", "DELETE")
+		_, _ = e.AddPolicy("admin", "/api/v1/series/:id", "DELETE")
 	}
 
 	// 3. user — route policies
 	userRoutes := [][]string{
 		{"user", "/api/v1/posts", "GET"},
 		{"user", "/api/v1/posts/:id", "GET"},
+		{"user", "/api/v1/posts/slug/:slug", "GET"},
+		{"user", "/api/v1/posts/search", "GET"},
+		{"user", "/api/v1/posts/:id/related", "GET"},
+		{"user", "/api/v1/categories", "GET"},
+		{"user", "/api/v1/categories/slug/:slug", "GET"},
+		{"user", "/api/v1/categories/slug/:slug/posts", "GET"},
+		{"user", "/api/v1/tags", "GET"},
+		{"user", "/api/v1/tags/:slug", "GET"},
+		{"user", "/api/v1/tags/:slug/posts", "GET"},
+		{"user", "/api/v1/posts/:id/comments", "GET"},
+		{"user", "/api/v1/posts/:id/comments", "POST"},
+		{"user", "/feed.xml", "GET"},
+		{"user", "/atom.xml", "GET"},
+		{"user", "/feed.json", "GET"},
+		{"user", "/tags/:slug/feed.xml", "GET"},
+		{"user", "/tags/:slug/atom.xml", "GET"},
+		{"user", "/tags/:slug/feed.json", "GET"},
+		{"user", "/categories/:slug/feed.xml", "GET"},
+		{"user", "/categories/:slug/atom.xml", "GET"},
+		{"user", "/categories/:slug/feed.json", "GET"},
+		{"user", "/sitemap.xml", "GET"},
 		{"user", "/api/v1/admin/posts", "GET"},
 		{"user", "/api/v1/admin/posts", "POST"},
 		{"user", "/api/v1/admin/posts/:id", "GET"},
 		{"user", "/api/v1/admin/posts/:id", "PUT"},
+		{"user", "/api/v1/admin/posts/:id/submit", "POST"},
+		{"user", "/api/v1/admin/posts/:id/revisions", "GET"},
+		{"user", "/api/v1/admin/posts/:id/revisions/diff", "GET"},
+		{"user", "/api/v1/admin/posts/:id/revisions/:rev", "GET"},
+		{"user", "/api/v1/admin/posts/:id/revisions/:rev/restore", "POST"},
+		{"user", "/api/v1/admin/posts/:id/autosave", "GET"},
+		{"user", "/api/v1/admin/posts/:id/autosave", "PUT"},
+		{"user", "/api/v1/admin/posts/:id/autosave", "DELETE"},
+		{"user", "/api/v1/admin/posts/:id/autosave/promote", "POST"},
+		{"user", "/api/v1/admin/posts/:id/previews", "GET"},
+		{"user", "/api/v1/admin/posts/:id/previews", "POST"},
+		{"user", "/api/v1/admin/posts/:id/previews/:preview", "DELETE"},
+		{"user", "/api/v1/admin/posts/bulk", "POST"},
+		{"user", "/api/v1/content/:type", "GET"},
+		{"user", "/api/v1/content/:type/:slug", "GET"},
+		{"user", "/api/v1/admin/content/:type", "GET"},
+		{"user", "/api/v1/admin/content/:type", "POST"},
+		{"user", "/api/v1/admin/content/:type/:id", "GET"},
+		{"user", "/api/v1/admin/content/:type/:id", "PUT"},
+		{"user", "/api/v1/pages", "GET"},
+		{"user", "/api/v1/pages/*path", "GET"},
+		{"user", "/api/v1/admin/pages", "GET"},
+		{"user", "/api/v1/admin/pages", "POST"},
+		{"user", "/api/v1/admin/pages/:id", "GET"},
+		{"user", "/api/v1/admin/pages/:id", "PUT"},
+		{"user", "/api/v1/series", "GET"},
+		{"user", "/api/v1/series/:slug", "GET"},
+		{"user", "/api/v1/series/:slug/posts", "GET"},
 		// capability policies
 		{"user", "post:draft", "create"},
 		{"user", "post:draft", "list:own"},
 		{"user", "post:draft", "read:own"},
 		{"user", "post:draft", "update:own"},
+		{"user", "post:draft", "submit:own"},
 		// media read
 		{"user", "/api/v1/media", "GET"},
 	}
@@ -117,6 +206,34 @@ func setupTestEnforcer(t *testing.T, opts setupOpts) *casbin.Enforcer {
 	if opts.AllowAnonymousRead {
 		_, _ = e.AddPolicy("anonymous", "/api/v1/posts", "GET")
 		_, _ = e.AddPolicy("anonymous", "/api/v1/posts/:id", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/posts/slug/:slug", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/posts/search", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/posts/:id/related", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/categories", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/categories/slug/:slug", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/categories/slug/:slug/posts", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/tags", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/tags/:slug", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/tags/:slug/posts", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/posts/:id/comments", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/posts/:id/comments", "POST")
+		_, _ = e.AddPolicy("anonymous", "/feed.xml", "GET")
+		_, _ = e.AddPolicy("anonymous", "/atom.xml", "GET")
+		_, _ = e.AddPolicy("anonymous", "/feed.json", "GET")
+		_, _ = e.AddPolicy("anonymous", "/tags/:slug/feed.xml", "GET")
+		_, _ = e.AddPolicy("anonymous", "/tags/:slug/atom.xml", "GET")
+		_, _ = e.AddPolicy("anonymous", "/tags/:slug/feed.json", "GET")
+		_, _ = e.AddPolicy("anonymous", "/categories/:slug/feed.xml", "GET")
+		_, _ = e.AddPolicy("anonymous", "/categories/:slug/atom.xml", "GET")
+		_, _ = e.AddPolicy("anonymous", "/categories/:slug/feed.json", "GET")
+		_, _ = e.AddPolicy("anonymous", "/sitemap.xml", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/content/:type", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/content/:type/:slug", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/pages", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/pages/*path", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/series", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/series/:slug", "GET")
+		_, _ = e.AddPolicy("anonymous", "/api/v1/series/:slug/posts", "GET")
 	}
 
 	// 5. Role inheritance
@@ -169,6 +286,33 @@ func TestRoutePolicy_DefaultSetup(t *testing.T) {
 		{"admin can DELETE admin post", "admin", "/api/v1/admin/posts/:id", "DELETE", true},
 		{"admin can publish post", "admin", "/api/v1/admin/posts/:id/publish", "POST", true},
 		{"admin can draft post", "admin", "/api/v1/admin/posts/:id/draft", "POST", true},
+		{"admin can schedule post", "admin", "/api/v1/admin/posts/:id/schedule", "POST", true},
+		{"admin can list review queue", "admin", "/api/v1/admin/posts/review", "GET", true},
+		{"admin can approve post", "admin", "/api/v1/admin/posts/:id/approve", "POST", true},
+		{"admin can reject post", "admin", "/api/v1/admin/posts/:id/reject", "POST", true},
+		{"admin can submit own draft (inherited)", "admin", "/api/v1/admin/posts/:id/submit", "POST", true},
+		{"admin can list trash", "admin", "/api/v1/admin/posts/trash", "GET", true},
+		{"admin can restore trashed post", "admin", "/api/v1/admin/posts/:id/restore", "POST", true},
+		{"admin can purge trashed post", "admin", "/api/v1/admin/posts/:id/purge", "DELETE", true},
+		{"admin can link post translation", "admin", "/api/v1/admin/posts/:id/translations", "POST", true},
+		{"admin can unlink post translation", "admin", "/api/v1/admin/posts/:id/translations", "DELETE", true},
+		{"admin can define content type", "admin", "/api/v1/admin/content-types", "POST", true},
+		{"admin can delete content type", "admin", "/api/v1/admin/content-types/:type", "DELETE", true},
+		{"admin can publish entry", "admin", "/api/v1/admin/content/:type/:id/publish", "POST", true},
+		{"admin can delete entry", "admin", "/api/v1/admin/content/:type/:id", "DELETE", true},
+		{"admin can publish page", "admin", "/api/v1/admin/pages/:id/publish", "POST", true},
+		{"admin can reorder pages", "admin", "/api/v1/admin/pages/reorder", "POST", true},
+		{"admin can delete page", "admin", "/api/v1/admin/pages/:id", "DELETE", true},
+		{"admin can create series", "admin", "/api/v1/series", "POST", true},
+		{"admin can delete series", "admin", "/api/v1/series/:id", "DELETE", true},
+		{"admin can read post analytics", "admin", "/api/v1/admin/analytics/posts/:id/views", "GET", true},
+		{"admin can import content", "admin", "/api/v1/admin/import", "POST", true},
+		{"admin can export the site", "admin", "/api/v1/admin/export", "GET", true},
+		{"admin can diff revisions (inherited)", "admin", "/api/v1/admin/posts/:id/revisions/diff", "GET", true},
+		{"admin can list moderation queue", "admin", "/api/v1/admin/comments", "GET", true},
+		{"admin can approve comment", "admin", "/api/v1/admin/comments/:id/approve", "POST", true},
+		{"admin can reject comment", "admin", "/api/v1/admin/comments/:id/reject", "POST", true},
+		{"admin can mark comment spam", "admin", "/api/v1/admin/comments/:id/spam", "POST", true},
 		{"admin can POST media", "admin", "/api/v1/media", "POST", true},
 		{"admin can DELETE media", "admin", "/api/v1/media/:id", "DELETE", true},
 		{"admin can logout", "admin", "/api/v1/users/logout", "POST", true},
@@ -182,6 +326,7 @@ func TestRoutePolicy_DefaultSetup(t *testing.T) {
 		// ── user: limited access ──
 		{"user can GET public posts", "user", "/api/v1/posts", "GET", true},
 		{"user can GET public post by id", "user", "/api/v1/posts/:id", "GET", true},
+		{"user can GET public post by slug", "user", "/api/v1/posts/slug/:slug", "GET", true},
 		{"user can GET admin posts (own drafts)", "user", "/api/v1/admin/posts", "GET", true},
 		{"user can POST admin posts (create draft)", "user", "/api/v1/admin/posts", "POST", true},
 		{"user can GET admin post by id", "user", "/api/v1/admin/posts/:id", "GET", true},
@@ -189,19 +334,92 @@ func TestRoutePolicy_DefaultSetup(t *testing.T) {
 		{"user can GET media", "user", "/api/v1/media", "GET", true},
 		{"user can logout", "user", "/api/v1/users/logout", "POST", true},
 		// user CANNOT access publish/draft/delete routes
+		{"user can list revisions", "user", "/api/v1/admin/posts/:id/revisions", "GET", true},
+		{"user can restore revision (own draft)", "user", "/api/v1/admin/posts/:id/revisions/:rev/restore", "POST", true},
+		{"user can autosave post", "user", "/api/v1/admin/posts/:id/autosave", "PUT", true},
+		{"user can promote autosave", "user", "/api/v1/admin/posts/:id/autosave/promote", "POST", true},
+		{"user can create preview link", "user", "/api/v1/admin/posts/:id/previews", "POST", true},
+		{"user can revoke preview link", "user", "/api/v1/admin/posts/:id/previews/:preview", "DELETE", true},
+		{"user can run bulk post actions", "user", "/api/v1/admin/posts/bulk", "POST", true},
+		{"user can create entry", "user", "/api/v1/admin/content/:type", "POST", true},
+		{"user can update entry (own draft)", "user", "/api/v1/admin/content/:type/:id", "PUT", true},
+		{"user cannot publish entry", "user", "/api/v1/admin/content/:type/:id/publish", "POST", false},
+		{"user cannot delete entry", "user", "/api/v1/admin/content/:type/:id", "DELETE", false},
+		{"user cannot list content types", "user", "/api/v1/admin/content-types", "GET", false},
+		{"user can create page", "user", "/api/v1/admin/pages", "POST", true},
+		{"user can update page (own draft)", "user", "/api/v1/admin/pages/:id", "PUT", true},
+		{"user cannot publish page", "user", "/api/v1/admin/pages/:id/publish", "POST", false},
+		{"user cannot reorder pages", "user", "/api/v1/admin/pages/reorder", "POST", false},
+		{"user cannot delete page", "user", "/api/v1/admin/pages/:id", "DELETE", false},
+		{"user can read series parts", "user", "/api/v1/series/:slug/posts", "GET", true},
+		{"user cannot create series", "user", "/api/v1/series", "POST", false},
+		{"user cannot update series", "user", "/api/v1/series/:id", "PUT", false},
+		{"user cannot read site analytics", "user", "/api/v1/admin/analytics/views", "GET", false},
+		{"user cannot read top posts", "user", "/api/v1/admin/analytics/top-posts", "GET", false},
+		{"user cannot import content", "user", "/api/v1/admin/import", "POST", false},
+		{"user cannot export the site", "user", "/api/v1/admin/export", "GET", false},
 		{"user cannot publish post", "user", "/api/v1/admin/posts/:id/publish", "POST", false},
 		{"user cannot draft post", "user", "/api/v1/admin/posts/:id/draft", "POST", false},
+		{"user cannot schedule post", "user", "/api/v1/admin/posts/:id/schedule", "POST", false},
+		{"user can submit draft for review", "user", "/api/v1/admin/posts/:id/submit", "POST", true},
+		{"user cannot approve post", "user", "/api/v1/admin/posts/:id/approve", "POST", false},
+		{"user cannot reject post", "user", "/api/v1/admin/posts/:id/reject", "POST", false},
+		{"user cannot restore trashed post", "user", "/api/v1/admin/posts/:id/restore", "POST", false},
+		{"user cannot purge trashed post", "user", "/api/v1/admin/posts/:id/purge", "DELETE", false},
+		{"user cannot link post translation", "user", "/api/v1/admin/posts/:id/translations", "POST", false},
 		{"user cannot DELETE admin post", "user", "/api/v1/admin/posts/:id", "DELETE", false},
+		{"user can list categories", "user", "/api/v1/categories", "GET", true},
+		{"user cannot update category", "user", "/api/v1/categories/:id", "PUT", false},
+		{"user can list tag posts", "user", "/api/v1/tags/:slug/posts", "GET", true},
+		{"user cannot create tag", "user", "/api/v1/tags", "POST", false},
+		{"user can comment", "user", "/api/v1/posts/:id/comments", "POST", true},
+		{"user cannot list moderation queue", "user", "/api/v1/admin/comments", "GET", false},
+		{"user cannot approve comment", "user", "/api/v1/admin/comments/:id/approve", "POST", false},
+		{"user can read site feed", "user", "/feed.xml", "GET", true},
 		{"user cannot POST media (no upload)", "user", "/api/v1/media", "POST", false},
 		{"user cannot DELETE media", "user", "/api/v1/media/:id", "DELETE", false},
 
 		// ── anonymous: only public read ──
 		{"anonymous can GET public posts", "anonymous", "/api/v1/posts", "GET", true},
 		{"anonymous can GET public post by id", "anonymous", "/api/v1/posts/:id", "GET", true},
+		{"anonymous can GET public post by slug", "anonymous", "/api/v1/posts/slug/:slug", "GET", true},
+		{"anonymous can search public posts", "anonymous", "/api/v1/posts/search", "GET", true},
+		{"anonymous can list related posts", "anonymous", "/api/v1/posts/:id/related", "GET", true},
+		{"anonymous can list categories", "anonymous", "/api/v1/categories", "GET", true},
+		{"anonymous can list category posts", "anonymous", "/api/v1/categories/slug/:slug/posts", "GET", true},
+		{"anonymous cannot create category", "anonymous", "/api/v1/categories", "POST", false},
+		{"anonymous can list tags", "anonymous", "/api/v1/tags", "GET", true},
+		{"anonymous can list tag posts", "anonymous", "/api/v1/tags/:slug/posts", "GET", true},
+		{"anonymous cannot update tag", "anonymous", "/api/v1/tags/:id", "PUT", false},
+		{"anonymous can read site feed", "anonymous", "/feed.xml", "GET", true},
+		{"anonymous can list comments", "anonymous", "/api/v1/posts/:id/comments", "GET", true},
+		{"anonymous can comment", "anonymous", "/api/v1/posts/:id/comments", "POST", true},
+		{"anonymous cannot mark spam", "anonymous", "/api/v1/admin/comments/:id/spam", "POST", false},
+		{"anonymous can read tag atom feed", "anonymous", "/tags/:slug/atom.xml", "GET", true},
+		{"anonymous can read category json feed", "anonymous", "/categories/:slug/feed.json", "GET", true},
+		{"anonymous cannot post to feed", "anonymous", "/feed.xml", "POST", false},
+		{"anonymous can read sitemap", "anonymous", "/sitemap.xml", "GET", true},
+		{"anonymous can list published entries", "anonymous", "/api/v1/content/:type", "GET", true},
+		{"anonymous can read published entry", "anonymous", "/api/v1/content/:type/:slug", "GET", true},
+		{"anonymous cannot create entry", "anonymous", "/api/v1/admin/content/:type", "POST", false},
+		{"anonymous can read page tree", "anonymous", "/api/v1/pages", "GET", true},
+		{"anonymous can read page by path", "anonymous", "/api/v1/pages/*path", "GET", true},
+		{"anonymous cannot create page", "anonymous", "/api/v1/admin/pages", "POST", false},
+		{"anonymous can list series", "anonymous", "/api/v1/series", "GET", true},
+		{"anonymous can read series parts", "anonymous", "/api/v1/series/:slug/posts", "GET", true},
+		{"anonymous cannot delete series", "anonymous", "/api/v1/series/:id", "DELETE", false},
+		{"anonymous cannot read analytics", "anonymous", "/api/v1/admin/analytics/views", "GET", false},
+		{"anonymous cannot import content", "anonymous", "/api/v1/admin/import", "POST", false},
+		{"anonymous cannot export the site", "anonymous", "/api/v1/admin/export", "GET", false},
 		{"anonymous cannot GET admin posts", "anonymous", "/api/v1/admin/posts", "GET", false},
 		{"anonymous cannot POST admin posts", "anonymous", "/api/v1/admin/posts", "POST", false},
 		{"anonymous cannot DELETE", "anonymous", "/api/v1/admin/posts/:id", "DELETE", false},
 		{"anonymous cannot publish", "anonymous", "/api/v1/admin/posts/:id/publish", "POST", false},
+		{"anonymous cannot submit for review", "anonymous", "/api/v1/admin/posts/:id/submit", "POST", false},
+		{"anonymous cannot list revisions", "anonymous", "/api/v1/admin/posts/:id/revisions", "GET", false},
+		{"anonymous cannot autosave post", "anonymous", "/api/v1/admin/posts/:id/autosave", "PUT", false},
+		{"anonymous cannot create preview link", "anonymous", "/api/v1/admin/posts/:id/previews", "POST", false},
+		{"anonymous cannot run bulk post actions", "anonymous", "/api/v1/admin/posts/bulk", "POST", false},
 		{"anonymous cannot logout", "anonymous", "/api/v1/users/logout", "POST", false},
 	}
 
@@ -242,17 +460,21 @@ func TestCapabilityPolicy_DefaultSetup(t *testing.T) {
 		{"admin can publish", "admin", "post", "publish", true},
 		{"admin can unpublish", "admin", "post", "unpublish", true},
 		{"admin can delete", "admin", "post", "delete", true},
+		{"admin can review", "admin", "post", "review", true},
+		{"admin can purge", "admin", "post", "purge", true},
 		// admin inherits user's draft capabilities
 		{"admin can create draft (inherited)", "admin", "post:draft", "create", true},
 		{"admin can list:own (inherited)", "admin", "post:draft", "list:own", true},
 		{"admin can read:own (inherited)", "admin", "post:draft", "read:own", true},
 		{"admin can update:own (inherited)", "admin", "post:draft", "update:own", true},
+		{"admin can submit:own (inherited)", "admin", "post:draft", "submit:own", true},
 
 		// ── user: only own drafts ──
 		{"user can create draft", "user", "post:draft", "create", true},
 		{"user can list:own", "user", "post:draft", "list:own", true},
 		{"user can read:own", "user", "post:draft", "read:own", true},
 		{"user can update:own", "user", "post:draft", "update:own", true},
+		{"user can submit:own", "user", "post:draft", "submit:own", true},
 		// user CANNOT do admin-level operations
 		{"user cannot list:any", "user", "post", "list:any", false},
 		{"user cannot read:any", "user", "post", "read:any", false},
@@ -260,6 +482,8 @@ func TestCapabilityPolicy_DefaultSetup(t *testing.T) {
 		{"user cannot publish", "user", "post", "publish", false},
 		{"user cannot unpublish", "user", "post", "unpublish", false},
 		{"user cannot delete", "user", "post", "delete", false},
+		{"user cannot review", "user", "post", "review", false},
+		{"user cannot purge", "user", "post", "purge", false},
 
 		// ── anonymous: no capabilities ──
 		{"anonymous cannot create draft", "anonymous", "post:draft", "create", false},
@@ -321,6 +545,12 @@ func TestAdminCanDeleteFalse(t *testing.T) {
 	if enforce(t, e, "admin", "/api/v1/admin/posts/:id", "DELETE") {
 		t.Error("admin should NOT be able to DELETE posts route when AdminCanDelete=false")
 	}
+	if enforce(t, e, "admin", "/api/v1/admin/posts/:id/purge", "DELETE") {
+		t.Error("admin should NOT be able to purge posts when AdminCanDelete=false")
+	}
+	if enforce(t, e, "admin", "/api/v1/admin/content/:type/:id", "DELETE") {
+		t.Error("admin should NOT be able to delete entries when AdminCanDelete=false")
+	}
 	if enforce(t, e, "admin", "/api/v1/media/:id", "DELETE") {
 		t.Error("admin should NOT be able to DELETE media route when AdminCanDelete=false")
 	}
@@ -355,6 +585,9 @@ func TestAnonymousReadDisabled(t *testing.T) {
 	if enforce(t, e, "anonymous", "/api/v1/posts/:id", "GET") {
 		t.Error("anonymous should NOT be able to GET /api/v1/posts/:id when AllowAnonymousRead=false")
 	}
+	if enforce(t, e, "anonymous", "/api/v1/posts/slug/:slug", "GET") {
+		t.Error("anonymous should NOT be able to GET /api/v1/posts/slug/:slug when AllowAnonymousRead=false")
+	}
 
 	// NOTE: This test validates the POLICY layer. In production, the public post routes
 	// (/api/v1/posts) are currently registered OUTSIDE the Casbin middleware group,
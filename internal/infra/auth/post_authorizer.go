@@ -49,6 +49,8 @@ func mapPostPermission(permission core.PostPermission) (obj string, act string,
 		return "post:draft", "read:own", nil
 	case core.PostPermissionUpdateOwnDraft:
 		return "post:draft", "update:own", nil
+	case core.PostPermissionSubmitOwnDraft:
+		return "post:draft", "submit:own", nil
 	case core.PostPermissionListAnyPost:
 		return "post", "list:any", nil
 	case core.PostPermissionReadAnyPost:
@@ -61,6 +63,10 @@ func mapPostPermission(permission core.PostPermission) (obj string, act string,
 		return "post", "unpublish", nil
 	case core.PostPermissionDeletePost:
 		return "post", "delete", nil
+	case core.PostPermissionReviewPost:
+		return "post", "review", nil
+	case core.PostPermissionPurgePost:
+		return "post", "purge", nil
 	default:
 		return "", "", fmt.Errorf("unknown post permission: %s", permission)
 	}
@@ -21,12 +21,15 @@ func TestMapPostPermission(t *testing.T) {
 		{core.PostPermissionListOwnDrafts, "post:draft", "list:own", false},
 		{core.PostPermissionReadOwnDraft, "post:draft", "read:own", false},
 		{core.PostPermissionUpdateOwnDraft, "post:draft", "update:own", false},
+		{core.PostPermissionSubmitOwnDraft, "post:draft", "submit:own", false},
 		{core.PostPermissionListAnyPost, "post", "list:any", false},
 		{core.PostPermissionReadAnyPost, "post", "read:any", false},
 		{core.PostPermissionUpdateAnyPost, "post", "update:any", false},
 		{core.PostPermissionPublishPost, "post", "publish", false},
 		{core.PostPermissionUnpublishPost, "post", "unpublish", false},
 		{core.PostPermissionDeletePost, "post", "delete", false},
+		{core.PostPermissionReviewPost, "post", "review", false},
+		{core.PostPermissionPurgePost, "post", "purge", false},
 		// unknown permission
 		{"post:nonexistent", "", "", true},
 	}
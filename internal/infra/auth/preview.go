@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// previewAudience marks preview tokens so no other token type is accepted in their place.
+const previewAudience = "post-preview"
+
+type previewClaims struct {
+	PostID uint `json:"post_id"`
+	jwt.RegisteredClaims
+}
+
+// PreviewSigner issues preview link tokens as HS256 JWTs. The signing key is derived from
+// the server secret, so a preview token can never pass as a session token or vice versa.
+type PreviewSigner struct {
+	key []byte
+	now func() time.Time
+}
+
+var _ core.PreviewTokenSigner = (*PreviewSigner)(nil)
+
+func NewPreviewSigner(secret []byte) *PreviewSigner {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(previewAudience))
+	return &PreviewSigner{key: mac.Sum(nil), now: time.Now}
+}
+
+func (s *PreviewSigner) SignPreview(grant entity.PreviewGrant) (string, error) {
+	claims := previewClaims{
+		PostID: grant.PostID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        strconv.FormatUint(uint64(grant.TokenID), 10),
+			Audience:  jwt.ClaimStrings{previewAudience},
+			ExpiresAt: jwt.NewNumericDate(grant.ExpiresAt),
+			IssuedAt:  jwt.NewNumericDate(s.now()),
+			Issuer:    "KaldalisCMS",
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("sign preview token: %w", err)
+	}
+	return token, nil
+}
+
+func (s *PreviewSigner) VerifyPreview(token string) (entity.PreviewGrant, error) {
+	var claims previewClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		return s.key, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(previewAudience),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(s.now),
+	)
+	if err != nil {
+		return entity.PreviewGrant{}, fmt.Errorf("%w: invalid preview token", core.ErrPermission)
+	}
+	tokenID, err := strconv.ParseUint(claims.ID, 10, 32)
+	if err != nil || tokenID == 0 || claims.PostID == 0 {
+		return entity.PreviewGrant{}, fmt.Errorf("%w: invalid preview token", core.ErrPermission)
+	}
+	return entity.PreviewGrant{TokenID: uint(tokenID), PostID: claims.PostID, ExpiresAt: claims.ExpiresAt.Time}, nil
+}
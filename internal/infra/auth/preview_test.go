@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	pkgauth "KaldalisCMS/pkg/auth"
+)
+
+func TestPreviewSigner_RoundTrip(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	signer := NewPreviewSigner(secret)
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	token, err := signer.SignPreview(entity.PreviewGrant{TokenID: 4, PostID: 9, ExpiresAt: expires})
+	if err != nil {
+		t.Fatal(err)
+	}
+	grant, err := signer.VerifyPreview(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grant.TokenID != 4 || grant.PostID != 9 || !grant.ExpiresAt.Equal(expires) {
+		t.Fatalf("unexpected grant: %+v", grant)
+	}
+
+	if _, err := NewPreviewSigner([]byte("another secret")).VerifyPreview(token); !errors.Is(err, core.ErrPermission) {
+		t.Fatalf("foreign secret: want ErrPermission, got %v", err)
+	}
+	if _, err := signer.VerifyPreview(token + "x"); !errors.Is(err, core.ErrPermission) {
+		t.Fatalf("tampered token: want ErrPermission, got %v", err)
+	}
+	// Session tokens are signed with the raw secret and must not open previews, nor the reverse.
+	session, err := pkgauth.GenerateHashCSRF(1, "admin", secret, time.Hour, "csrf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signer.VerifyPreview(session); !errors.Is(err, core.ErrPermission) {
+		t.Fatalf("session token: want ErrPermission, got %v", err)
+	}
+	if _, err := pkgauth.Parse(token, secret); err == nil {
+		t.Fatal("preview token accepted as a session token")
+	}
+}
+
+func TestPreviewSigner_Expired(t *testing.T) {
+	signer := NewPreviewSigner([]byte("0123456789abcdef0123456789abcdef"))
+	token, err := signer.SignPreview(entity.PreviewGrant{TokenID: 1, PostID: 1, ExpiresAt: time.Now().Add(time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer.now = func() time.Time { return time.Now().Add(time.Hour) }
+	if _, err := signer.VerifyPreview(token); !errors.Is(err, core.ErrPermission) {
+		t.Fatalf("want ErrPermission, got %v", err)
+	}
+}
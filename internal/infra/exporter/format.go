@@ -0,0 +1,135 @@
+// Package exporter writes site exports: zip archives holding every post as Markdown with YAML
+// front matter, the users, terms and settings as JSON, and the media tree. The layout is
+// described in docs/EXPORT_FORMAT.md; the Markdown importer reads it back.
+package exporter
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"time"
+)
+
+const (
+	// FormatName identifies an export archive in its manifest.
+	FormatName = "kaldalis-export"
+	// FormatVersion is bumped whenever the layout or a file changes incompatibly. Readers
+	// refuse archives of a newer version.
+	FormatVersion = 1
+)
+
+// Paths of the entries of an archive.
+const (
+	ManifestFile   = "manifest.json"
+	SettingsFile   = "settings.json"
+	UsersFile      = "users.json"
+	CategoriesFile = "categories.json"
+	TagsFile       = "tags.json"
+	SeriesFile     = "series.json"
+	PostsDir       = "posts"
+	MediaDir       = "media"
+)
+
+// Filename names an archive after the time it was taken.
+func Filename(at time.Time) string {
+	return FormatName + "-" + at.UTC().Format("20060102-150405") + ".zip"
+}
+
+// Manifest is manifest.json. It is written last, so its counts describe the archive as
+// finished; an archive without it is incomplete.
+type Manifest struct {
+	Format    string         `json:"format"`
+	Version   int            `json:"version"`
+	CreatedAt time.Time      `json:"created_at"`
+	SiteName  string         `json:"site_name,omitempty"`
+	Counts    ManifestCounts `json:"counts"`
+}
+
+type ManifestCounts struct {
+	Users      int   `json:"users"`
+	Categories int   `json:"categories"`
+	Tags       int   `json:"tags"`
+	Series     int   `json:"series"`
+	Posts      int   `json:"posts"`
+	MediaFiles int   `json:"media_files"`
+	MediaBytes int64 `json:"media_bytes"`
+}
+
+// Settings is settings.json.
+type Settings struct {
+	SiteName    string     `json:"site_name"`
+	InstalledAt *time.Time `json:"installed_at,omitempty"`
+}
+
+// User is one entry of users.json. Password hashes are never exported.
+type User struct {
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Term is one entry of categories.json or tags.json.
+type Term struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// Series is one entry of series.json.
+type Series struct {
+	Title       string `json:"title"`
+	Slug        string `json:"slug"`
+	Description string `json:"description,omitempty"`
+}
+
+// FrontMatter is the YAML header of a post file. It uses the keys Hugo uses where Hugo has
+// one, so the files also work as a Hugo content directory.
+type FrontMatter struct {
+	Title  string `yaml:"title"`
+	Slug   string `yaml:"slug"`
+	Locale string `yaml:"locale,omitempty"`
+	// Date is the creation time and LastMod the last modification, in RFC 3339.
+	Date    string `yaml:"date"`
+	LastMod string `yaml:"lastmod,omitempty"`
+	Status  string `yaml:"status"`
+	// Draft is set for every post that is not published, for tools that do not know Status.
+	Draft            bool     `yaml:"draft,omitempty"`
+	PublishDate      string   `yaml:"publishDate,omitempty"`
+	ExpiryDate       string   `yaml:"expiryDate,omitempty"`
+	Author           string   `yaml:"author,omitempty"`
+	Categories       []string `yaml:"categories,omitempty"`
+	Tags             []string `yaml:"tags,omitempty"`
+	Cover            string   `yaml:"cover,omitempty"`
+	NoIndex          bool     `yaml:"noindex,omitempty"`
+	CommentsDisabled bool     `yaml:"comments_disabled,omitempty"`
+	// Series is the slug of the post's series in series.json.
+	Series         string `yaml:"series,omitempty"`
+	SeriesPosition int    `yaml:"series_position,omitempty"`
+	// TranslationKey is shared by the posts of a translation group. It only links the files of
+	// one archive; the group IDs of the exporting site mean nothing elsewhere.
+	TranslationKey string `yaml:"translationKey,omitempty"`
+}
+
+// postStatusNames are the values of the status key.
+var postStatusNames = map[int]string{
+	entity.StatusDraft:         "draft",
+	entity.StatusPublished:     "published",
+	entity.StatusScheduled:     "scheduled",
+	entity.StatusPendingReview: "pending_review",
+}
+
+// PostStatusName returns the front matter name of a post status.
+func PostStatusName(status int) string {
+	if name, ok := postStatusNames[status]; ok {
+		return name
+	}
+	return postStatusNames[entity.StatusDraft]
+}
+
+// ParsePostStatus maps a front matter status name back to the post status.
+func ParsePostStatus(name string) (int, bool) {
+	for status, n := range postStatusNames {
+		if n == name {
+			return status, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,213 @@
+package exporter
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ZipWriter implements core.ExportWriter. A zip can be written front to back without seeking,
+// so the archive streams to any io.Writer, e.g. an HTTP response.
+type ZipWriter struct {
+	zw      *zip.Writer
+	created time.Time
+	paths   map[string]bool
+	// series maps the IDs of the series written so far to their slugs.
+	series map[uint]string
+}
+
+var _ core.ExportWriter = (*ZipWriter)(nil)
+
+// NewZipWriter starts an archive on w. The caller closes w after Close.
+func NewZipWriter(w io.Writer) *ZipWriter {
+	return &ZipWriter{zw: zip.NewWriter(w), created: time.Now(), paths: make(map[string]bool), series: make(map[uint]string)}
+}
+
+func (w *ZipWriter) WriteSettings(settings entity.ExportSettings) error {
+	return w.writeJSON(SettingsFile, Settings{SiteName: settings.SiteName, InstalledAt: settings.InstalledAt})
+}
+
+func (w *ZipWriter) WriteUsers(users []entity.User) error {
+	out := make([]User, len(users))
+	for i, u := range users {
+		out[i] = User{Username: u.Username, Email: u.Email, Role: u.Role, CreatedAt: u.CreatedAt.UTC()}
+	}
+	return w.writeJSON(UsersFile, out)
+}
+
+func (w *ZipWriter) WriteCategories(categories []entity.Category) error {
+	out := make([]Term, len(categories))
+	for i, c := range categories {
+		out[i] = Term{Name: c.Name, Slug: c.Slug}
+	}
+	return w.writeJSON(CategoriesFile, out)
+}
+
+func (w *ZipWriter) WriteTags(tags []entity.Tag) error {
+	out := make([]Term, len(tags))
+	for i, t := range tags {
+		out[i] = Term{Name: t.Name, Slug: t.Slug}
+	}
+	return w.writeJSON(TagsFile, out)
+}
+
+func (w *ZipWriter) WriteSeries(series []entity.Series) error {
+	out := make([]Series, len(series))
+	for i, s := range series {
+		out[i] = Series{Title: s.Title, Slug: s.Slug, Description: s.Description}
+		w.series[s.ID] = s.Slug
+	}
+	return w.writeJSON(SeriesFile, out)
+}
+
+// WritePost writes posts/{locale}/{slug}.md: the front matter, then the content unchanged.
+func (w *ZipWriter) WritePost(post entity.Post) error {
+	fm := FrontMatter{
+		Title:            post.Title,
+		Slug:             post.Slug,
+		Locale:           post.Locale,
+		Date:             formatTime(&post.CreatedAt),
+		LastMod:          formatTime(&post.UpdatedAt),
+		Status:           PostStatusName(post.Status),
+		Draft:            post.Status != entity.StatusPublished,
+		PublishDate:      formatTime(post.PublishAt),
+		ExpiryDate:       formatTime(post.UnpublishAt),
+		Author:           post.Author.Username,
+		Cover:            post.Cover,
+		NoIndex:          post.NoIndex,
+		CommentsDisabled: post.CommentsDisabled,
+	}
+	if post.CategoryID != nil && post.Category.Name != "" {
+		fm.Categories = []string{post.Category.Name}
+	}
+	for _, t := range post.Tags {
+		fm.Tags = append(fm.Tags, t.Name)
+	}
+	if post.SeriesID != nil && w.series[*post.SeriesID] != "" {
+		fm.Series, fm.SeriesPosition = w.series[*post.SeriesID], post.SeriesPosition
+	}
+	if post.TranslationGroupID != nil {
+		fm.TranslationKey = fmt.Sprintf("group-%d", *post.TranslationGroupID)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(fm); err != nil {
+		return fmt.Errorf("exporter.WritePost %d: %w", post.ID, err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("exporter.WritePost %d: %w", post.ID, err)
+	}
+	buf.WriteString("---\n\n")
+	buf.WriteString(post.Content)
+
+	f, err := w.create(w.postPath(post), zip.Deflate, post.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("exporter.WritePost %d: %w", post.ID, err)
+	}
+	return nil
+}
+
+// WriteMedia stores the file under media/{objectKey}. Media is mostly compressed already, so
+// it is stored rather than deflated.
+func (w *ZipWriter) WriteMedia(objectKey string, modified time.Time, r io.Reader) error {
+	key := path.Clean("/" + strings.ReplaceAll(objectKey, "\\", "/"))
+	if key == "/" {
+		return fmt.Errorf("%w: invalid media key %q", core.ErrInvalidInput, objectKey)
+	}
+	f, err := w.create(path.Join(MediaDir, key), zip.Store, modified)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		return fmt.Errorf("exporter.WriteMedia %s: %w", key, err)
+	}
+	return nil
+}
+
+// Close writes manifest.json and the zip directory.
+func (w *ZipWriter) Close(summary entity.ExportSummary) error {
+	manifest := Manifest{
+		Format:    FormatName,
+		Version:   FormatVersion,
+		CreatedAt: summary.CreatedAt.UTC(),
+		SiteName:  summary.SiteName,
+		Counts: ManifestCounts{
+			Users:      summary.Users,
+			Categories: summary.Categories,
+			Tags:       summary.Tags,
+			Series:     summary.Series,
+			Posts:      summary.Posts,
+			MediaFiles: summary.MediaFiles,
+			MediaBytes: summary.MediaBytes,
+		},
+	}
+	if err := w.writeJSON(ManifestFile, manifest); err != nil {
+		return err
+	}
+	if err := w.zw.Close(); err != nil {
+		return fmt.Errorf("exporter.Close: %w", err)
+	}
+	return nil
+}
+
+func (w *ZipWriter) writeJSON(name string, v any) error {
+	f, err := w.create(name, zip.Deflate, w.created)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("exporter.write %s: %w", name, err)
+	}
+	return nil
+}
+
+func (w *ZipWriter) create(name string, method uint16, modified time.Time) (io.Writer, error) {
+	f, err := w.zw.CreateHeader(&zip.FileHeader{Name: name, Method: method, Modified: modified.UTC()})
+	if err != nil {
+		return nil, fmt.Errorf("exporter.create %s: %w", name, err)
+	}
+	return f, nil
+}
+
+// postPath names the file of a post. Slugs are unique per locale, but a slug that is not a
+// safe file name, or a clash, falls back to the post ID.
+func (w *ZipWriter) postPath(post entity.Post) string {
+	name := post.Slug
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\") {
+		name = fmt.Sprintf("post-%d", post.ID)
+	}
+	dir := PostsDir
+	if post.Locale != "" && !strings.ContainsAny(post.Locale, "/\\.") {
+		dir = path.Join(PostsDir, post.Locale)
+	}
+	p := path.Join(dir, name+".md")
+	if w.paths[p] {
+		p = path.Join(dir, fmt.Sprintf("%s-%d.md", name, post.ID))
+	}
+	w.paths[p] = true
+	return p
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
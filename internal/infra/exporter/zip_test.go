@@ -0,0 +1,150 @@
+package exporter
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeTestArchive(t *testing.T) *zip.Reader {
+	t.Helper()
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	publishAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	category, series, group := uint(4), uint(7), uint(1)
+
+	var buf bytes.Buffer
+	w := NewZipWriter(&buf)
+	steps := []error{
+		w.WriteSettings(entity.ExportSettings{SiteName: "My blog"}),
+		w.WriteUsers([]entity.User{{ID: 1, Username: "jane", Email: "jane@example.com", Password: "$2a$10$secret", Role: "admin", CreatedAt: created}}),
+		w.WriteCategories([]entity.Category{{ID: 4, Name: "News", Slug: "news"}}),
+		w.WriteTags(nil),
+		w.WriteSeries([]entity.Series{{ID: 7, Title: "Go Basics", Slug: "go-basics"}}),
+		w.WritePost(entity.Post{
+			ID: 1, Title: "Hello: world", Slug: "hello", Locale: "en", Content: "Body\n\n![a](/media/a/1/cat.png)\n",
+			CreatedAt: created, UpdatedAt: created, Status: entity.StatusScheduled, PublishAt: &publishAt,
+			Author: entity.User{Username: "jane"}, CategoryID: &category, Category: entity.Category{Name: "News"},
+			Tags: []entity.Tag{{Name: "Go"}}, NoIndex: true,
+			SeriesID: &series, SeriesPosition: 2, TranslationGroupID: &group,
+		}),
+		w.WritePost(entity.Post{ID: 2, Slug: "../escape", Status: entity.StatusPublished, CreatedAt: created}),
+		w.WriteMedia("a/1/cat.png", created, strings.NewReader("png")),
+		w.Close(entity.ExportSummary{CreatedAt: created, SiteName: "My blog", Users: 1, Categories: 1, Posts: 2, MediaFiles: 1, MediaBytes: 3}),
+	}
+	for i, err := range steps {
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return zr
+}
+
+func readEntry(t *testing.T, zr *zip.Reader, name string) string {
+	t.Helper()
+	f, err := zr.Open(name)
+	if err != nil {
+		t.Fatalf("open %s: %v", name, err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestZipWriter(t *testing.T) {
+	zr := writeTestArchive(t)
+
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	want := []string{SettingsFile, UsersFile, CategoriesFile, TagsFile, SeriesFile, "posts/en/hello.md", "posts/post-2.md", "media/a/1/cat.png", ManifestFile}
+	if strings.Join(names, " ") != strings.Join(want, " ") {
+		t.Fatalf("entries = %v, want %v", names, want)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal([]byte(readEntry(t, zr, ManifestFile)), &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Format != FormatName || manifest.Version != FormatVersion || manifest.Counts.Posts != 2 || manifest.Counts.MediaBytes != 3 {
+		t.Fatalf("manifest = %+v", manifest)
+	}
+
+	users := readEntry(t, zr, UsersFile)
+	if strings.Contains(users, "secret") || !strings.Contains(users, `"email": "jane@example.com"`) {
+		t.Fatalf("users.json = %s", users)
+	}
+	if tags := readEntry(t, zr, TagsFile); strings.TrimSpace(tags) != "[]" {
+		t.Fatalf("tags.json = %s", tags)
+	}
+	if series := readEntry(t, zr, SeriesFile); !strings.Contains(series, `"slug": "go-basics"`) || strings.Contains(series, "description") {
+		t.Fatalf("series.json = %s", series)
+	}
+
+	post := readEntry(t, zr, "posts/en/hello.md")
+	wantPost := `---
+title: 'Hello: world'
+slug: hello
+locale: en
+date: "2020-01-02T03:04:05Z"
+lastmod: "2020-01-02T03:04:05Z"
+status: scheduled
+draft: true
+publishDate: "2030-01-01T00:00:00Z"
+author: jane
+categories:
+  - News
+tags:
+  - Go
+noindex: true
+series: go-basics
+series_position: 2
+translationKey: group-1
+---
+
+Body
+
+![a](/media/a/1/cat.png)
+`
+	if post != wantPost {
+		t.Fatalf("post file:\n%s\nwant:\n%s", post, wantPost)
+	}
+	if got := readEntry(t, zr, "media/a/1/cat.png"); got != "png" {
+		t.Fatalf("media = %q", got)
+	}
+}
+
+func TestZipWriter_WriteMediaStaysInMediaDir(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewZipWriter(&buf)
+	if err := w.WriteMedia("../../etc/passwd", time.Now(), strings.NewReader("x")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteMedia("..", time.Now(), strings.NewReader("x")); err == nil {
+		t.Fatal("key without a file name accepted")
+	}
+	if err := w.Close(entity.ExportSummary{}); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zr.File[0].Name != "media/etc/passwd" {
+		t.Fatalf("entry = %q", zr.File[0].Name)
+	}
+}
@@ -0,0 +1,98 @@
+// Package importer decodes exports of other blogging systems for the import service:
+// WordPress WXR files and zips of Markdown files with YAML front matter.
+package importer
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// Decoder implements core.ImportDecoder. Assets referenced by absolute URL are downloaded
+// with its HTTP client.
+type Decoder struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+var _ core.ImportDecoder = (*Decoder)(nil)
+
+// NewDecoder creates a Decoder. A nil client gets the default one, which only connects to
+// public addresses and times out after 30s; see newAssetClient.
+func NewDecoder(client *http.Client) *Decoder {
+	if client == nil {
+		client = newAssetClient()
+	}
+	return &Decoder{client: client, now: time.Now}
+}
+
+// Decode parses the export; see decodeWXR and decodeMarkdownZip for the format details.
+func (d *Decoder) Decode(format entity.ImportFormat, r io.ReaderAt, size int64) (entity.ImportBundle, core.ImportAssets, error) {
+	switch format {
+	case entity.ImportFormatWXR:
+		return d.decodeWXR(io.NewSectionReader(r, 0, size))
+	case entity.ImportFormatMarkdown:
+		return d.decodeMarkdownZip(r, size)
+	default:
+		return entity.ImportBundle{}, nil, fmt.Errorf("%w: unsupported import format %q", core.ErrInvalidInput, format)
+	}
+}
+
+// remoteAssets downloads assets over HTTP(S). Relative references are resolved against base,
+// when the export names its site.
+type remoteAssets struct {
+	client *http.Client
+	base   *url.URL
+}
+
+func (a remoteAssets) Open(ctx context.Context, _ entity.ImportPost, ref string) (io.ReadCloser, string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: invalid asset reference %q", core.ErrInvalidInput, ref)
+	}
+	if u.Scheme == "" && u.Host != "" {
+		u.Scheme = "https"
+	}
+	if !u.IsAbs() && a.base != nil {
+		u = a.base.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, "", fmt.Errorf("%w: asset reference %q is not an http(s) URL", core.ErrInvalidInput, ref)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: invalid asset reference %q", core.ErrInvalidInput, ref)
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		// Refusals are reported as such; anything else is an internal error in the report, so
+		// dial and TLS failures do not tell the uploader what the server can reach.
+		switch {
+		case errors.Is(err, errAssetHostBlocked):
+			return nil, "", errAssetHostBlocked
+		case errors.Is(err, core.ErrInvalidInput):
+			return nil, "", fmt.Errorf("%w: asset redirect refused", core.ErrInvalidInput)
+		}
+		return nil, "", fmt.Errorf("importer.fetch %s: %w", u, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("%w: asset download answered %s", core.ErrNotFound, resp.Status)
+	}
+	return resp.Body, path.Base(u.Path), nil
+}
+
+// isRemoteRef reports whether ref is an absolute URL rather than a path.
+func isRemoteRef(ref string) bool {
+	lower := strings.ToLower(ref)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(ref, "//")
+}
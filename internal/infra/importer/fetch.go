@@ -0,0 +1,90 @@
+package importer
+
+import (
+	"KaldalisCMS/internal/core"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// maxAssetRedirects bounds how many redirects one asset download follows.
+const maxAssetRedirects = 5
+
+// errAssetHostBlocked is returned for asset URLs that resolve to a non-public address. Its text
+// goes into the import report, so it names neither the address nor the dial error.
+var errAssetHostBlocked = fmt.Errorf("%w: asset host is not a public address", core.ErrInvalidInput)
+
+// nonPublicPrefixes are the ranges that net/netip does not classify but that still must not be
+// reached from an import: shared address space, benchmarking, IETF protocol assignments,
+// reserved space, and NAT64, which can map onto any IPv4 address.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+}
+
+// newAssetClient returns the client used to download assets named in an uploaded export.
+// Every connection is checked after DNS resolution, including those opened for redirects, so
+// an export cannot make the server probe its own network. Proxies are not used because they
+// would dial on the server's behalf and bypass the check.
+func newAssetClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: rejectNonPublicAddr}
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 15 * time.Second,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: checkAssetRedirect,
+	}
+}
+
+// rejectNonPublicAddr is a net.Dialer Control hook; address is the resolved ip:port.
+func rejectNonPublicAddr(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errAssetHostBlocked
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !isPublicAddr(addr) {
+		return errAssetHostBlocked
+	}
+	return nil
+}
+
+// isPublicAddr reports whether addr is a globally routable unicast address.
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() {
+		return false
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkAssetRedirect only follows a few redirects, and only to http(s). The target address is
+// checked again by the dialer.
+func checkAssetRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxAssetRedirects {
+		return errors.New("too many redirects")
+	}
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return fmt.Errorf("%w: asset redirected to a non-http(s) URL", core.ErrInvalidInput)
+	}
+	return nil
+}
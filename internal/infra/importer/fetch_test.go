@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	cases := map[string]bool{
+		"93.184.216.34":        true,
+		"2606:4700::1111":      true,
+		"127.0.0.1":            false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"100.64.0.1":           false,
+		"0.0.0.0":              false,
+		"255.255.255.255":      false,
+		"::1":                  false,
+		"fd00::1":              false,
+		"fe80::1":              false,
+		"::ffff:127.0.0.1":     false,
+		"64:ff9b::a00:1":       false,
+		"::ffff:93.184.216.34": true,
+	}
+	for raw, want := range cases {
+		if got := isPublicAddr(netip.MustParseAddr(raw)); got != want {
+			t.Errorf("isPublicAddr(%s) = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestRemoteAssets_Open_RefusesNonPublicHosts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "internal")
+	}))
+	defer srv.Close()
+
+	const data = `<rss><channel><link>https://old.example.com</link></channel></rss>`
+	_, assets, err := NewDecoder(nil).Decode(entity.ImportFormatWXR, strings.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ref := range []string{srv.URL + "/a.png", strings.Replace(srv.URL, "127.0.0.1", "localhost", 1) + "/a.png"} {
+		_, _, err := assets.Open(context.Background(), entity.ImportPost{}, ref)
+		if !errors.Is(err, errAssetHostBlocked) {
+			t.Fatalf("%s: want errAssetHostBlocked, got %v", ref, err)
+		}
+		if strings.Contains(err.Error(), "127.0.0.1") {
+			t.Fatalf("error names the address: %v", err)
+		}
+	}
+}
+
+func TestCheckAssetRedirect(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://cdn.example.com/a.png", nil)
+	if err := checkAssetRedirect(req, make([]*http.Request, maxAssetRedirects-1)); err != nil {
+		t.Fatalf("redirect refused: %v", err)
+	}
+	if err := checkAssetRedirect(req, make([]*http.Request, maxAssetRedirects)); err == nil {
+		t.Fatal("redirect chain not bounded")
+	}
+	req.URL.Scheme = "ftp"
+	if err := checkAssetRedirect(req, nil); err == nil {
+		t.Fatal("redirect to ftp followed")
+	}
+}
@@ -0,0 +1,383 @@
+package importer
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/exporter"
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+	"gopkg.in/yaml.v3"
+)
+
+// maxMarkdownBytes bounds a single Markdown file read from an archive.
+const maxMarkdownBytes = 16 << 20
+
+// frontMatter holds the keys Hugo, Jekyll and similar generators commonly write.
+type frontMatter struct {
+	Title       string     `yaml:"title"`
+	Slug        string     `yaml:"slug"`
+	Date        flexTime   `yaml:"date"`
+	PublishDate flexTime   `yaml:"publishDate"`
+	Updated     flexTime   `yaml:"updated"`
+	LastMod     flexTime   `yaml:"lastmod"`
+	Draft       bool       `yaml:"draft"`
+	Published   *bool      `yaml:"published"`
+	Author      stringList `yaml:"author"`
+	Authors     stringList `yaml:"authors"`
+	Category    stringList `yaml:"category"`
+	Categories  stringList `yaml:"categories"`
+	Tags        stringList `yaml:"tags"`
+	Cover       string     `yaml:"cover"`
+	Image       string     `yaml:"image"`
+	Locale      string     `yaml:"locale"`
+	Lang        string     `yaml:"lang"`
+	// The keys below are written by the exporter.
+	Status           string   `yaml:"status"`
+	ExpiryDate       flexTime `yaml:"expiryDate"`
+	NoIndex          bool     `yaml:"noindex"`
+	CommentsDisabled bool     `yaml:"comments_disabled"`
+	// Hugo sites often keep series as a taxonomy, i.e. a list of names; the first one is used.
+	Series         stringList `yaml:"series"`
+	SeriesPosition int        `yaml:"series_position"`
+	TranslationKey string     `yaml:"translationKey"`
+}
+
+// flexTime accepts the date formats static site generators write, with or without time
+// and zone, e.g. "2021-03-04", "2021-03-04T05:06:07Z" or Jekyll's "2021-03-04 05:06:07 +0800".
+type flexTime struct{ time.Time }
+
+var flexTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+func (t *flexTime) UnmarshalYAML(node *yaml.Node) error {
+	v := strings.TrimSpace(node.Value)
+	if v == "" {
+		return nil
+	}
+	for _, layout := range flexTimeLayouts {
+		if parsed, err := time.Parse(layout, v); err == nil {
+			t.Time = parsed.UTC()
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid date %q", v)
+}
+
+// stringList accepts a single string or a list of strings.
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if v := strings.TrimSpace(node.Value); v != "" {
+			*l = stringList{v}
+		}
+		return nil
+	case yaml.SequenceNode:
+		var items []string
+		if err := node.Decode(&items); err != nil {
+			return err
+		}
+		for _, v := range items {
+			if v = strings.TrimSpace(v); v != "" {
+				*l = append(*l, v)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("expected a string or a list of strings")
+	}
+}
+
+// jekyllDatePrefix matches the date Jekyll puts in front of post file names.
+var jekyllDatePrefix = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-`)
+
+// decodeMarkdownZip reads a zip of .md/.markdown files. Each file is one post; its YAML front
+// matter is optional. Without a slug the file name is used (the directory name for Hugo's
+// index.md bundles), and without a date the date prefix of a Jekyll file name or the
+// file's modification time. Drafts stay drafts and future dates become scheduled posts.
+// Relative image references are resolved inside the archive; absolute URLs are downloaded.
+func (d *Decoder) decodeMarkdownZip(r io.ReaderAt, size int64) (entity.ImportBundle, core.ImportAssets, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return entity.ImportBundle{}, nil, fmt.Errorf("%w: invalid zip archive: %v", core.ErrInvalidInput, err)
+	}
+
+	assets := zipAssets{files: make(map[string]*zip.File), remote: remoteAssets{client: d.client}}
+	var docs []*zip.File
+	for _, f := range zr.File {
+		name := path.Clean(strings.TrimPrefix(f.Name, "/"))
+		if f.FileInfo().IsDir() || skipArchivePath(name) {
+			continue
+		}
+		assets.files[name] = f
+		if ext := strings.ToLower(path.Ext(name)); ext == ".md" || ext == ".markdown" {
+			docs = append(docs, f)
+		}
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Name < docs[j].Name })
+
+	var bundle entity.ImportBundle
+	authors := make(map[string]bool)
+	categories := make(map[string]bool)
+	tags := make(map[string]bool)
+	series := make(map[string]bool)
+	export, err := readSiteExport(assets.files)
+	if err != nil {
+		return entity.ImportBundle{}, nil, err
+	}
+	if export != nil {
+		bundle.Authors, bundle.Categories, bundle.Tags, bundle.Series = export.authors, export.categories, export.tags, export.series
+		for _, a := range export.authors {
+			authors[a.Login] = true
+		}
+		for _, c := range export.categories {
+			categories[c.Slug] = true
+		}
+		for _, t := range export.tags {
+			tags[t.Slug] = true
+		}
+		for _, s := range export.series {
+			series[s.Slug] = true
+		}
+	}
+
+	now := d.now()
+	for _, f := range docs {
+		post, err := d.decodeMarkdownFile(f, now)
+		if err != nil {
+			return entity.ImportBundle{}, nil, err
+		}
+		if export != nil {
+			restoreSlugs(post.Categories, export.categories)
+			restoreSlugs(post.Tags, export.tags)
+			restoreSeries(post.Series, export.series)
+		}
+		if post.Author != "" && !authors[post.Author] {
+			authors[post.Author] = true
+			bundle.Authors = append(bundle.Authors, entity.ImportAuthor{Login: post.Author})
+		}
+		for _, c := range post.Categories {
+			if !categories[c.Slug] {
+				categories[c.Slug] = true
+				bundle.Categories = append(bundle.Categories, c)
+			}
+		}
+		for _, t := range post.Tags {
+			if !tags[t.Slug] {
+				tags[t.Slug] = true
+				bundle.Tags = append(bundle.Tags, t)
+			}
+		}
+		if post.Series != nil && !series[post.Series.Slug] {
+			series[post.Series.Slug] = true
+			bundle.Series = append(bundle.Series, *post.Series)
+		}
+		bundle.Posts = append(bundle.Posts, post)
+	}
+	return bundle, assets, nil
+}
+
+func (d *Decoder) decodeMarkdownFile(f *zip.File, now time.Time) (entity.ImportPost, error) {
+	name := path.Clean(strings.TrimPrefix(f.Name, "/"))
+	if f.UncompressedSize64 > maxMarkdownBytes {
+		return entity.ImportPost{}, fmt.Errorf("%w: %s is larger than %d bytes", core.ErrInvalidInput, name, maxMarkdownBytes)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return entity.ImportPost{}, fmt.Errorf("%w: open %s: %v", core.ErrInvalidInput, name, err)
+	}
+	raw, err := io.ReadAll(io.LimitReader(rc, maxMarkdownBytes+1))
+	rc.Close()
+	if err != nil {
+		return entity.ImportPost{}, fmt.Errorf("%w: read %s: %v", core.ErrInvalidInput, name, err)
+	}
+	if len(raw) > maxMarkdownBytes {
+		return entity.ImportPost{}, fmt.Errorf("%w: %s is larger than %d bytes", core.ErrInvalidInput, name, maxMarkdownBytes)
+	}
+
+	var fm frontMatter
+	header, body := splitFrontMatter(raw)
+	if header != nil {
+		if err := yaml.Unmarshal(header, &fm); err != nil {
+			return entity.ImportPost{}, fmt.Errorf("%w: front matter of %s: %v", core.ErrInvalidInput, name, err)
+		}
+	}
+
+	stem := strings.TrimSuffix(path.Base(name), path.Ext(name))
+	if strings.EqualFold(stem, "index") && path.Dir(name) != "." {
+		stem = path.Base(path.Dir(name))
+	}
+	var fileDate time.Time
+	if m := jekyllDatePrefix.FindStringSubmatch(stem); m != nil {
+		fileDate, _ = time.Parse("2006-01-02", m[1])
+		stem = strings.TrimPrefix(stem, m[0])
+	}
+
+	post := entity.ImportPost{
+		Source:  name,
+		Title:   strings.TrimSpace(fm.Title),
+		Slug:    strings.TrimSpace(fm.Slug),
+		Content: strings.TrimLeft(string(body), "\r\n"),
+		Cover:   firstNonEmpty(fm.Cover, fm.Image),
+		Locale:  firstNonEmpty(fm.Locale, fm.Lang),
+		Status:  entity.StatusPublished,
+	}
+	if post.Slug == "" {
+		post.Slug = stem
+	}
+	if post.Title == "" {
+		post.Title = stem
+	}
+	if names := append(fm.Author, fm.Authors...); len(names) > 0 {
+		post.Author = names[0]
+	}
+
+	post.CreatedAt = firstTime(fm.Date.Time, fm.PublishDate.Time, fileDate, f.Modified.UTC(), now)
+	post.UpdatedAt = firstTime(fm.LastMod.Time, fm.Updated.Time, post.CreatedAt)
+	status, known := exporter.ParsePostStatus(strings.TrimSpace(fm.Status))
+	switch {
+	case known:
+		post.Status = status
+		if status == entity.StatusScheduled {
+			at := firstTime(fm.PublishDate.Time, post.CreatedAt)
+			post.PublishAt = &at
+		}
+	case fm.Draft || (fm.Published != nil && !*fm.Published):
+		post.Status = entity.StatusDraft
+	case post.CreatedAt.After(now):
+		post.Status = entity.StatusScheduled
+		at := post.CreatedAt
+		post.PublishAt = &at
+	}
+
+	if !fm.ExpiryDate.IsZero() {
+		at := fm.ExpiryDate.Time
+		post.UnpublishAt = &at
+	}
+	post.NoIndex = fm.NoIndex
+	post.CommentsDisabled = fm.CommentsDisabled
+
+	for _, c := range append(fm.Category, fm.Categories...) {
+		post.Categories = append(post.Categories, entity.ImportTerm{Name: c, Slug: slug.Make(c)})
+	}
+	for _, t := range fm.Tags {
+		post.Tags = append(post.Tags, entity.ImportTerm{Name: t, Slug: slug.Make(t)})
+	}
+	if len(fm.Series) > 0 {
+		post.Series = &entity.ImportSeries{Title: fm.Series[0], Slug: slug.Make(fm.Series[0])}
+		post.SeriesPosition = max(fm.SeriesPosition, 0)
+	}
+	post.TranslationKey = strings.TrimSpace(fm.TranslationKey)
+	return post, nil
+}
+
+// splitFrontMatter separates a leading "---" delimited YAML block from the body. header is
+// nil when the file has no front matter.
+func splitFrontMatter(raw []byte) (header []byte, body []byte) {
+	raw = bytes.TrimPrefix(raw, []byte("\ufeff"))
+	if !bytes.HasPrefix(raw, []byte("---\n")) && !bytes.HasPrefix(raw, []byte("---\r\n")) {
+		return nil, raw
+	}
+	rest := raw[bytes.IndexByte(raw, '\n')+1:]
+	for offset := 0; offset < len(rest); {
+		end := bytes.IndexByte(rest[offset:], '\n')
+		line := rest[offset:]
+		if end >= 0 {
+			line = rest[offset : offset+end]
+		}
+		if trimmed := bytes.TrimRight(line, "\r"); bytes.Equal(trimmed, []byte("---")) || bytes.Equal(trimmed, []byte("...")) {
+			if end < 0 {
+				return rest[:offset], nil
+			}
+			return rest[:offset], rest[offset+end+1:]
+		}
+		if end < 0 {
+			break
+		}
+		offset += end + 1
+	}
+	return nil, raw
+}
+
+// skipArchivePath leaves out the metadata macOS and editors add to archives.
+func skipArchivePath(name string) bool {
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") || part == "__MACOSX" {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func firstTime(values ...time.Time) time.Time {
+	for _, v := range values {
+		if !v.IsZero() {
+			return v
+		}
+	}
+	return time.Time{}
+}
+
+// zipAssets serves files from the archive and downloads absolute URLs.
+type zipAssets struct {
+	files  map[string]*zip.File
+	remote remoteAssets
+}
+
+// Open looks a relative reference up next to the post, and a root-relative one at the archive
+// root and under static/, where Hugo keeps site-wide files.
+func (a zipAssets) Open(ctx context.Context, post entity.ImportPost, ref string) (io.ReadCloser, string, error) {
+	if isRemoteRef(ref) {
+		return a.remote.Open(ctx, post, ref)
+	}
+	u, err := url.Parse(ref)
+	if err != nil || u.Path == "" {
+		return nil, "", fmt.Errorf("%w: invalid asset reference %q", core.ErrInvalidInput, ref)
+	}
+
+	var candidates []string
+	if strings.HasPrefix(u.Path, "/") {
+		root := strings.TrimPrefix(path.Clean(u.Path), "/")
+		candidates = []string{root, path.Join("static", root)}
+	} else {
+		candidates = []string{path.Join(path.Dir(post.Source), u.Path)}
+	}
+	for _, name := range candidates {
+		if f, ok := a.files[name]; ok {
+			rc, err := f.Open()
+			if err != nil {
+				return nil, "", fmt.Errorf("%w: open %s: %v", core.ErrInvalidInput, name, err)
+			}
+			return rc, path.Base(name), nil
+		}
+	}
+	return nil, "", fmt.Errorf("%w: %s is not in the archive", core.ErrNotFound, ref)
+}
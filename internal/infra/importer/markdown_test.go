@@ -0,0 +1,118 @@
+package importer
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func markdownZip(t *testing.T, files map[string]string) *bytes.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Date(2020, 2, 2, 0, 0, 0, 0, time.UTC)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(w, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestDecodeMarkdownZip(t *testing.T) {
+	r := markdownZip(t, map[string]string{
+		"posts/2018-07-01-first-post.md": "\ufeff---\r\ntitle: First post\r\nauthor: jane\r\ntags: [Go, Web Dev]\r\ncategory: News\r\n---\r\n\r\nBody ![a](img/a.png)\r\n",
+		"posts/img/a.png":                "png",
+		"content/trip/index.md":          "---\ntitle: Trip\ndate: 2019-04-05T06:07:08Z\nlastmod: 2019-05-01\ndraft: true\nauthors: [bob, jane]\ncategories:\n  - Travel\n  - News\nimage: /images/cover.jpg\nlang: fr\nseries: [Road Trips]\ntranslationKey: trip\n---\nText\n",
+		"static/images/cover.jpg":        "jpg",
+		"future.markdown":                "---\ndate: 2999-01-01\n---\nSoon\n",
+		"plain.md":                       "No front matter\n",
+		"__MACOSX/._plain.md":            "junk",
+		".hidden/notes.md":               "skip me",
+		"README.txt":                     "not a post",
+	})
+	d := NewDecoder(nil)
+	d.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	bundle, assets, err := d.Decode(entity.ImportFormatMarkdown, r, r.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bundle.Posts) != 4 {
+		t.Fatalf("want 4 posts, got %d: %+v", len(bundle.Posts), bundle.Posts)
+	}
+	// Posts are sorted by path.
+	trip, future, plain, first := bundle.Posts[0], bundle.Posts[1], bundle.Posts[2], bundle.Posts[3]
+
+	if first.Slug != "first-post" || first.Title != "First post" || first.Author != "jane" || first.Status != entity.StatusPublished {
+		t.Fatalf("first = %+v", first)
+	}
+	if !first.CreatedAt.Equal(time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)) || first.Content != "Body ![a](img/a.png)\r\n" {
+		t.Fatalf("first date/content = %v %q", first.CreatedAt, first.Content)
+	}
+	if len(first.Tags) != 2 || first.Tags[1] != (entity.ImportTerm{Name: "Web Dev", Slug: "web-dev"}) || first.Categories[0].Slug != "news" {
+		t.Fatalf("first terms = %+v %+v", first.Tags, first.Categories)
+	}
+
+	if trip.Slug != "trip" || trip.Status != entity.StatusDraft || trip.Author != "bob" || trip.Locale != "fr" || trip.Cover != "/images/cover.jpg" {
+		t.Fatalf("trip = %+v", trip)
+	}
+	if !trip.CreatedAt.Equal(time.Date(2019, 4, 5, 6, 7, 8, 0, time.UTC)) || !trip.UpdatedAt.Equal(time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("trip dates = %v %v", trip.CreatedAt, trip.UpdatedAt)
+	}
+	if trip.Series == nil || *trip.Series != (entity.ImportSeries{Title: "Road Trips", Slug: "road-trips"}) || trip.SeriesPosition != 0 || trip.TranslationKey != "trip" {
+		t.Fatalf("trip series = %+v at %d, translation key %q", trip.Series, trip.SeriesPosition, trip.TranslationKey)
+	}
+	if future.Status != entity.StatusScheduled || future.PublishAt == nil || future.Slug != "future" {
+		t.Fatalf("future = %+v", future)
+	}
+	if plain.Title != "plain" || plain.Content != "No front matter\n" || !plain.CreatedAt.Equal(time.Date(2020, 2, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("plain = %+v", plain)
+	}
+
+	if len(bundle.Authors) != 2 || len(bundle.Categories) != 2 || len(bundle.Tags) != 2 || len(bundle.Series) != 1 {
+		t.Fatalf("bundle terms not de-duplicated: %+v %+v %+v %+v", bundle.Authors, bundle.Categories, bundle.Tags, bundle.Series)
+	}
+
+	for _, tc := range []struct {
+		post entity.ImportPost
+		ref  string
+		want string
+	}{
+		{first, "img/a.png", "png"},
+		{trip, "/images/cover.jpg", "jpg"},
+	} {
+		rc, _, err := assets.Open(context.Background(), tc.post, tc.ref)
+		if err != nil {
+			t.Fatalf("open %s: %v", tc.ref, err)
+		}
+		body, _ := io.ReadAll(rc)
+		rc.Close()
+		if string(body) != tc.want {
+			t.Fatalf("open %s = %q", tc.ref, body)
+		}
+	}
+	if _, _, err := assets.Open(context.Background(), first, "img/missing.png"); !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("missing asset: want ErrNotFound, got %v", err)
+	}
+}
+
+func TestDecodeMarkdownZip_InvalidFrontMatter(t *testing.T) {
+	r := markdownZip(t, map[string]string{"bad.md": "---\ntitle: [unterminated\n---\n"})
+	_, _, err := NewDecoder(nil).Decode(entity.ImportFormatMarkdown, r, r.Size())
+	if !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("want ErrInvalidInput, got %v", err)
+	}
+}
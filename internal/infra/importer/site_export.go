@@ -0,0 +1,114 @@
+package importer
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/exporter"
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// maxSiteExportJSONBytes bounds the JSON files of a site export read into memory.
+const maxSiteExportJSONBytes = 64 << 20
+
+// siteExport is what an archive written by the exporter adds to its Markdown files: the
+// accounts behind the author names, the terms with their original slugs, including terms no
+// post uses, and the series.
+type siteExport struct {
+	authors    []entity.ImportAuthor
+	categories []entity.ImportTerm
+	tags       []entity.ImportTerm
+	series     []entity.ImportSeries
+}
+
+// readSiteExport returns nil when the archive has no export manifest. Passwords and roles are
+// not restored: imported accounts get a random password and the user role.
+func readSiteExport(files map[string]*zip.File) (*siteExport, error) {
+	f, ok := files[exporter.ManifestFile]
+	if !ok {
+		return nil, nil
+	}
+	var manifest exporter.Manifest
+	if err := readZipJSON(f, &manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Format != exporter.FormatName {
+		return nil, nil
+	}
+	if manifest.Version < 1 || manifest.Version > exporter.FormatVersion {
+		return nil, fmt.Errorf("%w: export format version %d is not supported (up to %d)", core.ErrInvalidInput, manifest.Version, exporter.FormatVersion)
+	}
+
+	export := &siteExport{}
+	var users []exporter.User
+	var categories, tags []exporter.Term
+	var series []exporter.Series
+	for _, part := range []struct {
+		name string
+		v    any
+	}{{exporter.UsersFile, &users}, {exporter.CategoriesFile, &categories}, {exporter.TagsFile, &tags}, {exporter.SeriesFile, &series}} {
+		if f, ok := files[part.name]; ok {
+			if err := readZipJSON(f, part.v); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, u := range users {
+		if u.Username != "" {
+			export.authors = append(export.authors, entity.ImportAuthor{Login: u.Username, Email: u.Email})
+		}
+	}
+	for _, c := range categories {
+		export.categories = append(export.categories, entity.ImportTerm{Name: c.Name, Slug: c.Slug})
+	}
+	for _, t := range tags {
+		export.tags = append(export.tags, entity.ImportTerm{Name: t.Name, Slug: t.Slug})
+	}
+	for _, s := range series {
+		export.series = append(export.series, entity.ImportSeries{Title: s.Title, Slug: s.Slug, Description: s.Description})
+	}
+	return export, nil
+}
+
+// restoreSlugs gives terms named in front matter the slug the export lists for that name.
+func restoreSlugs(terms []entity.ImportTerm, exported []entity.ImportTerm) {
+	for i := range terms {
+		for _, e := range exported {
+			if e.Name == terms[i].Name {
+				terms[i].Slug = e.Slug
+				break
+			}
+		}
+	}
+}
+
+// restoreSeries replaces the series named in front matter, by slug or title, with the one the
+// export lists.
+func restoreSeries(series *entity.ImportSeries, exported []entity.ImportSeries) {
+	if series == nil {
+		return
+	}
+	for _, e := range exported {
+		if e.Slug == series.Slug || e.Title == series.Title {
+			*series = e
+			return
+		}
+	}
+}
+
+func readZipJSON(f *zip.File, v any) error {
+	if f.UncompressedSize64 > maxSiteExportJSONBytes {
+		return fmt.Errorf("%w: %s is larger than %d bytes", core.ErrInvalidInput, f.Name, maxSiteExportJSONBytes)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: open %s: %v", core.ErrInvalidInput, f.Name, err)
+	}
+	defer rc.Close()
+	if err := json.NewDecoder(io.LimitReader(rc, maxSiteExportJSONBytes)).Decode(v); err != nil {
+		return fmt.Errorf("%w: %s: %v", core.ErrInvalidInput, f.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package importer
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/exporter"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeMarkdownZip_SiteExport(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	publishAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	unpublishAt := time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)
+	category, series, group := uint(4), uint(3), uint(1)
+
+	var buf bytes.Buffer
+	w := exporter.NewZipWriter(&buf)
+	for i, err := range []error{
+		w.WriteSettings(entity.ExportSettings{SiteName: "My blog"}),
+		w.WriteUsers([]entity.User{{Username: "jane", Email: "jane@example.com", Role: "admin"}}),
+		w.WriteCategories([]entity.Category{{Name: "Release notes", Slug: "changelog"}, {Name: "Unused", Slug: "unused"}}),
+		w.WriteTags([]entity.Tag{{Name: "Go", Slug: "golang"}}),
+		w.WriteSeries([]entity.Series{{ID: 3, Title: "Go Basics", Slug: "basics", Description: "From zero"}, {ID: 5, Title: "Empty", Slug: "empty"}}),
+		w.WritePost(entity.Post{
+			ID: 1, Title: "Soon", Slug: "soon", Locale: "en", Content: "![a](/media/a/1/cat.png)\n",
+			CreatedAt: created, UpdatedAt: created.Add(time.Hour), Status: entity.StatusScheduled, PublishAt: &publishAt,
+			UnpublishAt: &unpublishAt, Author: entity.User{Username: "jane"}, CategoryID: &category,
+			Category: entity.Category{Name: "Release notes"}, Tags: []entity.Tag{{Name: "Go"}}, NoIndex: true, CommentsDisabled: true,
+			SeriesID: &series, SeriesPosition: 2, TranslationGroupID: &group,
+		}),
+		w.WritePost(entity.Post{ID: 2, Title: "Waiting", Slug: "waiting", Locale: "en", CreatedAt: created, Status: entity.StatusPendingReview}),
+		w.WriteMedia("a/1/cat.png", created, strings.NewReader("png")),
+		w.Close(entity.ExportSummary{CreatedAt: created}),
+	} {
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+
+	r := bytes.NewReader(buf.Bytes())
+	bundle, assets, err := NewDecoder(nil).Decode(entity.ImportFormatMarkdown, r, r.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bundle.Authors) != 1 || bundle.Authors[0] != (entity.ImportAuthor{Login: "jane", Email: "jane@example.com"}) {
+		t.Fatalf("authors = %+v", bundle.Authors)
+	}
+	if len(bundle.Categories) != 2 || bundle.Categories[1].Slug != "unused" || len(bundle.Tags) != 1 {
+		t.Fatalf("terms = %+v %+v", bundle.Categories, bundle.Tags)
+	}
+	if len(bundle.Posts) != 2 {
+		t.Fatalf("posts = %+v", bundle.Posts)
+	}
+
+	soon := bundle.Posts[0]
+	if soon.Slug != "soon" || soon.Locale != "en" || soon.Author != "jane" || soon.Status != entity.StatusScheduled {
+		t.Fatalf("post = %+v", soon)
+	}
+	if !soon.CreatedAt.Equal(created) || !soon.UpdatedAt.Equal(created.Add(time.Hour)) ||
+		soon.PublishAt == nil || !soon.PublishAt.Equal(publishAt) || soon.UnpublishAt == nil || !soon.UnpublishAt.Equal(unpublishAt) {
+		t.Fatalf("dates = %v %v %v %v", soon.CreatedAt, soon.UpdatedAt, soon.PublishAt, soon.UnpublishAt)
+	}
+	if !soon.NoIndex || !soon.CommentsDisabled || soon.Content != "![a](/media/a/1/cat.png)\n" {
+		t.Fatalf("post = %+v", soon)
+	}
+	if soon.Categories[0] != (entity.ImportTerm{Name: "Release notes", Slug: "changelog"}) || soon.Tags[0].Slug != "golang" {
+		t.Fatalf("term slugs not restored: %+v %+v", soon.Categories, soon.Tags)
+	}
+	if len(bundle.Series) != 2 || soon.Series == nil || *soon.Series != bundle.Series[0] || bundle.Series[0].Description != "From zero" {
+		t.Fatalf("series = %+v, post series %+v", bundle.Series, soon.Series)
+	}
+	if soon.SeriesPosition != 2 || soon.TranslationKey != "group-1" || bundle.Posts[1].Series != nil || bundle.Posts[1].TranslationKey != "" {
+		t.Fatalf("series position %d, translation keys %q %q", soon.SeriesPosition, soon.TranslationKey, bundle.Posts[1].TranslationKey)
+	}
+	if bundle.Posts[1].Status != entity.StatusPendingReview {
+		t.Fatalf("pending review post = %+v", bundle.Posts[1])
+	}
+
+	rc, name, err := assets.Open(context.Background(), soon, "/media/a/1/cat.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if name != "cat.png" || string(data) != "png" {
+		t.Fatalf("media = %q %q", name, data)
+	}
+}
+
+func TestDecodeMarkdownZip_NewerSiteExport(t *testing.T) {
+	r := markdownZip(t, map[string]string{
+		exporter.ManifestFile: `{"format":"kaldalis-export","version":99}`,
+		"posts/a.md":          "---\ntitle: A\n---\n",
+	})
+	_, _, err := NewDecoder(nil).Decode(entity.ImportFormatMarkdown, r, r.Size())
+	if !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("want ErrInvalidInput, got %v", err)
+	}
+
+	// A manifest.json of some other tool is just another file.
+	r = markdownZip(t, map[string]string{
+		exporter.ManifestFile: `{"name":"theme"}`,
+		"posts/a.md":          "---\ntitle: A\n---\n",
+	})
+	if bundle, _, err := NewDecoder(nil).Decode(entity.ImportFormatMarkdown, r, r.Size()); err != nil || len(bundle.Posts) != 1 {
+		t.Fatalf("got %+v, %v", bundle, err)
+	}
+}
@@ -0,0 +1,203 @@
+package importer
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// wxrDateLayout is how WordPress writes post_date and post_date_gmt.
+const wxrDateLayout = "2006-01-02 15:04:05"
+
+type wxrFile struct {
+	Channel wxrChannel `xml:"channel"`
+}
+
+type wxrChannel struct {
+	Link        string        `xml:"link"`
+	BaseSiteURL string        `xml:"base_site_url"`
+	Language    string        `xml:"language"`
+	Authors     []wxrAuthor   `xml:"author"`
+	Categories  []wxrCategory `xml:"category"`
+	Tags        []wxrTag      `xml:"tag"`
+	Items       []wxrItem     `xml:"item"`
+}
+
+type wxrAuthor struct {
+	Login       string `xml:"author_login"`
+	Email       string `xml:"author_email"`
+	DisplayName string `xml:"author_display_name"`
+}
+
+type wxrCategory struct {
+	Nicename string `xml:"category_nicename"`
+	Name     string `xml:"cat_name"`
+}
+
+type wxrTag struct {
+	Slug string `xml:"tag_slug"`
+	Name string `xml:"tag_name"`
+}
+
+// Elements are matched by local name so every WXR version (1.0 to 1.2) decodes alike; only
+// content:encoded names its namespace, to tell it apart from excerpt:encoded.
+type wxrItem struct {
+	Title         string        `xml:"title"`
+	Creator       string        `xml:"creator"`
+	Content       string        `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PostID        string        `xml:"post_id"`
+	PostDate      string        `xml:"post_date"`
+	PostDateGMT   string        `xml:"post_date_gmt"`
+	ModifiedGMT   string        `xml:"post_modified_gmt"`
+	PostName      string        `xml:"post_name"`
+	Status        string        `xml:"status"`
+	PostType      string        `xml:"post_type"`
+	AttachmentURL string        `xml:"attachment_url"`
+	Terms         []wxrItemTerm `xml:"category"`
+	Meta          []wxrPostMeta `xml:"postmeta"`
+}
+
+type wxrItemTerm struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type wxrPostMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+// decodeWXR reads a WordPress export. Only items of type post are imported; attachments are
+// used to resolve featured images, and pages, menus, revisions and trashed posts are left out.
+// Media stays on the old site and is downloaded from there.
+func (d *Decoder) decodeWXR(r io.Reader) (entity.ImportBundle, core.ImportAssets, error) {
+	var file wxrFile
+	dec := xml.NewDecoder(r)
+	// WordPress declares UTF-8 but exports from old installs are sometimes labelled otherwise.
+	dec.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+	if err := dec.Decode(&file); err != nil {
+		return entity.ImportBundle{}, nil, fmt.Errorf("%w: invalid WXR file: %v", core.ErrInvalidInput, err)
+	}
+	ch := file.Channel
+
+	var bundle entity.ImportBundle
+	for _, a := range ch.Authors {
+		if login := strings.TrimSpace(a.Login); login != "" {
+			bundle.Authors = append(bundle.Authors, entity.ImportAuthor{Login: login, Email: strings.TrimSpace(a.Email), DisplayName: a.DisplayName})
+		}
+	}
+	for _, c := range ch.Categories {
+		if c.Name != "" || c.Nicename != "" {
+			bundle.Categories = append(bundle.Categories, entity.ImportTerm{Name: strings.TrimSpace(c.Name), Slug: unescapeSlug(c.Nicename)})
+		}
+	}
+	for _, t := range ch.Tags {
+		if t.Name != "" || t.Slug != "" {
+			bundle.Tags = append(bundle.Tags, entity.ImportTerm{Name: strings.TrimSpace(t.Name), Slug: unescapeSlug(t.Slug)})
+		}
+	}
+
+	attachments := make(map[string]string)
+	for _, item := range ch.Items {
+		if item.PostType == "attachment" && item.AttachmentURL != "" {
+			attachments[item.PostID] = strings.TrimSpace(item.AttachmentURL)
+		}
+	}
+
+	now := d.now()
+	for _, item := range ch.Items {
+		if item.PostType != "post" {
+			continue
+		}
+		status, ok := wxrStatus(item.Status)
+		if !ok {
+			continue
+		}
+
+		post := entity.ImportPost{
+			Source:  "post " + item.PostID,
+			Title:   strings.TrimSpace(item.Title),
+			Slug:    unescapeSlug(item.PostName),
+			Content: item.Content,
+			Author:  strings.TrimSpace(item.Creator),
+			Locale:  strings.TrimSpace(ch.Language),
+			Status:  status,
+		}
+		post.CreatedAt = wxrDate(item.PostDateGMT, item.PostDate, now)
+		post.UpdatedAt = wxrDate(item.ModifiedGMT, "", post.CreatedAt)
+		if post.Status == entity.StatusScheduled {
+			at := post.CreatedAt
+			post.PublishAt = &at
+		}
+		for _, meta := range item.Meta {
+			if meta.Key == "_thumbnail_id" {
+				post.Cover = attachments[strings.TrimSpace(meta.Value)]
+			}
+		}
+		for _, term := range item.Terms {
+			t := entity.ImportTerm{Name: strings.TrimSpace(term.Name), Slug: unescapeSlug(term.Nicename)}
+			switch term.Domain {
+			case "category":
+				post.Categories = append(post.Categories, t)
+			case "post_tag":
+				post.Tags = append(post.Tags, t)
+			}
+		}
+		bundle.Posts = append(bundle.Posts, post)
+	}
+
+	assets := remoteAssets{client: d.client}
+	for _, site := range []string{ch.BaseSiteURL, ch.Link} {
+		if u, err := url.Parse(strings.TrimSpace(site)); err == nil && u.IsAbs() {
+			assets.base = u
+			break
+		}
+	}
+	return bundle, assets, nil
+}
+
+// wxrStatus maps a WordPress post status; ok is false for posts that are not imported.
+func wxrStatus(status string) (int, bool) {
+	switch status {
+	case "publish":
+		return entity.StatusPublished, true
+	case "future":
+		return entity.StatusScheduled, true
+	case "pending":
+		return entity.StatusPendingReview, true
+	case "draft", "private":
+		return entity.StatusDraft, true
+	default: // trash, auto-draft, inherit
+		return 0, false
+	}
+}
+
+// wxrDate parses the GMT date, then the site-local one (read as UTC), falling back to
+// fallback. Unpublished drafts carry a zero GMT date.
+func wxrDate(gmt string, local string, fallback time.Time) time.Time {
+	for _, v := range []string{gmt, local} {
+		v = strings.TrimSpace(v)
+		if v == "" || strings.HasPrefix(v, "0000-00-00") {
+			continue
+		}
+		if t, err := time.Parse(wxrDateLayout, v); err == nil {
+			return t
+		}
+	}
+	return fallback
+}
+
+// unescapeSlug undoes the percent-encoding WordPress applies to non-ASCII slugs.
+func unescapeSlug(s string) string {
+	s = strings.TrimSpace(s)
+	if decoded, err := url.PathUnescape(s); err == nil {
+		return decoded
+	}
+	return s
+}
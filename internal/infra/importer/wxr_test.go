@@ -0,0 +1,180 @@
+package importer
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleWXR = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0"
+	xmlns:excerpt="http://wordpress.org/export/1.2/excerpt/"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<title>Old blog</title>
+	<link>https://old.example.com</link>
+	<language>de-DE</language>
+	<wp:base_site_url>https://old.example.com</wp:base_site_url>
+	<wp:author><wp:author_login><![CDATA[jane]]></wp:author_login><wp:author_email><![CDATA[jane@example.com]]></wp:author_email><wp:author_display_name><![CDATA[Jane]]></wp:author_display_name></wp:author>
+	<wp:category><wp:category_nicename><![CDATA[news]]></wp:category_nicename><wp:cat_name><![CDATA[News]]></wp:cat_name></wp:category>
+	<wp:tag><wp:tag_slug><![CDATA[go]]></wp:tag_slug><wp:tag_name><![CDATA[Go]]></wp:tag_name></wp:tag>
+	<item>
+		<title>Café</title>
+		<dc:creator><![CDATA[jane]]></dc:creator>
+		<content:encoded><![CDATA[<p>Hello <img src="/wp-content/uploads/a.png"></p>]]></content:encoded>
+		<excerpt:encoded><![CDATA[Not the content]]></excerpt:encoded>
+		<wp:post_id>10</wp:post_id>
+		<wp:post_date><![CDATA[2015-03-04 12:00:00]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[2015-03-04 11:00:00]]></wp:post_date_gmt>
+		<wp:post_modified_gmt><![CDATA[2016-01-01 00:00:00]]></wp:post_modified_gmt>
+		<wp:post_name><![CDATA[caf%c3%a9]]></wp:post_name>
+		<wp:status><![CDATA[publish]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+		<category domain="category" nicename="news"><![CDATA[News]]></category>
+		<category domain="post_tag" nicename="go"><![CDATA[Go]]></category>
+		<wp:postmeta><wp:meta_key><![CDATA[_thumbnail_id]]></wp:meta_key><wp:meta_value><![CDATA[11]]></wp:meta_value></wp:postmeta>
+	</item>
+	<item>
+		<title>cover</title>
+		<wp:post_id>11</wp:post_id>
+		<wp:post_type><![CDATA[attachment]]></wp:post_type>
+		<wp:status><![CDATA[inherit]]></wp:status>
+		<wp:attachment_url><![CDATA[https://old.example.com/wp-content/uploads/cover.jpg]]></wp:attachment_url>
+	</item>
+	<item>
+		<title>Later</title>
+		<wp:post_id>12</wp:post_id>
+		<wp:post_date><![CDATA[2031-01-01 09:00:00]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[2031-01-01 08:00:00]]></wp:post_date_gmt>
+		<wp:post_name><![CDATA[later]]></wp:post_name>
+		<wp:status><![CDATA[future]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+	</item>
+	<item>
+		<title>Unsaved</title>
+		<wp:post_id>13</wp:post_id>
+		<wp:post_date><![CDATA[2015-05-05 10:00:00]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[0000-00-00 00:00:00]]></wp:post_date_gmt>
+		<wp:status><![CDATA[draft]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+	</item>
+	<item>
+		<title>Gone</title>
+		<wp:post_id>14</wp:post_id>
+		<wp:status><![CDATA[trash]]></wp:status>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+	</item>
+	<item>
+		<title>About</title>
+		<wp:post_id>15</wp:post_id>
+		<wp:status><![CDATA[publish]]></wp:status>
+		<wp:post_type><![CDATA[page]]></wp:post_type>
+	</item>
+</channel>
+</rss>`
+
+func TestDecodeWXR(t *testing.T) {
+	d := NewDecoder(nil)
+	bundle, assets, err := d.Decode(entity.ImportFormatWXR, strings.NewReader(sampleWXR), int64(len(sampleWXR)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bundle.Authors) != 1 || bundle.Authors[0] != (entity.ImportAuthor{Login: "jane", Email: "jane@example.com", DisplayName: "Jane"}) {
+		t.Fatalf("authors = %+v", bundle.Authors)
+	}
+	if len(bundle.Categories) != 1 || bundle.Categories[0] != (entity.ImportTerm{Name: "News", Slug: "news"}) {
+		t.Fatalf("categories = %+v", bundle.Categories)
+	}
+	if len(bundle.Tags) != 1 || bundle.Tags[0] != (entity.ImportTerm{Name: "Go", Slug: "go"}) {
+		t.Fatalf("tags = %+v", bundle.Tags)
+	}
+	if len(bundle.Posts) != 3 {
+		t.Fatalf("want 3 posts (no trash, pages or attachments), got %d", len(bundle.Posts))
+	}
+
+	first := bundle.Posts[0]
+	if first.Source != "post 10" || first.Slug != "café" || first.Title != "Café" || first.Author != "jane" || first.Locale != "de-DE" {
+		t.Fatalf("post = %+v", first)
+	}
+	if first.Content != `<p>Hello <img src="/wp-content/uploads/a.png"></p>` {
+		t.Fatalf("content = %q", first.Content)
+	}
+	if first.Status != entity.StatusPublished || !first.CreatedAt.Equal(time.Date(2015, 3, 4, 11, 0, 0, 0, time.UTC)) ||
+		!first.UpdatedAt.Equal(time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("status/dates = %d %v %v", first.Status, first.CreatedAt, first.UpdatedAt)
+	}
+	if first.Cover != "https://old.example.com/wp-content/uploads/cover.jpg" {
+		t.Fatalf("cover = %q", first.Cover)
+	}
+	if len(first.Categories) != 1 || first.Categories[0].Slug != "news" || len(first.Tags) != 1 || first.Tags[0].Slug != "go" {
+		t.Fatalf("terms = %+v %+v", first.Categories, first.Tags)
+	}
+
+	later := bundle.Posts[1]
+	if later.Status != entity.StatusScheduled || later.PublishAt == nil || !later.PublishAt.Equal(later.CreatedAt) {
+		t.Fatalf("future post = %+v", later)
+	}
+	draft := bundle.Posts[2]
+	if draft.Status != entity.StatusDraft || !draft.CreatedAt.Equal(time.Date(2015, 5, 5, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("draft = %+v", draft)
+	}
+
+	base := assets.(remoteAssets).base
+	if base == nil || base.String() != "https://old.example.com" {
+		t.Fatalf("asset base = %v", base)
+	}
+}
+
+func TestDecodeWXR_Invalid(t *testing.T) {
+	const data = "<rss><channel>"
+	_, _, err := NewDecoder(nil).Decode(entity.ImportFormatWXR, strings.NewReader(data), int64(len(data)))
+	if !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("want ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestRemoteAssets_Open(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/uploads/a.png" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, "png")
+	}))
+	defer srv.Close()
+
+	d := NewDecoder(srv.Client())
+	const data = `<rss><channel><link>` + "PLACEHOLDER" + `</link></channel></rss>`
+	wxr := strings.Replace(data, "PLACEHOLDER", srv.URL, 1)
+	_, assets, err := d.Decode(entity.ImportFormatWXR, strings.NewReader(wxr), int64(len(wxr)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rc, name, err := assets.Open(context.Background(), entity.ImportPost{}, "/uploads/a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(rc)
+	rc.Close()
+	if name != "a.png" || string(body) != "png" {
+		t.Fatalf("got %q %q", name, body)
+	}
+
+	if _, _, err := assets.Open(context.Background(), entity.ImportPost{}, srv.URL+"/missing.png"); !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("missing asset: want ErrNotFound, got %v", err)
+	}
+	if _, _, err := assets.Open(context.Background(), entity.ImportPost{}, "file:///etc/passwd"); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("file URL: want ErrInvalidInput, got %v", err)
+	}
+}
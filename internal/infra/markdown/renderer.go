@@ -0,0 +1,120 @@
+// Package markdown renders post Markdown into sanitized HTML with a table of contents.
+package markdown
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"bytes"
+	"fmt"
+	stdhtml "html"
+	"regexp"
+	"strings"
+
+	"github.com/gosimple/slug"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+// Renderer implements core.ContentRenderer with goldmark (CommonMark + GFM) and a
+// bluemonday whitelist. Raw HTML in the Markdown is passed through the parser and then
+// sanitized, so harmless markup survives while scripts, event handlers and
+// javascript: URLs are removed.
+type Renderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+	// strip removes all markup to derive the plain-text form.
+	strip *bluemonday.Policy
+}
+
+var _ core.ContentRenderer = (*Renderer)(nil)
+
+func NewRenderer() *Renderer {
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+		goldmark.WithRendererOptions(html.WithUnsafe()),
+	)
+
+	// UGCPolicy already keeps id attributes, which the heading anchors rely on.
+	policy := bluemonday.UGCPolicy()
+	// GFM task lists render as disabled checkboxes.
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+	// Fenced code blocks keep their language hint for client-side highlighting.
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+-]+$`)).OnElements("code")
+
+	return &Renderer{md: md, policy: policy, strip: bluemonday.StrictPolicy()}
+}
+
+// Render converts Markdown to sanitized HTML. Headings get ids derived from their text
+// (transliterated to ASCII, de-duplicated with -1, -2 ...), so anchors stay the same
+// across renders as long as the heading text does.
+func (r *Renderer) Render(content string) (entity.RenderedContent, error) {
+	source := []byte(content)
+	ctx := parser.NewContext(parser.WithIDs(newAnchorIDs()))
+	doc := r.md.Parser().Parse(text.NewReader(source), parser.WithContext(ctx))
+
+	var toc []entity.TOCEntry
+	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+		id, _ := heading.AttributeString("id")
+		anchor, _ := id.([]byte)
+		toc = append(toc, entity.TOCEntry{
+			Level:  heading.Level,
+			Text:   strings.TrimSpace(string(heading.Text(source))),
+			Anchor: string(anchor),
+		})
+		return ast.WalkSkipChildren, nil
+	})
+	if err != nil {
+		return entity.RenderedContent{}, fmt.Errorf("markdown.Render: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.md.Renderer().Render(&buf, source, doc); err != nil {
+		return entity.RenderedContent{}, fmt.Errorf("markdown.Render: %w", err)
+	}
+
+	sanitized := r.policy.SanitizeBytes(buf.Bytes())
+	plain := stdhtml.UnescapeString(string(r.strip.SanitizeBytes(sanitized)))
+
+	return entity.RenderedContent{
+		HTML: string(sanitized),
+		TOC:  toc,
+		Text: strings.Join(strings.Fields(plain), " "),
+	}, nil
+}
+
+// anchorIDs implements parser.IDs with slug-based, per-document unique heading ids.
+type anchorIDs struct {
+	used map[string]bool
+}
+
+func newAnchorIDs() *anchorIDs {
+	return &anchorIDs{used: map[string]bool{}}
+}
+
+func (a *anchorIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	base := slug.Make(string(value))
+	if base == "" {
+		base = "section"
+	}
+	candidate := base
+	for i := 1; a.used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+	a.used[candidate] = true
+	return []byte(candidate)
+}
+
+func (a *anchorIDs) Put(value []byte) {
+	a.used[string(value)] = true
+}
@@ -0,0 +1,71 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderer_RendersMarkdownAndTOC(t *testing.T) {
+	got, err := NewRenderer().Render("# Hello World\n\nSome *text*.\n\n## Setup\n\n## Setup\n\n### 安装步骤\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`<h1 id="hello-world">Hello World</h1>`, `<em>text</em>`, `<h2 id="setup">`, `<h2 id="setup-1">`} {
+		if !strings.Contains(got.HTML, want) {
+			t.Fatalf("missing %q in %s", want, got.HTML)
+		}
+	}
+
+	if !strings.HasPrefix(got.Text, "Hello World Some text. Setup") {
+		t.Fatalf("plain text: %q", got.Text)
+	}
+
+	if len(got.TOC) != 4 {
+		t.Fatalf("toc: %+v", got.TOC)
+	}
+	if got.TOC[0].Level != 1 || got.TOC[0].Text != "Hello World" || got.TOC[0].Anchor != "hello-world" {
+		t.Fatalf("toc[0]: %+v", got.TOC[0])
+	}
+	if got.TOC[2].Anchor != "setup-1" {
+		t.Fatalf("duplicate heading anchor not suffixed: %+v", got.TOC[2])
+	}
+	if got.TOC[3].Level != 3 || got.TOC[3].Text != "安装步骤" || got.TOC[3].Anchor == "" || got.TOC[3].Anchor == "section" {
+		t.Fatalf("non-ASCII heading anchor: %+v", got.TOC[3])
+	}
+}
+
+func TestRenderer_AnchorsAreStable(t *testing.T) {
+	r := NewRenderer()
+	a, _ := r.Render("## Intro\n\n## Intro\n")
+	b, _ := r.Render("## Intro\n\n## Intro\n")
+	if a.HTML != b.HTML {
+		t.Fatalf("render not deterministic:\n%s\n%s", a.HTML, b.HTML)
+	}
+}
+
+func TestRenderer_Sanitizes(t *testing.T) {
+	src := strings.Join([]string{
+		`<script>alert(1)</script>`,
+		``,
+		`<img src="x.png" onerror="alert(1)">`,
+		``,
+		`[click](javascript:alert(1))`,
+		``,
+		`<a href="JavaScript:alert(1)">raw</a>`,
+		``,
+		`<b>kept</b>`,
+	}, "\n")
+	got, err := NewRenderer().Render(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lower := strings.ToLower(got.HTML)
+	for _, banned := range []string{"<script", "onerror", "javascript:"} {
+		if strings.Contains(lower, banned) {
+			t.Fatalf("%q survived sanitization: %s", banned, got.HTML)
+		}
+	}
+	if !strings.Contains(got.HTML, "<b>kept</b>") {
+		t.Fatalf("harmless markup removed: %s", got.HTML)
+	}
+}
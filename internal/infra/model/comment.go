@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Comment 是读者在已发布文章下的评论，ParentID 非空时为回复。
+type Comment struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// 公开列表按 (post_id, status) 查询已通过的评论。
+	PostID   uint  `gorm:"not null;index:idx_comments_post_status,priority:1" json:"post_id"`
+	ParentID *uint `gorm:"index" json:"parent_id"`
+
+	// 登录用户评论记录 AuthorID，显示名取自用户；游客只有自填的昵称与邮箱。
+	AuthorID    *uint  `json:"author_id"`
+	Author      *User  `gorm:"foreignKey:AuthorID" json:"author,omitempty"`
+	AuthorName  string `gorm:"size:100" json:"author_name"`
+	AuthorEmail string `gorm:"size:255" json:"-"`
+
+	// Body 为原始 Markdown，BodyHTML 为写入时渲染并清洗后的 HTML。
+	Body     string `gorm:"type:text;not null;check:char_length(TRIM(body)) > 0" json:"body"`
+	BodyHTML string `gorm:"type:text;not null" json:"body_html"`
+
+	// 审核状态：pending / approved / rejected / spam；审核队列按状态检索。
+	Status string `gorm:"type:varchar(20);not null;default:pending;index:idx_comments_post_status,priority:2;index:idx_comments_status" json:"status"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// ContentEntryAsset maps which media assets the media fields of a content entry hold, like
+// PageAsset does for pages, so assets in use are not deleted.
+type ContentEntryAsset struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	EntryID uint `gorm:"not null;index;uniqueIndex:idx_content_entry_asset" json:"entry_id"`
+	AssetID uint `gorm:"not null;index;uniqueIndex:idx_content_entry_asset" json:"asset_id"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// ContentField is one field of a content type schema, stored inside ContentType.Fields.
+type ContentField struct {
+	Key      string `json:"key"`
+	Label    string `json:"label,omitempty"`
+	Type     string `json:"type"`
+	Required bool   `json:"required,omitempty"`
+	Target   string `json:"target,omitempty"`
+}
+
+// ContentType 是管理员定义的结构化内容类型，字段定义以 JSONB 存储。
+type ContentType struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Name        string         `gorm:"unique;not null;check:char_length(TRIM(name)) > 0" json:"name"`
+	Slug        string         `gorm:"unique;not null;check:char_length(TRIM(slug)) > 0" json:"slug"`
+	Description string         `gorm:"type:text;not null;default:''" json:"description"`
+	Fields      []ContentField `gorm:"type:jsonb;not null;serializer:json" json:"fields"`
+}
+
+// ContentEntry 是某个内容类型下的一条内容，字段值以 JSONB 存储并在写入时按类型定义校验。
+type ContentEntry struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// slug 在同一内容类型内唯一；公开列表按 (type_id, status) 查询。
+	TypeID uint         `gorm:"not null;uniqueIndex:idx_content_entries_type_slug,priority:1;index:idx_content_entries_type_status,priority:1" json:"type_id"`
+	Type   *ContentType `gorm:"foreignKey:TypeID;constraint:OnDelete:RESTRICT" json:"-"`
+	Slug   string       `gorm:"not null;uniqueIndex:idx_content_entries_type_slug,priority:2" json:"slug"`
+	Title  string       `gorm:"not null;check:char_length(TRIM(title)) > 0" json:"title"`
+
+	Data     map[string]any `gorm:"type:jsonb;not null;serializer:json" json:"data"`
+	Status   int            `gorm:"not null;default:0;index:idx_content_entries_type_status,priority:2" json:"status"`
+	AuthorID uint           `gorm:"not null;index" json:"author_id"`
+}
@@ -25,8 +25,8 @@ type MediaAsset struct {
 	MimeType     string `gorm:"not null" json:"mime_type"`
 	SizeBytes    int64  `gorm:"not null" json:"size_bytes"`
 
-	// SHA256 is optional today and can be used later for dedupe/verification.
-	SHA256 string `gorm:"" json:"sha256"`
+	// SHA256 is set for imported files, so importing the same file again reuses the asset.
+	SHA256 string `gorm:"index" json:"sha256"`
 
 	// Storage is reserved for future backends (s3/minio). For now: "local".
 	Storage string `gorm:"not null;default:'local'" json:"storage"`
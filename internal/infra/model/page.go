@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Page 是独立于文章的静态页面（关于、联系、文档等），通过 parent_id 组成树。
+type Page struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// 同级页面按 sort_order 排序；有子页面的页面不能删除。
+	ParentID  *uint `gorm:"index:idx_pages_parent_sort,priority:1" json:"parent_id,omitempty"`
+	Parent    *Page `gorm:"foreignKey:ParentID;constraint:OnDelete:RESTRICT" json:"-"`
+	SortOrder int   `gorm:"not null;default:0;index:idx_pages_parent_sort,priority:2" json:"sort_order"`
+
+	Title string `gorm:"not null;check:char_length(TRIM(title)) > 0" json:"title"`
+	Slug  string `gorm:"not null;check:char_length(TRIM(slug)) > 0" json:"slug"`
+	// 从顶级页面起的完整 slug 路径（如 docs/install/linux），全局唯一；
+	// 页面改名或移动时由仓储层在同一事务内重写整棵子树的路径。
+	Path string `gorm:"not null;uniqueIndex" json:"path"`
+
+	Content  string `gorm:"type:text" json:"content"`
+	Cover    string `json:"cover"`
+	Status   int    `gorm:"not null;default:0;index" json:"status"`
+	AuthorID uint   `gorm:"not null;index" json:"author_id"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// PageAsset maps which media assets are referenced by a page, like PostAsset does for posts.
+// Purpose is "content" or "cover".
+type PageAsset struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	PageID  uint   `gorm:"not null;index;uniqueIndex:idx_page_asset" json:"page_id"`
+	AssetID uint   `gorm:"not null;index;uniqueIndex:idx_page_asset" json:"asset_id"`
+	Purpose string `gorm:"not null;default:'content';uniqueIndex:idx_page_asset" json:"purpose"`
+}
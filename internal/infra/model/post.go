@@ -17,8 +17,14 @@ type Post struct {
 	Title string `gorm:"not null;check:char_length(TRIM(title)) > 0" json:"title"`
 
 	// 3. 别名 (Slug)：用于 URL (如 /post/my-first-post)
-	// 必须唯一，且不能为空
-	Slug string `gorm:"unique;not null;check:char_length(TRIM(slug)) > 0" json:"slug"`
+	// 同一语言内唯一（译文可以沿用原文的 slug），且不能为空
+	Slug string `gorm:"not null;uniqueIndex:idx_posts_locale_slug,priority:2;check:char_length(TRIM(slug)) > 0" json:"slug"`
+
+	// 语言标签（BCP 47，如 zh-CN、en），与 slug 组成唯一索引。
+	Locale string `gorm:"type:varchar(35);not null;default:'zh-CN';uniqueIndex:idx_posts_locale_slug,priority:1" json:"locale"`
+
+	// 翻译组：互为译文的文章共享同一个组 ID（即建组时原文的 ID），没有译文时为空。
+	TranslationGroupID *uint `gorm:"index" json:"translation_group_id,omitempty"`
 
 	// 4. 内容：文章通常很长，显式指定 type:text 防止被截断
 	// 内容允许为空（比如存草稿时可能只写了标题）
@@ -27,11 +33,31 @@ type Post struct {
 	// 5. 封面图：存 URL，允许为空
 	Cover string `json:"cover"`
 
-	// 6. 状态：0=草稿/下线, 1=已发布。
+	// 6. 状态：0=草稿/下线, 1=已发布, 2=定时待发布, 3=待审核。
 	// 公共读取与作者草稿查询都会基于该字段过滤，并参与 author+status 复合索引。
 	//这个索引是为了支持 GetDraftsByAuthor 中使用的 “按作者获取草稿” 查询模式
 	Status int `gorm:"not null;default:0;index:idx_posts_author_status,priority:2" json:"status"`
 
+	// 定时发布/自动下线时间，调度器按这两个字段扫描到期文章。
+	PublishAt   *time.Time `gorm:"index" json:"publish_at,omitempty"`
+	UnpublishAt *time.Time `gorm:"index" json:"unpublish_at,omitempty"`
+
+	// 不希望被搜索引擎收录的文章不会出现在 sitemap 中。
+	NoIndex bool `gorm:"not null;default:false" json:"no_index"`
+
+	// 关闭后不再接受新评论，已通过审核的评论仍然公开。
+	CommentsDisabled bool `gorm:"not null;default:false" json:"comments_disabled"`
+
+	// 乐观锁版本号，每次更新加一；后台编辑通过 ETag/If-Match 携带该值防止相互覆盖。
+	Version uint `gorm:"not null;default:1" json:"version"`
+
+	// 审核驳回理由，作者重新提交审核时清空。
+	ReviewNote string `gorm:"type:text;not null;default:''" json:"review_note"`
+
+	// 全文检索向量（标题权重 A，正文权重 B），由仓储层在写入时维护；ORM 既不读也不写该列（仍参与迁移）。
+	// 中文按单字切分后以短语方式匹配，因此无需安装 zhparser 等扩展。
+	SearchVector string `gorm:"type:tsvector;index:idx_posts_search_vector,type:gin;<-:false;->:false" json:"-"`
+
 	// --- 关联关系 ---
 
 	// 作者 (必填)
@@ -46,4 +72,10 @@ type Post struct {
 
 	// 标签 (多对多)
 	Tags []Tag `gorm:"many2many:post_tags;" json:"tags,omitempty"`
+
+	// 系列 (选填)：所属系列及其中的序号，按 (series_id, series_position) 检索系列目录。
+	// 序号唯一性由服务层保证，这样回收站中的文章不会占住序号。
+	SeriesID       *uint   `gorm:"index:idx_posts_series_position,priority:1" json:"series_id"`
+	Series         *Series `gorm:"foreignKey:SeriesID;constraint:OnDelete:SET NULL" json:"series,omitempty"`
+	SeriesPosition int     `gorm:"not null;default:0;index:idx_posts_series_position,priority:2" json:"series_position"`
 }
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// PostAutosave buffers an editor's unsaved changes to a post, one row per (post, user).
+// Rows are overwritten in place and removed once promoted, discarded or stale.
+type PostAutosave struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UpdatedAt time.Time `gorm:"index" json:"updated_at"`
+
+	PostID uint `gorm:"not null;uniqueIndex:idx_post_autosaves_post_user,priority:1" json:"post_id"`
+	UserID uint `gorm:"not null;uniqueIndex:idx_post_autosaves_post_user,priority:2" json:"user_id"`
+
+	Title       string `gorm:"not null;default:''" json:"title"`
+	Content     string `gorm:"type:text;not null;default:''" json:"content"`
+	BaseVersion uint   `gorm:"not null" json:"base_version"`
+}
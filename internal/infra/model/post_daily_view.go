@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// PostDailyView 每篇文章每天（UTC）的浏览量聚合，由内存缓冲批量累加写入。
+// 不保存任何访客信息（IP、UA 或其哈希），去重只在内存中按天进行。
+type PostDailyView struct {
+	PostID uint      `gorm:"primaryKey;autoIncrement:false" json:"post_id"`
+	Day    time.Time `gorm:"primaryKey;type:date;index" json:"day"`
+	Views  int64     `gorm:"not null;default:0" json:"views"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// PostPreviewToken 记录为文章签发的预览链接；链接本身是指向本记录的签名令牌，
+// 撤销记录即可让链接在过期前失效。
+type PostPreviewToken struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	PostID    uint       `gorm:"not null;index" json:"post_id"`
+	CreatedBy uint       `gorm:"not null" json:"created_by"`
+	ExpiresAt time.Time  `gorm:"not null;index" json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
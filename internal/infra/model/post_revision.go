@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// PostRevision is an append-only snapshot of a post after each change.
+// There is intentionally no UpdatedAt/DeletedAt: rows are written once and never modified.
+type PostRevision struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `gorm:"index:idx_post_revisions_post_created,priority:2" json:"created_at"`
+
+	PostID       uint   `gorm:"not null;index:idx_post_revisions_post_created,priority:1" json:"post_id"`
+	ActorID      uint   `gorm:"not null" json:"actor_id"`
+	Action       string `gorm:"type:varchar(20);not null" json:"action"`
+	RestoredFrom *uint  `json:"restored_from"`
+
+	Title      string `gorm:"not null" json:"title"`
+	Slug       string `gorm:"not null" json:"slug"`
+	Content    string `gorm:"type:text" json:"content"`
+	Cover      string `json:"cover"`
+	CategoryID *uint  `json:"category_id"`
+	TagIDs     []uint `gorm:"type:jsonb;serializer:json" json:"tag_ids"`
+	Status     int    `gorm:"not null" json:"status"`
+	// Nullable so revisions written before these were snapshotted restore without touching them.
+	NoIndex          *bool `json:"no_index"`
+	CommentsDisabled *bool `json:"comments_disabled"`
+	SeriesID         *uint `json:"series_id"`
+	SeriesPosition   int   `gorm:"not null;default:0" json:"series_position"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// PostSlugHistory remembers slugs a post used to have so old links can be redirected.
+// Like post slugs, a slug appears at most once per locale; when a post takes a slug back,
+// its history row is removed. Rows are never soft-deleted: they only exist to resolve URLs.
+type PostSlugHistory struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	PostID uint   `gorm:"not null;index" json:"post_id"`
+	Locale string `gorm:"type:varchar(35);not null;default:'zh-CN';uniqueIndex:idx_post_slug_histories_locale_slug,priority:1" json:"locale"`
+	Slug   string `gorm:"not null;uniqueIndex:idx_post_slug_histories_locale_slug,priority:2;check:char_length(TRIM(slug)) > 0" json:"slug"`
+}
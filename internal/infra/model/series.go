@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Series 系列：把多篇文章按顺序串成一个连载（例如分多篇的教程）。
+// 文章通过 posts.series_id + posts.series_position 归属到系列，删除系列时文章只解除关联。
+type Series struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// 标题与 Slug 均禁止空串；Slug 全局唯一，用于公开地址 /series/slug/{slug}
+	Title string `gorm:"not null;check:char_length(TRIM(title)) > 0" json:"title"`
+	Slug  string `gorm:"unique;not null;check:char_length(TRIM(slug)) > 0" json:"slug"`
+
+	Description string `gorm:"type:text;not null;default:''" json:"description"`
+}
+
+// TableName 避免 GORM 把 Series 复数化成 "series" 以外的名字。
+func (Series) TableName() string {
+	return "series"
+}
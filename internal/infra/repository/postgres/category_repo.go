@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+func categoryToEntity(m model.Category) entity.Category {
+	return entity.Category{ID: m.ID, Name: m.Name, Slug: m.Slug}
+}
+
+// CategoryRepository persists categories in Postgres.
+type CategoryRepository struct {
+	db *gorm.DB
+}
+
+var _ core.CategoryRepository = (*CategoryRepository)(nil)
+
+func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, category entity.Category) (entity.Category, error) {
+	m := model.Category{Name: category.Name, Slug: category.Slug}
+	if err := conn(ctx, r.db).Create(&m).Error; err != nil {
+		if isUniqueViolation(err) {
+			return entity.Category{}, core.ErrDuplicate
+		}
+		return entity.Category{}, fmt.Errorf("category_repository.Create: %w", err)
+	}
+	return categoryToEntity(m), nil
+}
+
+// ListWithPostCounts returns every category ordered by name, each with its number of published posts.
+func (r *CategoryRepository) ListWithPostCounts(ctx context.Context) ([]entity.CategoryWithPostCount, error) {
+	var rows []struct {
+		model.Category
+		PostCount int64
+	}
+	err := conn(ctx, r.db).Model(&model.Category{}).
+		Select("categories.*, COUNT(posts.id) AS post_count").
+		Joins("LEFT JOIN posts ON posts.category_id = categories.id AND posts.status = ? AND posts.deleted_at IS NULL", entity.StatusPublished).
+		Group("categories.id").
+		Order("categories.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("category_repository.ListWithPostCounts: %w", err)
+	}
+
+	out := make([]entity.CategoryWithPostCount, len(rows))
+	for i, row := range rows {
+		out[i] = entity.CategoryWithPostCount{Category: categoryToEntity(row.Category), PostCount: row.PostCount}
+	}
+	return out, nil
+}
+
+func (r *CategoryRepository) GetByID(ctx context.Context, id uint) (entity.Category, error) {
+	return r.first(ctx, "category_repository.GetByID", "id = ?", id)
+}
+
+func (r *CategoryRepository) GetBySlug(ctx context.Context, slug string) (entity.Category, error) {
+	return r.first(ctx, "category_repository.GetBySlug", "slug = ?", slug)
+}
+
+func (r *CategoryRepository) GetByName(ctx context.Context, name string) (entity.Category, error) {
+	return r.first(ctx, "category_repository.GetByName", "name = ?", name)
+}
+
+func (r *CategoryRepository) first(ctx context.Context, op string, query string, arg any) (entity.Category, error) {
+	var m model.Category
+	if err := conn(ctx, r.db).Where(query, arg).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Category{}, core.ErrNotFound
+		}
+		return entity.Category{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return categoryToEntity(m), nil
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, category entity.Category) (entity.Category, error) {
+	res := conn(ctx, r.db).Model(&model.Category{ID: category.ID}).
+		Updates(map[string]any{"name": category.Name, "slug": category.Slug})
+	if res.Error != nil {
+		if isUniqueViolation(res.Error) {
+			return entity.Category{}, core.ErrDuplicate
+		}
+		return entity.Category{}, fmt.Errorf("category_repository.Update: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return entity.Category{}, core.ErrNotFound
+	}
+	return category, nil
+}
+
+// CountPosts counts live posts of any status that reference the category.
+func (r *CategoryRepository) CountPosts(ctx context.Context, id uint) (int64, error) {
+	var n int64
+	if err := conn(ctx, r.db).Model(&model.Post{}).Where("category_id = ?", id).Count(&n).Error; err != nil {
+		return 0, fmt.Errorf("category_repository.CountPosts: %w", err)
+	}
+	return n, nil
+}
+
+// Delete removes a category permanently so its name and slug can be reused.
+// With reassignTo set, every post in the category (including soft-deleted ones) moves there first;
+// without it, only soft-deleted posts are detached and live references make the delete fail.
+// Moved or detached posts get a new version, so writes based on the old one are refused.
+func (r *CategoryRepository) Delete(ctx context.Context, id uint, reassignTo *uint) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		posts := tx.Unscoped().Model(&model.Post{}).Where("category_id = ?", id)
+		changes := map[string]any{"category_id": nil, "version": gorm.Expr("version + 1"), "updated_at": time.Now()}
+		if reassignTo != nil {
+			changes["category_id"] = *reassignTo
+			if err := posts.Updates(changes).Error; err != nil {
+				return err
+			}
+		} else if err := posts.Where("deleted_at IS NOT NULL").Updates(changes).Error; err != nil {
+			return err
+		}
+
+		res := tx.Unscoped().Delete(&model.Category{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return core.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			return err
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation: a post was assigned concurrently
+			return core.ErrConflict
+		}
+		return fmt.Errorf("category_repository.Delete: %w", err)
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" // 23505 is the SQLSTATE for unique_violation
+}
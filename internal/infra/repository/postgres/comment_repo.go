@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/model"
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func commentToEntity(m model.Comment) entity.Comment {
+	c := entity.Comment{
+		ID:          m.ID,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		PostID:      m.PostID,
+		ParentID:    m.ParentID,
+		AuthorID:    m.AuthorID,
+		AuthorName:  m.AuthorName,
+		AuthorEmail: m.AuthorEmail,
+		Body:        m.Body,
+		BodyHTML:    m.BodyHTML,
+		Status:      m.Status,
+	}
+	if m.Author != nil {
+		c.AuthorName = m.Author.Username
+	}
+	return c
+}
+
+func commentsToEntities(ms []model.Comment) []entity.Comment {
+	out := make([]entity.Comment, len(ms))
+	for i, m := range ms {
+		out[i] = commentToEntity(m)
+	}
+	return out
+}
+
+// CommentRepository persists comments in Postgres.
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+var _ core.CommentRepository = (*CommentRepository)(nil)
+
+func NewCommentRepository(db *gorm.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+func (r *CommentRepository) Create(ctx context.Context, comment entity.Comment) (entity.Comment, error) {
+	m := model.Comment{
+		PostID:      comment.PostID,
+		ParentID:    comment.ParentID,
+		AuthorID:    comment.AuthorID,
+		AuthorName:  comment.AuthorName,
+		AuthorEmail: comment.AuthorEmail,
+		Body:        comment.Body,
+		BodyHTML:    comment.BodyHTML,
+		Status:      comment.Status,
+	}
+	if err := conn(ctx, r.db).Create(&m).Error; err != nil {
+		return entity.Comment{}, fmt.Errorf("comment_repository.Create: %w", err)
+	}
+	created := comment
+	created.ID, created.CreatedAt, created.UpdatedAt = m.ID, m.CreatedAt, m.UpdatedAt
+	return created, nil
+}
+
+func (r *CommentRepository) GetByID(ctx context.Context, id uint) (entity.Comment, error) {
+	var m model.Comment
+	if err := conn(ctx, r.db).Preload("Author").First(&m, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Comment{}, core.ErrNotFound
+		}
+		return entity.Comment{}, fmt.Errorf("comment_repository.GetByID: %w", err)
+	}
+	return commentToEntity(m), nil
+}
+
+func (r *CommentRepository) ListApprovedByPost(ctx context.Context, postID uint) ([]entity.Comment, error) {
+	var ms []model.Comment
+	err := conn(ctx, r.db).Preload("Author").
+		Where("post_id = ? AND status = ?", postID, entity.CommentStatusApproved).
+		Order("created_at ASC, id ASC").
+		Find(&ms).Error
+	if err != nil {
+		return nil, fmt.Errorf("comment_repository.ListApprovedByPost: %w", err)
+	}
+	return commentsToEntities(ms), nil
+}
+
+func (r *CommentRepository) List(ctx context.Context, query entity.CommentListQuery) ([]entity.Comment, int64, error) {
+	query = query.Normalized()
+	base := conn(ctx, r.db).Model(&model.Comment{})
+	if query.Status != "" {
+		base = base.Where("status = ?", query.Status)
+	}
+	if query.PostID != nil {
+		base = base.Where("post_id = ?", *query.PostID)
+	}
+
+	var total int64
+	if err := base.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("comment_repository.List.count: %w", err)
+	}
+
+	var ms []model.Comment
+	if err := base.Preload("Author").
+		Order("created_at DESC, id DESC").
+		Offset(query.Offset()).
+		Limit(query.PageSize).
+		Find(&ms).Error; err != nil {
+		return nil, 0, fmt.Errorf("comment_repository.List: %w", err)
+	}
+	return commentsToEntities(ms), total, nil
+}
+
+func (r *CommentRepository) UpdateStatus(ctx context.Context, id uint, status string) error {
+	res := conn(ctx, r.db).Model(&model.Comment{ID: id}).Update("status", status)
+	if res.Error != nil {
+		return fmt.Errorf("comment_repository.UpdateStatus: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return core.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/model"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+func contentTypeToEntity(m model.ContentType) entity.ContentType {
+	fields := make([]entity.ContentField, len(m.Fields))
+	for i, f := range m.Fields {
+		fields[i] = entity.ContentField{
+			Key:      f.Key,
+			Label:    f.Label,
+			Type:     entity.ContentFieldType(f.Type),
+			Required: f.Required,
+			Target:   f.Target,
+		}
+	}
+	return entity.ContentType{
+		ID:          m.ID,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		Name:        m.Name,
+		Slug:        m.Slug,
+		Description: m.Description,
+		Fields:      fields,
+	}
+}
+
+func contentTypeToModel(e entity.ContentType) model.ContentType {
+	fields := make([]model.ContentField, len(e.Fields))
+	for i, f := range e.Fields {
+		fields[i] = model.ContentField{
+			Key:      f.Key,
+			Label:    f.Label,
+			Type:     string(f.Type),
+			Required: f.Required,
+			Target:   f.Target,
+		}
+	}
+	return model.ContentType{
+		ID:          e.ID,
+		Name:        e.Name,
+		Slug:        e.Slug,
+		Description: e.Description,
+		Fields:      fields,
+	}
+}
+
+func contentEntryToEntity(m model.ContentEntry) entity.ContentEntry {
+	data := m.Data
+	if data == nil {
+		data = map[string]any{}
+	}
+	return entity.ContentEntry{
+		ID:        m.ID,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+		TypeID:    m.TypeID,
+		Title:     m.Title,
+		Slug:      m.Slug,
+		Data:      data,
+		Status:    m.Status,
+		AuthorID:  m.AuthorID,
+	}
+}
+
+func contentEntryToModel(e entity.ContentEntry) model.ContentEntry {
+	data := e.Data
+	if data == nil {
+		data = map[string]any{}
+	}
+	return model.ContentEntry{
+		ID:       e.ID,
+		TypeID:   e.TypeID,
+		Title:    e.Title,
+		Slug:     e.Slug,
+		Data:     data,
+		Status:   e.Status,
+		AuthorID: e.AuthorID,
+	}
+}
+
+// ContentTypeRepository persists content type schemas in Postgres.
+type ContentTypeRepository struct {
+	db *gorm.DB
+}
+
+var _ core.ContentTypeRepository = (*ContentTypeRepository)(nil)
+
+func NewContentTypeRepository(db *gorm.DB) *ContentTypeRepository {
+	return &ContentTypeRepository{db: db}
+}
+
+func (r *ContentTypeRepository) Create(ctx context.Context, contentType entity.ContentType) (entity.ContentType, error) {
+	m := contentTypeToModel(contentType)
+	m.ID = 0
+	if err := conn(ctx, r.db).Create(&m).Error; err != nil {
+		if isUniqueViolation(err) {
+			return entity.ContentType{}, core.ErrDuplicate
+		}
+		return entity.ContentType{}, fmt.Errorf("content_type_repository.Create: %w", err)
+	}
+	return contentTypeToEntity(m), nil
+}
+
+func (r *ContentTypeRepository) GetBySlug(ctx context.Context, slug string) (entity.ContentType, error) {
+	var m model.ContentType
+	if err := conn(ctx, r.db).Where("slug = ?", slug).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.ContentType{}, core.ErrNotFound
+		}
+		return entity.ContentType{}, fmt.Errorf("content_type_repository.GetBySlug: %w", err)
+	}
+	return contentTypeToEntity(m), nil
+}
+
+// List returns every content type ordered by name.
+func (r *ContentTypeRepository) List(ctx context.Context) ([]entity.ContentType, error) {
+	var ms []model.ContentType
+	if err := conn(ctx, r.db).Order("name ASC").Find(&ms).Error; err != nil {
+		return nil, fmt.Errorf("content_type_repository.List: %w", err)
+	}
+	out := make([]entity.ContentType, len(ms))
+	for i, m := range ms {
+		out[i] = contentTypeToEntity(m)
+	}
+	return out, nil
+}
+
+// Update replaces name, description and fields. The slug is left untouched.
+func (r *ContentTypeRepository) Update(ctx context.Context, contentType entity.ContentType) (entity.ContentType, error) {
+	m := contentTypeToModel(contentType)
+	res := conn(ctx, r.db).Model(&model.ContentType{ID: contentType.ID}).
+		Select("name", "description", "fields", "updated_at").
+		Updates(&m)
+	if res.Error != nil {
+		if isUniqueViolation(res.Error) {
+			return entity.ContentType{}, core.ErrDuplicate
+		}
+		return entity.ContentType{}, fmt.Errorf("content_type_repository.Update: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return entity.ContentType{}, core.ErrNotFound
+	}
+	contentType.UpdatedAt = m.UpdatedAt
+	return contentType, nil
+}
+
+func (r *ContentTypeRepository) Delete(ctx context.Context, id uint) error {
+	res := conn(ctx, r.db).Delete(&model.ContentType{}, id)
+	if res.Error != nil {
+		return fmt.Errorf("content_type_repository.Delete: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return core.ErrNotFound
+	}
+	return nil
+}
+
+// ContentEntryRepository persists content entries in Postgres.
+type ContentEntryRepository struct {
+	db *gorm.DB
+}
+
+var _ core.ContentEntryRepository = (*ContentEntryRepository)(nil)
+
+func NewContentEntryRepository(db *gorm.DB) *ContentEntryRepository {
+	return &ContentEntryRepository{db: db}
+}
+
+func (r *ContentEntryRepository) Create(ctx context.Context, entry entity.ContentEntry) (entity.ContentEntry, error) {
+	m := contentEntryToModel(entry)
+	m.ID = 0
+	if err := conn(ctx, r.db).Create(&m).Error; err != nil {
+		if isUniqueViolation(err) {
+			return entity.ContentEntry{}, core.ErrDuplicate
+		}
+		return entity.ContentEntry{}, fmt.Errorf("content_entry_repository.Create: %w", err)
+	}
+	return contentEntryToEntity(m), nil
+}
+
+func (r *ContentEntryRepository) GetByID(ctx context.Context, typeID uint, id uint) (entity.ContentEntry, error) {
+	return r.first(ctx, "content_entry_repository.GetByID", "type_id = ? AND id = ?", typeID, id)
+}
+
+func (r *ContentEntryRepository) GetBySlug(ctx context.Context, typeID uint, slug string) (entity.ContentEntry, error) {
+	return r.first(ctx, "content_entry_repository.GetBySlug", "type_id = ? AND slug = ?", typeID, slug)
+}
+
+func (r *ContentEntryRepository) first(ctx context.Context, op string, query string, args ...any) (entity.ContentEntry, error) {
+	var m model.ContentEntry
+	if err := conn(ctx, r.db).Where(query, args...).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.ContentEntry{}, core.ErrNotFound
+		}
+		return entity.ContentEntry{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return contentEntryToEntity(m), nil
+}
+
+// List returns one page of entries, most recently updated first.
+func (r *ContentEntryRepository) List(ctx context.Context, query entity.ContentEntryQuery) ([]entity.ContentEntry, int64, error) {
+	query = query.Normalized()
+	base := conn(ctx, r.db).Model(&model.ContentEntry{}).Where("type_id = ?", query.TypeID)
+	if query.AuthorID != nil {
+		base = base.Where("author_id = ?", *query.AuthorID)
+	}
+	if query.Status != nil {
+		base = base.Where("status = ?", *query.Status)
+	}
+
+	var total int64
+	if err := base.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("content_entry_repository.List.count: %w", err)
+	}
+
+	var ms []model.ContentEntry
+	if err := base.Order("updated_at DESC, id DESC").
+		Offset(query.Offset()).
+		Limit(query.PageSize).
+		Find(&ms).Error; err != nil {
+		return nil, 0, fmt.Errorf("content_entry_repository.List: %w", err)
+	}
+	out := make([]entity.ContentEntry, len(ms))
+	for i, m := range ms {
+		out[i] = contentEntryToEntity(m)
+	}
+	return out, total, nil
+}
+
+func (r *ContentEntryRepository) Update(ctx context.Context, entry entity.ContentEntry) error {
+	m := contentEntryToModel(entry)
+	res := conn(ctx, r.db).Model(&model.ContentEntry{ID: entry.ID}).
+		Where("type_id = ?", entry.TypeID).
+		Select("title", "slug", "data", "status", "updated_at").
+		Updates(&m)
+	if res.Error != nil {
+		if isUniqueViolation(res.Error) {
+			return core.ErrDuplicate
+		}
+		return fmt.Errorf("content_entry_repository.Update: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return core.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes an entry together with its media references.
+func (r *ContentEntryRepository) Delete(ctx context.Context, typeID uint, id uint) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("type_id = ?", typeID).Delete(&model.ContentEntry{}, id)
+		if res.Error != nil {
+			return fmt.Errorf("content_entry_repository.Delete: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return core.ErrNotFound
+		}
+		if err := tx.Where("entry_id = ?", id).Delete(&model.ContentEntryAsset{}).Error; err != nil {
+			return fmt.Errorf("content_entry_repository.Delete.assets: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *ContentEntryRepository) IsSlugExists(ctx context.Context, typeID uint, slug string) (bool, error) {
+	var n int64
+	if err := conn(ctx, r.db).Model(&model.ContentEntry{}).
+		Where("type_id = ? AND slug = ?", typeID, slug).
+		Count(&n).Error; err != nil {
+		return false, fmt.Errorf("content_entry_repository.IsSlugExists: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (r *ContentEntryRepository) CountByType(ctx context.Context, typeID uint) (int64, error) {
+	var n int64
+	if err := conn(ctx, r.db).Model(&model.ContentEntry{}).Where("type_id = ?", typeID).Count(&n).Error; err != nil {
+		return 0, fmt.Errorf("content_entry_repository.CountByType: %w", err)
+	}
+	return n, nil
+}
+
+// CountReferencing matches reference values as stored by entity.ContentType.ValidateData,
+// a JSON number holding the target entry's ID.
+func (r *ContentEntryRepository) CountReferencing(ctx context.Context, typeID uint, field string, targetID uint) (int64, error) {
+	var n int64
+	if err := conn(ctx, r.db).Model(&model.ContentEntry{}).
+		Where("type_id = ? AND data ->> ? = ?", typeID, field, strconv.FormatUint(uint64(targetID), 10)).
+		Count(&n).Error; err != nil {
+		return 0, fmt.Errorf("content_entry_repository.CountReferencing: %w", err)
+	}
+	return n, nil
+}
+
+// backfillContentEntryAssets records the media held by entries saved before their
+// references were tracked. It runs once, when the content_entry_assets table is created.
+func backfillContentEntryAssets(db *gorm.DB) error {
+	return db.Exec(`INSERT INTO content_entry_assets (created_at, entry_id, asset_id)
+		SELECT DISTINCT now(), e.id, (e.data ->> (f ->> 'key'))::bigint
+		FROM content_entries e
+		JOIN content_types t ON t.id = e.type_id
+		CROSS JOIN LATERAL jsonb_array_elements(t.fields) f
+		WHERE f ->> 'type' = 'media' AND jsonb_typeof(e.data -> (f ->> 'key')) = 'number'
+		ON CONFLICT DO NOTHING`).Error
+}
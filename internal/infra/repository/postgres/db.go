@@ -47,15 +47,31 @@ func InitDB(dsn string) (*gorm.DB, error) {
 	log.Printf("[DATABASE] 成功连接并校验数据库: %s", currentDB)
 	// --- END 核心校验 ---
 
+	// Media references of content entries are tracked from the release that adds their table;
+	// entries saved before it are backfilled once, right after the table is created.
+	backfillEntryAssets := !db.Migrator().HasTable(&model2.ContentEntryAsset{})
+
 	// Auto-migrate the schema
 	err = db.AutoMigrate(
 		&model2.User{},
 		&model2.Category{},
 		&model2.Tag{},
+		&model2.Series{},
 		&model2.Post{},
 		&model2.SystemSetting{},
 		&model2.MediaAsset{},
 		&model2.PostAsset{},
+		&model2.PostSlugHistory{},
+		&model2.PostRevision{},
+		&model2.PostAutosave{},
+		&model2.Comment{},
+		&model2.ContentType{},
+		&model2.ContentEntry{},
+		&model2.ContentEntryAsset{},
+		&model2.Page{},
+		&model2.PageAsset{},
+		&model2.PostDailyView{},
+		&model2.PostPreviewToken{},
 	)
 	if err != nil {
 		log.Printf("Failed to auto-migrate database: %v", err)
@@ -63,5 +79,22 @@ func InitDB(dsn string) (*gorm.DB, error) {
 	}
 	fmt.Println("Database schema migrated successfully.")
 
+	if backfillEntryAssets {
+		if err := backfillContentEntryAssets(db); err != nil {
+			log.Printf("Failed to backfill content entry media references: %v", err)
+			return nil, fmt.Errorf("failed to backfill content entry media references: %w", err)
+		}
+	}
+
+	if err := migrateSlugHistoryLocale(db); err != nil {
+		log.Printf("Failed to migrate post slug history: %v", err)
+		return nil, fmt.Errorf("failed to migrate post slug history: %w", err)
+	}
+
+	if err := backfillSearchVectors(db); err != nil {
+		log.Printf("Failed to backfill post search vectors: %v", err)
+		return nil, fmt.Errorf("failed to backfill post search vectors: %w", err)
+	}
+
 	return db, nil
 }
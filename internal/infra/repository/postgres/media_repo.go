@@ -74,7 +74,7 @@ func (r *MediaRepository) Create(ctx context.Context, asset *entity.MediaAsset)
 		return fmt.Errorf("media_repository.Create: asset is nil")
 	}
 	m := mediaEntityToModel(*asset)
-	if err := r.db.WithContext(ctx).Create(&m).Error; err != nil {
+	if err := conn(ctx, r.db).Create(&m).Error; err != nil {
 		return fmt.Errorf("media_repository.Create: %w", err)
 	}
 	asset.ID = m.ID
@@ -85,7 +85,7 @@ func (r *MediaRepository) Create(ctx context.Context, asset *entity.MediaAsset)
 
 func (r *MediaRepository) GetByID(ctx context.Context, id uint) (entity.MediaAsset, error) {
 	var m model.MediaAsset
-	if err := r.db.WithContext(ctx).First(&m, id).Error; err != nil {
+	if err := conn(ctx, r.db).First(&m, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return entity.MediaAsset{}, ErrMediaNotFound
 		}
@@ -94,10 +94,21 @@ func (r *MediaRepository) GetByID(ctx context.Context, id uint) (entity.MediaAss
 	return mediaModelToEntity(m), nil
 }
 
+func (r *MediaRepository) GetBySHA256(ctx context.Context, sum string) (entity.MediaAsset, error) {
+	var m model.MediaAsset
+	if err := conn(ctx, r.db).Where("sha256 = ? AND status = ?", sum, int(entity.MediaStatusUploaded)).Order("id ASC").First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.MediaAsset{}, core.ErrNotFound
+		}
+		return entity.MediaAsset{}, fmt.Errorf("media_repository.GetBySHA256: %w", err)
+	}
+	return mediaModelToEntity(m), nil
+}
+
 func (r *MediaRepository) List(ctx context.Context, ownerUserID *uint, offset, limit int, q string) ([]entity.MediaAsset, int64, error) {
 	var ms []model.MediaAsset
 	// Only list UPLOADED assets by default, hide PENDING/FAILED from normal users
-	query := r.db.WithContext(ctx).Model(&model.MediaAsset{}).Where("status = ?", 1) // 1 = UPLOADED
+	query := conn(ctx, r.db).Model(&model.MediaAsset{}).Where("status = ?", 1) // 1 = UPLOADED
 	if ownerUserID != nil {
 		query = query.Where("owner_user_id = ?", *ownerUserID)
 	}
@@ -128,7 +139,7 @@ func (r *MediaRepository) List(ctx context.Context, ownerUserID *uint, offset, l
 
 func (r *MediaRepository) Delete(ctx context.Context, id uint) error {
 	// GORM Default is Soft Delete if model has DeletedAt
-	if err := r.db.WithContext(ctx).Delete(&model.MediaAsset{}, id).Error; err != nil {
+	if err := conn(ctx, r.db).Delete(&model.MediaAsset{}, id).Error; err != nil {
 		return fmt.Errorf("media_repository.Delete: %w", err)
 	}
 	return nil
@@ -136,14 +147,14 @@ func (r *MediaRepository) Delete(ctx context.Context, id uint) error {
 
 func (r *MediaRepository) DeletePhysical(ctx context.Context, id uint) error {
 	// Hard delete (Unscoped)
-	if err := r.db.WithContext(ctx).Unscoped().Delete(&model.MediaAsset{}, id).Error; err != nil {
+	if err := conn(ctx, r.db).Unscoped().Delete(&model.MediaAsset{}, id).Error; err != nil {
 		return fmt.Errorf("media_repository.DeletePhysical: %w", err)
 	}
 	return nil
 }
 
 func (r *MediaRepository) UpdateStatus(ctx context.Context, id uint, status entity.MediaStatus) error {
-	if err := r.db.WithContext(ctx).Model(&model.MediaAsset{}).Where("id = ?", id).Update("status", int(status)).Error; err != nil {
+	if err := conn(ctx, r.db).Model(&model.MediaAsset{}).Where("id = ?", id).Update("status", int(status)).Error; err != nil {
 		return fmt.Errorf("media_repository.UpdateStatus: %w", err)
 	}
 	return nil
@@ -152,7 +163,7 @@ func (r *MediaRepository) UpdateStatus(ctx context.Context, id uint, status enti
 func (r *MediaRepository) ListPendingOlderThan(ctx context.Context, cutoff time.Time, limit int) ([]entity.MediaAsset, error) {
 	var ms []model.MediaAsset
 	// Status 0: PENDING
-	if err := r.db.WithContext(ctx).Where("status = ? AND created_at < ?", 0, cutoff).Limit(limit).Find(&ms).Error; err != nil {
+	if err := conn(ctx, r.db).Where("status = ? AND created_at < ?", 0, cutoff).Limit(limit).Find(&ms).Error; err != nil {
 		return nil, fmt.Errorf("media_repository.ListPendingOlderThan: %w", err)
 	}
 	out := make([]entity.MediaAsset, 0, len(ms))
@@ -166,7 +177,7 @@ func (r *MediaRepository) ListSoftDeletedOlderThan(ctx context.Context, cutoff t
 	var ms []model.MediaAsset
 	// Find records where deleted_at IS NOT NULL (soft deleted)
 	// We use Unscoped() to include soft-deleted records in the query
-	if err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Limit(limit).Find(&ms).Error; err != nil {
+	if err := conn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Limit(limit).Find(&ms).Error; err != nil {
 		return nil, fmt.Errorf("media_repository.ListSoftDeletedOlderThan: %w", err)
 	}
 	out := make([]entity.MediaAsset, 0, len(ms))
@@ -176,19 +187,26 @@ func (r *MediaRepository) ListSoftDeletedOlderThan(ctx context.Context, cutoff t
 	return out, nil
 }
 
+// CountReferences counts the posts, pages and content entries that reference the asset.
 func (r *MediaRepository) CountReferences(ctx context.Context, assetID uint) (int64, error) {
-	var cnt int64
-	if err := r.db.WithContext(ctx).Model(&model.PostAsset{}).Where("asset_id = ?", assetID).Count(&cnt).Error; err != nil {
+	var postCnt, pageCnt, entryCnt int64
+	if err := conn(ctx, r.db).Model(&model.PostAsset{}).Where("asset_id = ?", assetID).Count(&postCnt).Error; err != nil {
 		return 0, fmt.Errorf("media_repository.CountReferences: %w", err)
 	}
-	return cnt, nil
+	if err := conn(ctx, r.db).Model(&model.PageAsset{}).Where("asset_id = ?", assetID).Count(&pageCnt).Error; err != nil {
+		return 0, fmt.Errorf("media_repository.CountReferences.pages: %w", err)
+	}
+	if err := conn(ctx, r.db).Model(&model.ContentEntryAsset{}).Where("asset_id = ?", assetID).Count(&entryCnt).Error; err != nil {
+		return 0, fmt.Errorf("media_repository.CountReferences.entries: %w", err)
+	}
+	return postCnt + pageCnt + entryCnt, nil
 }
 
 func (r *MediaRepository) UpsertPostReferences(ctx context.Context, postID uint, purpose string, assetIDs []uint) error {
 	if purpose == "" {
 		purpose = "content"
 	}
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Unscoped().Where("post_id = ? AND purpose = ?", postID, purpose).Delete(&model.PostAsset{}).Error; err != nil {
 			return fmt.Errorf("media_repository.UpsertPostReferences.delete: %w", err)
 		}
@@ -206,8 +224,49 @@ func (r *MediaRepository) UpsertPostReferences(ctx context.Context, postID uint,
 	})
 }
 
+func (r *MediaRepository) UpsertPageReferences(ctx context.Context, pageID uint, purpose string, assetIDs []uint) error {
+	if purpose == "" {
+		purpose = "content"
+	}
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("page_id = ? AND purpose = ?", pageID, purpose).Delete(&model.PageAsset{}).Error; err != nil {
+			return fmt.Errorf("media_repository.UpsertPageReferences.delete: %w", err)
+		}
+		if len(assetIDs) == 0 {
+			return nil
+		}
+		rows := make([]model.PageAsset, 0, len(assetIDs))
+		for _, id := range assetIDs {
+			rows = append(rows, model.PageAsset{PageID: pageID, AssetID: id, Purpose: purpose})
+		}
+		if err := tx.Create(&rows).Error; err != nil {
+			return fmt.Errorf("media_repository.UpsertPageReferences.insert: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *MediaRepository) UpsertEntryReferences(ctx context.Context, entryID uint, assetIDs []uint) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("entry_id = ?", entryID).Delete(&model.ContentEntryAsset{}).Error; err != nil {
+			return fmt.Errorf("media_repository.UpsertEntryReferences.delete: %w", err)
+		}
+		if len(assetIDs) == 0 {
+			return nil
+		}
+		rows := make([]model.ContentEntryAsset, 0, len(assetIDs))
+		for _, id := range assetIDs {
+			rows = append(rows, model.ContentEntryAsset{EntryID: entryID, AssetID: id})
+		}
+		if err := tx.Create(&rows).Error; err != nil {
+			return fmt.Errorf("media_repository.UpsertEntryReferences.insert: %w", err)
+		}
+		return nil
+	})
+}
+
 func (r *MediaRepository) ListPostMedia(ctx context.Context, postID uint, purpose *string) ([]entity.MediaAsset, error) {
-	q := r.db.WithContext(ctx).
+	q := conn(ctx, r.db).
 		Table("media_assets").
 		Select("media_assets.*").
 		Joins("JOIN post_assets ON post_assets.asset_id = media_assets.id").
@@ -232,7 +291,7 @@ func (r *MediaRepository) UpdateAssetFields(ctx context.Context, assetID uint, f
 	if len(fields) == 0 {
 		return nil
 	}
-	if err := r.db.WithContext(ctx).Model(&model.MediaAsset{}).Where("id = ?", assetID).Updates(fields).Error; err != nil {
+	if err := conn(ctx, r.db).Model(&model.MediaAsset{}).Where("id = ?", assetID).Updates(fields).Error; err != nil {
 		return fmt.Errorf("media_repository.UpdateAssetFields: %w", err)
 	}
 	return nil
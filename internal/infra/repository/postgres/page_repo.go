@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/model"
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+func pageToEntity(m model.Page) entity.Page {
+	return entity.Page{
+		ID:        m.ID,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+		ParentID:  m.ParentID,
+		Title:     m.Title,
+		Slug:      m.Slug,
+		Path:      m.Path,
+		Content:   m.Content,
+		Cover:     m.Cover,
+		Status:    m.Status,
+		SortOrder: m.SortOrder,
+		AuthorID:  m.AuthorID,
+	}
+}
+
+func pageToModel(e entity.Page) model.Page {
+	return model.Page{
+		ID:        e.ID,
+		UpdatedAt: e.UpdatedAt,
+		ParentID:  e.ParentID,
+		SortOrder: e.SortOrder,
+		Title:     e.Title,
+		Slug:      e.Slug,
+		Path:      e.Path,
+		Content:   e.Content,
+		Cover:     e.Cover,
+		Status:    e.Status,
+		AuthorID:  e.AuthorID,
+	}
+}
+
+// PageRepository persists static pages in Postgres.
+type PageRepository struct {
+	db *gorm.DB
+}
+
+var _ core.PageRepository = (*PageRepository)(nil)
+
+func NewPageRepository(db *gorm.DB) *PageRepository {
+	return &PageRepository{db: db}
+}
+
+func (r *PageRepository) Create(ctx context.Context, page entity.Page) (entity.Page, error) {
+	m := pageToModel(page)
+	m.ID = 0
+	if err := conn(ctx, r.db).Create(&m).Error; err != nil {
+		if isUniqueViolation(err) {
+			return entity.Page{}, core.ErrDuplicate
+		}
+		return entity.Page{}, fmt.Errorf("page_repository.Create: %w", err)
+	}
+	return pageToEntity(m), nil
+}
+
+func (r *PageRepository) GetByID(ctx context.Context, id uint) (entity.Page, error) {
+	return r.first(ctx, "page_repository.GetByID", "id = ?", id)
+}
+
+func (r *PageRepository) GetByPath(ctx context.Context, path string) (entity.Page, error) {
+	return r.first(ctx, "page_repository.GetByPath", "path = ?", path)
+}
+
+func (r *PageRepository) first(ctx context.Context, op string, query string, args ...any) (entity.Page, error) {
+	var m model.Page
+	if err := conn(ctx, r.db).Where(query, args...).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Page{}, core.ErrNotFound
+		}
+		return entity.Page{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return pageToEntity(m), nil
+}
+
+func (r *PageRepository) ListByPaths(ctx context.Context, paths []string) ([]entity.Page, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	var ms []model.Page
+	if err := conn(ctx, r.db).Where("path IN ?", paths).Find(&ms).Error; err != nil {
+		return nil, fmt.Errorf("page_repository.ListByPaths: %w", err)
+	}
+	return pagesToEntities(ms), nil
+}
+
+// List returns the matching pages in sibling order; the service assembles the tree.
+func (r *PageRepository) List(ctx context.Context, query entity.PageQuery) ([]entity.Page, error) {
+	var ms []model.Page
+	if err := r.filtered(ctx, query).Order("sort_order ASC, id ASC").Find(&ms).Error; err != nil {
+		return nil, fmt.Errorf("page_repository.List: %w", err)
+	}
+	return pagesToEntities(ms), nil
+}
+
+func (r *PageRepository) ListChildren(ctx context.Context, parentID *uint, query entity.PageQuery) ([]entity.Page, error) {
+	var ms []model.Page
+	if err := whereParent(r.filtered(ctx, query), parentID).Order("sort_order ASC, id ASC").Find(&ms).Error; err != nil {
+		return nil, fmt.Errorf("page_repository.ListChildren: %w", err)
+	}
+	return pagesToEntities(ms), nil
+}
+
+func (r *PageRepository) IsPathExists(ctx context.Context, path string) (bool, error) {
+	var n int64
+	if err := conn(ctx, r.db).Model(&model.Page{}).Where("path = ?", path).Count(&n).Error; err != nil {
+		return false, fmt.Errorf("page_repository.IsPathExists: %w", err)
+	}
+	return n > 0, nil
+}
+
+// Update saves the page. When its path changed, the paths of all descendants are rewritten
+// in the same transaction so the subtree stays addressable under the new prefix.
+func (r *PageRepository) Update(ctx context.Context, page entity.Page) error {
+	m := pageToModel(page)
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var current model.Page
+		if err := tx.Select("id", "path").First(&current, page.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return core.ErrNotFound
+			}
+			return fmt.Errorf("page_repository.Update.load: %w", err)
+		}
+
+		if err := tx.Model(&model.Page{ID: page.ID}).
+			Select("parent_id", "sort_order", "title", "slug", "path", "content", "cover", "status", "updated_at").
+			Updates(&m).Error; err != nil {
+			if isUniqueViolation(err) {
+				return core.ErrDuplicate
+			}
+			return fmt.Errorf("page_repository.Update: %w", err)
+		}
+
+		if current.Path == page.Path {
+			return nil
+		}
+		prefix := current.Path + "/"
+		n := utf8.RuneCountInString(prefix)
+		if err := tx.Model(&model.Page{}).
+			Where("substr(path, 1, ?) = ?", n, prefix).
+			Update("path", gorm.Expr("? || substr(path, ?)", page.Path+"/", n+1)).Error; err != nil {
+			if isUniqueViolation(err) {
+				return core.ErrDuplicate
+			}
+			return fmt.Errorf("page_repository.Update.descendants: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *PageRepository) Reorder(ctx context.Context, parentID *uint, ids []uint) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			res := whereParent(tx.Model(&model.Page{}).Where("id = ?", id), parentID).Update("sort_order", i)
+			if res.Error != nil {
+				return fmt.Errorf("page_repository.Reorder: %w", res.Error)
+			}
+			if res.RowsAffected == 0 {
+				return core.ErrNotFound
+			}
+		}
+		return nil
+	})
+}
+
+// Delete removes a page together with its media references. Pages with children are rejected
+// by the parent foreign key; the service checks for them first to report a clear conflict.
+func (r *PageRepository) Delete(ctx context.Context, id uint) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("page_id = ?", id).Delete(&model.PageAsset{}).Error; err != nil {
+			return fmt.Errorf("page_repository.Delete.assets: %w", err)
+		}
+		res := tx.Delete(&model.Page{}, id)
+		if res.Error != nil {
+			return fmt.Errorf("page_repository.Delete: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return core.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (r *PageRepository) filtered(ctx context.Context, query entity.PageQuery) *gorm.DB {
+	q := conn(ctx, r.db).Model(&model.Page{})
+	if query.AuthorID != nil {
+		q = q.Where("author_id = ?", *query.AuthorID)
+	}
+	if query.Status != nil {
+		q = q.Where("status = ?", *query.Status)
+	}
+	return q
+}
+
+func whereParent(q *gorm.DB, parentID *uint) *gorm.DB {
+	if parentID == nil {
+		return q.Where("parent_id IS NULL")
+	}
+	return q.Where("parent_id = ?", *parentID)
+}
+
+func pagesToEntities(ms []model.Page) []entity.Page {
+	out := make([]entity.Page, len(ms))
+	for i, m := range ms {
+		out[i] = pageToEntity(m)
+	}
+	return out
+}
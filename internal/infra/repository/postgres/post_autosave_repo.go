@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ core.PostAutosaveRepository = (*PostAutosaveRepository)(nil)
+
+func postAutosaveToEntity(m model.PostAutosave) entity.PostAutosave {
+	return entity.PostAutosave{
+		PostID:      m.PostID,
+		UserID:      m.UserID,
+		Title:       m.Title,
+		Content:     m.Content,
+		BaseVersion: m.BaseVersion,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+type PostAutosaveRepository struct {
+	db *gorm.DB
+}
+
+func NewPostAutosaveRepository(db *gorm.DB) *PostAutosaveRepository {
+	return &PostAutosaveRepository{db: db}
+}
+
+// Save overwrites the user's autosave for the post, creating it on first use.
+func (r *PostAutosaveRepository) Save(ctx context.Context, autosave entity.PostAutosave) (entity.PostAutosave, error) {
+	m := model.PostAutosave{
+		PostID:      autosave.PostID,
+		UserID:      autosave.UserID,
+		Title:       autosave.Title,
+		Content:     autosave.Content,
+		BaseVersion: autosave.BaseVersion,
+	}
+	err := conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "post_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "content", "base_version", "updated_at"}),
+	}).Create(&m).Error
+	if err != nil {
+		return entity.PostAutosave{}, fmt.Errorf("post_autosave_repository.Save: %w", err)
+	}
+	return postAutosaveToEntity(m), nil
+}
+
+func (r *PostAutosaveRepository) Get(ctx context.Context, postID uint, userID uint) (entity.PostAutosave, error) {
+	var m model.PostAutosave
+	if err := conn(ctx, r.db).Where("post_id = ? AND user_id = ?", postID, userID).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.PostAutosave{}, core.ErrNotFound
+		}
+		return entity.PostAutosave{}, fmt.Errorf("post_autosave_repository.Get: %w", err)
+	}
+	return postAutosaveToEntity(m), nil
+}
+
+// Delete removes the user's autosave for the post; deleting a missing autosave is not an error.
+func (r *PostAutosaveRepository) Delete(ctx context.Context, postID uint, userID uint) error {
+	if err := conn(ctx, r.db).Where("post_id = ? AND user_id = ?", postID, userID).Delete(&model.PostAutosave{}).Error; err != nil {
+		return fmt.Errorf("post_autosave_repository.Delete: %w", err)
+	}
+	return nil
+}
+
+// DeleteOlderThan removes autosaves that have not been written since cutoff.
+func (r *PostAutosaveRepository) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	res := conn(ctx, r.db).Where("updated_at < ?", cutoff).Delete(&model.PostAutosave{})
+	if res.Error != nil {
+		return 0, fmt.Errorf("post_autosave_repository.DeleteOlderThan: %w", res.Error)
+	}
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/model"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var _ core.PostPreviewTokenRepository = (*PostPreviewTokenRepository)(nil)
+
+func postPreviewTokenToEntity(m model.PostPreviewToken) entity.PostPreviewToken {
+	return entity.PostPreviewToken{
+		ID:        m.ID,
+		PostID:    m.PostID,
+		CreatedBy: m.CreatedBy,
+		CreatedAt: m.CreatedAt,
+		ExpiresAt: m.ExpiresAt,
+		RevokedAt: m.RevokedAt,
+	}
+}
+
+type PostPreviewTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPostPreviewTokenRepository(db *gorm.DB) *PostPreviewTokenRepository {
+	return &PostPreviewTokenRepository{db: db}
+}
+
+func (r *PostPreviewTokenRepository) Create(ctx context.Context, token entity.PostPreviewToken) (entity.PostPreviewToken, error) {
+	m := model.PostPreviewToken{
+		PostID:    token.PostID,
+		CreatedBy: token.CreatedBy,
+		ExpiresAt: token.ExpiresAt,
+	}
+	if err := conn(ctx, r.db).Create(&m).Error; err != nil {
+		return entity.PostPreviewToken{}, fmt.Errorf("post_preview_token_repository.Create: %w", err)
+	}
+	return postPreviewTokenToEntity(m), nil
+}
+
+func (r *PostPreviewTokenRepository) GetByID(ctx context.Context, id uint) (entity.PostPreviewToken, error) {
+	var m model.PostPreviewToken
+	if err := conn(ctx, r.db).First(&m, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.PostPreviewToken{}, core.ErrNotFound
+		}
+		return entity.PostPreviewToken{}, fmt.Errorf("post_preview_token_repository.GetByID: %w", err)
+	}
+	return postPreviewTokenToEntity(m), nil
+}
+
+// ListByPost returns the post's tokens, newest first, including expired and revoked ones.
+func (r *PostPreviewTokenRepository) ListByPost(ctx context.Context, postID uint) ([]entity.PostPreviewToken, error) {
+	var models []model.PostPreviewToken
+	if err := conn(ctx, r.db).Where("post_id = ?", postID).Order("created_at DESC, id DESC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("post_preview_token_repository.ListByPost: %w", err)
+	}
+	tokens := make([]entity.PostPreviewToken, len(models))
+	for i, m := range models {
+		tokens[i] = postPreviewTokenToEntity(m)
+	}
+	return tokens, nil
+}
+
+func (r *PostPreviewTokenRepository) Revoke(ctx context.Context, postID uint, id uint, at time.Time) error {
+	res := conn(ctx, r.db).Model(&model.PostPreviewToken{}).
+		Where("id = ? AND post_id = ? AND revoked_at IS NULL", id, postID).
+		Update("revoked_at", at)
+	if res.Error != nil {
+		return fmt.Errorf("post_preview_token_repository.Revoke: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return core.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/model"
+	"context"
+	"fmt"
+)
+
+// relatedTagScoreSQL counts the tags a candidate post shares with the source post.
+const relatedTagScoreSQL = `(SELECT COUNT(*) FROM post_tags pt WHERE pt.post_id = posts.id AND pt.tag_id IN (SELECT tag_id FROM post_tags WHERE post_id = src.id))`
+
+// relatedCategoryScoreSQL is 1 when the candidate is in the source post's category; NULL categories never match.
+const relatedCategoryScoreSQL = `(CASE WHEN posts.category_id = src.category_id THEN 1 ELSE 0 END)`
+
+// relatedTextScoreSQL ranks the candidate's search vector against any word of the source title.
+// plainto_tsquery joins the title words with '&'; swapping in '|' turns it into an OR query.
+const relatedTextScoreSQL = `ts_rank(posts.search_vector, replace(plainto_tsquery('simple', regexp_replace(coalesce(src.title, ''), '` + cjkSegmentPattern + `', ' \1 ', 'g'))::text, '&', '|')::tsquery)`
+
+// GetRelatedPublished scores every other published post in the source post's locale by shared
+// tags, same category and, when asked, text similarity, and returns the best-scoring ones.
+// Posts without any relatedness are left out; ties go to the newer post.
+func (r *PostRepository) GetRelatedPublished(ctx context.Context, query entity.RelatedPostQuery) ([]entity.Post, error) {
+	query = query.Normalized()
+
+	score := "? * " + relatedTagScoreSQL + " + ? * " + relatedCategoryScoreSQL
+	args := []any{entity.RelatedTagWeight, entity.RelatedCategoryWeight}
+	if query.TextSimilarity {
+		score += " + ? * " + relatedTextScoreSQL
+		args = append(args, entity.RelatedTextWeight)
+	}
+
+	scored := conn(ctx, r.db).Model(&model.Post{}).
+		Select("posts.id, posts.created_at, ("+score+") AS score", args...).
+		Joins("JOIN posts src ON src.id = ?", query.PostID).
+		Where("posts.status = ? AND posts.id <> src.id AND posts.locale = src.locale", entity.StatusPublished)
+
+	var rows []struct {
+		ID    uint
+		Score float64
+	}
+	if err := conn(ctx, r.db).Table("(?) AS related", scored).
+		Select("id, score").
+		Where("score > 0").
+		Order("score DESC, created_at DESC, id DESC").
+		Limit(query.Limit).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("post_repository.GetRelatedPublished.score: %w", err)
+	}
+	if len(rows) == 0 {
+		return []entity.Post{}, nil
+	}
+
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	var postModels []model.Post
+	if err := r.scopedQuery(ctx).Where("id IN ?", ids).Find(&postModels).Error; err != nil {
+		return nil, fmt.Errorf("post_repository.GetRelatedPublished: %w", err)
+	}
+
+	byID := make(map[uint]model.Post, len(postModels))
+	for _, m := range postModels {
+		byID[m.ID] = m
+	}
+	posts := make([]entity.Post, 0, len(ids))
+	for _, id := range ids {
+		if m, ok := byID[id]; ok {
+			posts = append(posts, postToEntity(m))
+		}
+	}
+	return posts, nil
+}
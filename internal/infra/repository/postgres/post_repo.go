@@ -5,11 +5,15 @@ import (
 	"KaldalisCMS/internal/core/entity"
 	"KaldalisCMS/internal/infra/model"
 	"context"
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // --- Mapper Functions ---
@@ -26,17 +30,12 @@ func postToEntity(m model.Post) entity.Post {
 	var categoryEntity entity.Category
 
 	if m.Category != nil {
-		categoryEntity = entity.Category{
-			ID:   m.Category.ID,
-			Name: m.Category.Name,
-			// 如果需要 Slug 就加上，不需要就删掉这行
-			// Slug: m.Category.Slug,
-		}
+		categoryEntity = categoryToEntity(*m.Category)
 	}
 
 	var tagsEntity []entity.Tag
 	for _, tagModel := range m.Tags {
-		tagsEntity = append(tagsEntity, entity.Tag{ID: tagModel.ID, Name: tagModel.Name})
+		tagsEntity = append(tagsEntity, tagToEntity(tagModel))
 	}
 
 	return entity.Post{
@@ -54,24 +53,57 @@ func postToEntity(m model.Post) entity.Post {
 		CategoryID: m.CategoryID,
 		Category:   categoryEntity,
 
-		Tags:   tagsEntity,
-		Status: m.Status,
+		Tags:             tagsEntity,
+		Status:           m.Status,
+		Version:          m.Version,
+		PublishAt:        m.PublishAt,
+		UnpublishAt:      m.UnpublishAt,
+		NoIndex:          m.NoIndex,
+		CommentsDisabled: m.CommentsDisabled,
+		ReviewNote:       m.ReviewNote,
+		DeletedAt:        deletedAtToEntity(m.DeletedAt),
+
+		Locale:             m.Locale,
+		TranslationGroupID: m.TranslationGroupID,
+
+		SeriesID:       m.SeriesID,
+		SeriesPosition: m.SeriesPosition,
 	}
 }
 
+func deletedAtToEntity(d gorm.DeletedAt) *time.Time {
+	if !d.Valid {
+		return nil
+	}
+	t := d.Time
+	return &t
+}
+
 // entity转换成model
 func postToModel(e entity.Post) model.Post {
 	return model.Post{
-		ID:         e.ID,
-		CreatedAt:  e.CreatedAt,
-		UpdatedAt:  e.UpdatedAt,
-		Title:      e.Title,
-		Slug:       e.Slug,
-		Content:    e.Content,
-		Cover:      e.Cover,
-		AuthorID:   e.AuthorID,
-		CategoryID: e.CategoryID,
-		Status:     e.Status,
+		ID:               e.ID,
+		CreatedAt:        e.CreatedAt,
+		UpdatedAt:        e.UpdatedAt,
+		Title:            e.Title,
+		Slug:             e.Slug,
+		Content:          e.Content,
+		Cover:            e.Cover,
+		AuthorID:         e.AuthorID,
+		CategoryID:       e.CategoryID,
+		Status:           e.Status,
+		Version:          e.Version,
+		PublishAt:        e.PublishAt,
+		UnpublishAt:      e.UnpublishAt,
+		NoIndex:          e.NoIndex,
+		CommentsDisabled: e.CommentsDisabled,
+		ReviewNote:       e.ReviewNote,
+
+		Locale:             e.Locale,
+		TranslationGroupID: e.TranslationGroupID,
+
+		SeriesID:       e.SeriesID,
+		SeriesPosition: e.SeriesPosition,
 	}
 }
 
@@ -83,6 +115,25 @@ func postToEntities(postModels []model.Post) []entity.Post {
 	return postEntities
 }
 
+// textArray binds a string slice as a single Postgres text[] parameter; gorm would otherwise
+// expand it into a parenthesized list.
+type textArray []string
+
+func (a textArray) Value() (driver.Value, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, v := range a {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('"')
+		b.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
+
 type PostRepository struct {
 	db *gorm.DB
 }
@@ -90,12 +141,15 @@ type PostRepository struct {
 // scopedQuery applies the eager-loading required by both public delivery and management views.
 // Keeping the preload policy here avoids repeating relation wiring across repository methods.
 func (r *PostRepository) scopedQuery(ctx context.Context) *gorm.DB {
-	return r.db.WithContext(ctx).Preload("Author").Preload("Category").Preload("Tags")
+	return conn(ctx, r.db).Preload("Author").Preload("Category").Preload("Tags")
 }
 
-func (r *PostRepository) IsSlugExists(ctx context.Context, slug string) (bool, error) {
+// IsSlugExists reports whether slug is taken within locale. It also counts trashed posts:
+// they keep their slug until purged so a restore never collides, and the unique index would
+// reject the duplicate anyway.
+func (r *PostRepository) IsSlugExists(ctx context.Context, locale string, slug string) (bool, error) {
 	var postModel model.Post
-	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&postModel).Error; err != nil {
+	if err := conn(ctx, r.db).Unscoped().Where("locale = ? AND slug = ?", locale, slug).First(&postModel).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return false, nil //Slug不重复
 		}
@@ -118,14 +172,75 @@ func (r *PostRepository) GetAll(ctx context.Context) ([]entity.Post, error) {
 	return postToEntities(postModels), nil
 }
 
-func (r *PostRepository) GetPublished(ctx context.Context) ([]entity.Post, error) {
+func (r *PostRepository) GetAllAfter(ctx context.Context, afterID uint, limit int) ([]entity.Post, error) {
 	var postModels []model.Post
-	if err := r.scopedQuery(ctx).Where("status = ?", entity.StatusPublished).Find(&postModels).Error; err != nil {
-		return nil, fmt.Errorf("post_repository.GetPublished: %w", err)
+	if err := r.scopedQuery(ctx).
+		Where("posts.id > ?", afterID).
+		Order("posts.id ASC").
+		Limit(limit).
+		Find(&postModels).Error; err != nil {
+		return nil, fmt.Errorf("post_repository.GetAllAfter: %w", err)
 	}
 	return postToEntities(postModels), nil
 }
 
+func (r *PostRepository) GetPublished(ctx context.Context, query entity.PostListQuery) ([]entity.Post, int64, error) {
+	query = query.Normalized()
+	base := applyPostListFilters(conn(ctx, r.db).Model(&model.Post{}), query).
+		Where("posts.status = ?", entity.StatusPublished)
+
+	var total int64
+	if err := base.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("post_repository.GetPublished.count: %w", err)
+	}
+
+	var postModels []model.Post
+	if err := base.Preload("Author").Preload("Category").Preload("Tags").
+		Order(postListOrder(query)).
+		Offset(query.Offset()).
+		Limit(query.PageSize).
+		Find(&postModels).Error; err != nil {
+		return nil, 0, fmt.Errorf("post_repository.GetPublished: %w", err)
+	}
+	return postToEntities(postModels), total, nil
+}
+
+// applyPostListFilters narrows a posts query by the optional dimensions of a list query.
+// Tag filtering uses a subquery on the join table so pagination counts stay one row per post.
+func applyPostListFilters(db *gorm.DB, query entity.PostListQuery) *gorm.DB {
+	if query.TagID != nil {
+		db = db.Where("posts.id IN (SELECT post_id FROM post_tags WHERE tag_id = ?)", *query.TagID)
+	}
+	if query.CategoryID != nil {
+		db = db.Where("posts.category_id = ?", *query.CategoryID)
+	}
+	if query.AuthorID != nil {
+		db = db.Where("posts.author_id = ?", *query.AuthorID)
+	}
+	if len(query.Locales) > 0 {
+		// Keep a post only if no published sibling of its translation group is in a locale
+		// that comes earlier in the chain.
+		db = db.Where("posts.locale IN ?", query.Locales).
+			Where(`posts.translation_group_id IS NULL OR NOT EXISTS (
+				SELECT 1 FROM posts t
+				WHERE t.translation_group_id = posts.translation_group_id
+					AND t.id <> posts.id
+					AND t.status = ?
+					AND t.deleted_at IS NULL
+					AND t.locale IN ?
+					AND array_position(?::text[], t.locale::text) < array_position(?::text[], posts.locale::text))`,
+				entity.StatusPublished, query.Locales, textArray(query.Locales), textArray(query.Locales))
+	}
+	return db
+}
+
+// postListOrder builds an ORDER BY clause from a normalized query.
+// SortBy/Order are whitelisted by PostListQuery.Normalized, so interpolation is safe here.
+// The id tie-breaker keeps page boundaries stable when timestamps collide.
+func postListOrder(query entity.PostListQuery) string {
+	return fmt.Sprintf("posts.%s %s, posts.id %s", query.SortBy, query.Order, query.Order)
+}
+
 func (r *PostRepository) GetByID(ctx context.Context, id uint) (entity.Post, error) {
 	var postModel model.Post
 	if err := r.scopedQuery(ctx).First(&postModel, id).Error; err != nil {
@@ -148,20 +263,101 @@ func (r *PostRepository) GetPublishedByID(ctx context.Context, id uint) (entity.
 	return postToEntity(postModel), nil
 }
 
+// GetPublishedBySlug resolves a slug that may exist in several locales. The post in the
+// earliest locale of the chain wins; without a match in the chain, the oldest post does.
+func (r *PostRepository) GetPublishedBySlug(ctx context.Context, slug string, locales []string) (entity.Post, error) {
+	var postModel model.Post
+	db := r.scopedQuery(ctx).Where("slug = ? AND status = ?", slug, entity.StatusPublished)
+	if len(locales) > 0 {
+		db = db.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "array_position(?::text[], posts.locale::text) NULLS LAST",
+			Vars: []any{textArray(locales)},
+		}})
+	}
+	if err := db.Order("posts.id").First(&postModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Post{}, core.ErrNotFound
+		}
+		return entity.Post{}, fmt.Errorf("post_repository.GetPublishedBySlug: %w", err)
+	}
+	return postToEntity(postModel), nil
+}
+
+// GetTranslations returns the posts of the given translation groups, without relations.
+// Trashed posts are never included; publishedOnly further limits them to published ones.
+func (r *PostRepository) GetTranslations(ctx context.Context, groupIDs []uint, publishedOnly bool) ([]entity.Post, error) {
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+	db := conn(ctx, r.db).
+		Select("id", "title", "slug", "status", "locale", "translation_group_id").
+		Where("translation_group_id IN ?", groupIDs)
+	if publishedOnly {
+		db = db.Where("status = ?", entity.StatusPublished)
+	}
+	var postModels []model.Post
+	if err := db.Order("locale, id").Find(&postModels).Error; err != nil {
+		return nil, fmt.Errorf("post_repository.GetTranslations: %w", err)
+	}
+	return postToEntities(postModels), nil
+}
+
+// SetTranslationGroup moves the given posts into a translation group, or out of any group
+// when groupID is nil. Their versions are bumped so cached representations are invalidated.
+func (r *PostRepository) SetTranslationGroup(ctx context.Context, ids []uint, groupID *uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	res := conn(ctx, r.db).Model(&model.Post{}).
+		Where("id IN ?", ids).
+		Updates(map[string]any{"translation_group_id": groupID, "version": gorm.Expr("version + 1")})
+	if res.Error != nil {
+		return fmt.Errorf("post_repository.SetTranslationGroup: %w", res.Error)
+	}
+	if res.RowsAffected != int64(len(ids)) {
+		return core.ErrNotFound
+	}
+	return nil
+}
+
+// GetPostIDBySlugHistory resolves a slug a post used previously in one of the locales.
+func (r *PostRepository) GetPostIDBySlugHistory(ctx context.Context, slug string, locales []string) (uint, error) {
+	if len(locales) == 0 {
+		return 0, core.ErrNotFound
+	}
+	var h model.PostSlugHistory
+	err := conn(ctx, r.db).
+		Where("slug = ? AND locale IN ?", slug, locales).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "array_position(?::text[], locale::text)",
+			Vars: []any{textArray(locales)},
+		}}).
+		First(&h).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, core.ErrNotFound
+		}
+		return 0, fmt.Errorf("post_repository.GetPostIDBySlugHistory: %w", err)
+	}
+	return h.PostID, nil
+}
+
+// GetDraftsByAuthor returns the author's unpublished work: drafts and drafts awaiting review.
 func (r *PostRepository) GetDraftsByAuthor(ctx context.Context, authorID uint) ([]entity.Post, error) {
 	var postModels []model.Post
 	if err := r.scopedQuery(ctx).
-		Where("author_id = ? AND status = ?", authorID, entity.StatusDraft).
+		Where("author_id = ? AND status IN ?", authorID, []int{entity.StatusDraft, entity.StatusPendingReview}).
 		Find(&postModels).Error; err != nil {
 		return nil, fmt.Errorf("post_repository.GetDraftsByAuthor: %w", err)
 	}
 	return postToEntities(postModels), nil
 }
 
+// GetDraftByIDAndAuthor loads one of the author's drafts, including one awaiting review.
 func (r *PostRepository) GetDraftByIDAndAuthor(ctx context.Context, id uint, authorID uint) (entity.Post, error) {
 	var postModel model.Post
 	if err := r.scopedQuery(ctx).
-		Where("id = ? AND author_id = ? AND status = ?", id, authorID, entity.StatusDraft).
+		Where("id = ? AND author_id = ? AND status IN ?", id, authorID, []int{entity.StatusDraft, entity.StatusPendingReview}).
 		First(&postModel).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return entity.Post{}, core.ErrNotFound
@@ -171,9 +367,59 @@ func (r *PostRepository) GetDraftByIDAndAuthor(ctx context.Context, id uint, aut
 	return postToEntity(postModel), nil
 }
 
+// GetPendingReview returns the editorial review queue, longest-waiting first.
+func (r *PostRepository) GetPendingReview(ctx context.Context) ([]entity.Post, error) {
+	var postModels []model.Post
+	if err := r.scopedQuery(ctx).
+		Where("status = ?", entity.StatusPendingReview).
+		Order("updated_at ASC").
+		Find(&postModels).Error; err != nil {
+		return nil, fmt.Errorf("post_repository.GetPendingReview: %w", err)
+	}
+	return postToEntities(postModels), nil
+}
+
+// GetDueScheduled returns scheduled posts whose publish time has passed, oldest first.
+func (r *PostRepository) GetDueScheduled(ctx context.Context, now time.Time, limit int) ([]entity.Post, error) {
+	var postModels []model.Post
+	if err := r.scopedQuery(ctx).
+		Where("status = ? AND publish_at <= ?", entity.StatusScheduled, now).
+		Order("publish_at ASC").
+		Limit(limit).
+		Find(&postModels).Error; err != nil {
+		return nil, fmt.Errorf("post_repository.GetDueScheduled: %w", err)
+	}
+	return postToEntities(postModels), nil
+}
+
+// GetDueExpired returns published posts whose unpublish time has passed, oldest first.
+func (r *PostRepository) GetDueExpired(ctx context.Context, now time.Time, limit int) ([]entity.Post, error) {
+	var postModels []model.Post
+	if err := r.scopedQuery(ctx).
+		Where("status = ? AND unpublish_at <= ?", entity.StatusPublished, now).
+		Order("unpublish_at ASC").
+		Limit(limit).
+		Find(&postModels).Error; err != nil {
+		return nil, fmt.Errorf("post_repository.GetDueExpired: %w", err)
+	}
+	return postToEntities(postModels), nil
+}
+
 func (r *PostRepository) Create(ctx context.Context, post entity.Post) (entity.Post, error) {
 	postModel := postToModel(post)
-	if err := r.db.WithContext(ctx).Create(&postModel).Error; err != nil {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&postModel).Error; err != nil {
+			return err
+		}
+		if err := replacePostTags(tx, postModel.ID, post.Tags); err != nil {
+			return err
+		}
+		return refreshSearchVector(tx, postModel.ID)
+	})
+	if err != nil {
+		if errors.Is(err, core.ErrInvalidInput) {
+			return entity.Post{}, err
+		}
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			if pgErr.Code == "23505" { // 23505 is the SQLSTATE for unique_violation
@@ -185,26 +431,126 @@ func (r *PostRepository) Create(ctx context.Context, post entity.Post) (entity.P
 
 	created := post
 	created.ID = postModel.ID
+	created.Version = postModel.Version
 	return created, nil
 }
 
+// Update saves the post only if its stored version still equals post.Version, then bumps the
+// version. A concurrent change in between makes it fail with ErrConflict instead of silently
+// overwriting the other writer.
 func (r *PostRepository) Update(ctx context.Context, post entity.Post) error {
 	postModel := postToModel(post)
+	postModel.Version = post.Version + 1
 
-	if err := r.db.WithContext(ctx).Save(&postModel).Error; err != nil {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := recordSlugChange(tx, postModel.ID, postModel.Locale, postModel.Slug); err != nil {
+			return err
+		}
+		// deleted_at is owned by Delete/Restore and search_vector by refreshSearchVector; writing
+		// them from the in-memory post would undo a concurrent trash or blank the vector.
+		res := tx.Model(&postModel).
+			Where("version = ?", post.Version).
+			Select("*").
+			Omit("id", "created_at", "deleted_at", "search_vector", clause.Associations).
+			Updates(&postModel)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("%w: post %d is no longer at version %d", core.ErrConflict, post.ID, post.Version)
+		}
+		if err := replacePostTags(tx, postModel.ID, post.Tags); err != nil {
+			return err
+		}
+		return refreshSearchVector(tx, postModel.ID)
+	})
+	if err != nil {
+		if errors.Is(err, core.ErrInvalidInput) || errors.Is(err, core.ErrConflict) {
+			return err
+		}
 		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			if pgErr.Code == "23505" { // 23505 is the SQLSTATE for unique_violation
-				return core.ErrDuplicate
-			}
-			return fmt.Errorf("post_repository.Update: %w", err)
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // 23505 is the SQLSTATE for unique_violation
+			return core.ErrDuplicate
+		}
+		return fmt.Errorf("post_repository.Update: %w", err)
+	}
+	return nil
+}
+
+// recordSlugChange keeps the slug history in step with a post that is about to be saved:
+// the outgoing slug is remembered for redirects in the locale it was used in, and the
+// incoming slug is released from that locale's history so it resolves directly again.
+func recordSlugChange(tx *gorm.DB, postID uint, newLocale string, newSlug string) error {
+	if postID == 0 || newSlug == "" {
+		return nil
+	}
+	var current model.Post
+	if err := tx.Unscoped().Select("id", "locale", "slug").First(&current, postID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if current.Locale == newLocale && current.Slug == newSlug {
+		return nil
+	}
+	if err := tx.Where("locale = ? AND slug = ?", newLocale, newSlug).Delete(&model.PostSlugHistory{}).Error; err != nil {
+		return err
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "locale"}, {Name: "slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"post_id", "created_at"}),
+	}).Create(&model.PostSlugHistory{PostID: postID, Locale: current.Locale, Slug: current.Slug}).Error
+}
+
+// migrateSlugHistoryLocale upgrades slug history recorded while it was keyed by slug alone:
+// each row takes its post's locale, and the old unique index is dropped so another locale
+// can remember the same slug.
+func migrateSlugHistoryLocale(db *gorm.DB) error {
+	const legacyIndex = "idx_post_slug_histories_slug"
+	if !db.Migrator().HasIndex(&model.PostSlugHistory{}, legacyIndex) {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`UPDATE post_slug_histories h SET locale = p.locale
+			FROM posts p WHERE p.id = h.post_id AND h.locale <> p.locale`).Error
+		if err != nil {
+			return err
+		}
+		return tx.Migrator().DropIndex(&model.PostSlugHistory{}, legacyIndex)
+	})
+}
+
+// replacePostTags makes the post's tag set exactly tags. Unknown tag IDs fail the whole
+// save with ErrInvalidInput rather than being dropped silently.
+func replacePostTags(tx *gorm.DB, postID uint, tags []entity.Tag) error {
+	if err := tx.Exec("DELETE FROM post_tags WHERE post_id = ?", postID).Error; err != nil {
+		return err
+	}
+	seen := make(map[uint]struct{}, len(tags))
+	ids := make([]uint, 0, len(tags))
+	for _, t := range tags {
+		if _, dup := seen[t.ID]; dup {
+			continue
 		}
+		seen[t.ID] = struct{}{}
+		ids = append(ids, t.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	res := tx.Exec("INSERT INTO post_tags (post_id, tag_id) SELECT ?, id FROM tags WHERE id IN ? AND deleted_at IS NULL", postID, ids)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected != int64(len(ids)) {
+		return fmt.Errorf("%w: unknown tag id", core.ErrInvalidInput)
 	}
 	return nil
 }
 
 func (r *PostRepository) Delete(ctx context.Context, id uint) error {
-	res := r.db.WithContext(ctx).Delete(&model.Post{}, id)
+	res := conn(ctx, r.db).Delete(&model.Post{}, id)
 	if res.Error != nil {
 		return fmt.Errorf("post_repository.Delete: %w", res.Error)
 	}
@@ -214,3 +560,78 @@ func (r *PostRepository) Delete(ctx context.Context, id uint) error {
 
 	return nil
 }
+
+// GetTrashed returns soft-deleted posts, most recently deleted first.
+func (r *PostRepository) GetTrashed(ctx context.Context) ([]entity.Post, error) {
+	var postModels []model.Post
+	if err := r.scopedQuery(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Find(&postModels).Error; err != nil {
+		return nil, fmt.Errorf("post_repository.GetTrashed: %w", err)
+	}
+	return postToEntities(postModels), nil
+}
+
+// GetTrashedBefore returns posts that were moved to the trash before cutoff, oldest first.
+func (r *PostRepository) GetTrashedBefore(ctx context.Context, cutoff time.Time, limit int) ([]entity.Post, error) {
+	var postModels []model.Post
+	if err := conn(ctx, r.db).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Find(&postModels).Error; err != nil {
+		return nil, fmt.Errorf("post_repository.GetTrashedBefore: %w", err)
+	}
+	return postToEntities(postModels), nil
+}
+
+// Restore takes a post out of the trash. It returns ErrNotFound unless the post is trashed.
+func (r *PostRepository) Restore(ctx context.Context, id uint) error {
+	res := conn(ctx, r.db).Unscoped().Model(&model.Post{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		return fmt.Errorf("post_repository.Restore: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return core.ErrNotFound
+	}
+	return nil
+}
+
+// Purge permanently removes a trashed post together with everything that only exists for it:
+// tag links, media references, slug history, revisions and comments. Dropping the PostAsset
+// rows lets the media cleanup reclaim assets no other post uses; dropping the post row frees
+// its slug. It returns ErrNotFound unless the post is trashed.
+func (r *PostRepository) Purge(ctx context.Context, id uint) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var trashed int64
+		if err := tx.Unscoped().Model(&model.Post{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Count(&trashed).Error; err != nil {
+			return err
+		}
+		if trashed == 0 {
+			return core.ErrNotFound
+		}
+		// Children first: post_tags references posts through a foreign key.
+		if err := tx.Exec("DELETE FROM post_tags WHERE post_id = ?", id).Error; err != nil {
+			return err
+		}
+		dependents := []any{&model.PostAsset{}, &model.PostSlugHistory{}, &model.PostRevision{}, &model.PostAutosave{}, &model.Comment{}, &model.PostDailyView{}, &model.PostPreviewToken{}}
+		for _, m := range dependents {
+			if err := tx.Unscoped().Where("post_id = ?", id).Delete(m).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Delete(&model.Post{}, id).Error
+	})
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("post_repository.Purge: %w", err)
+	}
+	return nil
+}
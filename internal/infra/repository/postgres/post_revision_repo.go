@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/model"
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+var _ core.PostRevisionRepository = (*PostRevisionRepository)(nil)
+
+func postRevisionToEntity(m model.PostRevision) entity.PostRevision {
+	return entity.PostRevision{
+		ID:           m.ID,
+		CreatedAt:    m.CreatedAt,
+		PostID:       m.PostID,
+		ActorID:      m.ActorID,
+		Action:       m.Action,
+		RestoredFrom: m.RestoredFrom,
+		Title:        m.Title,
+		Slug:         m.Slug,
+		Content:      m.Content,
+		Cover:        m.Cover,
+		CategoryID:   m.CategoryID,
+		TagIDs:       m.TagIDs,
+		Status:       m.Status,
+
+		NoIndex:          m.NoIndex,
+		CommentsDisabled: m.CommentsDisabled,
+		SeriesID:         m.SeriesID,
+		SeriesPosition:   m.SeriesPosition,
+	}
+}
+
+func postRevisionToModel(e entity.PostRevision) model.PostRevision {
+	return model.PostRevision{
+		PostID:       e.PostID,
+		ActorID:      e.ActorID,
+		Action:       e.Action,
+		RestoredFrom: e.RestoredFrom,
+		Title:        e.Title,
+		Slug:         e.Slug,
+		Content:      e.Content,
+		Cover:        e.Cover,
+		CategoryID:   e.CategoryID,
+		TagIDs:       e.TagIDs,
+		Status:       e.Status,
+
+		NoIndex:          e.NoIndex,
+		CommentsDisabled: e.CommentsDisabled,
+		SeriesID:         e.SeriesID,
+		SeriesPosition:   e.SeriesPosition,
+	}
+}
+
+type PostRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewPostRevisionRepository(db *gorm.DB) *PostRevisionRepository {
+	return &PostRevisionRepository{db: db}
+}
+
+func (r *PostRevisionRepository) Create(ctx context.Context, revision entity.PostRevision) (entity.PostRevision, error) {
+	m := postRevisionToModel(revision)
+	if err := conn(ctx, r.db).Create(&m).Error; err != nil {
+		return entity.PostRevision{}, fmt.Errorf("post_revision_repository.Create: %w", err)
+	}
+	return postRevisionToEntity(m), nil
+}
+
+// ListByPost returns revisions newest first.
+func (r *PostRevisionRepository) ListByPost(ctx context.Context, postID uint) ([]entity.PostRevision, error) {
+	var ms []model.PostRevision
+	if err := conn(ctx, r.db).
+		Where("post_id = ?", postID).
+		Order("created_at DESC, id DESC").
+		Find(&ms).Error; err != nil {
+		return nil, fmt.Errorf("post_revision_repository.ListByPost: %w", err)
+	}
+	out := make([]entity.PostRevision, 0, len(ms))
+	for _, m := range ms {
+		out = append(out, postRevisionToEntity(m))
+	}
+	return out, nil
+}
+
+func (r *PostRevisionRepository) GetByID(ctx context.Context, postID uint, revisionID uint) (entity.PostRevision, error) {
+	var m model.PostRevision
+	if err := conn(ctx, r.db).Where("id = ? AND post_id = ?", revisionID, postID).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.PostRevision{}, core.ErrNotFound
+		}
+		return entity.PostRevision{}, fmt.Errorf("post_revision_repository.GetByID: %w", err)
+	}
+	return postRevisionToEntity(m), nil
+}
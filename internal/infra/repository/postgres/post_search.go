@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/model"
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// cjkSegmentPattern matches a single CJK character. The built-in 'simple' parser treats a run
+// of CJK characters as one token, so both documents and queries surround every such character
+// with spaces; queries then use phrase matching so consecutive characters must stay adjacent.
+const cjkSegmentPattern = `([぀-ヿ㐀-䶿一-鿿豈-﫿])`
+
+// postSearchDocumentSQL computes the search vector of a posts row. Title terms outrank body terms.
+const postSearchDocumentSQL = `setweight(to_tsvector('simple', regexp_replace(coalesce(title, ''), '` + cjkSegmentPattern + `', ' \1 ', 'g')), 'A') || ` +
+	`setweight(to_tsvector('simple', regexp_replace(coalesce(content, ''), '` + cjkSegmentPattern + `', ' \1 ', 'g')), 'B')`
+
+// postSearchTermSQL turns one user-supplied term into a phrase tsquery.
+const postSearchTermSQL = `phraseto_tsquery('simple', regexp_replace(?, '` + cjkSegmentPattern + `', ' \1 ', 'g'))`
+
+// refreshSearchVector recomputes the search vector of one post from its stored title and content.
+func refreshSearchVector(tx *gorm.DB, postID uint) error {
+	return tx.Exec("UPDATE posts SET search_vector = "+postSearchDocumentSQL+" WHERE id = ?", postID).Error
+}
+
+// backfillSearchVectors fills the search vector of rows written before the column existed.
+func backfillSearchVectors(db *gorm.DB) error {
+	return db.Exec("UPDATE posts SET search_vector = " + postSearchDocumentSQL + " WHERE search_vector IS NULL").Error
+}
+
+// postSearchTSQuery combines the terms of a search into one tsquery expression where every term must match.
+func postSearchTSQuery(terms []string) (string, []any) {
+	parts := make([]string, len(terms))
+	args := make([]any, len(terms))
+	for i, term := range terms {
+		parts[i] = postSearchTermSQL
+		args[i] = term
+	}
+	return "(" + strings.Join(parts, " && ") + ")", args
+}
+
+// SearchPublished runs a full-text search over published posts ordered by relevance.
+func (r *PostRepository) SearchPublished(ctx context.Context, query entity.PostSearchQuery) ([]entity.Post, int64, error) {
+	query = query.Normalized()
+	terms := query.Terms()
+	if len(terms) == 0 {
+		return []entity.Post{}, 0, nil
+	}
+	tsQuery, args := postSearchTSQuery(terms)
+
+	base := conn(ctx, r.db).Model(&model.Post{}).
+		Where("posts.status = ?", entity.StatusPublished).
+		Where("posts.search_vector @@ "+tsQuery, args...)
+
+	var total int64
+	if err := base.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("post_repository.SearchPublished.count: %w", err)
+	}
+
+	var postModels []model.Post
+	if err := base.Preload("Author").Preload("Category").Preload("Tags").
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "ts_rank(posts.search_vector, " + tsQuery + ") DESC, posts.id DESC",
+			Vars:               args,
+			WithoutParentheses: true,
+		}}).
+		Offset(query.Offset()).
+		Limit(query.PageSize).
+		Find(&postModels).Error; err != nil {
+		return nil, 0, fmt.Errorf("post_repository.SearchPublished: %w", err)
+	}
+	return postToEntities(postModels), total, nil
+}
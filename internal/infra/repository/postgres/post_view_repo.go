@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/model"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostViewRepository persists daily post view aggregates in Postgres.
+type PostViewRepository struct {
+	db *gorm.DB
+}
+
+var _ core.PostViewRepository = (*PostViewRepository)(nil)
+
+func NewPostViewRepository(db *gorm.DB) *PostViewRepository {
+	return &PostViewRepository{db: db}
+}
+
+// AddDailyViews upserts the counts, adding to any views already stored for the same post and day.
+func (r *PostViewRepository) AddDailyViews(ctx context.Context, counts []entity.PostDailyViews) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	rows := make([]model.PostDailyView, len(counts))
+	for i, c := range counts {
+		rows[i] = model.PostDailyView{PostID: c.PostID, Day: entity.ViewDay(c.Day), Views: c.Views}
+	}
+	err := conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "post_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]any{"views": gorm.Expr("post_daily_views.views + excluded.views")}),
+	}).CreateInBatches(&rows, 500).Error
+	if err != nil {
+		return fmt.Errorf("post_view_repository.AddDailyViews: %w", err)
+	}
+	return nil
+}
+
+func (r *PostViewRepository) DailyViews(ctx context.Context, postID *uint, rng entity.AnalyticsRange) ([]entity.DailyViews, error) {
+	q := conn(ctx, r.db).Model(&model.PostDailyView{}).
+		Select("day, SUM(views) AS views").
+		Where("day BETWEEN ? AND ?", rng.From, rng.To)
+	if postID != nil {
+		q = q.Where("post_id = ?", *postID)
+	}
+	var rows []struct {
+		Day   time.Time
+		Views int64
+	}
+	if err := q.Group("day").Order("day ASC").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("post_view_repository.DailyViews: %w", err)
+	}
+
+	out := make([]entity.DailyViews, len(rows))
+	for i, row := range rows {
+		out[i] = entity.DailyViews{Day: entity.ViewDay(row.Day), Views: row.Views}
+	}
+	return out, nil
+}
+
+func (r *PostViewRepository) TopPosts(ctx context.Context, rng entity.AnalyticsRange, limit int) ([]entity.TopPost, error) {
+	var rows []struct {
+		PostID uint
+		Title  string
+		Slug   string
+		Locale string
+		Views  int64
+	}
+	err := conn(ctx, r.db).Model(&model.PostDailyView{}).
+		Select("post_daily_views.post_id, posts.title, posts.slug, posts.locale, SUM(post_daily_views.views) AS views").
+		Joins("JOIN posts ON posts.id = post_daily_views.post_id AND posts.deleted_at IS NULL").
+		Where("post_daily_views.day BETWEEN ? AND ?", rng.From, rng.To).
+		Group("post_daily_views.post_id, posts.title, posts.slug, posts.locale").
+		Order("views DESC, post_daily_views.post_id ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("post_view_repository.TopPosts: %w", err)
+	}
+
+	out := make([]entity.TopPost, len(rows))
+	for i, row := range rows {
+		out[i] = entity.TopPost{PostID: row.PostID, Title: row.Title, Slug: row.Slug, Locale: row.Locale, Views: row.Views}
+	}
+	return out, nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/model"
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+func seriesToEntity(m model.Series) entity.Series {
+	return entity.Series{
+		ID:          m.ID,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		Title:       m.Title,
+		Slug:        m.Slug,
+		Description: m.Description,
+	}
+}
+
+// SeriesRepository persists post series in Postgres.
+type SeriesRepository struct {
+	db *gorm.DB
+}
+
+var _ core.SeriesRepository = (*SeriesRepository)(nil)
+
+func NewSeriesRepository(db *gorm.DB) *SeriesRepository {
+	return &SeriesRepository{db: db}
+}
+
+func (r *SeriesRepository) Create(ctx context.Context, series entity.Series) (entity.Series, error) {
+	m := model.Series{Title: series.Title, Slug: series.Slug, Description: series.Description}
+	if err := conn(ctx, r.db).Create(&m).Error; err != nil {
+		if isUniqueViolation(err) {
+			return entity.Series{}, core.ErrDuplicate
+		}
+		return entity.Series{}, fmt.Errorf("series_repository.Create: %w", err)
+	}
+	return seriesToEntity(m), nil
+}
+
+func (r *SeriesRepository) GetByID(ctx context.Context, id uint) (entity.Series, error) {
+	return r.first(ctx, "series_repository.GetByID", "id = ?", id)
+}
+
+func (r *SeriesRepository) GetBySlug(ctx context.Context, slug string) (entity.Series, error) {
+	return r.first(ctx, "series_repository.GetBySlug", "slug = ?", slug)
+}
+
+func (r *SeriesRepository) first(ctx context.Context, op string, query string, arg any) (entity.Series, error) {
+	var m model.Series
+	if err := conn(ctx, r.db).Where(query, arg).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Series{}, core.ErrNotFound
+		}
+		return entity.Series{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return seriesToEntity(m), nil
+}
+
+// ListWithPartCounts returns every series ordered by title, each with its number of published parts.
+func (r *SeriesRepository) ListWithPartCounts(ctx context.Context) ([]entity.SeriesWithPartCount, error) {
+	var rows []struct {
+		model.Series
+		PartCount int64
+	}
+	err := conn(ctx, r.db).Model(&model.Series{}).
+		Select("series.*, COUNT(posts.id) AS part_count").
+		Joins("LEFT JOIN posts ON posts.series_id = series.id AND posts.status = ? AND posts.deleted_at IS NULL", entity.StatusPublished).
+		Group("series.id").
+		Order("series.title ASC, series.id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("series_repository.ListWithPartCounts: %w", err)
+	}
+
+	out := make([]entity.SeriesWithPartCount, len(rows))
+	for i, row := range rows {
+		out[i] = entity.SeriesWithPartCount{Series: seriesToEntity(row.Series), PartCount: row.PartCount}
+	}
+	return out, nil
+}
+
+func (r *SeriesRepository) Update(ctx context.Context, series entity.Series) (entity.Series, error) {
+	res := conn(ctx, r.db).Model(&model.Series{ID: series.ID}).
+		Updates(map[string]any{"title": series.Title, "slug": series.Slug, "description": series.Description})
+	if res.Error != nil {
+		if isUniqueViolation(res.Error) {
+			return entity.Series{}, core.ErrDuplicate
+		}
+		return entity.Series{}, fmt.Errorf("series_repository.Update: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return entity.Series{}, core.ErrNotFound
+	}
+	return r.GetByID(ctx, series.ID)
+}
+
+// Delete removes a series permanently. Its posts, including trashed ones, stay where they are
+// and simply leave the series.
+func (r *SeriesRepository) Delete(ctx context.Context, id uint) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&model.Post{}).Where("series_id = ?", id).
+			Updates(map[string]any{"series_id": nil, "series_position": 0}).Error; err != nil {
+			return err
+		}
+		res := tx.Delete(&model.Series{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return core.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("series_repository.Delete: %w", err)
+	}
+	return nil
+}
+
+// ListParts returns the live posts of a series ordered by position, then ID.
+func (r *SeriesRepository) ListParts(ctx context.Context, seriesID uint, publishedOnly bool) ([]entity.SeriesPart, error) {
+	q := conn(ctx, r.db).Model(&model.Post{}).
+		Select("id", "title", "slug", "locale", "status", "series_position").
+		Where("series_id = ?", seriesID)
+	if publishedOnly {
+		q = q.Where("status = ?", entity.StatusPublished)
+	}
+	var rows []model.Post
+	if err := q.Order("series_position ASC, id ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("series_repository.ListParts: %w", err)
+	}
+
+	parts := make([]entity.SeriesPart, len(rows))
+	for i, m := range rows {
+		parts[i] = entity.SeriesPart{ID: m.ID, Title: m.Title, Slug: m.Slug, Locale: m.Locale, Status: m.Status, Position: m.SeriesPosition}
+	}
+	return parts, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// sitemapEntriesSQL lists every sitemap page. Category and tag pages change whenever one of
+// their published posts does, so their lastmod is the newest of their own and those posts'
+// update times (GREATEST ignores the NULL of a page without posts).
+const sitemapEntriesSQL = `
+SELECT 1 AS kind_order, 'post' AS kind, p.id, p.slug, p.updated_at AS last_mod, p.locale, p.translation_group_id AS group_id
+FROM posts p
+WHERE p.status = @published AND NOT p.no_index AND p.deleted_at IS NULL
+UNION ALL
+SELECT 2, 'category', c.id, c.slug, GREATEST(c.updated_at, MAX(p.updated_at)), '', NULL
+FROM categories c
+LEFT JOIN posts p ON p.category_id = c.id AND p.status = @published AND p.deleted_at IS NULL
+WHERE c.deleted_at IS NULL
+GROUP BY c.id
+UNION ALL
+SELECT 3, 'tag', t.id, t.slug, GREATEST(t.updated_at, MAX(p.updated_at)), '', NULL
+FROM tags t
+LEFT JOIN post_tags pt ON pt.tag_id = t.id
+LEFT JOIN posts p ON p.id = pt.post_id AND p.status = @published AND p.deleted_at IS NULL
+WHERE t.deleted_at IS NULL
+GROUP BY t.id`
+
+// SitemapRepository reads sitemap entries from Postgres.
+type SitemapRepository struct {
+	db *gorm.DB
+}
+
+var _ core.SitemapRepository = (*SitemapRepository)(nil)
+
+func NewSitemapRepository(db *gorm.DB) *SitemapRepository {
+	return &SitemapRepository{db: db}
+}
+
+func (r *SitemapRepository) CountEntries(ctx context.Context) (int64, error) {
+	var n int64
+	err := conn(ctx, r.db).
+		Raw("SELECT COUNT(*) FROM ("+sitemapEntriesSQL+") entries", map[string]any{"published": entity.StatusPublished}).
+		Scan(&n).Error
+	if err != nil {
+		return 0, fmt.Errorf("sitemap_repository.CountEntries: %w", err)
+	}
+	return n, nil
+}
+
+func (r *SitemapRepository) ListEntries(ctx context.Context, offset int, limit int) ([]entity.SitemapEntry, error) {
+	var rows []entity.SitemapEntry
+	err := conn(ctx, r.db).
+		Raw("SELECT kind, slug, last_mod, locale, group_id FROM ("+sitemapEntriesSQL+") entries ORDER BY kind_order, id OFFSET @offset LIMIT @limit",
+			map[string]any{"published": entity.StatusPublished, "offset": offset, "limit": limit}).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("sitemap_repository.ListEntries: %w", err)
+	}
+	return rows, nil
+}
+
+// ListPostAlternates returns the sitemap-visible posts of the given translation groups,
+// i.e. every language version that may be linked as an hreflang alternate.
+func (r *SitemapRepository) ListPostAlternates(ctx context.Context, groupIDs []uint) ([]entity.SitemapEntry, error) {
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+	var rows []entity.SitemapEntry
+	err := conn(ctx, r.db).
+		Raw(`SELECT 'post' AS kind, slug, updated_at AS last_mod, locale, translation_group_id AS group_id
+FROM posts
+WHERE translation_group_id IN @groups AND status = @published AND NOT no_index AND deleted_at IS NULL
+ORDER BY locale, id`,
+			map[string]any{"groups": groupIDs, "published": entity.StatusPublished}).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("sitemap_repository.ListPostAlternates: %w", err)
+	}
+	return rows, nil
+}
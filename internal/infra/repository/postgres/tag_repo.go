@@ -3,11 +3,154 @@ package repository
 import (
 	"KaldalisCMS/internal/core"
 	"KaldalisCMS/internal/core/entity"
+	"KaldalisCMS/internal/infra/model"
 	"context"
+	"errors"
+	"fmt"
+	"sort"
 	"strings"
 	"sync"
+
+	"gorm.io/gorm"
 )
 
+func tagToEntity(m model.Tag) entity.Tag {
+	return entity.Tag{ID: m.ID, Name: m.Name, Slug: m.Slug}
+}
+
+// TagRepository persists tags in Postgres.
+type TagRepository struct {
+	db *gorm.DB
+}
+
+var _ core.TagRepository = (*TagRepository)(nil)
+
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// Create inserts in a transaction of its own: inside a caller's transaction that is a
+// savepoint, so a duplicate only rolls back the insert and the caller can still re-read the
+// tag that won the race.
+func (r *TagRepository) Create(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
+	m := model.Tag{Name: tag.Name, Slug: tag.Slug}
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&m).Error
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.Tag{}, core.ErrDuplicate
+		}
+		return entity.Tag{}, fmt.Errorf("tag_repository.Create: %w", err)
+	}
+	return tagToEntity(m), nil
+}
+
+func (r *TagRepository) GetAll(ctx context.Context) ([]entity.Tag, error) {
+	var models []model.Tag
+	if err := conn(ctx, r.db).Order("name ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("tag_repository.GetAll: %w", err)
+	}
+	tags := make([]entity.Tag, len(models))
+	for i, m := range models {
+		tags[i] = tagToEntity(m)
+	}
+	return tags, nil
+}
+
+// ListWithPostCounts returns every tag ordered by name, each with its number of published posts.
+func (r *TagRepository) ListWithPostCounts(ctx context.Context) ([]entity.TagWithPostCount, error) {
+	var rows []struct {
+		model.Tag
+		PostCount int64
+	}
+	err := conn(ctx, r.db).Model(&model.Tag{}).
+		Select("tags.*, COUNT(posts.id) AS post_count").
+		Joins("LEFT JOIN post_tags ON post_tags.tag_id = tags.id").
+		Joins("LEFT JOIN posts ON posts.id = post_tags.post_id AND posts.status = ? AND posts.deleted_at IS NULL", entity.StatusPublished).
+		Group("tags.id").
+		Order("tags.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("tag_repository.ListWithPostCounts: %w", err)
+	}
+
+	out := make([]entity.TagWithPostCount, len(rows))
+	for i, row := range rows {
+		out[i] = entity.TagWithPostCount{Tag: tagToEntity(row.Tag), PostCount: row.PostCount}
+	}
+	return out, nil
+}
+
+func (r *TagRepository) GetByID(ctx context.Context, id uint) (entity.Tag, error) {
+	return r.first(ctx, "tag_repository.GetByID", "id = ?", id)
+}
+
+// GetByName matches case-insensitively so "Go" and "go" resolve to the same tag.
+func (r *TagRepository) GetByName(ctx context.Context, name string) (entity.Tag, error) {
+	return r.first(ctx, "tag_repository.GetByName", "LOWER(name) = LOWER(?)", name)
+}
+
+func (r *TagRepository) GetBySlug(ctx context.Context, slug string) (entity.Tag, error) {
+	return r.first(ctx, "tag_repository.GetBySlug", "slug = ?", slug)
+}
+
+func (r *TagRepository) first(ctx context.Context, op string, query string, arg any) (entity.Tag, error) {
+	var m model.Tag
+	if err := conn(ctx, r.db).Where(query, arg).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.Tag{}, core.ErrNotFound
+		}
+		return entity.Tag{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return tagToEntity(m), nil
+}
+
+// Update changes the non-empty fields of tag and returns the stored result.
+func (r *TagRepository) Update(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
+	updates := map[string]any{}
+	if tag.Name != "" {
+		updates["name"] = tag.Name
+	}
+	if tag.Slug != "" {
+		updates["slug"] = tag.Slug
+	}
+	if len(updates) > 0 {
+		res := conn(ctx, r.db).Model(&model.Tag{ID: tag.ID}).Updates(updates)
+		if res.Error != nil {
+			if isUniqueViolation(res.Error) {
+				return entity.Tag{}, core.ErrDuplicate
+			}
+			return entity.Tag{}, fmt.Errorf("tag_repository.Update: %w", res.Error)
+		}
+	}
+	return r.GetByID(ctx, tag.ID)
+}
+
+// Delete removes a tag permanently, detaching it from every post, so its name and slug can be reused.
+func (r *TagRepository) Delete(ctx context.Context, id uint) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM post_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		res := tx.Unscoped().Delete(&model.Tag{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return core.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("tag_repository.Delete: %w", err)
+	}
+	return nil
+}
+
 // InMemoryTagRepository is a temporary repository implementation for tests/smoke runs.
 //
 // NOTE: This is NOT intended for production use.
@@ -90,6 +233,34 @@ func (r *InMemoryTagRepository) GetByName(ctx context.Context, name string) (ent
 	return entity.Tag{}, core.ErrNotFound
 }
 
+func (r *InMemoryTagRepository) GetBySlug(ctx context.Context, slug string) (entity.Tag, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.byID {
+		if t.Slug == slug {
+			return t, nil
+		}
+	}
+	return entity.Tag{}, core.ErrNotFound
+}
+
+// ListWithPostCounts returns every tag ordered by name; the in-memory store tracks no posts,
+// so counts are always zero.
+func (r *InMemoryTagRepository) ListWithPostCounts(ctx context.Context) ([]entity.TagWithPostCount, error) {
+	_ = ctx
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]entity.TagWithPostCount, 0, len(r.byID))
+	for _, t := range r.byID {
+		res = append(res, entity.TagWithPostCount{Tag: t})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res, nil
+}
+
 func (r *InMemoryTagRepository) Update(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
 	_ = ctx
 	r.mu.Lock()
@@ -119,6 +290,14 @@ func (r *InMemoryTagRepository) Update(ctx context.Context, tag entity.Tag) (ent
 
 		existing.Name = name
 	}
+	if tag.Slug != "" {
+		for id, t := range r.byID {
+			if id != tag.ID && t.Slug == tag.Slug {
+				return entity.Tag{}, core.ErrDuplicate
+			}
+		}
+		existing.Slug = tag.Slug
+	}
 
 	r.byID[tag.ID] = existing
 	return existing, nil
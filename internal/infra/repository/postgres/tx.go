@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"KaldalisCMS/internal/core"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txContextKey struct{}
+
+var _ core.Transactor = (*Transactor)(nil)
+
+// Transactor runs work in one database transaction carried through the context.
+type Transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// WithinTransaction commits when fn returns nil and rolls back otherwise. Calls nested in an
+// outer transaction become savepoints of it.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return conn(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txContextKey{}, tx))
+	})
+}
+
+// conn returns the transaction carried by ctx, or db when there is none. Repositories use it
+// instead of db.WithContext so they take part in WithinTransaction.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -50,7 +50,7 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 
 func (r *UserRepository) GetAll(ctx context.Context) ([]entity.User, error) {
 	var userModels []model.User
-	if err := r.db.WithContext(ctx).Find(&userModels).Error; err != nil {
+	if err := conn(ctx, r.db).Find(&userModels).Error; err != nil {
 		return nil, fmt.Errorf("user_repository.GetAll: %w", err)
 	}
 	var userEntities []entity.User
@@ -62,7 +62,7 @@ func (r *UserRepository) GetAll(ctx context.Context) ([]entity.User, error) {
 
 func (r *UserRepository) GetByID(ctx context.Context, id uint) (entity.User, error) {
 	var userModel model.User
-	if err := r.db.WithContext(ctx).First(&userModel, id).Error; err != nil {
+	if err := conn(ctx, r.db).First(&userModel, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return entity.User{}, core.ErrNotFound
 		}
@@ -73,7 +73,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uint) (entity.User, err
 
 func (r *UserRepository) GetByUsername(ctx context.Context, username string) (entity.User, error) {
 	var userModel model.User
-	if err := r.db.WithContext(ctx).Where("username = ?", username).First(&userModel).Error; err != nil {
+	if err := conn(ctx, r.db).Where("username = ?", username).First(&userModel).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return entity.User{}, core.ErrNotFound
 		}
@@ -84,7 +84,7 @@ func (r *UserRepository) GetByUsername(ctx context.Context, username string) (en
 
 func (r *UserRepository) Create(ctx context.Context, user entity.User) error {
 	userModel := userToModel(user)
-	if err := r.db.WithContext(ctx).Create(&userModel).Error; err != nil {
+	if err := conn(ctx, r.db).Create(&userModel).Error; err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			if pgErr.Code == "23505" { // unique_violation
@@ -98,7 +98,7 @@ func (r *UserRepository) Create(ctx context.Context, user entity.User) error {
 
 func (r *UserRepository) Update(ctx context.Context, user entity.User) error {
 	userModel := userToModel(user)
-	if err := r.db.WithContext(ctx).Save(&userModel).Error; err != nil {
+	if err := conn(ctx, r.db).Save(&userModel).Error; err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			if pgErr.Code == "23505" { // unique_violation
@@ -111,7 +111,7 @@ func (r *UserRepository) Update(ctx context.Context, user entity.User) error {
 }
 
 func (r *UserRepository) Delete(ctx context.Context, id uint) error {
-	if err := r.db.WithContext(ctx).Delete(&model.User{}, id).Error; err != nil {
+	if err := conn(ctx, r.db).Delete(&model.User{}, id).Error; err != nil {
 		return fmt.Errorf("repository.DeleteUser: %w", err)
 	}
 	return nil
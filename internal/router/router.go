@@ -4,6 +4,8 @@ import (
 	apimw "KaldalisCMS/internal/api/middleware"
 	v1 "KaldalisCMS/internal/api/v1"
 	"KaldalisCMS/internal/infra/auth"
+	"KaldalisCMS/internal/infra/importer"
+	"KaldalisCMS/internal/infra/markdown"
 	repository "KaldalisCMS/internal/infra/repository/postgres"
 	"KaldalisCMS/internal/service"
 	"KaldalisCMS/internal/utils"
@@ -51,6 +53,38 @@ func ensurePostWorkflowPolicies(enforcer *casbin.Enforcer) {
 		{"admin", "/api/v1/admin/posts/:id", "PUT"},
 		{"admin", "/api/v1/admin/posts/:id/publish", "POST"},
 		{"admin", "/api/v1/admin/posts/:id/draft", "POST"},
+		{"admin", "/api/v1/admin/posts/:id/schedule", "POST"},
+		{"admin", "/api/v1/admin/posts/review", "GET"},
+		{"admin", "/api/v1/admin/posts/:id/approve", "POST"},
+		{"admin", "/api/v1/admin/posts/:id/reject", "POST"},
+		{"admin", "/api/v1/admin/posts/trash", "GET"},
+		{"admin", "/api/v1/admin/posts/:id/restore", "POST"},
+		{"admin", "/api/v1/admin/posts/:id/translations", "POST"},
+		{"admin", "/api/v1/admin/posts/:id/translations", "DELETE"},
+		{"admin", "/api/v1/admin/posts/:id/previews", "GET"},
+		{"admin", "/api/v1/admin/posts/:id/previews", "POST"},
+		{"admin", "/api/v1/admin/posts/:id/previews/:preview", "DELETE"},
+		{"admin", "/api/v1/admin/posts/bulk", "POST"},
+		{"admin", "/api/v1/admin/comments", "GET"},
+		{"admin", "/api/v1/admin/comments/:id/approve", "POST"},
+		{"admin", "/api/v1/admin/comments/:id/reject", "POST"},
+		{"admin", "/api/v1/admin/comments/:id/spam", "POST"},
+		{"admin", "/api/v1/admin/content-types", "GET"},
+		{"admin", "/api/v1/admin/content-types", "POST"},
+		{"admin", "/api/v1/admin/content-types/:type", "GET"},
+		{"admin", "/api/v1/admin/content-types/:type", "PUT"},
+		{"admin", "/api/v1/admin/content/:type/:id/publish", "POST"},
+		{"admin", "/api/v1/admin/content/:type/:id/draft", "POST"},
+		{"admin", "/api/v1/admin/pages/:id/publish", "POST"},
+		{"admin", "/api/v1/admin/pages/:id/draft", "POST"},
+		{"admin", "/api/v1/admin/pages/reorder", "POST"},
+		{"admin", "/api/v1/series", "POST"},
+		{"admin", "/api/v1/series/:id", "PUT"},
+		{"admin", "/api/v1/admin/analytics/views", "GET"},
+		{"admin", "/api/v1/admin/analytics/posts/:id/views", "GET"},
+		{"admin", "/api/v1/admin/analytics/top-posts", "GET"},
+		{"admin", "/api/v1/admin/import", "POST"},
+		{"admin", "/api/v1/admin/export", "GET"},
 
 		// admin capability policies
 		{"admin", "post", "list:any"},
@@ -59,14 +93,65 @@ func ensurePostWorkflowPolicies(enforcer *casbin.Enforcer) {
 		{"admin", "post", "publish"},
 		{"admin", "post", "unpublish"},
 		{"admin", "post", "delete"},
+		{"admin", "post", "review"},
+		{"admin", "post", "purge"},
 
 		// user route policies
 		{"user", "/api/v1/posts", "GET"},
 		{"user", "/api/v1/posts/:id", "GET"},
+		{"user", "/api/v1/posts/slug/:slug", "GET"},
+		{"user", "/api/v1/posts/search", "GET"},
+		{"user", "/api/v1/posts/:id/related", "GET"},
+		{"user", "/api/v1/categories", "GET"},
+		{"user", "/api/v1/categories/slug/:slug", "GET"},
+		{"user", "/api/v1/categories/slug/:slug/posts", "GET"},
+		{"user", "/api/v1/tags", "GET"},
+		{"user", "/api/v1/tags/:slug", "GET"},
+		{"user", "/api/v1/tags/:slug/posts", "GET"},
+		{"user", "/api/v1/posts/:id/comments", "GET"},
+		{"user", "/api/v1/posts/:id/comments", "POST"},
+		{"user", "/feed.xml", "GET"},
+		{"user", "/atom.xml", "GET"},
+		{"user", "/feed.json", "GET"},
+		{"user", "/tags/:slug/feed.xml", "GET"},
+		{"user", "/tags/:slug/atom.xml", "GET"},
+		{"user", "/tags/:slug/feed.json", "GET"},
+		{"user", "/categories/:slug/feed.xml", "GET"},
+		{"user", "/categories/:slug/atom.xml", "GET"},
+		{"user", "/categories/:slug/feed.json", "GET"},
+		{"user", "/sitemap.xml", "GET"},
 		{"user", "/api/v1/admin/posts", "GET"},
 		{"user", "/api/v1/admin/posts", "POST"},
 		{"user", "/api/v1/admin/posts/:id", "GET"},
 		{"user", "/api/v1/admin/posts/:id", "PUT"},
+		{"user", "/api/v1/admin/posts/:id/submit", "POST"},
+		{"user", "/api/v1/admin/posts/:id/revisions", "GET"},
+		{"user", "/api/v1/admin/posts/:id/revisions/diff", "GET"},
+		{"user", "/api/v1/admin/posts/:id/revisions/:rev", "GET"},
+		{"user", "/api/v1/admin/posts/:id/revisions/:rev/restore", "POST"},
+		{"user", "/api/v1/admin/posts/:id/autosave", "GET"},
+		{"user", "/api/v1/admin/posts/:id/autosave", "PUT"},
+		{"user", "/api/v1/admin/posts/:id/autosave", "DELETE"},
+		{"user", "/api/v1/admin/posts/:id/autosave/promote", "POST"},
+		{"user", "/api/v1/admin/posts/:id/previews", "GET"},
+		{"user", "/api/v1/admin/posts/:id/previews", "POST"},
+		{"user", "/api/v1/admin/posts/:id/previews/:preview", "DELETE"},
+		{"user", "/api/v1/admin/posts/bulk", "POST"},
+		{"user", "/api/v1/content/:type", "GET"},
+		{"user", "/api/v1/content/:type/:slug", "GET"},
+		{"user", "/api/v1/admin/content/:type", "GET"},
+		{"user", "/api/v1/admin/content/:type", "POST"},
+		{"user", "/api/v1/admin/content/:type/:id", "GET"},
+		{"user", "/api/v1/admin/content/:type/:id", "PUT"},
+		{"user", "/api/v1/pages", "GET"},
+		{"user", "/api/v1/pages/*path", "GET"},
+		{"user", "/api/v1/admin/pages", "GET"},
+		{"user", "/api/v1/admin/pages", "POST"},
+		{"user", "/api/v1/admin/pages/:id", "GET"},
+		{"user", "/api/v1/admin/pages/:id", "PUT"},
+		{"user", "/api/v1/series", "GET"},
+		{"user", "/api/v1/series/:slug", "GET"},
+		{"user", "/api/v1/series/:slug/posts", "GET"},
 		{"user", "/api/v1/media", "GET"},
 
 		// user capability policies
@@ -74,18 +159,99 @@ func ensurePostWorkflowPolicies(enforcer *casbin.Enforcer) {
 		{"user", "post:draft", "list:own"},
 		{"user", "post:draft", "read:own"},
 		{"user", "post:draft", "update:own"},
+		{"user", "post:draft", "submit:own"},
 	}
 
 	for _, rule := range rules {
 		_, _ = enforcer.AddPolicy(rule[0], rule[1], rule[2])
 	}
 
+	// 3. Purging follows the install-time AdminCanDelete choice, like the delete route itself.
+	if ok, _ := enforcer.HasPolicy("admin", "/api/v1/admin/posts/:id", "DELETE"); ok {
+		_, _ = enforcer.AddPolicy("admin", "/api/v1/admin/posts/:id/purge", "DELETE")
+		_, _ = enforcer.AddPolicy("admin", "/api/v1/admin/content-types/:type", "DELETE")
+		_, _ = enforcer.AddPolicy("admin", "/api/v1/admin/content/:type/:id", "DELETE")
+		_, _ = enforcer.AddPolicy("admin", "/api/v1/admin/pages/:id", "DELETE")
+		_, _ = enforcer.AddPolicy("admin", "/api/v1/series/:id", "DELETE")
+	}
+
+	// 4. Anonymous read routes follow the install-time AllowAnonymousRead choice, which is
+	// recorded as the anonymous policy on the public post list.
+	if ok, _ := enforcer.HasPolicy("anonymous", "/api/v1/posts", "GET"); ok {
+		for _, route := range anonymousReadRoutes {
+			_, _ = enforcer.AddPolicy("anonymous", route, "GET")
+		}
+		// Visitors who may read may also leave comments; they are held for moderation.
+		_, _ = enforcer.AddPolicy("anonymous", "/api/v1/posts/:id/comments", "POST")
+	}
+
 	_ = enforcer.SavePolicy()
 }
 
-// NewAppRouter initializes the router for the fully functional application.
-func NewAppRouter(db *gorm.DB, authCfg auth.Config, enforcer *casbin.Enforcer, swaggerOpts SwaggerOptions) *gin.Engine {
+// anonymousReadRoutes are public read endpoints added after the initial install policy set.
+// They are granted to anonymous visitors only when anonymous post reads are enabled.
+var anonymousReadRoutes = []string{
+	"/api/v1/posts/slug/:slug",
+	"/api/v1/posts/search",
+	"/api/v1/posts/:id/related",
+	"/api/v1/categories",
+	"/api/v1/categories/slug/:slug",
+	"/api/v1/categories/slug/:slug/posts",
+	"/api/v1/tags",
+	"/api/v1/tags/:slug",
+	"/api/v1/tags/:slug/posts",
+	"/api/v1/posts/:id/comments",
+	"/feed.xml",
+	"/atom.xml",
+	"/feed.json",
+	"/tags/:slug/feed.xml",
+	"/tags/:slug/atom.xml",
+	"/tags/:slug/feed.json",
+	"/categories/:slug/feed.xml",
+	"/categories/:slug/atom.xml",
+	"/categories/:slug/feed.json",
+	"/sitemap.xml",
+	"/api/v1/content/:type",
+	"/api/v1/content/:type/:slug",
+	"/api/v1/pages",
+	"/api/v1/pages/*path",
+	"/api/v1/series",
+	"/api/v1/series/:slug",
+	"/api/v1/series/:slug/posts",
+}
+
+// MediaConfigFromEnv reads the media settings shared by the server and the CLI commands:
+// MEDIA_UPLOAD_DIR, MEDIA_MAX_UPLOAD_SIZE_MB, MEDIA_PUBLIC_BASE_URL and MEDIA_MAX_FILENAME_BYTES.
+func MediaConfigFromEnv() service.MediaConfig {
+	cfg := service.MediaConfig{UploadDir: os.Getenv("MEDIA_UPLOAD_DIR")}
+	if cfg.UploadDir == "" {
+		cfg.UploadDir = filepath.FromSlash("./data/uploads")
+	}
+	if v := utils.ParseInt64(os.Getenv("MEDIA_MAX_UPLOAD_SIZE_MB")); v > 0 {
+		cfg.MaxUploadSizeMB = v
+	} else {
+		cfg.MaxUploadSizeMB = 50
+	}
+	cfg.PublicBaseURL = os.Getenv("MEDIA_PUBLIC_BASE_URL")
+	if v := utils.ParseInt(os.Getenv("MEDIA_MAX_FILENAME_BYTES")); v > 0 {
+		cfg.MaxFilenameBytes = v
+	} else {
+		cfg.MaxFilenameBytes = 180
+	}
+	return cfg
+}
+
+// NewAppRouter initializes the router for the fully functional application. The returned
+// flush writes state that is buffered in memory (view counts); call it on shutdown, once the
+// server has stopped handling requests.
+func NewAppRouter(db *gorm.DB, authCfg auth.Config, enforcer *casbin.Enforcer, swaggerOpts SwaggerOptions) (*gin.Engine, func(ctx context.Context) error) {
 	r := gin.New()
+	// Client IPs feed logs and per-visitor view de-duplication, so X-Forwarded-For is only
+	// honoured from the reverse proxies listed in TRUSTED_PROXIES.
+	if err := r.SetTrustedProxies(utils.ParseList(os.Getenv("TRUSTED_PROXIES"))); err != nil {
+		log.Printf("level=error event=trusted_proxies message=%q", err.Error())
+		_ = r.SetTrustedProxies(nil)
+	}
 	r.Use(apimw.RequestContext())
 	r.Use(apimw.ObserveHTTP())
 	r.Use(apimw.RecoverAsContract())
@@ -93,39 +259,51 @@ func NewAppRouter(db *gorm.DB, authCfg auth.Config, enforcer *casbin.Enforcer, s
 	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	registerSwaggerRoutes(r, swaggerOpts)
 
-	uploadDir := os.Getenv("MEDIA_UPLOAD_DIR")
-	if uploadDir == "" {
-		uploadDir = filepath.FromSlash("./data/uploads")
-	}
-	maxUploadMB := os.Getenv("MEDIA_MAX_UPLOAD_SIZE_MB")
-	publicBaseURL := os.Getenv("MEDIA_PUBLIC_BASE_URL")
-	maxFilenameBytes := os.Getenv("MEDIA_MAX_FILENAME_BYTES")
-
-	r.Static("/media/a", filepath.Join(uploadDir, "a"))
+	mediaCfg := MediaConfigFromEnv()
+	r.Static("/media/a", filepath.Join(mediaCfg.UploadDir, "a"))
 
 	mediaRepo := repository.NewMediaRepository(db)
-	mediaCfg := service.MediaConfig{UploadDir: uploadDir}
-	if v := utils.ParseInt64(maxUploadMB); v > 0 {
-		mediaCfg.MaxUploadSizeMB = v
-	} else {
-		mediaCfg.MaxUploadSizeMB = 50
-	}
-	mediaCfg.PublicBaseURL = publicBaseURL
-	if v := utils.ParseInt(maxFilenameBytes); v > 0 {
-		mediaCfg.MaxFilenameBytes = v
-	} else {
-		mediaCfg.MaxFilenameBytes = 180
-	}
 	mediaSvc := service.NewMediaService(mediaRepo, mediaCfg)
 	mediaAPI := v1.NewMediaAPI(mediaSvc, mediaRepo)
 
 	postRepo := repository.NewPostRepository(db)
 	postAuthorizer := auth.NewCasbinPostAuthorizer(enforcer)
 	postService := service.NewPostServiceWithMedia(postRepo, mediaSvc, postAuthorizer)
+	postService.SetRevisionRepository(repository.NewPostRevisionRepository(db))
+	postService.SetAutosaveRepository(repository.NewPostAutosaveRepository(db))
+	tagService := service.NewTagService(repository.NewTagRepository(db))
+	postService.SetTagService(tagService)
+	postService.SetContentRenderer(markdown.NewRenderer())
+	postService.SetRelatedPostsConfig(service.RelatedPostsConfig{
+		TextSimilarity: utils.ParseBool(os.Getenv("RELATED_POSTS_TEXT_SIMILARITY")),
+	})
+	seriesRepo := repository.NewSeriesRepository(db)
+	postService.SetSeriesRepository(seriesRepo)
+	postService.SetTransactor(repository.NewTransactor(db))
+	postService.SetPreviewTokens(repository.NewPostPreviewTokenRepository(db), auth.NewPreviewSigner(authCfg.Secret))
 	publicPostAPI := v1.NewPublicPostAPI(postService)
+	analyticsService := service.NewAnalyticsService(repository.NewPostViewRepository(db))
+	publicPostAPI.SetViewTracker(analyticsService)
+	analyticsAPI := v1.NewAnalyticsAPI(analyticsService)
 	adminPostAPI := v1.NewAdminPostAPI(postService)
+	// POSTS_ALLOW_UNCONDITIONAL_WRITES keeps accepting post writes without If-Match, for
+	// clients that predate optimistic locking.
+	adminPostAPI.SetAllowUnconditionalWrites(utils.ParseBool(os.Getenv("POSTS_ALLOW_UNCONDITIONAL_WRITES")))
 	ensurePostWorkflowPolicies(enforcer)
 
+	categoryService := service.NewCategoryService(repository.NewCategoryRepository(db), postService.InvalidateRelated)
+	categoryAPI := v1.NewCategoryAPI(categoryService, postService)
+	tagAPI := v1.NewTagAPI(tagService, postService)
+	seriesAPI := v1.NewSeriesAPI(service.NewSeriesService(seriesRepo))
+	contentAPI := v1.NewContentAPI(service.NewContentService(
+		repository.NewContentTypeRepository(db),
+		repository.NewContentEntryRepository(db),
+		mediaRepo,
+		postAuthorizer,
+	))
+	pageAPI := v1.NewPageAPI(service.NewPageService(repository.NewPageRepository(db), mediaSvc, markdown.NewRenderer(), postAuthorizer))
+	commentAPI := v1.NewCommentAPI(service.NewCommentService(repository.NewCommentRepository(db), postRepo, markdown.NewRenderer()))
+
 	userRepo := repository.NewUserRepository(db)
 	userService := service.NewUserService(userRepo)
 	sessionMgr := auth.NewSessionManager(authCfg)
@@ -137,6 +315,64 @@ func NewAppRouter(db *gorm.DB, authCfg auth.Config, enforcer *casbin.Enforcer, s
 	healthAPI := v1.NewAppHealthAPI(systemService)
 	healthAPI.RegisterRootRoutes(r) // Correct: root registration
 
+	siteURL := os.Getenv("SITE_PUBLIC_BASE_URL")
+	feedCfg := service.FeedConfig{
+		SiteURL:      siteURL,
+		MediaBaseURL: mediaCfg.PublicBaseURL,
+	}
+	if v := utils.ParseInt(os.Getenv("FEED_ITEM_LIMIT")); v > 0 {
+		feedCfg.Limit = v
+	}
+	if status, err := systemService.Status(context.Background()); err == nil && status.SiteName != nil {
+		feedCfg.Title = *status.SiteName
+	}
+	feedAPI := v1.NewFeedAPI(service.NewFeedService(postService, tagService, categoryService, feedCfg))
+
+	// robots.txt defaults to keeping crawlers out of the JSON API; ROBOTS_DISALLOW overrides
+	// the list and an empty value allows everything.
+	sitemapCfg := service.SitemapConfig{SiteURL: siteURL, RobotsDisallow: []string{"/api/"}}
+	if v, ok := os.LookupEnv("ROBOTS_DISALLOW"); ok {
+		sitemapCfg.RobotsDisallow = utils.ParseList(v)
+	}
+	sitemapAPI := v1.NewSitemapAPI(service.NewSitemapService(repository.NewSitemapRepository(db), sitemapCfg))
+	r.GET("/robots.txt", sitemapAPI.RobotsTxt)
+
+	importAPI := v1.NewImportAPI(service.NewImportService(
+		importer.NewDecoder(nil),
+		userRepo,
+		repository.NewCategoryRepository(db),
+		repository.NewTagRepository(db),
+		seriesRepo,
+		postRepo,
+		mediaSvc,
+	))
+	if v := utils.ParseInt64(os.Getenv("IMPORT_MAX_UPLOAD_SIZE_MB")); v > 0 {
+		importAPI.SetMaxUploadBytes(v << 20)
+	}
+	exportAPI := v1.NewExportAPI(service.NewExportService(
+		userRepo,
+		repository.NewCategoryRepository(db),
+		repository.NewTagRepository(db),
+		seriesRepo,
+		postRepo,
+		mediaSvc,
+		systemService.ExportSettings,
+	))
+
+	// Feeds and the sitemap live at the site root so crawlers and readers find them at
+	// conventional paths; they share the public read policies with the post API.
+	publicRoot := r.Group("/")
+	publicRoot.Use(apimw.OptionalAuth(sessionMgr))
+	publicRoot.Use(apimw.Authorize(enforcer))
+	// Their links must come from configuration: deriving them from the Host header would let
+	// one request poison what every reader gets.
+	if siteURL != "" {
+		feedAPI.RegisterRootRoutes(publicRoot)
+		publicRoot.GET("/sitemap.xml", sitemapAPI.Sitemap)
+	} else {
+		log.Printf("level=warn event=feeds_disabled message=%q", "SITE_PUBLIC_BASE_URL is not set; feeds and /sitemap.xml are disabled")
+	}
+
 	go func() {
 		utils.RunTicker(1*time.Hour, func() {
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
@@ -147,21 +383,103 @@ func NewAppRouter(db *gorm.DB, authCfg auth.Config, enforcer *casbin.Enforcer, s
 		})
 	}()
 
+	// Trashed posts are purged after POST_TRASH_RETENTION_DAYS (default 30); 0 keeps them forever.
+	trashRetentionDays := 30
+	if v, ok := os.LookupEnv("POST_TRASH_RETENTION_DAYS"); ok {
+		trashRetentionDays = utils.ParseInt(v)
+	}
+	if trashRetentionDays > 0 {
+		go func() {
+			utils.RunTicker(1*time.Hour, func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+				defer cancel()
+				cutoff := time.Now().AddDate(0, 0, -trashRetentionDays)
+				if err := postService.PurgeExpiredTrash(ctx, cutoff); err != nil {
+					log.Printf("level=error event=post_trash_purge message=%q", err.Error())
+				}
+			})
+		}()
+	}
+
+	// Autosaves nobody has touched for POST_AUTOSAVE_RETENTION_DAYS (default 7) are dropped;
+	// 0 keeps them until the editor saves or discards.
+	autosaveRetentionDays := 7
+	if v, ok := os.LookupEnv("POST_AUTOSAVE_RETENTION_DAYS"); ok {
+		autosaveRetentionDays = utils.ParseInt(v)
+	}
+	if autosaveRetentionDays > 0 {
+		go func() {
+			utils.RunTicker(1*time.Hour, func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+				defer cancel()
+				cutoff := time.Now().AddDate(0, 0, -autosaveRetentionDays)
+				if err := postService.PurgeStaleAutosaves(ctx, cutoff); err != nil {
+					log.Printf("level=error event=post_autosave_purge message=%q", err.Error())
+				}
+			})
+		}()
+	}
+
+	go func() {
+		utils.RunTicker(1*time.Minute, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+			defer cancel()
+			if err := postService.RunScheduledTransitions(ctx, time.Now()); err != nil {
+				log.Printf("level=error event=post_scheduler message=%q", err.Error())
+			}
+		})
+	}()
+
+	// View counts are buffered in memory and written as daily aggregates once a minute, and
+	// once more by the returned flush on shutdown.
+	go func() {
+		utils.RunTicker(1*time.Minute, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+			defer cancel()
+			if err := analyticsService.Flush(ctx); err != nil {
+				log.Printf("level=error event=post_views_flush message=%q", err.Error())
+			}
+		})
+	}()
+
 	apiV1 := r.Group("/api/v1")
 	apiV1.Use(apimw.OptionalAuth(sessionMgr))
 	{
 		userAPI.RegisterRoutes(apiV1)
 		systemAPI.RegisterRoutes(apiV1)
 
+		// Preview links carry their own signed grant, so they work for reviewers without an
+		// account even when anonymous reads are disabled.
+		apiV1.GET("/preview/:token", publicPostAPI.GetPostPreview)
+
 		// Public post routes go through Casbin so the AllowAnonymousRead
 		// setting actually controls anonymous access.  OptionalAuth is
 		// already applied on the parent group; Authorize falls back to
 		// "anonymous" when no role is present in the context.
 		public := apiV1.Group("/")
 		public.Use(apimw.Authorize(enforcer))
+		public.Use(apimw.CSRFCheck(sessionMgr)) // comments are the one public write
 		{
 			public.GET("/posts", publicPostAPI.GetPosts)
 			public.GET("/posts/:id", publicPostAPI.GetPostByID)
+			public.GET("/posts/slug/:slug", publicPostAPI.GetPostBySlug)
+			public.GET("/posts/search", publicPostAPI.SearchPosts)
+			public.GET("/posts/:id/related", publicPostAPI.GetRelatedPosts)
+			public.GET("/categories", categoryAPI.GetCategories)
+			public.GET("/categories/slug/:slug", categoryAPI.GetCategoryBySlug)
+			public.GET("/categories/slug/:slug/posts", categoryAPI.GetCategoryPosts)
+			public.GET("/tags", tagAPI.GetTags)
+			public.GET("/tags/:slug", tagAPI.GetTagBySlug)
+			public.GET("/tags/:slug/posts", tagAPI.GetTagPosts)
+			public.GET("/posts/:id/comments", commentAPI.GetPostComments)
+			public.POST("/posts/:id/comments", commentAPI.CreatePostComment)
+			public.GET("/content/:type", contentAPI.GetEntries)
+			public.GET("/content/:type/:slug", contentAPI.GetEntryBySlug)
+			public.GET("/pages", pageAPI.GetPageTree)
+			public.GET("/pages/*path", pageAPI.GetPageByPath)
+			public.GET("/series", seriesAPI.GetSeries)
+			public.GET("/series/:slug", seriesAPI.GetSeriesBySlug)
+			public.GET("/series/:slug/posts", seriesAPI.GetSeriesPosts)
 		}
 
 		protected := apiV1.Group("/")
@@ -173,18 +491,79 @@ func NewAppRouter(db *gorm.DB, authCfg auth.Config, enforcer *casbin.Enforcer, s
 
 			adminPosts := protected.Group("/admin")
 			adminPosts.GET("/posts", adminPostAPI.GetPosts)
+			adminPosts.GET("/posts/review", adminPostAPI.GetReviewQueue)
+			adminPosts.GET("/posts/trash", adminPostAPI.GetTrashedPosts)
 			adminPosts.GET("/posts/:id", adminPostAPI.GetPostByID)
 			adminPosts.POST("/posts", adminPostAPI.CreatePost)
+			adminPosts.POST("/posts/bulk", adminPostAPI.BulkPosts)
 			adminPosts.PUT("/posts/:id", adminPostAPI.UpdatePost)
 			adminPosts.POST("/posts/:id/publish", adminPostAPI.PublishPost)
 			adminPosts.POST("/posts/:id/draft", adminPostAPI.DraftPost)
+			adminPosts.POST("/posts/:id/schedule", adminPostAPI.SchedulePost)
+			adminPosts.POST("/posts/:id/submit", adminPostAPI.SubmitPostForReview)
+			adminPosts.POST("/posts/:id/approve", adminPostAPI.ApprovePost)
+			adminPosts.POST("/posts/:id/reject", adminPostAPI.RejectPost)
 			adminPosts.DELETE("/posts/:id", adminPostAPI.DeletePost)
+			adminPosts.POST("/posts/:id/restore", adminPostAPI.RestorePost)
+			adminPosts.DELETE("/posts/:id/purge", adminPostAPI.PurgePost)
+			adminPosts.POST("/posts/:id/translations", adminPostAPI.LinkPostTranslation)
+			adminPosts.DELETE("/posts/:id/translations", adminPostAPI.UnlinkPostTranslation)
+			adminPosts.GET("/posts/:id/revisions", adminPostAPI.GetPostRevisions)
+			adminPosts.GET("/posts/:id/revisions/diff", adminPostAPI.DiffPostRevisions)
+			adminPosts.GET("/posts/:id/revisions/:rev", adminPostAPI.GetPostRevision)
+			adminPosts.POST("/posts/:id/revisions/:rev/restore", adminPostAPI.RestorePostRevision)
+			adminPosts.GET("/posts/:id/autosave", adminPostAPI.GetPostAutosave)
+			adminPosts.PUT("/posts/:id/autosave", adminPostAPI.SavePostAutosave)
+			adminPosts.DELETE("/posts/:id/autosave", adminPostAPI.DiscardPostAutosave)
+			adminPosts.POST("/posts/:id/autosave/promote", adminPostAPI.PromotePostAutosave)
+			adminPosts.GET("/posts/:id/previews", adminPostAPI.GetPostPreviews)
+			adminPosts.POST("/posts/:id/previews", adminPostAPI.CreatePostPreview)
+			adminPosts.DELETE("/posts/:id/previews/:preview", adminPostAPI.RevokePostPreview)
+			adminPosts.GET("/comments", commentAPI.GetComments)
+			adminPosts.POST("/comments/:id/approve", commentAPI.ApproveComment)
+			adminPosts.POST("/comments/:id/reject", commentAPI.RejectComment)
+			adminPosts.POST("/comments/:id/spam", commentAPI.SpamComment)
+			adminPosts.GET("/content-types", contentAPI.GetContentTypes)
+			adminPosts.POST("/content-types", contentAPI.CreateContentType)
+			adminPosts.GET("/content-types/:type", contentAPI.GetContentType)
+			adminPosts.PUT("/content-types/:type", contentAPI.UpdateContentType)
+			adminPosts.DELETE("/content-types/:type", contentAPI.DeleteContentType)
+			adminPosts.GET("/content/:type", contentAPI.GetAdminEntries)
+			adminPosts.POST("/content/:type", contentAPI.CreateEntry)
+			adminPosts.GET("/content/:type/:id", contentAPI.GetAdminEntry)
+			adminPosts.PUT("/content/:type/:id", contentAPI.UpdateEntry)
+			adminPosts.POST("/content/:type/:id/publish", contentAPI.PublishEntry)
+			adminPosts.POST("/content/:type/:id/draft", contentAPI.DraftEntry)
+			adminPosts.DELETE("/content/:type/:id", contentAPI.DeleteEntry)
+			adminPosts.GET("/pages", pageAPI.GetAdminPages)
+			adminPosts.POST("/pages", pageAPI.CreatePage)
+			adminPosts.POST("/pages/reorder", pageAPI.ReorderPages)
+			adminPosts.GET("/pages/:id", pageAPI.GetAdminPage)
+			adminPosts.PUT("/pages/:id", pageAPI.UpdatePage)
+			adminPosts.POST("/pages/:id/publish", pageAPI.PublishPage)
+			adminPosts.POST("/pages/:id/draft", pageAPI.DraftPage)
+			adminPosts.DELETE("/pages/:id", pageAPI.DeletePage)
+			adminPosts.GET("/analytics/views", analyticsAPI.GetSiteViews)
+			adminPosts.GET("/analytics/posts/:id/views", analyticsAPI.GetPostViews)
+			adminPosts.GET("/analytics/top-posts", analyticsAPI.GetTopPosts)
+			adminPosts.POST("/import", importAPI.Import)
+			adminPosts.GET("/export", exportAPI.Export)
+
+			protected.POST("/categories", categoryAPI.CreateCategory)
+			protected.PUT("/categories/:id", categoryAPI.UpdateCategory)
+			protected.DELETE("/categories/:id", categoryAPI.DeleteCategory)
+			protected.POST("/tags", tagAPI.CreateTag)
+			protected.PUT("/tags/:id", tagAPI.UpdateTag)
+			protected.DELETE("/tags/:id", tagAPI.DeleteTag)
+			protected.POST("/series", seriesAPI.CreateSeries)
+			protected.PUT("/series/:id", seriesAPI.UpdateSeries)
+			protected.DELETE("/series/:id", seriesAPI.DeleteSeries)
 
 			mediaAPI.RegisterRoutes(protected)
 		}
 	}
 
-	return r
+	return r, analyticsService.Flush
 }
 
 func NewSetupRouter(save func(string, int, string, string, string) error, reload func() error, swaggerOpts SwaggerOptions) *gin.Engine {
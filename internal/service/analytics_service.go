@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// maxTrackedVisitors bounds the per-day de-duplication set. Past it, views are still counted
+// but no longer de-duplicated, so a flood of unique visitors cannot exhaust memory.
+const maxTrackedVisitors = 1 << 20
+
+type viewKey struct {
+	postID uint
+	day    time.Time
+}
+
+// analyticsService implements core.AnalyticsService. Views are buffered in memory and only
+// reach the repository on Flush; visitor hashes never leave the process.
+type analyticsService struct {
+	repo core.PostViewRepository
+	now  func() time.Time
+
+	mu sync.Mutex
+	// day is the UTC day salt and seen belong to; both are replaced when the day changes.
+	day     time.Time
+	salt    []byte
+	seen    map[[sha256.Size]byte]struct{}
+	pending map[viewKey]int64
+}
+
+// NewAnalyticsService creates an AnalyticsService.
+func NewAnalyticsService(repo core.PostViewRepository) core.AnalyticsService {
+	return &analyticsService{repo: repo, now: time.Now, pending: make(map[viewKey]int64)}
+}
+
+// RecordView buffers one view of postID. Bots are ignored, and so is every view after the
+// first by the same visitor of the same post on the same UTC day. The visitor is only kept
+// as a hash salted with a random per-day secret, which is discarded at the end of the day.
+func (s *analyticsService) RecordView(postID uint, visitor entity.Visitor) bool {
+	if postID == 0 || visitor.IsBot() {
+		return false
+	}
+	day := entity.ViewDay(s.now())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !day.Equal(s.day) {
+		s.rotate(day)
+	}
+	h := s.visitorHash(postID, visitor)
+	if _, ok := s.seen[h]; ok {
+		return false
+	}
+	if len(s.seen) < maxTrackedVisitors {
+		s.seen[h] = struct{}{}
+	}
+	s.pending[viewKey{postID: postID, day: day}]++
+	return true
+}
+
+// rotate starts a new de-duplication day with a fresh salt. Callers hold s.mu.
+func (s *analyticsService) rotate(day time.Time) {
+	s.day = day
+	s.salt = make([]byte, 32)
+	_, _ = rand.Read(s.salt)
+	s.seen = make(map[[sha256.Size]byte]struct{})
+}
+
+func (s *analyticsService) visitorHash(postID uint, visitor entity.Visitor) [sha256.Size]byte {
+	h := sha256.New()
+	h.Write(s.salt)
+	_ = binary.Write(h, binary.BigEndian, uint64(postID))
+	h.Write([]byte(visitor.IP))
+	h.Write([]byte{0})
+	h.Write([]byte(visitor.UserAgent))
+	var sum [sha256.Size]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// Flush writes the buffered counts in one batch. On failure they are put back into the
+// buffer, so the next flush retries them.
+func (s *analyticsService) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[viewKey]int64)
+	s.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	counts := make([]entity.PostDailyViews, 0, len(pending))
+	for k, n := range pending {
+		counts = append(counts, entity.PostDailyViews{PostID: k.postID, DailyViews: entity.DailyViews{Day: k.day, Views: n}})
+	}
+	slices.SortFunc(counts, func(a, b entity.PostDailyViews) int {
+		if c := a.Day.Compare(b.Day); c != 0 {
+			return c
+		}
+		return int(a.PostID) - int(b.PostID)
+	})
+
+	if err := s.repo.AddDailyViews(ctx, counts); err != nil {
+		s.mu.Lock()
+		for k, n := range pending {
+			s.pending[k] += n
+		}
+		s.mu.Unlock()
+		return normalizeServiceErrorWithOpMsg("analytics.flush", "flush post views failed", err)
+	}
+	return nil
+}
+
+// PostViews returns the daily views of one post over the range, one point per day.
+func (s *analyticsService) PostViews(ctx context.Context, postID uint, r entity.AnalyticsRange) ([]entity.DailyViews, error) {
+	if postID == 0 {
+		return nil, core.ErrInvalidInput
+	}
+	return s.dailyViews(ctx, &postID, r)
+}
+
+// SiteViews returns the daily views of all posts over the range, one point per day.
+func (s *analyticsService) SiteViews(ctx context.Context, r entity.AnalyticsRange) ([]entity.DailyViews, error) {
+	return s.dailyViews(ctx, nil, r)
+}
+
+func (s *analyticsService) dailyViews(ctx context.Context, postID *uint, r entity.AnalyticsRange) ([]entity.DailyViews, error) {
+	r, ok := r.Normalized(s.now())
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid analytics range", core.ErrInvalidInput)
+	}
+	stored, err := s.repo.DailyViews(ctx, postID, r)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("analytics.daily_views", "load daily views failed", err)
+	}
+
+	byDay := make(map[time.Time]int64, len(stored))
+	for _, d := range stored {
+		byDay[d.Day] = d.Views
+	}
+	points := make([]entity.DailyViews, 0, r.Days())
+	for day := r.From; !day.After(r.To); day = day.AddDate(0, 0, 1) {
+		points = append(points, entity.DailyViews{Day: day, Views: byDay[day]})
+	}
+	return points, nil
+}
+
+// TopPosts returns the most viewed posts over the range.
+func (s *analyticsService) TopPosts(ctx context.Context, r entity.AnalyticsRange, limit int) ([]entity.TopPost, error) {
+	r, ok := r.Normalized(s.now())
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid analytics range", core.ErrInvalidInput)
+	}
+	if limit <= 0 {
+		limit = entity.DefaultTopPostLimit
+	}
+	limit = min(limit, entity.MaxTopPostLimit)
+
+	posts, err := s.repo.TopPosts(ctx, r, limit)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("analytics.top_posts", "load top posts failed", err)
+	}
+	return posts, nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/core/entity"
+)
+
+// memPostViewRepo records flushed counts and serves canned daily views.
+type memPostViewRepo struct {
+	added   []entity.PostDailyViews
+	daily   []entity.DailyViews
+	addErr  error
+	gotPost *uint
+}
+
+func (m *memPostViewRepo) AddDailyViews(ctx context.Context, counts []entity.PostDailyViews) error {
+	if m.addErr != nil {
+		return m.addErr
+	}
+	m.added = append(m.added, counts...)
+	return nil
+}
+func (m *memPostViewRepo) DailyViews(ctx context.Context, postID *uint, r entity.AnalyticsRange) ([]entity.DailyViews, error) {
+	m.gotPost = postID
+	return m.daily, nil
+}
+func (m *memPostViewRepo) TopPosts(ctx context.Context, r entity.AnalyticsRange, limit int) ([]entity.TopPost, error) {
+	return nil, nil
+}
+
+func newTestAnalyticsService(repo *memPostViewRepo, now *time.Time) *analyticsService {
+	svc := NewAnalyticsService(repo).(*analyticsService)
+	svc.now = func() time.Time { return *now }
+	return svc
+}
+
+const browserUA = "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0"
+
+func TestAnalyticsService_RecordView(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	svc := newTestAnalyticsService(&memPostViewRepo{}, &now)
+	alice := entity.Visitor{IP: "203.0.113.7", UserAgent: browserUA}
+
+	if !svc.RecordView(1, alice) {
+		t.Fatal("first view not counted")
+	}
+	if svc.RecordView(1, alice) {
+		t.Fatal("repeat view counted")
+	}
+	if !svc.RecordView(2, alice) {
+		t.Fatal("view of another post not counted")
+	}
+	if svc.RecordView(1, entity.Visitor{IP: "203.0.113.8", UserAgent: "Googlebot/2.1"}) {
+		t.Fatal("bot view counted")
+	}
+
+	salt := string(svc.salt)
+	now = now.Add(24 * time.Hour)
+	if !svc.RecordView(1, alice) {
+		t.Fatal("view on the next day not counted")
+	}
+	if string(svc.salt) == salt {
+		t.Fatal("salt not rotated with the day")
+	}
+
+	day1 := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	want := map[viewKey]int64{{1, day1}: 1, {2, day1}: 1, {1, day1.AddDate(0, 0, 1)}: 1}
+	if len(svc.pending) != len(want) {
+		t.Fatalf("unexpected pending counts: %v", svc.pending)
+	}
+	for k, n := range want {
+		if svc.pending[k] != n {
+			t.Fatalf("pending[%v] = %d, want %d", k, svc.pending[k], n)
+		}
+	}
+}
+
+func TestAnalyticsService_Flush(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	repo := &memPostViewRepo{addErr: errors.New("db down")}
+	svc := newTestAnalyticsService(repo, &now)
+	svc.RecordView(1, entity.Visitor{IP: "203.0.113.7", UserAgent: browserUA})
+
+	if err := svc.Flush(ctx); err == nil {
+		t.Fatal("want flush error")
+	}
+	// Counts that failed to flush are retried, together with views recorded since.
+	svc.RecordView(1, entity.Visitor{IP: "203.0.113.8", UserAgent: browserUA})
+	repo.addErr = nil
+	if err := svc.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.added) != 1 || repo.added[0].PostID != 1 || repo.added[0].Views != 2 {
+		t.Fatalf("unexpected flushed counts: %+v", repo.added)
+	}
+	if err := svc.Flush(ctx); err != nil || len(repo.added) != 1 {
+		t.Fatalf("empty flush wrote counts: %v %+v", err, repo.added)
+	}
+}
+
+func TestAnalyticsService_PostViews_FillsMissingDays(t *testing.T) {
+	now := time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	repo := &memPostViewRepo{daily: []entity.DailyViews{{Day: day(2), Views: 4}, {Day: day(4), Views: 1}}}
+	svc := newTestAnalyticsService(repo, &now)
+
+	points, err := svc.PostViews(context.Background(), 7, entity.AnalyticsRange{From: day(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int64{0, 4, 0, 1, 0}
+	if len(points) != len(want) {
+		t.Fatalf("want %d points, got %+v", len(want), points)
+	}
+	for i, p := range points {
+		if !p.Day.Equal(day(i+1)) || p.Views != want[i] {
+			t.Fatalf("point %d: %+v", i, p)
+		}
+	}
+	if repo.gotPost == nil || *repo.gotPost != 7 {
+		t.Fatalf("unexpected post filter: %v", repo.gotPost)
+	}
+
+	if _, err := svc.SiteViews(context.Background(), entity.AnalyticsRange{From: day(6), To: day(1)}); err == nil {
+		t.Fatal("want error for inverted range")
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gosimple/slug"
+)
+
+// ErrCategoryInUse is returned when deleting a category that posts still reference.
+var ErrCategoryInUse = fmt.Errorf("%w: category is referenced by posts", core.ErrConflict)
+
+// CategoryInUseError carries the number of posts blocking a category delete.
+// It unwraps to ErrCategoryInUse, so errors.Is checks keep working.
+type CategoryInUseError struct {
+	Posts int64
+}
+
+func (e *CategoryInUseError) Error() string {
+	return fmt.Sprintf("%v (%d posts)", ErrCategoryInUse, e.Posts)
+}
+
+func (e *CategoryInUseError) Unwrap() error { return ErrCategoryInUse }
+
+// categoryService implements core.CategoryService.
+type categoryService struct {
+	repo core.CategoryRepository
+	// postsMoved is called after a delete moved posts to another category.
+	postsMoved func()
+}
+
+// NewCategoryService creates a CategoryService. postsMoved, which may be nil, is called after
+// a delete reassigned posts, e.g. PostService.InvalidateRelated to drop recommendations
+// scored on the old category.
+func NewCategoryService(repo core.CategoryRepository, postsMoved func()) core.CategoryService {
+	return &categoryService{repo: repo, postsMoved: postsMoved}
+}
+
+// Create creates a new category. The slug is derived from the name when not provided
+// and suffixed with a counter if it is already taken.
+func (s *categoryService) Create(ctx context.Context, category entity.Category) (entity.Category, error) {
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return entity.Category{}, core.ErrInvalidInput
+	}
+
+	_, err := s.repo.GetByName(ctx, category.Name)
+	switch {
+	case err == nil:
+		return entity.Category{}, core.ErrDuplicate
+	case errors.Is(err, core.ErrNotFound):
+		// ok, not exists
+	default:
+		return entity.Category{}, normalizeServiceErrorWithOpMsg("category.create.lookup", "check existing category by name failed", err)
+	}
+
+	base := category.Name
+	if strings.TrimSpace(category.Slug) != "" {
+		base = category.Slug
+	}
+	baseSlug := slug.Make(base)
+	if baseSlug == "" {
+		return entity.Category{}, fmt.Errorf("%w: cannot generate a valid slug", core.ErrInvalidInput)
+	}
+	category.Slug, err = s.uniqueSlug(ctx, baseSlug)
+	if err != nil {
+		return entity.Category{}, err
+	}
+
+	created, err := s.repo.Create(ctx, category)
+	if err != nil {
+		return entity.Category{}, normalizeServiceErrorWithOpMsg("category.create", "create category failed", err)
+	}
+	return created, nil
+}
+
+func (s *categoryService) uniqueSlug(ctx context.Context, base string) (string, error) {
+	candidate := base
+	for i := 1; i <= 100; i++ {
+		_, err := s.repo.GetBySlug(ctx, candidate)
+		if errors.Is(err, core.ErrNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", normalizeServiceErrorWithOpMsg("category.unique_slug", "check category slug uniqueness failed", err)
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+	return "", fmt.Errorf("%w: unable to generate unique category slug", core.ErrConflict)
+}
+
+// List returns all categories with their published post counts.
+func (s *categoryService) List(ctx context.Context) ([]entity.CategoryWithPostCount, error) {
+	categories, err := s.repo.ListWithPostCounts(ctx)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("category.list", "list categories failed", err)
+	}
+	return categories, nil
+}
+
+// GetByID returns a category by ID.
+func (s *categoryService) GetByID(ctx context.Context, id uint) (entity.Category, error) {
+	if id == 0 {
+		return entity.Category{}, core.ErrInvalidInput
+	}
+	category, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return entity.Category{}, normalizeServiceErrorWithOpMsg("category.get_by_id", "get category by id failed", err)
+	}
+	return category, nil
+}
+
+// GetBySlug returns a category by slug.
+func (s *categoryService) GetBySlug(ctx context.Context, slugValue string) (entity.Category, error) {
+	if slugValue == "" {
+		return entity.Category{}, core.ErrInvalidInput
+	}
+	category, err := s.repo.GetBySlug(ctx, slugValue)
+	if err != nil {
+		return entity.Category{}, normalizeServiceErrorWithOpMsg("category.get_by_slug", "get category by slug failed", err)
+	}
+	return category, nil
+}
+
+// Update renames a category and/or changes its slug. Empty fields are left unchanged;
+// renaming alone keeps the slug so existing category URLs stay valid.
+func (s *categoryService) Update(ctx context.Context, category entity.Category) (entity.Category, error) {
+	if category.ID == 0 {
+		return entity.Category{}, core.ErrInvalidInput
+	}
+	existing, err := s.repo.GetByID(ctx, category.ID)
+	if err != nil {
+		return entity.Category{}, normalizeServiceErrorWithOpMsg("category.update.load", "load category failed", err)
+	}
+
+	if category.Name != "" {
+		name := strings.TrimSpace(category.Name)
+		if name == "" {
+			return entity.Category{}, core.ErrInvalidInput
+		}
+		existing.Name = name
+	}
+	if category.Slug != "" {
+		newSlug := slug.Make(category.Slug)
+		if newSlug == "" {
+			return entity.Category{}, fmt.Errorf("%w: slug cannot be empty", core.ErrInvalidInput)
+		}
+		if newSlug != existing.Slug {
+			other, err := s.repo.GetBySlug(ctx, newSlug)
+			switch {
+			case err == nil && other.ID != existing.ID:
+				return entity.Category{}, fmt.Errorf("%w: slug is already in use", core.ErrDuplicate)
+			case err != nil && !errors.Is(err, core.ErrNotFound):
+				return entity.Category{}, normalizeServiceErrorWithOpMsg("category.update.check_slug", "check category slug uniqueness failed", err)
+			}
+			existing.Slug = newSlug
+		}
+	}
+
+	updated, err := s.repo.Update(ctx, existing)
+	if err != nil {
+		return entity.Category{}, normalizeServiceErrorWithOpMsg("category.update", "update category failed", err)
+	}
+	return updated, nil
+}
+
+// Delete removes a category. Posts still in it block the delete unless reassignTo
+// names another existing category, in which case they are moved there first.
+func (s *categoryService) Delete(ctx context.Context, id uint, reassignTo *uint) error {
+	if id == 0 {
+		return core.ErrInvalidInput
+	}
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return normalizeServiceErrorWithOpMsg("category.delete.load", "load category failed", err)
+	}
+
+	if reassignTo != nil {
+		if *reassignTo == id {
+			return fmt.Errorf("%w: cannot reassign posts to the category being deleted", core.ErrInvalidInput)
+		}
+		if _, err := s.repo.GetByID(ctx, *reassignTo); err != nil {
+			if errors.Is(err, core.ErrNotFound) {
+				return fmt.Errorf("%w: reassignment target category does not exist", core.ErrInvalidInput)
+			}
+			return normalizeServiceErrorWithOpMsg("category.delete.load_target", "load reassignment target failed", err)
+		}
+	} else {
+		n, err := s.repo.CountPosts(ctx, id)
+		if err != nil {
+			return normalizeServiceErrorWithOpMsg("category.delete.count_posts", "count category posts failed", err)
+		}
+		if n > 0 {
+			return &CategoryInUseError{Posts: n}
+		}
+	}
+
+	if err := s.repo.Delete(ctx, id, reassignTo); err != nil {
+		return normalizeServiceErrorWithOpMsg("category.delete", "delete category failed", err)
+	}
+	if reassignTo != nil && s.postsMoved != nil {
+		s.postsMoved()
+	}
+	return nil
+}
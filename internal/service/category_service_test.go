@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+type fakeCategoryRepo struct {
+	createFn     func(ctx context.Context, c entity.Category) (entity.Category, error)
+	listFn       func(ctx context.Context) ([]entity.CategoryWithPostCount, error)
+	getByIDFn    func(ctx context.Context, id uint) (entity.Category, error)
+	getBySlugFn  func(ctx context.Context, slug string) (entity.Category, error)
+	getByNameFn  func(ctx context.Context, name string) (entity.Category, error)
+	updateFn     func(ctx context.Context, c entity.Category) (entity.Category, error)
+	countPostsFn func(ctx context.Context, id uint) (int64, error)
+	deleteFn     func(ctx context.Context, id uint, reassignTo *uint) error
+}
+
+func (f *fakeCategoryRepo) Create(ctx context.Context, c entity.Category) (entity.Category, error) {
+	return f.createFn(ctx, c)
+}
+func (f *fakeCategoryRepo) ListWithPostCounts(ctx context.Context) ([]entity.CategoryWithPostCount, error) {
+	return f.listFn(ctx)
+}
+func (f *fakeCategoryRepo) GetByID(ctx context.Context, id uint) (entity.Category, error) {
+	return f.getByIDFn(ctx, id)
+}
+func (f *fakeCategoryRepo) GetBySlug(ctx context.Context, slug string) (entity.Category, error) {
+	return f.getBySlugFn(ctx, slug)
+}
+func (f *fakeCategoryRepo) GetByName(ctx context.Context, name string) (entity.Category, error) {
+	return f.getByNameFn(ctx, name)
+}
+func (f *fakeCategoryRepo) Update(ctx context.Context, c entity.Category) (entity.Category, error) {
+	return f.updateFn(ctx, c)
+}
+func (f *fakeCategoryRepo) CountPosts(ctx context.Context, id uint) (int64, error) {
+	return f.countPostsFn(ctx, id)
+}
+func (f *fakeCategoryRepo) Delete(ctx context.Context, id uint, reassignTo *uint) error {
+	return f.deleteFn(ctx, id, reassignTo)
+}
+
+func TestCategoryService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("empty name rejected", func(t *testing.T) {
+		_, err := NewCategoryService(&fakeCategoryRepo{}, nil).Create(ctx, entity.Category{Name: "  "})
+		if !errors.Is(err, core.ErrInvalidInput) {
+			t.Fatalf("want ErrInvalidInput, got %v", err)
+		}
+	})
+
+	t.Run("duplicate name rejected", func(t *testing.T) {
+		repo := &fakeCategoryRepo{
+			getByNameFn: func(ctx context.Context, name string) (entity.Category, error) {
+				return entity.Category{ID: 3, Name: name}, nil
+			},
+		}
+		_, err := NewCategoryService(repo, nil).Create(ctx, entity.Category{Name: "Go"})
+		if !errors.Is(err, core.ErrDuplicate) {
+			t.Fatalf("want ErrDuplicate, got %v", err)
+		}
+	})
+
+	t.Run("slug generated and suffixed when taken", func(t *testing.T) {
+		repo := &fakeCategoryRepo{
+			getByNameFn: func(ctx context.Context, name string) (entity.Category, error) {
+				return entity.Category{}, core.ErrNotFound
+			},
+			getBySlugFn: func(ctx context.Context, slug string) (entity.Category, error) {
+				if slug == "go-tips" {
+					return entity.Category{ID: 1, Slug: slug}, nil
+				}
+				return entity.Category{}, core.ErrNotFound
+			},
+			createFn: func(ctx context.Context, c entity.Category) (entity.Category, error) {
+				c.ID = 2
+				return c, nil
+			},
+		}
+		got, err := NewCategoryService(repo, nil).Create(ctx, entity.Category{Name: " Go Tips "})
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if got.Name != "Go Tips" || got.Slug != "go-tips-1" {
+			t.Fatalf("unexpected category: %+v", got)
+		}
+	})
+}
+
+func TestCategoryService_Update_SlugTaken(t *testing.T) {
+	repo := &fakeCategoryRepo{
+		getByIDFn: func(ctx context.Context, id uint) (entity.Category, error) {
+			return entity.Category{ID: id, Name: "a", Slug: "a"}, nil
+		},
+		getBySlugFn: func(ctx context.Context, slug string) (entity.Category, error) {
+			return entity.Category{ID: 9, Slug: slug}, nil
+		},
+	}
+	_, err := NewCategoryService(repo, nil).Update(context.Background(), entity.Category{ID: 1, Slug: "b"})
+	if !errors.Is(err, core.ErrDuplicate) {
+		t.Fatalf("want ErrDuplicate, got %v", err)
+	}
+}
+
+func TestCategoryService_Delete(t *testing.T) {
+	ctx := context.Background()
+	exists := func(ctx context.Context, id uint) (entity.Category, error) {
+		if id == 404 {
+			return entity.Category{}, core.ErrNotFound
+		}
+		return entity.Category{ID: id}, nil
+	}
+
+	t.Run("refuses while posts reference it", func(t *testing.T) {
+		repo := &fakeCategoryRepo{
+			getByIDFn:    exists,
+			countPostsFn: func(ctx context.Context, id uint) (int64, error) { return 4, nil },
+		}
+		err := NewCategoryService(repo, nil).Delete(ctx, 1, nil)
+		var inUse *CategoryInUseError
+		if !errors.As(err, &inUse) || inUse.Posts != 4 {
+			t.Fatalf("want CategoryInUseError{4}, got %v", err)
+		}
+		if !errors.Is(err, core.ErrConflict) {
+			t.Fatalf("in-use error must map to ErrConflict, got %v", err)
+		}
+	})
+
+	t.Run("reassigns then deletes", func(t *testing.T) {
+		var gotTarget *uint
+		moved := 0
+		repo := &fakeCategoryRepo{
+			getByIDFn: exists,
+			deleteFn: func(ctx context.Context, id uint, reassignTo *uint) error {
+				if moved != 0 {
+					t.Fatal("postsMoved called before the delete")
+				}
+				gotTarget = reassignTo
+				return nil
+			},
+		}
+		target := uint(2)
+		if err := NewCategoryService(repo, func() { moved++ }).Delete(ctx, 1, &target); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if gotTarget == nil || *gotTarget != 2 {
+			t.Fatalf("reassign target not passed: %v", gotTarget)
+		}
+		if moved != 1 {
+			t.Fatalf("postsMoved called %d times, want 1", moved)
+		}
+	})
+
+	t.Run("failed reassign keeps the caches", func(t *testing.T) {
+		moved := 0
+		repo := &fakeCategoryRepo{
+			getByIDFn: exists,
+			deleteFn:  func(ctx context.Context, id uint, reassignTo *uint) error { return core.ErrConflict },
+		}
+		target := uint(2)
+		if err := NewCategoryService(repo, func() { moved++ }).Delete(ctx, 1, &target); !errors.Is(err, core.ErrConflict) {
+			t.Fatalf("want ErrConflict, got %v", err)
+		}
+		if moved != 0 {
+			t.Fatalf("postsMoved called after a failed delete")
+		}
+	})
+
+	t.Run("invalid reassign targets rejected", func(t *testing.T) {
+		repo := &fakeCategoryRepo{getByIDFn: exists}
+		for _, target := range []uint{1, 404} {
+			target := target
+			if err := NewCategoryService(repo, nil).Delete(ctx, 1, &target); !errors.Is(err, core.ErrInvalidInput) {
+				t.Fatalf("target %d: want ErrInvalidInput, got %v", target, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCommentsClosed is returned when commenting on a post whose comments are disabled.
+var ErrCommentsClosed = fmt.Errorf("%w: comments are closed for this post", core.ErrPermission)
+
+// commentService implements core.CommentService.
+type commentService struct {
+	repo     core.CommentRepository
+	posts    core.PostRepository
+	renderer core.ContentRenderer
+}
+
+// NewCommentService creates a CommentService. Comment Markdown is rendered and sanitized
+// with renderer when the comment is written.
+func NewCommentService(repo core.CommentRepository, posts core.PostRepository, renderer core.ContentRenderer) core.CommentService {
+	return &commentService{repo: repo, posts: posts, renderer: renderer}
+}
+
+// Create stores a new comment in the moderation queue. Replies must target an approved
+// comment on the same post.
+func (s *commentService) Create(ctx context.Context, comment entity.Comment, actorUserID uint) (entity.Comment, error) {
+	comment.Body = strings.TrimSpace(comment.Body)
+	if comment.PostID == 0 || comment.Body == "" {
+		return entity.Comment{}, core.ErrInvalidInput
+	}
+
+	post, err := s.posts.GetPublishedByID(ctx, comment.PostID)
+	if err != nil {
+		return entity.Comment{}, normalizeServiceErrorWithOpMsg("comment.create.load_post", "load post failed", err)
+	}
+	if post.CommentsDisabled {
+		return entity.Comment{}, ErrCommentsClosed
+	}
+
+	if actorUserID != 0 {
+		// Logged-in comments are shown under the account's username.
+		comment.AuthorID = &actorUserID
+		comment.AuthorName = ""
+		comment.AuthorEmail = ""
+	} else {
+		comment.AuthorID = nil
+		comment.AuthorName = strings.TrimSpace(comment.AuthorName)
+		comment.AuthorEmail = strings.TrimSpace(comment.AuthorEmail)
+		if comment.AuthorName == "" {
+			return entity.Comment{}, fmt.Errorf("%w: author name is required", core.ErrInvalidInput)
+		}
+	}
+
+	if comment.ParentID != nil {
+		parent, err := s.repo.GetByID(ctx, *comment.ParentID)
+		switch {
+		case errors.Is(err, core.ErrNotFound):
+			return entity.Comment{}, fmt.Errorf("%w: parent comment does not exist", core.ErrInvalidInput)
+		case err != nil:
+			return entity.Comment{}, normalizeServiceErrorWithOpMsg("comment.create.load_parent", "load parent comment failed", err)
+		case parent.PostID != comment.PostID || parent.Status != entity.CommentStatusApproved:
+			return entity.Comment{}, fmt.Errorf("%w: parent comment does not exist", core.ErrInvalidInput)
+		}
+	}
+
+	rendered, err := s.renderer.Render(comment.Body)
+	if err != nil {
+		return entity.Comment{}, normalizeServiceErrorWithOpMsg("comment.create.render", "render comment failed", err)
+	}
+	comment.BodyHTML = rendered.HTML
+	comment.Status = entity.CommentStatusPending
+
+	created, err := s.repo.Create(ctx, comment)
+	if err != nil {
+		return entity.Comment{}, normalizeServiceErrorWithOpMsg("comment.create", "create comment failed", err)
+	}
+	return created, nil
+}
+
+// ListApproved returns the approved comments of a published post, threaded. Replies whose
+// parent is not approved (any more) are left out together with the parent.
+func (s *commentService) ListApproved(ctx context.Context, postID uint) ([]entity.Comment, error) {
+	if postID == 0 {
+		return nil, core.ErrInvalidInput
+	}
+	if _, err := s.posts.GetPublishedByID(ctx, postID); err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("comment.list_approved.load_post", "load post failed", err)
+	}
+	comments, err := s.repo.ListApprovedByPost(ctx, postID)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("comment.list_approved", "list comments failed", err)
+	}
+	return threadComments(comments), nil
+}
+
+func threadComments(flat []entity.Comment) []entity.Comment {
+	roots := []entity.Comment{}
+	children := make(map[uint][]entity.Comment)
+	for _, c := range flat {
+		if c.ParentID == nil {
+			roots = append(roots, c)
+		} else {
+			children[*c.ParentID] = append(children[*c.ParentID], c)
+		}
+	}
+
+	var attach func(list []entity.Comment) []entity.Comment
+	attach = func(list []entity.Comment) []entity.Comment {
+		for i := range list {
+			list[i].Replies = attach(children[list[i].ID])
+		}
+		return list
+	}
+	return attach(roots)
+}
+
+// List returns one page of comments for moderation.
+func (s *commentService) List(ctx context.Context, query entity.CommentListQuery) ([]entity.Comment, int64, error) {
+	if query.Status != "" && !entity.IsValidCommentStatus(query.Status) {
+		return nil, 0, fmt.Errorf("%w: unknown comment status", core.ErrInvalidInput)
+	}
+	comments, total, err := s.repo.List(ctx, query.Normalized())
+	if err != nil {
+		return nil, 0, normalizeServiceErrorWithOpMsg("comment.list", "list comments failed", err)
+	}
+	return comments, total, nil
+}
+
+// Moderate records a moderation decision. Decisions can be revised, e.g. approving a
+// comment that was marked as spam by mistake.
+func (s *commentService) Moderate(ctx context.Context, id uint, status string) error {
+	if id == 0 {
+		return core.ErrInvalidInput
+	}
+	switch status {
+	case entity.CommentStatusApproved, entity.CommentStatusRejected, entity.CommentStatusSpam:
+	default:
+		return fmt.Errorf("%w: unsupported moderation status", core.ErrInvalidInput)
+	}
+	return normalizeServiceErrorWithOpMsg("comment.moderate", "update comment status failed", s.repo.UpdateStatus(ctx, id, status))
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+type fakeCommentRepo struct {
+	createFn       func(ctx context.Context, c entity.Comment) (entity.Comment, error)
+	getByIDFn      func(ctx context.Context, id uint) (entity.Comment, error)
+	listApprovedFn func(ctx context.Context, postID uint) ([]entity.Comment, error)
+	listFn         func(ctx context.Context, q entity.CommentListQuery) ([]entity.Comment, int64, error)
+	updateStatusFn func(ctx context.Context, id uint, status string) error
+}
+
+func (f *fakeCommentRepo) Create(ctx context.Context, c entity.Comment) (entity.Comment, error) {
+	return f.createFn(ctx, c)
+}
+func (f *fakeCommentRepo) GetByID(ctx context.Context, id uint) (entity.Comment, error) {
+	return f.getByIDFn(ctx, id)
+}
+func (f *fakeCommentRepo) ListApprovedByPost(ctx context.Context, postID uint) ([]entity.Comment, error) {
+	return f.listApprovedFn(ctx, postID)
+}
+func (f *fakeCommentRepo) List(ctx context.Context, q entity.CommentListQuery) ([]entity.Comment, int64, error) {
+	return f.listFn(ctx, q)
+}
+func (f *fakeCommentRepo) UpdateStatus(ctx context.Context, id uint, status string) error {
+	return f.updateStatusFn(ctx, id, status)
+}
+
+func publishedPostRepo(post entity.Post) *fakePostRepo {
+	return &fakePostRepo{getPublishedByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+		if id != post.ID {
+			return entity.Post{}, core.ErrNotFound
+		}
+		return post, nil
+	}}
+}
+
+func TestCommentService_Create(t *testing.T) {
+	ctx := context.Background()
+	posts := publishedPostRepo(entity.Post{ID: 1})
+	echo := func(ctx context.Context, c entity.Comment) (entity.Comment, error) {
+		c.ID = 10
+		return c, nil
+	}
+
+	t.Run("guest comment is pending and rendered", func(t *testing.T) {
+		svc := NewCommentService(&fakeCommentRepo{createFn: echo}, posts, &countingRenderer{})
+		got, err := svc.Create(ctx, entity.Comment{PostID: 1, Body: " hi ", AuthorName: " Ann "}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != entity.CommentStatusPending || got.BodyHTML != "<p>hi</p>" || got.AuthorName != "Ann" || got.AuthorID != nil {
+			t.Fatalf("unexpected comment: %+v", got)
+		}
+	})
+
+	t.Run("guest without name is rejected", func(t *testing.T) {
+		svc := NewCommentService(&fakeCommentRepo{}, posts, &countingRenderer{})
+		_, err := svc.Create(ctx, entity.Comment{PostID: 1, Body: "hi"}, 0)
+		if !errors.Is(err, core.ErrInvalidInput) {
+			t.Fatalf("want ErrInvalidInput, got %v", err)
+		}
+	})
+
+	t.Run("logged-in comment ignores supplied author fields", func(t *testing.T) {
+		svc := NewCommentService(&fakeCommentRepo{createFn: echo}, posts, &countingRenderer{})
+		got, err := svc.Create(ctx, entity.Comment{PostID: 1, Body: "hi", AuthorName: "fake", AuthorEmail: "x@y.z"}, 7)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.AuthorID == nil || *got.AuthorID != 7 || got.AuthorName != "" || got.AuthorEmail != "" {
+			t.Fatalf("unexpected author: %+v", got)
+		}
+	})
+
+	t.Run("closed post", func(t *testing.T) {
+		svc := NewCommentService(&fakeCommentRepo{}, publishedPostRepo(entity.Post{ID: 1, CommentsDisabled: true}), &countingRenderer{})
+		_, err := svc.Create(ctx, entity.Comment{PostID: 1, Body: "hi"}, 7)
+		if !errors.Is(err, ErrCommentsClosed) || !errors.Is(err, core.ErrPermission) {
+			t.Fatalf("want ErrCommentsClosed, got %v", err)
+		}
+	})
+
+	t.Run("unpublished post", func(t *testing.T) {
+		svc := NewCommentService(&fakeCommentRepo{}, posts, &countingRenderer{})
+		_, err := svc.Create(ctx, entity.Comment{PostID: 2, Body: "hi"}, 7)
+		if !errors.Is(err, core.ErrNotFound) {
+			t.Fatalf("want ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("reply needs an approved parent on the same post", func(t *testing.T) {
+		parents := map[uint]entity.Comment{
+			1: {ID: 1, PostID: 1, Status: entity.CommentStatusApproved},
+			2: {ID: 2, PostID: 1, Status: entity.CommentStatusPending},
+			3: {ID: 3, PostID: 9, Status: entity.CommentStatusApproved},
+		}
+		repo := &fakeCommentRepo{createFn: echo, getByIDFn: func(ctx context.Context, id uint) (entity.Comment, error) {
+			if c, ok := parents[id]; ok {
+				return c, nil
+			}
+			return entity.Comment{}, core.ErrNotFound
+		}}
+		svc := NewCommentService(repo, posts, &countingRenderer{})
+
+		for parentID, wantOK := range map[uint]bool{1: true, 2: false, 3: false, 4: false} {
+			pid := parentID
+			_, err := svc.Create(ctx, entity.Comment{PostID: 1, ParentID: &pid, Body: "re"}, 7)
+			if wantOK && err != nil {
+				t.Fatalf("parent %d: %v", pid, err)
+			}
+			if !wantOK && !errors.Is(err, core.ErrInvalidInput) {
+				t.Fatalf("parent %d: want ErrInvalidInput, got %v", pid, err)
+			}
+		}
+	})
+}
+
+func TestCommentService_ListApproved_Threads(t *testing.T) {
+	id := func(v uint) *uint { return &v }
+	repo := &fakeCommentRepo{listApprovedFn: func(ctx context.Context, postID uint) ([]entity.Comment, error) {
+		return []entity.Comment{
+			{ID: 1},
+			{ID: 2, ParentID: id(1)},
+			{ID: 3},
+			{ID: 4, ParentID: id(2)},
+			{ID: 5, ParentID: id(99)}, // parent not approved
+		}, nil
+	}}
+	svc := NewCommentService(repo, publishedPostRepo(entity.Post{ID: 1}), &countingRenderer{})
+
+	got, err := svc.ListApproved(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].ID != 1 || got[1].ID != 3 {
+		t.Fatalf("roots: %+v", got)
+	}
+	if len(got[0].Replies) != 1 || got[0].Replies[0].ID != 2 || len(got[0].Replies[0].Replies) != 1 || got[0].Replies[0].Replies[0].ID != 4 {
+		t.Fatalf("thread: %+v", got[0])
+	}
+}
+
+func TestCommentService_Moderate(t *testing.T) {
+	var gotStatus string
+	repo := &fakeCommentRepo{updateStatusFn: func(ctx context.Context, id uint, status string) error {
+		gotStatus = status
+		return nil
+	}}
+	svc := NewCommentService(repo, &fakePostRepo{}, &countingRenderer{})
+
+	if err := svc.Moderate(context.Background(), 3, entity.CommentStatusSpam); err != nil || gotStatus != entity.CommentStatusSpam {
+		t.Fatalf("err=%v status=%q", err, gotStatus)
+	}
+	if err := svc.Moderate(context.Background(), 3, entity.CommentStatusPending); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("want ErrInvalidInput, got %v", err)
+	}
+}
@@ -0,0 +1,572 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// ErrContentTypeInUse is returned when deleting a content type that still has entries
+// or that another type references.
+var ErrContentTypeInUse = fmt.Errorf("%w: content type is in use", core.ErrConflict)
+
+// ContentTypeInUseError carries what blocks a content type delete.
+// It unwraps to ErrContentTypeInUse, so errors.Is checks keep working.
+type ContentTypeInUseError struct {
+	Entries int64
+	// ReferencedBy lists the slugs of types whose reference fields target this type.
+	ReferencedBy []string
+}
+
+func (e *ContentTypeInUseError) Error() string {
+	return fmt.Sprintf("%v (%d entries, referenced by %v)", ErrContentTypeInUse, e.Entries, e.ReferencedBy)
+}
+
+func (e *ContentTypeInUseError) Unwrap() error { return ErrContentTypeInUse }
+
+// ErrContentEntryInUse is returned when deleting an entry that other entries reference.
+var ErrContentEntryInUse = fmt.Errorf("%w: content entry is referenced", core.ErrConflict)
+
+// ContentEntryInUseError carries what blocks an entry delete.
+// It unwraps to ErrContentEntryInUse.
+type ContentEntryInUseError struct {
+	// Entries counts the referencing entries; ReferencedBy lists their types' slugs.
+	Entries      int64
+	ReferencedBy []string
+}
+
+func (e *ContentEntryInUseError) Error() string {
+	return fmt.Sprintf("%v (%d entries of %v)", ErrContentEntryInUse, e.Entries, e.ReferencedBy)
+}
+
+func (e *ContentEntryInUseError) Unwrap() error { return ErrContentEntryInUse }
+
+// contentService implements core.ContentService.
+type contentService struct {
+	types   core.ContentTypeRepository
+	entries core.ContentEntryRepository
+	// media is optional; when nil, media fields are only checked for a well-formed ID.
+	media      core.MediaRepository
+	authorizer core.PostAuthorizer
+}
+
+// NewContentService creates a ContentService. Entry permissions are checked with the post
+// capabilities of authorizer.
+func NewContentService(types core.ContentTypeRepository, entries core.ContentEntryRepository, media core.MediaRepository, authorizer core.PostAuthorizer) core.ContentService {
+	return &contentService{types: types, entries: entries, media: media, authorizer: authorizer}
+}
+
+// CreateType creates a content type. The slug is derived from the name when not provided
+// and cannot be changed later, because reference fields and URLs address types by slug.
+func (s *contentService) CreateType(ctx context.Context, contentType entity.ContentType) (entity.ContentType, error) {
+	contentType.Name = strings.TrimSpace(contentType.Name)
+	base := contentType.Name
+	if strings.TrimSpace(contentType.Slug) != "" {
+		base = contentType.Slug
+	}
+	contentType.Slug = slug.Make(base)
+	if contentType.Slug == "" {
+		return entity.ContentType{}, fmt.Errorf("%w: cannot generate a valid slug", core.ErrInvalidInput)
+	}
+	if err := s.checkSchema(ctx, contentType); err != nil {
+		return entity.ContentType{}, err
+	}
+
+	created, err := s.types.Create(ctx, contentType)
+	if err != nil {
+		return entity.ContentType{}, normalizeServiceErrorWithOpMsg("content.type.create", "create content type failed", err)
+	}
+	return created, nil
+}
+
+// ListTypes returns every content type.
+func (s *contentService) ListTypes(ctx context.Context) ([]entity.ContentType, error) {
+	types, err := s.types.List(ctx)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("content.type.list", "list content types failed", err)
+	}
+	return types, nil
+}
+
+// GetType returns a content type by slug.
+func (s *contentService) GetType(ctx context.Context, slugValue string) (entity.ContentType, error) {
+	if slugValue == "" {
+		return entity.ContentType{}, core.ErrInvalidInput
+	}
+	contentType, err := s.types.GetBySlug(ctx, slugValue)
+	if err != nil {
+		return entity.ContentType{}, normalizeServiceErrorWithOpMsg("content.type.get", "get content type failed", err)
+	}
+	return contentType, nil
+}
+
+// UpdateType replaces the name, description and fields of a content type.
+// Existing entries are not rewritten; they are validated against the new schema on their next save.
+func (s *contentService) UpdateType(ctx context.Context, slugValue string, contentType entity.ContentType) (entity.ContentType, error) {
+	existing, err := s.GetType(ctx, slugValue)
+	if err != nil {
+		return entity.ContentType{}, err
+	}
+	existing.Name = strings.TrimSpace(contentType.Name)
+	existing.Description = contentType.Description
+	existing.Fields = contentType.Fields
+	if err := s.checkSchema(ctx, existing); err != nil {
+		return entity.ContentType{}, err
+	}
+
+	updated, err := s.types.Update(ctx, existing)
+	if err != nil {
+		return entity.ContentType{}, normalizeServiceErrorWithOpMsg("content.type.update", "update content type failed", err)
+	}
+	return updated, nil
+}
+
+// DeleteType removes a content type that has no entries and is not referenced by another type.
+func (s *contentService) DeleteType(ctx context.Context, slugValue string) error {
+	contentType, err := s.GetType(ctx, slugValue)
+	if err != nil {
+		return err
+	}
+
+	entries, err := s.entries.CountByType(ctx, contentType.ID)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("content.type.delete.count", "count content entries failed", err)
+	}
+	all, err := s.ListTypes(ctx)
+	if err != nil {
+		return err
+	}
+	var referencedBy []string
+	for _, other := range all {
+		if other.ID == contentType.ID {
+			continue
+		}
+		for _, f := range other.Fields {
+			if f.Type == entity.FieldTypeReference && f.Target == contentType.Slug {
+				referencedBy = append(referencedBy, other.Slug)
+				break
+			}
+		}
+	}
+	if entries > 0 || len(referencedBy) > 0 {
+		return &ContentTypeInUseError{Entries: entries, ReferencedBy: referencedBy}
+	}
+
+	if err := s.types.Delete(ctx, contentType.ID); err != nil {
+		return normalizeServiceErrorWithOpMsg("content.type.delete", "delete content type failed", err)
+	}
+	return nil
+}
+
+// checkSchema validates a schema and makes sure every reference field targets an existing type.
+// A type may reference itself, e.g. a team member's manager.
+func (s *contentService) checkSchema(ctx context.Context, contentType entity.ContentType) error {
+	if err := contentType.CheckValidity(); err != nil {
+		return fmt.Errorf("%w: %w", core.ErrInvalidInput, err)
+	}
+	for _, f := range contentType.Fields {
+		if f.Type != entity.FieldTypeReference || f.Target == contentType.Slug {
+			continue
+		}
+		if _, err := s.types.GetBySlug(ctx, f.Target); err != nil {
+			if errors.Is(err, core.ErrNotFound) {
+				return fmt.Errorf("%w: %w", core.ErrInvalidInput, &entity.ContentFieldError{Field: f.Key, Reason: "target content type does not exist"})
+			}
+			return normalizeServiceErrorWithOpMsg("content.type.check_target", "load reference target type failed", err)
+		}
+	}
+	return nil
+}
+
+// ListPublicEntries returns one page of a type's published entries.
+func (s *contentService) ListPublicEntries(ctx context.Context, typeSlug string, query entity.ContentEntryQuery) ([]entity.ContentEntry, int64, error) {
+	contentType, err := s.GetType(ctx, typeSlug)
+	if err != nil {
+		return nil, 0, err
+	}
+	published := entity.StatusPublished
+	query.TypeID = contentType.ID
+	query.Status = &published
+	query.AuthorID = nil
+
+	entries, total, err := s.entries.List(ctx, query)
+	if err != nil {
+		return nil, 0, normalizeServiceErrorWithOpMsg("content.entry.list_public", "list public content entries failed", err)
+	}
+	return entries, total, nil
+}
+
+// GetPublicEntryBySlug returns a published entry; drafts are reported as not found.
+func (s *contentService) GetPublicEntryBySlug(ctx context.Context, typeSlug string, slugValue string) (entity.ContentEntry, error) {
+	contentType, err := s.GetType(ctx, typeSlug)
+	if err != nil {
+		return entity.ContentEntry{}, err
+	}
+	entry, err := s.entries.GetBySlug(ctx, contentType.ID, slugValue)
+	if err != nil {
+		return entity.ContentEntry{}, normalizeServiceErrorWithOpMsg("content.entry.get_public", "get public content entry failed", err)
+	}
+	if entry.Status != entity.StatusPublished {
+		return entity.ContentEntry{}, core.ErrNotFound
+	}
+	return entry, nil
+}
+
+// ListAdminEntries lists every entry of a type for roles that may list any post,
+// and only the actor's own drafts otherwise.
+func (s *contentService) ListAdminEntries(ctx context.Context, typeSlug string, query entity.ContentEntryQuery, actorUserID uint, actorRole string) ([]entity.ContentEntry, int64, error) {
+	contentType, err := s.GetType(ctx, typeSlug)
+	if err != nil {
+		return nil, 0, err
+	}
+	query.TypeID = contentType.ID
+
+	canListAny, err := s.hasPermission(ctx, actorRole, core.PostPermissionListAnyPost)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !canListAny {
+		if actorUserID == 0 {
+			return nil, 0, core.ErrPermission
+		}
+		if err := s.authorize(ctx, actorRole, core.PostPermissionListOwnDrafts); err != nil {
+			return nil, 0, err
+		}
+		draft := entity.StatusDraft
+		query.AuthorID = &actorUserID
+		query.Status = &draft
+	}
+
+	entries, total, err := s.entries.List(ctx, query)
+	if err != nil {
+		return nil, 0, normalizeServiceErrorWithOpMsg("content.entry.list_admin", "list content entries failed", err)
+	}
+	return entries, total, nil
+}
+
+// GetAdminEntry returns one entry the actor can manage.
+func (s *contentService) GetAdminEntry(ctx context.Context, typeSlug string, id uint, actorUserID uint, actorRole string) (entity.ContentEntry, error) {
+	_, entry, err := s.loadEntry(ctx, typeSlug, id, actorUserID, actorRole, core.PostPermissionReadAnyPost, core.PostPermissionReadOwnDraft)
+	return entry, err
+}
+
+// CreateAdminEntry validates an entry against its type and stores it as a draft owned by the actor.
+func (s *contentService) CreateAdminEntry(ctx context.Context, typeSlug string, entry entity.ContentEntry, actorUserID uint, actorRole string) (entity.ContentEntry, error) {
+	if actorUserID == 0 {
+		return entity.ContentEntry{}, core.ErrPermission
+	}
+	if err := s.authorize(ctx, actorRole, core.PostPermissionCreateOwnDraft); err != nil {
+		return entity.ContentEntry{}, err
+	}
+	contentType, err := s.GetType(ctx, typeSlug)
+	if err != nil {
+		return entity.ContentEntry{}, err
+	}
+
+	entry.ID = 0
+	entry.TypeID = contentType.ID
+	entry.AuthorID = actorUserID
+	entry.Status = entity.StatusDraft
+	entry.Title = strings.TrimSpace(entry.Title)
+	if entry.Title == "" {
+		return entity.ContentEntry{}, fmt.Errorf("%w: title is required", core.ErrInvalidInput)
+	}
+	base := entry.Title
+	if strings.TrimSpace(entry.Slug) != "" {
+		base = entry.Slug
+	}
+	if entry.Slug, err = s.uniqueEntrySlug(ctx, contentType.ID, slug.Make(base)); err != nil {
+		return entity.ContentEntry{}, err
+	}
+	if entry.Data, err = s.validateEntryData(ctx, contentType, entry.Data); err != nil {
+		return entity.ContentEntry{}, err
+	}
+
+	created, err := s.entries.Create(ctx, entry)
+	if err != nil {
+		return entity.ContentEntry{}, normalizeServiceErrorWithOpMsg("content.entry.create", "create content entry failed", err)
+	}
+	s.syncEntryMedia(ctx, contentType, created)
+	return created, nil
+}
+
+// UpdateAdminEntry applies a patch to an entry the actor can update and re-validates its data.
+func (s *contentService) UpdateAdminEntry(ctx context.Context, typeSlug string, id uint, patch entity.ContentEntryPatch, actorUserID uint, actorRole string) (entity.ContentEntry, error) {
+	contentType, entry, err := s.loadEntry(ctx, typeSlug, id, actorUserID, actorRole, core.PostPermissionUpdateAnyPost, core.PostPermissionUpdateOwnDraft)
+	if err != nil {
+		return entity.ContentEntry{}, err
+	}
+
+	if patch.Title != nil {
+		entry.Title = strings.TrimSpace(*patch.Title)
+		if entry.Title == "" {
+			return entity.ContentEntry{}, fmt.Errorf("%w: title is required", core.ErrInvalidInput)
+		}
+	}
+	if patch.Slug != nil {
+		newSlug := slug.Make(*patch.Slug)
+		if newSlug == "" {
+			return entity.ContentEntry{}, fmt.Errorf("%w: slug cannot be empty", core.ErrInvalidInput)
+		}
+		if newSlug != entry.Slug {
+			exists, err := s.entries.IsSlugExists(ctx, contentType.ID, newSlug)
+			if err != nil {
+				return entity.ContentEntry{}, normalizeServiceErrorWithOpMsg("content.entry.update.check_slug", "check entry slug uniqueness failed", err)
+			}
+			if exists {
+				return entity.ContentEntry{}, fmt.Errorf("%w: slug is already in use", core.ErrDuplicate)
+			}
+			entry.Slug = newSlug
+		}
+	}
+	if patch.Data != nil {
+		entry.Data = patch.Data
+	}
+	// Data is re-validated even when unchanged, so saving an entry brings it in line with
+	// a schema that was edited since it was last written.
+	if entry.Data, err = s.validateEntryData(ctx, contentType, entry.Data); err != nil {
+		return entity.ContentEntry{}, err
+	}
+	entry.UpdatedAt = time.Now()
+
+	if err := s.entries.Update(ctx, entry); err != nil {
+		return entity.ContentEntry{}, normalizeServiceErrorWithOpMsg("content.entry.update", "update content entry failed", err)
+	}
+	s.syncEntryMedia(ctx, contentType, entry)
+	return entry, nil
+}
+
+// PublishAdminEntry performs the Draft -> Published transition.
+func (s *contentService) PublishAdminEntry(ctx context.Context, typeSlug string, id uint, actorUserID uint, actorRole string) error {
+	if err := s.authorize(ctx, actorRole, core.PostPermissionPublishPost); err != nil {
+		return err
+	}
+	contentType, entry, err := s.loadEntry(ctx, typeSlug, id, actorUserID, actorRole, core.PostPermissionReadAnyPost, core.PostPermissionReadOwnDraft)
+	if err != nil {
+		return err
+	}
+	if entry.Status == entity.StatusPublished {
+		return fmt.Errorf("%w: entry is already published", core.ErrConflict)
+	}
+	// Only entries that satisfy the current schema go public.
+	if entry.Data, err = s.validateEntryData(ctx, contentType, entry.Data); err != nil {
+		return err
+	}
+
+	entry.Status = entity.StatusPublished
+	entry.UpdatedAt = time.Now()
+	if err := s.entries.Update(ctx, entry); err != nil {
+		return normalizeServiceErrorWithOpMsg("content.entry.publish", "persist entry publish status failed", err)
+	}
+	// Validation may have dropped fields the schema no longer has.
+	s.syncEntryMedia(ctx, contentType, entry)
+	return nil
+}
+
+// MoveEntryToDraft takes a published entry offline.
+func (s *contentService) MoveEntryToDraft(ctx context.Context, typeSlug string, id uint, actorUserID uint, actorRole string) error {
+	if err := s.authorize(ctx, actorRole, core.PostPermissionUnpublishPost); err != nil {
+		return err
+	}
+	_, entry, err := s.loadEntry(ctx, typeSlug, id, actorUserID, actorRole, core.PostPermissionReadAnyPost, core.PostPermissionReadOwnDraft)
+	if err != nil {
+		return err
+	}
+	if entry.Status == entity.StatusDraft {
+		return fmt.Errorf("%w: entry is already draft", core.ErrConflict)
+	}
+
+	entry.Status = entity.StatusDraft
+	entry.UpdatedAt = time.Now()
+	if err := s.entries.Update(ctx, entry); err != nil {
+		return normalizeServiceErrorWithOpMsg("content.entry.move_draft", "persist entry draft status failed", err)
+	}
+	return nil
+}
+
+// DeleteAdminEntry permanently removes an entry that no other entry references.
+func (s *contentService) DeleteAdminEntry(ctx context.Context, typeSlug string, id uint, actorUserID uint, actorRole string) error {
+	if err := s.authorize(ctx, actorRole, core.PostPermissionDeletePost); err != nil {
+		return err
+	}
+	contentType, entry, err := s.loadEntry(ctx, typeSlug, id, actorUserID, actorRole, core.PostPermissionReadAnyPost, core.PostPermissionReadOwnDraft)
+	if err != nil {
+		return err
+	}
+	if err := s.checkEntryUnreferenced(ctx, contentType, entry); err != nil {
+		return err
+	}
+	if err := s.entries.Delete(ctx, contentType.ID, id); err != nil {
+		return normalizeServiceErrorWithOpMsg("content.entry.delete", "delete content entry failed", err)
+	}
+	return nil
+}
+
+// checkEntryUnreferenced rejects deleting an entry that reference fields of other entries
+// point at, which would leave them with a dangling ID. An entry referencing itself does not count.
+func (s *contentService) checkEntryUnreferenced(ctx context.Context, contentType entity.ContentType, entry entity.ContentEntry) error {
+	all, err := s.ListTypes(ctx)
+	if err != nil {
+		return err
+	}
+	inUse := &ContentEntryInUseError{}
+	for _, other := range all {
+		var count int64
+		for _, f := range other.Fields {
+			if f.Type != entity.FieldTypeReference || f.Target != contentType.Slug {
+				continue
+			}
+			n, err := s.entries.CountReferencing(ctx, other.ID, f.Key, entry.ID)
+			if err != nil {
+				return normalizeServiceErrorWithOpMsg("content.entry.delete.count_refs", "count entry references failed", err)
+			}
+			if other.ID == contentType.ID && contentType.References(entry.Data)[f.Key] == entry.ID {
+				n--
+			}
+			count += n
+		}
+		if count > 0 {
+			inUse.Entries += count
+			inUse.ReferencedBy = append(inUse.ReferencedBy, other.Slug)
+		}
+	}
+	if inUse.Entries > 0 {
+		return inUse
+	}
+	return nil
+}
+
+// syncEntryMedia records the assets held by the entry's media fields, so they cannot be
+// deleted while in use. Like page references, a failure is logged and the save stands.
+func (s *contentService) syncEntryMedia(ctx context.Context, contentType entity.ContentType, entry entity.ContentEntry) {
+	if s.media == nil {
+		return
+	}
+	seen := map[uint]struct{}{}
+	assetIDs := []uint{}
+	for _, id := range contentType.MediaIDs(entry.Data) {
+		if _, dup := seen[id]; !dup {
+			seen[id] = struct{}{}
+			assetIDs = append(assetIDs, id)
+		}
+	}
+	syncCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.media.UpsertEntryReferences(syncCtx, entry.ID, assetIDs); err != nil {
+		log.Printf("[WARN] Content entry saved (ID: %d) but failed to sync media references: %v", entry.ID, err)
+	}
+}
+
+// loadEntry resolves an entry within its type. With the any-scope permission every entry is
+// reachable; otherwise the own-scope permission grants the actor's own drafts only, as for posts.
+func (s *contentService) loadEntry(ctx context.Context, typeSlug string, id uint, actorUserID uint, actorRole string, anyScope, ownScope core.PostPermission) (entity.ContentType, entity.ContentEntry, error) {
+	contentType, err := s.GetType(ctx, typeSlug)
+	if err != nil {
+		return entity.ContentType{}, entity.ContentEntry{}, err
+	}
+	canAny, err := s.hasPermission(ctx, actorRole, anyScope)
+	if err != nil {
+		return entity.ContentType{}, entity.ContentEntry{}, err
+	}
+	if !canAny {
+		if actorUserID == 0 {
+			return entity.ContentType{}, entity.ContentEntry{}, core.ErrPermission
+		}
+		if err := s.authorize(ctx, actorRole, ownScope); err != nil {
+			return entity.ContentType{}, entity.ContentEntry{}, err
+		}
+	}
+
+	entry, err := s.entries.GetByID(ctx, contentType.ID, id)
+	if err != nil {
+		return entity.ContentType{}, entity.ContentEntry{}, normalizeServiceErrorWithOpMsg("content.entry.load", "load content entry failed", err)
+	}
+	if !canAny && (entry.AuthorID != actorUserID || entry.Status != entity.StatusDraft) {
+		return entity.ContentType{}, entity.ContentEntry{}, core.ErrNotFound
+	}
+	return contentType, entry, nil
+}
+
+// validateEntryData checks data against the schema, then makes sure media and reference
+// fields point at things that exist.
+func (s *contentService) validateEntryData(ctx context.Context, contentType entity.ContentType, data map[string]any) (map[string]any, error) {
+	normalized, err := contentType.ValidateData(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", core.ErrInvalidInput, err)
+	}
+
+	targets := make(map[string]string, len(contentType.Fields))
+	for _, f := range contentType.Fields {
+		targets[f.Key] = f.Target
+	}
+	for key, id := range contentType.References(normalized) {
+		target, err := s.types.GetBySlug(ctx, targets[key])
+		if err == nil {
+			_, err = s.entries.GetByID(ctx, target.ID, id)
+		}
+		if errors.Is(err, core.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %w", core.ErrInvalidInput, &entity.ContentFieldError{Field: key, Reason: "referenced entry does not exist"})
+		}
+		if err != nil {
+			return nil, normalizeServiceErrorWithOpMsg("content.entry.check_reference", "load referenced entry failed", err)
+		}
+	}
+	if s.media != nil {
+		for key, id := range contentType.MediaIDs(normalized) {
+			_, err := s.media.GetByID(ctx, id)
+			if errors.Is(err, core.ErrNotFound) {
+				return nil, fmt.Errorf("%w: %w", core.ErrInvalidInput, &entity.ContentFieldError{Field: key, Reason: "media asset does not exist"})
+			}
+			if err != nil {
+				return nil, normalizeServiceErrorWithOpMsg("content.entry.check_media", "load referenced media failed", err)
+			}
+		}
+	}
+	return normalized, nil
+}
+
+func (s *contentService) uniqueEntrySlug(ctx context.Context, typeID uint, base string) (string, error) {
+	if base == "" {
+		return "", fmt.Errorf("%w: cannot generate a valid slug", core.ErrInvalidInput)
+	}
+	candidate := base
+	for i := 1; i <= 100; i++ {
+		exists, err := s.entries.IsSlugExists(ctx, typeID, candidate)
+		if err != nil {
+			return "", normalizeServiceErrorWithOpMsg("content.entry.unique_slug", "check entry slug uniqueness failed", err)
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+	return "", fmt.Errorf("%w: unable to generate unique entry slug", core.ErrConflict)
+}
+
+func (s *contentService) authorize(ctx context.Context, actorRole string, permission core.PostPermission) error {
+	if s.authorizer == nil {
+		return core.ErrPermission
+	}
+	if err := s.authorizer.AuthorizePostAction(ctx, actorRole, permission); err != nil {
+		return normalizeServiceErrorWithOpMsg("content.authorize", "authorize content action failed", err)
+	}
+	return nil
+}
+
+func (s *contentService) hasPermission(ctx context.Context, actorRole string, permission core.PostPermission) (bool, error) {
+	if err := s.authorize(ctx, actorRole, permission); err != nil {
+		if errors.Is(err, core.ErrPermission) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
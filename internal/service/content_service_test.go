@@ -0,0 +1,385 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// memContentRepo is an in-memory ContentTypeRepository and ContentEntryRepository.
+type memContentRepo struct {
+	types   []entity.ContentType
+	entries []entity.ContentEntry
+}
+
+func (r *memContentRepo) Create(ctx context.Context, t entity.ContentType) (entity.ContentType, error) {
+	for _, existing := range r.types {
+		if existing.Slug == t.Slug || existing.Name == t.Name {
+			return entity.ContentType{}, core.ErrDuplicate
+		}
+	}
+	t.ID = uint(len(r.types) + 1)
+	r.types = append(r.types, t)
+	return t, nil
+}
+
+func (r *memContentRepo) GetBySlug(ctx context.Context, slug string) (entity.ContentType, error) {
+	for _, t := range r.types {
+		if t.Slug == slug {
+			return t, nil
+		}
+	}
+	return entity.ContentType{}, core.ErrNotFound
+}
+
+func (r *memContentRepo) List(ctx context.Context) ([]entity.ContentType, error) {
+	return append([]entity.ContentType(nil), r.types...), nil
+}
+
+func (r *memContentRepo) Update(ctx context.Context, t entity.ContentType) (entity.ContentType, error) {
+	for i := range r.types {
+		if r.types[i].ID == t.ID {
+			r.types[i] = t
+			return t, nil
+		}
+	}
+	return entity.ContentType{}, core.ErrNotFound
+}
+
+func (r *memContentRepo) Delete(ctx context.Context, id uint) error {
+	for i := range r.types {
+		if r.types[i].ID == id {
+			r.types = append(r.types[:i], r.types[i+1:]...)
+			return nil
+		}
+	}
+	return core.ErrNotFound
+}
+
+// memEntryRepo views the entries of a memContentRepo as a ContentEntryRepository.
+type memEntryRepo struct{ *memContentRepo }
+
+func (r memEntryRepo) Create(ctx context.Context, e entity.ContentEntry) (entity.ContentEntry, error) {
+	e.ID = uint(len(r.entries) + 1)
+	r.entries = append(r.entries, e)
+	return e, nil
+}
+
+func (r memEntryRepo) GetByID(ctx context.Context, typeID uint, id uint) (entity.ContentEntry, error) {
+	for _, e := range r.entries {
+		if e.TypeID == typeID && e.ID == id {
+			return e, nil
+		}
+	}
+	return entity.ContentEntry{}, core.ErrNotFound
+}
+
+func (r memEntryRepo) GetBySlug(ctx context.Context, typeID uint, slug string) (entity.ContentEntry, error) {
+	for _, e := range r.entries {
+		if e.TypeID == typeID && e.Slug == slug {
+			return e, nil
+		}
+	}
+	return entity.ContentEntry{}, core.ErrNotFound
+}
+
+func (r memEntryRepo) List(ctx context.Context, q entity.ContentEntryQuery) ([]entity.ContentEntry, int64, error) {
+	var out []entity.ContentEntry
+	for _, e := range r.entries {
+		if e.TypeID != q.TypeID ||
+			(q.AuthorID != nil && e.AuthorID != *q.AuthorID) ||
+			(q.Status != nil && e.Status != *q.Status) {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, int64(len(out)), nil
+}
+
+func (r memEntryRepo) Update(ctx context.Context, e entity.ContentEntry) error {
+	for i := range r.entries {
+		if r.entries[i].ID == e.ID && r.entries[i].TypeID == e.TypeID {
+			r.entries[i] = e
+			return nil
+		}
+	}
+	return core.ErrNotFound
+}
+
+func (r memEntryRepo) Delete(ctx context.Context, typeID uint, id uint) error {
+	for i := range r.entries {
+		if r.entries[i].ID == id && r.entries[i].TypeID == typeID {
+			r.entries = append(r.entries[:i], r.entries[i+1:]...)
+			return nil
+		}
+	}
+	return core.ErrNotFound
+}
+
+func (r memEntryRepo) IsSlugExists(ctx context.Context, typeID uint, slug string) (bool, error) {
+	_, err := r.GetBySlug(ctx, typeID, slug)
+	return err == nil, nil
+}
+
+func (r memEntryRepo) CountByType(ctx context.Context, typeID uint) (int64, error) {
+	var n int64
+	for _, e := range r.entries {
+		if e.TypeID == typeID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r memEntryRepo) CountReferencing(ctx context.Context, typeID uint, field string, targetID uint) (int64, error) {
+	var n int64
+	for _, e := range r.entries {
+		if v, ok := e.Data[field]; ok && e.TypeID == typeID && fmt.Sprint(v) == fmt.Sprint(targetID) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// newContentFixture defines brands and products, where products reference a brand.
+func newContentFixture(t *testing.T, authorizer core.PostAuthorizer) (core.ContentService, *memContentRepo) {
+	t.Helper()
+	repo := &memContentRepo{}
+	svc := NewContentService(repo, memEntryRepo{repo}, nil, authorizer)
+	ctx := context.Background()
+	if _, err := svc.CreateType(ctx, entity.ContentType{Name: "Brands", Fields: []entity.ContentField{
+		{Key: "country", Type: entity.FieldTypeText},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.CreateType(ctx, entity.ContentType{Name: "Products", Fields: []entity.ContentField{
+		{Key: "price", Type: entity.FieldTypeNumber, Required: true},
+		{Key: "brand", Type: entity.FieldTypeReference, Target: "brands"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	return svc, repo
+}
+
+func TestContentService_CreateType(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newContentFixture(t, allowAll())
+
+	got, err := svc.GetType(ctx, "products")
+	if err != nil || got.Name != "Products" || len(got.Fields) != 2 {
+		t.Fatalf("unexpected: %+v %v", got, err)
+	}
+
+	_, err = svc.CreateType(ctx, entity.ContentType{Name: "Reviews", Fields: []entity.ContentField{
+		{Key: "product", Type: entity.FieldTypeReference, Target: "missing"},
+	}})
+	var fieldErr *entity.ContentFieldError
+	if !errors.Is(err, core.ErrInvalidInput) || !errors.As(err, &fieldErr) || fieldErr.Field != "product" {
+		t.Fatalf("want invalid target on product, got %v", err)
+	}
+
+	// Self-references are allowed.
+	if _, err := svc.CreateType(ctx, entity.ContentType{Name: "People", Fields: []entity.ContentField{
+		{Key: "manager", Type: entity.FieldTypeReference, Target: "people"},
+	}}); err != nil {
+		t.Fatalf("self reference: %v", err)
+	}
+}
+
+func TestContentService_CreateAdminEntry(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newContentFixture(t, allowAll())
+
+	brand, err := svc.CreateAdminEntry(ctx, "brands", entity.ContentEntry{Title: "Acme"}, 5, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if brand.Slug != "acme" || brand.Status != entity.StatusDraft || brand.AuthorID != 5 {
+		t.Fatalf("unexpected entry: %+v", brand)
+	}
+
+	created, err := svc.CreateAdminEntry(ctx, "products", entity.ContentEntry{
+		Title: "Anvil",
+		Data:  map[string]any{"price": float64(10), "brand": float64(brand.ID)},
+	}, 5, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Data["brand"] != brand.ID {
+		t.Fatalf("reference not stored: %v", created.Data)
+	}
+
+	_, err = svc.CreateAdminEntry(ctx, "products", entity.ContentEntry{
+		Title: "Rocket",
+		Data:  map[string]any{"price": float64(1), "brand": float64(99)},
+	}, 5, "admin")
+	var fieldErr *entity.ContentFieldError
+	if !errors.Is(err, core.ErrInvalidInput) || !errors.As(err, &fieldErr) || fieldErr.Field != "brand" {
+		t.Fatalf("want dangling reference rejected, got %v", err)
+	}
+	if len(repo.entries) != 2 {
+		t.Fatalf("invalid entry was stored: %d entries", len(repo.entries))
+	}
+}
+
+func TestContentService_AuthorScope(t *testing.T) {
+	ctx := context.Background()
+	scope := authorScope()
+	scope.allow[core.PostPermissionCreateOwnDraft] = true
+	scope.allow[core.PostPermissionListOwnDrafts] = true
+	svc, repo := newContentFixture(t, scope)
+
+	repo.entries = []entity.ContentEntry{
+		{ID: 1, TypeID: 1, Title: "mine", Slug: "mine", AuthorID: 5, Status: entity.StatusDraft},
+		{ID: 2, TypeID: 1, Title: "other", Slug: "other", AuthorID: 6, Status: entity.StatusDraft},
+		{ID: 3, TypeID: 1, Title: "live", Slug: "live", AuthorID: 5, Status: entity.StatusPublished},
+	}
+
+	entries, total, err := svc.ListAdminEntries(ctx, "brands", entity.ContentEntryQuery{}, 5, "user")
+	if err != nil || total != 1 || entries[0].ID != 1 {
+		t.Fatalf("want only own draft, got %+v %d %v", entries, total, err)
+	}
+	if _, err := svc.GetAdminEntry(ctx, "brands", 2, 5, "user"); !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("other author's draft: want ErrNotFound, got %v", err)
+	}
+	title := "renamed"
+	if _, err := svc.UpdateAdminEntry(ctx, "brands", 3, entity.ContentEntryPatch{Title: &title}, 5, "user"); !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("own published entry: want ErrNotFound, got %v", err)
+	}
+	if err := svc.PublishAdminEntry(ctx, "brands", 1, 5, "user"); !errors.Is(err, core.ErrPermission) {
+		t.Fatalf("publish: want ErrPermission, got %v", err)
+	}
+}
+
+func TestContentService_PublishAdminEntry(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newContentFixture(t, allowAll())
+
+	// Written before "price" became required.
+	repo.entries = []entity.ContentEntry{{ID: 1, TypeID: 2, Title: "old", Slug: "old", AuthorID: 5, Status: entity.StatusDraft}}
+	if err := svc.PublishAdminEntry(ctx, "products", 1, 1, "admin"); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("want schema violation, got %v", err)
+	}
+
+	repo.entries[0].Data = map[string]any{"price": float64(3)}
+	if err := svc.PublishAdminEntry(ctx, "products", 1, 1, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := svc.GetPublicEntryBySlug(ctx, "products", "old")
+	if err != nil || got.Status != entity.StatusPublished {
+		t.Fatalf("unexpected: %+v %v", got, err)
+	}
+	if err := svc.PublishAdminEntry(ctx, "products", 1, 1, "admin"); !errors.Is(err, core.ErrConflict) {
+		t.Fatalf("republish: want ErrConflict, got %v", err)
+	}
+}
+
+func TestContentService_DeleteType(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newContentFixture(t, allowAll())
+
+	err := svc.DeleteType(ctx, "brands")
+	var inUse *ContentTypeInUseError
+	if !errors.As(err, &inUse) || len(inUse.ReferencedBy) != 1 || inUse.ReferencedBy[0] != "products" {
+		t.Fatalf("want referenced-by conflict, got %v", err)
+	}
+	if !errors.Is(err, core.ErrConflict) {
+		t.Fatalf("want ErrConflict, got %v", err)
+	}
+
+	repo.entries = []entity.ContentEntry{{ID: 1, TypeID: 2, Title: "x", Slug: "x"}}
+	if err := svc.DeleteType(ctx, "products"); !errors.As(err, &inUse) || inUse.Entries != 1 {
+		t.Fatalf("want entries conflict, got %v", err)
+	}
+
+	repo.entries = nil
+	if err := svc.DeleteType(ctx, "products"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.DeleteType(ctx, "brands"); err != nil {
+		t.Fatalf("brands should be free once products is gone: %v", err)
+	}
+}
+
+func TestContentService_DeleteAdminEntry_Referenced(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newContentFixture(t, allowAll())
+
+	brand, err := svc.CreateAdminEntry(ctx, "brands", entity.ContentEntry{Title: "Acme"}, 5, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	product, err := svc.CreateAdminEntry(ctx, "products", entity.ContentEntry{
+		Title: "Anvil",
+		Data:  map[string]any{"price": float64(10), "brand": float64(brand.ID)},
+	}, 5, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = svc.DeleteAdminEntry(ctx, "brands", brand.ID, 1, "admin")
+	var inUse *ContentEntryInUseError
+	if !errors.As(err, &inUse) || inUse.Entries != 1 || len(inUse.ReferencedBy) != 1 || inUse.ReferencedBy[0] != "products" {
+		t.Fatalf("want referenced-by conflict, got %v", err)
+	}
+	if !errors.Is(err, core.ErrConflict) || len(repo.entries) != 2 {
+		t.Fatalf("want ErrConflict and nothing deleted, got %v (%d entries)", err, len(repo.entries))
+	}
+
+	if err := svc.DeleteAdminEntry(ctx, "products", product.ID, 1, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.DeleteAdminEntry(ctx, "brands", brand.ID, 1, "admin"); err != nil {
+		t.Fatalf("brand should be free once the product is gone: %v", err)
+	}
+}
+
+// entryRefsMediaRepo knows every asset and records the media references of entries.
+type entryRefsMediaRepo struct {
+	fakeMediaRepoNoOp
+	refs map[uint][]uint
+}
+
+func (r *entryRefsMediaRepo) GetByID(ctx context.Context, id uint) (entity.MediaAsset, error) {
+	return entity.MediaAsset{ID: id}, nil
+}
+
+func (r *entryRefsMediaRepo) UpsertEntryReferences(ctx context.Context, entryID uint, assetIDs []uint) error {
+	r.refs[entryID] = assetIDs
+	return nil
+}
+
+func TestContentService_EntryMediaReferences(t *testing.T) {
+	ctx := context.Background()
+	repo := &memContentRepo{}
+	media := &entryRefsMediaRepo{refs: map[uint][]uint{}}
+	svc := NewContentService(repo, memEntryRepo{repo}, media, allowAll())
+	if _, err := svc.CreateType(ctx, entity.ContentType{Name: "Events", Fields: []entity.ContentField{
+		{Key: "poster", Type: entity.FieldTypeMedia},
+		{Key: "banner", Type: entity.FieldTypeMedia},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := svc.CreateAdminEntry(ctx, "events", entity.ContentEntry{
+		Title: "Launch",
+		Data:  map[string]any{"poster": float64(7), "banner": float64(7)},
+	}, 5, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := media.refs[entry.ID]; len(got) != 1 || got[0] != 7 {
+		t.Fatalf("create: refs = %v", got)
+	}
+
+	if _, err := svc.UpdateAdminEntry(ctx, "events", entry.ID, entity.ContentEntryPatch{Data: map[string]any{"banner": float64(8)}}, 5, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if got := media.refs[entry.ID]; len(got) != 1 || got[0] != 8 {
+		t.Fatalf("update: refs = %v", got)
+	}
+}
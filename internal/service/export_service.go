@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// exportPageSize is how many posts are loaded at a time, so memory stays flat however large
+// the site is.
+const exportPageSize = 100
+
+// exportService implements core.ExportService.
+type exportService struct {
+	users      core.UserRepository
+	categories core.CategoryRepository
+	tags       core.TagRepository
+	series     core.SeriesRepository
+	posts      core.PostRepository
+	media      *MediaService
+	settings   func(ctx context.Context) (entity.ExportSettings, error)
+}
+
+// NewExportService creates an ExportService. settings loads the site settings, e.g.
+// SystemService.ExportSettings. A nil series repository exports an empty series list.
+func NewExportService(users core.UserRepository, categories core.CategoryRepository, tags core.TagRepository, series core.SeriesRepository, posts core.PostRepository, media *MediaService, settings func(ctx context.Context) (entity.ExportSettings, error)) core.ExportService {
+	return &exportService{users: users, categories: categories, tags: tags, series: series, posts: posts, media: media, settings: settings}
+}
+
+// Export writes settings, users, categories, tags and series, then pages through the posts (trashed
+// ones are left out) and copies the media tree file by file.
+func (s *exportService) Export(ctx context.Context, w core.ExportWriter) (entity.ExportSummary, error) {
+	summary := entity.ExportSummary{CreatedAt: time.Now()}
+
+	settings, err := s.settings(ctx)
+	if err != nil {
+		return entity.ExportSummary{}, normalizeServiceErrorWithOpMsg("export.settings", "load settings failed", err)
+	}
+	summary.SiteName = settings.SiteName
+	if err := w.WriteSettings(settings); err != nil {
+		return entity.ExportSummary{}, normalizeServiceErrorWithOpMsg("export.write_settings", "write settings failed", err)
+	}
+
+	users, err := s.users.GetAll(ctx)
+	if err != nil {
+		return entity.ExportSummary{}, normalizeServiceErrorWithOpMsg("export.users", "list users failed", err)
+	}
+	for i := range users {
+		users[i].Password = ""
+	}
+	if err := w.WriteUsers(users); err != nil {
+		return entity.ExportSummary{}, normalizeServiceErrorWithOpMsg("export.write_users", "write users failed", err)
+	}
+	summary.Users = len(users)
+
+	counted, err := s.categories.ListWithPostCounts(ctx)
+	if err != nil {
+		return entity.ExportSummary{}, normalizeServiceErrorWithOpMsg("export.categories", "list categories failed", err)
+	}
+	categories := make([]entity.Category, len(counted))
+	for i, c := range counted {
+		categories[i] = c.Category
+	}
+	if err := w.WriteCategories(categories); err != nil {
+		return entity.ExportSummary{}, normalizeServiceErrorWithOpMsg("export.write_categories", "write categories failed", err)
+	}
+	summary.Categories = len(categories)
+
+	tags, err := s.tags.GetAll(ctx)
+	if err != nil {
+		return entity.ExportSummary{}, normalizeServiceErrorWithOpMsg("export.tags", "list tags failed", err)
+	}
+	if err := w.WriteTags(tags); err != nil {
+		return entity.ExportSummary{}, normalizeServiceErrorWithOpMsg("export.write_tags", "write tags failed", err)
+	}
+	summary.Tags = len(tags)
+
+	var series []entity.Series
+	if s.series != nil {
+		counted, err := s.series.ListWithPartCounts(ctx)
+		if err != nil {
+			return entity.ExportSummary{}, normalizeServiceErrorWithOpMsg("export.series", "list series failed", err)
+		}
+		for _, c := range counted {
+			series = append(series, c.Series)
+		}
+	}
+	if err := w.WriteSeries(series); err != nil {
+		return entity.ExportSummary{}, normalizeServiceErrorWithOpMsg("export.write_series", "write series failed", err)
+	}
+	summary.Series = len(series)
+
+	var afterID uint
+	for {
+		if err := ctx.Err(); err != nil {
+			return entity.ExportSummary{}, err
+		}
+		page, err := s.posts.GetAllAfter(ctx, afterID, exportPageSize)
+		if err != nil {
+			return entity.ExportSummary{}, normalizeServiceErrorWithOpMsg("export.posts", "list posts failed", err)
+		}
+		for _, post := range page {
+			if err := w.WritePost(post); err != nil {
+				return entity.ExportSummary{}, normalizeServiceErrorWithOpMsg("export.write_post", "write post failed", err)
+			}
+			afterID = post.ID
+			summary.Posts++
+		}
+		if len(page) < exportPageSize {
+			break
+		}
+	}
+
+	if s.media != nil {
+		err := s.media.WalkFiles(ctx, func(objectKey string, info fs.FileInfo, r io.Reader) error {
+			if err := w.WriteMedia(objectKey, info.ModTime(), r); err != nil {
+				return err
+			}
+			summary.MediaFiles++
+			summary.MediaBytes += info.Size()
+			return nil
+		})
+		if err != nil {
+			return entity.ExportSummary{}, err
+		}
+	}
+
+	if err := w.Close(summary); err != nil {
+		return entity.ExportSummary{}, normalizeServiceErrorWithOpMsg("export.close", "finish archive failed", err)
+	}
+	return summary, nil
+}
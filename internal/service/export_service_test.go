@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// recordingExportWriter records what an export writes, in order.
+type recordingExportWriter struct {
+	calls      []string
+	users      []entity.User
+	posts      []uint
+	media      map[string]string
+	summary    *entity.ExportSummary
+	writePostF func(post entity.Post) error
+}
+
+func (w *recordingExportWriter) WriteSettings(settings entity.ExportSettings) error {
+	w.calls = append(w.calls, "settings:"+settings.SiteName)
+	return nil
+}
+func (w *recordingExportWriter) WriteUsers(users []entity.User) error {
+	w.calls = append(w.calls, "users")
+	w.users = users
+	return nil
+}
+func (w *recordingExportWriter) WriteCategories(categories []entity.Category) error {
+	w.calls = append(w.calls, fmt.Sprintf("categories:%d", len(categories)))
+	return nil
+}
+func (w *recordingExportWriter) WriteTags(tags []entity.Tag) error {
+	w.calls = append(w.calls, fmt.Sprintf("tags:%d", len(tags)))
+	return nil
+}
+func (w *recordingExportWriter) WriteSeries(series []entity.Series) error {
+	w.calls = append(w.calls, fmt.Sprintf("series:%d", len(series)))
+	return nil
+}
+func (w *recordingExportWriter) WritePost(post entity.Post) error {
+	if w.writePostF != nil {
+		if err := w.writePostF(post); err != nil {
+			return err
+		}
+	}
+	w.posts = append(w.posts, post.ID)
+	return nil
+}
+func (w *recordingExportWriter) WriteMedia(objectKey string, modified time.Time, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if w.media == nil {
+		w.media = make(map[string]string)
+	}
+	w.media[objectKey] = string(data)
+	return nil
+}
+func (w *recordingExportWriter) Close(summary entity.ExportSummary) error {
+	w.calls = append(w.calls, "close")
+	w.summary = &summary
+	return nil
+}
+
+func newTestExportService(t *testing.T, postCount int) (core.ExportService, *int) {
+	t.Helper()
+	uploadDir := t.TempDir()
+	for name, body := range map[string]string{"a/1/cat.png": "cat", "a/2/dog.jpg": "dog!"} {
+		p := filepath.Join(uploadDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pages := 0
+	users := &fakeUserRepo{getAllFn: func(ctx context.Context) ([]entity.User, error) {
+		return []entity.User{{ID: 1, Username: "admin", Password: "$2a$10$hash", Role: "super_admin"}}, nil
+	}}
+	categories := &fakeCategoryRepo{listFn: func(ctx context.Context) ([]entity.CategoryWithPostCount, error) {
+		return []entity.CategoryWithPostCount{{Category: entity.Category{ID: 1, Name: "News", Slug: "news"}, PostCount: 3}}, nil
+	}}
+	tags := &fakeTagRepo{getAllFn: func(ctx context.Context) ([]entity.Tag, error) {
+		return []entity.Tag{{ID: 1, Name: "Go", Slug: "go"}, {ID: 2, Name: "Web", Slug: "web"}}, nil
+	}}
+	series := &memSeriesRepo{series: []entity.Series{{ID: 1, Title: "Go Basics", Slug: "go-basics"}}}
+	posts := &fakePostRepo{getAllAfterFn: func(ctx context.Context, afterID uint, limit int) ([]entity.Post, error) {
+		pages++
+		var page []entity.Post
+		for id := afterID + 1; id <= uint(postCount) && len(page) < limit; id++ {
+			page = append(page, entity.Post{ID: id, Slug: fmt.Sprintf("post-%d", id)})
+		}
+		return page, nil
+	}}
+	media := NewMediaService(&fakeMediaRepoNoOp{}, MediaConfig{UploadDir: uploadDir})
+	settings := func(ctx context.Context) (entity.ExportSettings, error) {
+		return entity.ExportSettings{SiteName: "My blog"}, nil
+	}
+	return NewExportService(users, categories, tags, series, posts, media, settings), &pages
+}
+
+func TestExportService_Export(t *testing.T) {
+	svc, pages := newTestExportService(t, exportPageSize+5)
+	w := &recordingExportWriter{}
+
+	summary, err := svc.Export(context.Background(), w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *pages != 2 || len(w.posts) != exportPageSize+5 || w.posts[len(w.posts)-1] != uint(exportPageSize+5) {
+		t.Fatalf("posts not paged through: %d pages, %d posts", *pages, len(w.posts))
+	}
+	if len(w.users) != 1 || w.users[0].Password != "" {
+		t.Fatalf("password hash exported: %+v", w.users)
+	}
+	if len(w.media) != 2 || w.media["a/1/cat.png"] != "cat" || w.media["a/2/dog.jpg"] != "dog!" {
+		t.Fatalf("media = %v", w.media)
+	}
+
+	want := entity.ExportSummary{SiteName: "My blog", Users: 1, Categories: 1, Tags: 2, Series: 1, Posts: exportPageSize + 5, MediaFiles: 2, MediaBytes: 7}
+	want.CreatedAt = summary.CreatedAt
+	if summary != want || w.summary == nil || *w.summary != want {
+		t.Fatalf("summary = %+v, closed with %+v", summary, w.summary)
+	}
+	if got := fmt.Sprint(w.calls); got != "[settings:My blog users categories:1 tags:2 series:1 close]" {
+		t.Fatalf("calls = %s", got)
+	}
+}
+
+func TestExportService_Export_StopsOnWriteError(t *testing.T) {
+	svc, _ := newTestExportService(t, 3)
+	w := &recordingExportWriter{writePostF: func(post entity.Post) error {
+		if post.ID == 2 {
+			return errors.New("broken pipe")
+		}
+		return nil
+	}}
+
+	if _, err := svc.Export(context.Background(), w); err == nil {
+		t.Fatal("expected an error")
+	}
+	if w.summary != nil || len(w.posts) != 1 {
+		t.Fatalf("export went on after the failure: posts %v, closed %v", w.posts, w.summary != nil)
+	}
+}
+
+func TestExportService_Export_MissingMediaTree(t *testing.T) {
+	svc, _ := newTestExportService(t, 0)
+	svc.(*exportService).media = NewMediaService(&fakeMediaRepoNoOp{}, MediaConfig{UploadDir: filepath.Join(t.TempDir(), "none")})
+	w := &recordingExportWriter{}
+
+	summary, err := svc.Export(context.Background(), w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.MediaFiles != 0 || summary.Posts != 0 || w.summary == nil {
+		t.Fatalf("summary = %+v", summary)
+	}
+}
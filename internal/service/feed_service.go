@@ -0,0 +1,180 @@
+package service
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	defaultFeedLimit = 20
+	// feedSummaryRunes bounds the plain-text excerpt carried next to the full content.
+	feedSummaryRunes = 280
+)
+
+// FeedConfig controls how feeds describe the site and build absolute URLs.
+type FeedConfig struct {
+	Title       string
+	Description string
+	// SiteURL is the public site origin; post links are SiteURL + "/posts/" + slug, with a
+	// "/<locale>" prefix for posts outside entity.DefaultLocale. It must be configured: request
+	// headers are never used to build links, since feeds are cached and shared.
+	SiteURL string
+	// MediaBaseURL is MediaConfig.PublicBaseURL, used to absolutize relative cover paths.
+	// When empty, the site URL is used.
+	MediaBaseURL string
+	// Limit is the number of newest posts per feed.
+	Limit int
+}
+
+// FeedScope narrows a feed to one tag or one category, addressed by slug.
+// The zero value is the site-wide feed.
+type FeedScope struct {
+	TagSlug      string
+	CategorySlug string
+	// Locales is a fallback chain; when set, each translated post appears once, in the
+	// first locale of the chain it is available in.
+	Locales []string
+}
+
+// FeedService builds syndication feeds from the public post listing.
+type FeedService struct {
+	posts      *PostService
+	tags       core.TagService
+	categories core.CategoryService
+	cfg        FeedConfig
+}
+
+func NewFeedService(posts *PostService, tags core.TagService, categories core.CategoryService, cfg FeedConfig) *FeedService {
+	if cfg.Limit <= 0 {
+		cfg.Limit = defaultFeedLimit
+	}
+	cfg.SiteURL = strings.TrimRight(cfg.SiteURL, "/")
+	return &FeedService{posts: posts, tags: tags, categories: categories, cfg: cfg}
+}
+
+// SiteURL returns the configured site origin without a trailing slash.
+func (s *FeedService) SiteURL() string {
+	return s.cfg.SiteURL
+}
+
+// Build returns the newest published posts in scope.
+func (s *FeedService) Build(ctx context.Context, scope FeedScope) (entity.Feed, error) {
+	siteURL := s.cfg.SiteURL
+	mediaURL := strings.TrimRight(s.cfg.MediaBaseURL, "/")
+	if mediaURL == "" {
+		mediaURL = siteURL
+	}
+
+	feed := entity.Feed{Title: s.cfg.Title, Description: s.cfg.Description, Link: siteURL + "/"}
+	query := entity.PostListQuery{
+		Page:     1,
+		PageSize: s.cfg.Limit,
+		SortBy:   entity.PostSortCreatedAt,
+		Order:    entity.SortOrderDesc,
+		Locales:  scope.Locales,
+	}
+	if len(scope.Locales) > 0 {
+		feed.Language = scope.Locales[0]
+	}
+
+	switch {
+	case scope.TagSlug != "":
+		tag, err := s.tags.GetBySlug(ctx, scope.TagSlug)
+		if err != nil {
+			return entity.Feed{}, err
+		}
+		query.TagID = &tag.ID
+		feed.Title = scopedFeedTitle(s.cfg.Title, tag.Name)
+		feed.Link = siteURL + "/tags/" + url.PathEscape(tag.Slug)
+	case scope.CategorySlug != "":
+		category, err := s.categories.GetBySlug(ctx, scope.CategorySlug)
+		if err != nil {
+			return entity.Feed{}, err
+		}
+		query.CategoryID = &category.ID
+		feed.Title = scopedFeedTitle(s.cfg.Title, category.Name)
+		feed.Link = siteURL + "/categories/" + url.PathEscape(category.Slug)
+	}
+
+	posts, _, err := s.posts.ListPublicPosts(ctx, query)
+	if err != nil {
+		return entity.Feed{}, err
+	}
+
+	feed.Items = make([]entity.FeedItem, 0, len(posts))
+	for i := range posts {
+		post := &posts[i]
+		s.posts.attachRendered(post)
+		if post.UpdatedAt.After(feed.Updated) {
+			feed.Updated = post.UpdatedAt
+		}
+		feed.Items = append(feed.Items, feedItem(post, siteURL, mediaURL))
+	}
+	return feed, nil
+}
+
+func scopedFeedTitle(site string, scope string) string {
+	if site == "" {
+		return scope
+	}
+	return fmt.Sprintf("%s - %s", site, scope)
+}
+
+func feedItem(post *entity.Post, siteURL string, mediaURL string) entity.FeedItem {
+	item := entity.FeedItem{
+		ID:         post.ID,
+		Title:      post.Title,
+		URL:        localizedPostURL(siteURL, post.Locale, post.Slug),
+		AuthorName: post.Author.Username,
+		Published:  post.CreatedAt,
+		Updated:    post.UpdatedAt,
+		Language:   post.Locale,
+	}
+	for _, t := range post.Translations {
+		item.Alternates = append(item.Alternates, entity.LocaleAlternate{
+			Locale: t.Locale,
+			URL:    localizedPostURL(siteURL, t.Locale, t.Slug),
+		})
+	}
+
+	if post.Rendered != nil {
+		item.ContentHTML = post.Rendered.HTML
+		item.Summary = truncateRunes(post.Rendered.Text, feedSummaryRunes)
+	} else {
+		item.Summary = truncateRunes(strings.Join(strings.Fields(post.Content), " "), feedSummaryRunes)
+	}
+
+	if post.Cover != "" {
+		item.ImageURL = absoluteMediaURL(mediaURL, post.Cover)
+	}
+	if post.CategoryID != nil && post.Category.Name != "" {
+		item.Categories = append(item.Categories, post.Category.Name)
+	}
+	for _, t := range post.Tags {
+		item.Categories = append(item.Categories, t.Name)
+	}
+	return item
+}
+
+// absoluteMediaURL keeps absolute cover URLs as they are and resolves paths against base.
+func absoluteMediaURL(base string, ref string) string {
+	if u, err := url.Parse(ref); err == nil && u.IsAbs() {
+		return ref
+	}
+	if !strings.HasPrefix(ref, "/") {
+		ref = "/" + ref
+	}
+	return joinPublicURL(base, ref)
+}
+
+func truncateRunes(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return strings.TrimSpace(string(r[:max])) + "…"
+}
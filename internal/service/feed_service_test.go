@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+func TestFeedService_Build_SiteFeed(t *testing.T) {
+	catID := uint(3)
+	posts := []entity.Post{
+		{ID: 2, Title: "Newer", Slug: "newer", Content: "# Hi\n\nbody", Cover: "/media/a/x.png",
+			CategoryID: &catID, Category: entity.Category{ID: catID, Name: "Go"},
+			Tags:      []entity.Tag{{ID: 1, Name: "gin"}},
+			CreatedAt: time.Unix(200, 0), UpdatedAt: time.Unix(250, 0)},
+		{ID: 1, Title: "Older", Slug: "older", Content: "text", Cover: "https://cdn.example.com/c.jpg",
+			CreatedAt: time.Unix(100, 0), UpdatedAt: time.Unix(300, 0)},
+	}
+	var gotQuery entity.PostListQuery
+	repo := &fakePostRepo{getPublishedFn: func(ctx context.Context, q entity.PostListQuery) ([]entity.Post, int64, error) {
+		gotQuery = q
+		return posts, int64(len(posts)), nil
+	}}
+	postSvc := NewPostService(repo, allowAll())
+	postSvc.SetContentRenderer(&countingRenderer{})
+
+	svc := NewFeedService(postSvc, nil, nil, FeedConfig{Title: "Blog", SiteURL: "http://localhost:8080/", MediaBaseURL: "https://media.example.com/"})
+	feed, err := svc.Build(context.Background(), FeedScope{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotQuery.PageSize != defaultFeedLimit || gotQuery.Order != entity.SortOrderDesc {
+		t.Fatalf("unexpected query: %+v", gotQuery)
+	}
+	if feed.Title != "Blog" || feed.Link != "http://localhost:8080/" {
+		t.Fatalf("unexpected feed header: %+v", feed)
+	}
+	if !feed.Updated.Equal(time.Unix(300, 0)) {
+		t.Fatalf("updated = %v, want newest post update", feed.Updated)
+	}
+	if len(feed.Items) != 2 {
+		t.Fatalf("want 2 items, got %d", len(feed.Items))
+	}
+	first := feed.Items[0]
+	if first.URL != "http://localhost:8080/posts/newer" {
+		t.Fatalf("url = %q", first.URL)
+	}
+	if first.ImageURL != "https://media.example.com/media/a/x.png" {
+		t.Fatalf("relative cover not absolutized: %q", first.ImageURL)
+	}
+	if first.ContentHTML == "" {
+		t.Fatal("expected rendered content")
+	}
+	if len(first.Categories) != 2 || first.Categories[0] != "Go" || first.Categories[1] != "gin" {
+		t.Fatalf("categories = %v", first.Categories)
+	}
+	if feed.Items[1].ImageURL != "https://cdn.example.com/c.jpg" {
+		t.Fatalf("absolute cover changed: %q", feed.Items[1].ImageURL)
+	}
+}
+
+func TestFeedService_Build_TagScope(t *testing.T) {
+	var gotQuery entity.PostListQuery
+	repo := &fakePostRepo{getPublishedFn: func(ctx context.Context, q entity.PostListQuery) ([]entity.Post, int64, error) {
+		gotQuery = q
+		return nil, 0, nil
+	}}
+	tags := NewTagService(&fakeTagRepo{getBySlugFn: func(ctx context.Context, slug string) (entity.Tag, error) {
+		if slug != "go" {
+			return entity.Tag{}, core.ErrNotFound
+		}
+		return entity.Tag{ID: 7, Name: "Go", Slug: "go"}, nil
+	}})
+
+	svc := NewFeedService(NewPostService(repo, allowAll()), tags, nil, FeedConfig{Title: "Blog", SiteURL: "https://blog.example.com/"})
+	feed, err := svc.Build(context.Background(), FeedScope{TagSlug: "go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotQuery.TagID == nil || *gotQuery.TagID != 7 {
+		t.Fatalf("tag filter not applied: %+v", gotQuery)
+	}
+	if feed.Title != "Blog - Go" || feed.Link != "https://blog.example.com/tags/go" {
+		t.Fatalf("unexpected feed header: %+v", feed)
+	}
+	if !feed.Updated.IsZero() || len(feed.Items) != 0 {
+		t.Fatalf("empty feed expected: %+v", feed)
+	}
+
+	_, err = svc.Build(context.Background(), FeedScope{TagSlug: "missing"})
+	if !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("want ErrNotFound for unknown tag, got %v", err)
+	}
+}
+
+func TestFeedService_Build_LocaleScope(t *testing.T) {
+	group := uint(1)
+	var gotQuery entity.PostListQuery
+	repo := &fakePostRepo{
+		getPublishedFn: func(ctx context.Context, q entity.PostListQuery) ([]entity.Post, int64, error) {
+			gotQuery = q
+			return []entity.Post{{ID: 2, Title: "Hello", Slug: "hello", Locale: "en", TranslationGroupID: &group}}, 1, nil
+		},
+		getTranslationsFn: func(ctx context.Context, groupIDs []uint, publishedOnly bool) ([]entity.Post, error) {
+			if !publishedOnly {
+				t.Fatal("feeds must only link published translations")
+			}
+			return []entity.Post{
+				{ID: 1, Slug: "ni-hao", Locale: "zh-CN", Status: entity.StatusPublished, TranslationGroupID: &group},
+				{ID: 2, Slug: "hello", Locale: "en", Status: entity.StatusPublished, TranslationGroupID: &group},
+			}, nil
+		},
+	}
+	svc := NewFeedService(NewPostService(repo, allowAll()), nil, nil, FeedConfig{SiteURL: "https://blog.example.com"})
+
+	feed, err := svc.Build(context.Background(), FeedScope{Locales: []string{"en", "zh-CN"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gotQuery.Locales) != 2 || feed.Language != "en" {
+		t.Fatalf("locale chain not applied: %+v %q", gotQuery.Locales, feed.Language)
+	}
+	item := feed.Items[0]
+	if item.URL != "https://blog.example.com/en/posts/hello" || item.Language != "en" {
+		t.Fatalf("unexpected item: %+v", item)
+	}
+	if len(item.Alternates) != 1 || item.Alternates[0] != (entity.LocaleAlternate{Locale: "zh-CN", URL: "https://blog.example.com/posts/ni-hao"}) {
+		t.Fatalf("unexpected alternates: %+v", item.Alternates)
+	}
+}
@@ -0,0 +1,576 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+
+	"github.com/gosimple/slug"
+)
+
+// importedUserEmailDomain is used for authors the export names without an address. The
+// .invalid TLD is reserved, so these addresses can never receive mail.
+const importedUserEmailDomain = "import.invalid"
+
+// importService implements core.ImportService. It writes through the repositories directly
+// rather than the post workflow: imported posts keep their original dates, slugs and status.
+type importService struct {
+	decoder    core.ImportDecoder
+	users      core.UserRepository
+	categories core.CategoryRepository
+	tags       core.TagRepository
+	series     core.SeriesRepository
+	posts      core.PostRepository
+	media      *MediaService
+}
+
+// NewImportService creates an ImportService. With a nil series repository posts are imported
+// outside any series.
+func NewImportService(decoder core.ImportDecoder, users core.UserRepository, categories core.CategoryRepository, tags core.TagRepository, series core.SeriesRepository, posts core.PostRepository, media *MediaService) core.ImportService {
+	return &importService{decoder: decoder, users: users, categories: categories, tags: tags, series: series, posts: posts, media: media}
+}
+
+// importRef is the outcome of importing one user or term; ok is false when it failed.
+// In a dry run, items that would be created are ok with a zero id.
+type importRef struct {
+	id uint
+	ok bool
+}
+
+// termStore adapts the category and tag repositories to one import routine.
+type termStore struct {
+	kind   entity.ImportItemKind
+	bySlug func(ctx context.Context, slug string) (uint, error)
+	byName func(ctx context.Context, name string) (uint, error)
+	create func(ctx context.Context, term entity.ImportTerm) (uint, error)
+	seen   map[string]importRef
+}
+
+// importRun carries the state of one Import call.
+type importRun struct {
+	*importService
+	req        entity.ImportRequest
+	assets     core.ImportAssets
+	report     entity.ImportReport
+	authors    map[string]importRef
+	categories termStore
+	tags       termStore
+	seriesRefs map[string]importRef
+	// translations collects the created posts of each translation key, in import order.
+	translations map[string][]uint
+}
+
+// Import decodes the export, then imports authors, categories, tags and series before the
+// posts that reference them. Users, categories, tags and series are matched by username and
+// slug (or name); posts by locale and slug. Images are only copied for posts that are
+// created, and only created posts are linked into translation groups.
+func (s *importService) Import(ctx context.Context, req entity.ImportRequest, r io.ReaderAt, size int64) (entity.ImportReport, error) {
+	if err := req.Validate(); err != nil {
+		return entity.ImportReport{}, fmt.Errorf("%w: %v", core.ErrInvalidInput, err)
+	}
+	if req.Locale != "" {
+		locale, err := normalizePostLocale(req.Locale)
+		if err != nil {
+			return entity.ImportReport{}, err
+		}
+		req.Locale = locale
+	}
+
+	bundle, assets, err := s.decoder.Decode(req.Format, r, size)
+	if err != nil {
+		return entity.ImportReport{}, normalizeServiceErrorWithOpMsg("import.decode", "decode export failed", err)
+	}
+
+	run := &importRun{
+		importService: s,
+		req:           req,
+		assets:        assets,
+		report:        entity.ImportReport{Format: req.Format, DryRun: req.DryRun},
+		authors:       make(map[string]importRef),
+		categories: termStore{
+			kind: entity.ImportKindCategory,
+			bySlug: func(ctx context.Context, slug string) (uint, error) {
+				c, err := s.categories.GetBySlug(ctx, slug)
+				return c.ID, err
+			},
+			byName: func(ctx context.Context, name string) (uint, error) {
+				c, err := s.categories.GetByName(ctx, name)
+				return c.ID, err
+			},
+			create: func(ctx context.Context, term entity.ImportTerm) (uint, error) {
+				c, err := s.categories.Create(ctx, entity.Category{Name: term.Name, Slug: term.Slug})
+				return c.ID, err
+			},
+			seen: make(map[string]importRef),
+		},
+		tags: termStore{
+			kind: entity.ImportKindTag,
+			bySlug: func(ctx context.Context, slug string) (uint, error) {
+				t, err := s.tags.GetBySlug(ctx, slug)
+				return t.ID, err
+			},
+			byName: func(ctx context.Context, name string) (uint, error) {
+				t, err := s.tags.GetByName(ctx, name)
+				return t.ID, err
+			},
+			create: func(ctx context.Context, term entity.ImportTerm) (uint, error) {
+				t, err := s.tags.Create(ctx, entity.Tag{Name: term.Name, Slug: term.Slug})
+				return t.ID, err
+			},
+			seen: make(map[string]importRef),
+		},
+		seriesRefs:   make(map[string]importRef),
+		translations: make(map[string][]uint),
+	}
+
+	for _, author := range bundle.Authors {
+		run.author(ctx, author)
+	}
+	for _, term := range bundle.Categories {
+		run.term(ctx, &run.categories, term)
+	}
+	for _, term := range bundle.Tags {
+		run.term(ctx, &run.tags, term)
+	}
+	for _, series := range bundle.Series {
+		run.seriesRef(ctx, series)
+	}
+	for _, post := range bundle.Posts {
+		if err := ctx.Err(); err != nil {
+			return run.report, normalizeServiceErrorWithOpMsg("import.posts", "import interrupted", err)
+		}
+		run.post(ctx, post)
+	}
+	run.linkTranslations(ctx)
+	return run.report, nil
+}
+
+// author maps a source author to a local user, creating the user when no mapping or user of
+// the same name exists. Created users get an unusable random password; an administrator has
+// to set a real one before they can sign in.
+func (run *importRun) author(ctx context.Context, author entity.ImportAuthor) importRef {
+	if ref, done := run.authors[author.Login]; done {
+		return ref
+	}
+	ref := run.importAuthor(ctx, author)
+	run.authors[author.Login] = ref
+	return ref
+}
+
+func (run *importRun) importAuthor(ctx context.Context, author entity.ImportAuthor) importRef {
+	if mapped, ok := run.req.AuthorMap[author.Login]; ok {
+		user, err := run.users.GetByUsername(ctx, mapped)
+		if err != nil {
+			if errors.Is(err, core.ErrNotFound) {
+				err = fmt.Errorf("%w: mapped user %q does not exist", core.ErrInvalidInput, mapped)
+			}
+			run.report.Add(entity.ImportKindUser, author.Login, entity.ImportActionError, 0, normalizeServiceErrorWithOpMsg("import.user.map", "map author failed", err))
+			return importRef{}
+		}
+		run.report.Add(entity.ImportKindUser, author.Login, entity.ImportActionExists, user.ID, nil)
+		return importRef{id: user.ID, ok: true}
+	}
+
+	user, err := run.users.GetByUsername(ctx, author.Login)
+	if err == nil {
+		run.report.Add(entity.ImportKindUser, author.Login, entity.ImportActionExists, user.ID, nil)
+		return importRef{id: user.ID, ok: true}
+	}
+	if !errors.Is(err, core.ErrNotFound) {
+		run.report.Add(entity.ImportKindUser, author.Login, entity.ImportActionError, 0, normalizeServiceErrorWithOpMsg("import.user.lookup", "look up author failed", err))
+		return importRef{}
+	}
+	if run.req.DryRun {
+		run.report.Add(entity.ImportKindUser, author.Login, entity.ImportActionCreate, 0, nil)
+		return importRef{ok: true}
+	}
+
+	user = entity.User{Username: author.Login, Email: author.Email, Role: "user"}
+	if user.Email == "" {
+		// Distinct logins can slugify alike ("Jane Doe", "jane.doe"), and emails are unique, so
+		// a synthesized address always carries a random suffix.
+		local := slug.Make(author.Login)
+		if local == "" {
+			local = "author"
+		}
+		user.Email = local + "-" + randomHex(4) + "@" + importedUserEmailDomain
+	}
+	if err := user.SetPassword(randomHex(32)); err != nil {
+		run.report.Add(entity.ImportKindUser, author.Login, entity.ImportActionError, 0, normalizeServiceErrorWithOpMsg("import.user.password", "set author password failed", err))
+		return importRef{}
+	}
+	if err := run.users.Create(ctx, user); err != nil {
+		run.report.Add(entity.ImportKindUser, author.Login, entity.ImportActionError, 0, normalizeServiceErrorWithOpMsg("import.user.create", "create author failed", err))
+		return importRef{}
+	}
+	created, err := run.users.GetByUsername(ctx, author.Login)
+	if err != nil {
+		run.report.Add(entity.ImportKindUser, author.Login, entity.ImportActionError, 0, normalizeServiceErrorWithOpMsg("import.user.reload", "reload created author failed", err))
+		return importRef{}
+	}
+	run.report.Add(entity.ImportKindUser, author.Login, entity.ImportActionCreate, created.ID, nil)
+	return importRef{id: created.ID, ok: true}
+}
+
+// term finds a category or tag by slug, then by name, and creates it when neither matches.
+func (run *importRun) term(ctx context.Context, store *termStore, term entity.ImportTerm) importRef {
+	term.Name = strings.TrimSpace(term.Name)
+	term.Slug = strings.TrimSpace(term.Slug)
+	if term.Slug == "" {
+		term.Slug = slug.Make(term.Name)
+	}
+	if term.Name == "" {
+		term.Name = term.Slug
+	}
+	if term.Slug == "" {
+		run.report.Add(store.kind, term.Name, entity.ImportActionError, 0, fmt.Errorf("%w: %s has neither name nor slug", core.ErrInvalidInput, store.kind))
+		return importRef{}
+	}
+	if ref, done := store.seen[term.Slug]; done {
+		return ref
+	}
+	ref := run.importTerm(ctx, store, term)
+	store.seen[term.Slug] = ref
+	return ref
+}
+
+func (run *importRun) importTerm(ctx context.Context, store *termStore, term entity.ImportTerm) importRef {
+	op := "import." + string(store.kind)
+	id, err := store.bySlug(ctx, term.Slug)
+	if errors.Is(err, core.ErrNotFound) {
+		id, err = store.byName(ctx, term.Name)
+	}
+	if err == nil {
+		run.report.Add(store.kind, term.Slug, entity.ImportActionExists, id, nil)
+		return importRef{id: id, ok: true}
+	}
+	if !errors.Is(err, core.ErrNotFound) {
+		run.report.Add(store.kind, term.Slug, entity.ImportActionError, 0, normalizeServiceErrorWithOpMsg(op+".lookup", "look up "+string(store.kind)+" failed", err))
+		return importRef{}
+	}
+	if run.req.DryRun {
+		run.report.Add(store.kind, term.Slug, entity.ImportActionCreate, 0, nil)
+		return importRef{ok: true}
+	}
+
+	id, err = store.create(ctx, term)
+	if err != nil {
+		run.report.Add(store.kind, term.Slug, entity.ImportActionError, 0, normalizeServiceErrorWithOpMsg(op+".create", "create "+string(store.kind)+" failed", err))
+		return importRef{}
+	}
+	run.report.Add(store.kind, term.Slug, entity.ImportActionCreate, id, nil)
+	return importRef{id: id, ok: true}
+}
+
+// seriesRef finds a series by slug and creates it when none matches. Without a series
+// repository every series is skipped silently.
+func (run *importRun) seriesRef(ctx context.Context, series entity.ImportSeries) importRef {
+	if run.series == nil {
+		return importRef{}
+	}
+	series.Title = strings.TrimSpace(series.Title)
+	series.Slug = strings.TrimSpace(series.Slug)
+	if series.Slug == "" {
+		series.Slug = slug.Make(series.Title)
+	}
+	if series.Title == "" {
+		series.Title = series.Slug
+	}
+	if series.Slug == "" {
+		run.report.Add(entity.ImportKindSeries, series.Title, entity.ImportActionError, 0, fmt.Errorf("%w: series has neither title nor slug", core.ErrInvalidInput))
+		return importRef{}
+	}
+	if ref, done := run.seriesRefs[series.Slug]; done {
+		return ref
+	}
+	ref := run.importSeries(ctx, series)
+	run.seriesRefs[series.Slug] = ref
+	return ref
+}
+
+func (run *importRun) importSeries(ctx context.Context, series entity.ImportSeries) importRef {
+	existing, err := run.series.GetBySlug(ctx, series.Slug)
+	if err == nil {
+		run.report.Add(entity.ImportKindSeries, series.Slug, entity.ImportActionExists, existing.ID, nil)
+		return importRef{id: existing.ID, ok: true}
+	}
+	if !errors.Is(err, core.ErrNotFound) {
+		run.report.Add(entity.ImportKindSeries, series.Slug, entity.ImportActionError, 0, normalizeServiceErrorWithOpMsg("import.series.lookup", "look up series failed", err))
+		return importRef{}
+	}
+	if run.req.DryRun {
+		run.report.Add(entity.ImportKindSeries, series.Slug, entity.ImportActionCreate, 0, nil)
+		return importRef{ok: true}
+	}
+
+	created, err := run.series.Create(ctx, entity.Series{Title: series.Title, Slug: series.Slug, Description: series.Description})
+	if err != nil {
+		run.report.Add(entity.ImportKindSeries, series.Slug, entity.ImportActionError, 0, normalizeServiceErrorWithOpMsg("import.series.create", "create series failed", err))
+		return importRef{}
+	}
+	run.report.Add(entity.ImportKindSeries, series.Slug, entity.ImportActionCreate, created.ID, nil)
+	return importRef{id: created.ID, ok: true}
+}
+
+// seriesPosition keeps the exported position unless another post of the series holds it
+// already; then, or without a position, the post goes after the last part.
+func (run *importRun) seriesPosition(ctx context.Context, seriesID uint, position int) (int, error) {
+	parts, err := run.series.ListParts(ctx, seriesID, false)
+	if err != nil {
+		return 0, err
+	}
+	last, taken := 0, false
+	for _, p := range parts {
+		last = max(last, p.Position)
+		taken = taken || p.Position == position
+	}
+	if position <= 0 || taken {
+		return last + 1, nil
+	}
+	return position, nil
+}
+
+// linkTranslations puts the created posts that share a translation key into one group,
+// started from the first of them.
+func (run *importRun) linkTranslations(ctx context.Context) {
+	keys := make([]string, 0, len(run.translations))
+	for key := range run.translations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		ids := run.translations[key]
+		if len(ids) < 2 {
+			continue
+		}
+		group := ids[0]
+		if err := run.posts.SetTranslationGroup(ctx, ids, &group); err != nil {
+			run.report.Add(entity.ImportKindPost, "translationKey "+key, entity.ImportActionError, 0, normalizeServiceErrorWithOpMsg("import.post.translations", "link translations failed", err))
+		}
+	}
+}
+
+// post creates one post unless a post with the same locale and slug exists. Its images are
+// copied into the media library first and the references rewritten to the local URLs.
+func (run *importRun) post(ctx context.Context, src entity.ImportPost) {
+	fail := func(op string, msg string, err error) {
+		run.report.Add(entity.ImportKindPost, src.Source, entity.ImportActionError, 0, normalizeServiceErrorWithOpMsg(op, msg, err))
+	}
+
+	post := entity.Post{
+		Title:            strings.TrimSpace(src.Title),
+		Slug:             strings.TrimSpace(src.Slug),
+		Content:          src.Content,
+		Cover:            strings.TrimSpace(src.Cover),
+		CreatedAt:        src.CreatedAt,
+		UpdatedAt:        src.UpdatedAt,
+		Status:           src.Status,
+		PublishAt:        src.PublishAt,
+		UnpublishAt:      src.UnpublishAt,
+		NoIndex:          src.NoIndex,
+		CommentsDisabled: src.CommentsDisabled,
+	}
+	if post.Slug == "" {
+		post.Slug = slug.Make(post.Title)
+	}
+	if post.Title == "" {
+		post.Title = post.Slug
+	}
+	if post.Slug == "" {
+		fail("import.post.validate", "invalid post", fmt.Errorf("%w: post has neither title nor slug", core.ErrInvalidInput))
+		return
+	}
+	if post.UpdatedAt.IsZero() {
+		post.UpdatedAt = post.CreatedAt
+	}
+
+	locale := src.Locale
+	if locale == "" {
+		locale = run.req.Locale
+	}
+	locale, err := normalizePostLocale(locale)
+	if err != nil {
+		fail("import.post.locale", "invalid post locale", err)
+		return
+	}
+	post.Locale = locale
+
+	exists, err := run.posts.IsSlugExists(ctx, post.Locale, post.Slug)
+	if err != nil {
+		fail("import.post.lookup", "look up post failed", err)
+		return
+	}
+	if exists {
+		run.report.Add(entity.ImportKindPost, src.Source, entity.ImportActionExists, 0, nil)
+		return
+	}
+
+	post.AuthorID = run.req.ActorUserID
+	if src.Author != "" {
+		author := run.author(ctx, entity.ImportAuthor{Login: src.Author})
+		if !author.ok {
+			fail("import.post.author", "post author was not imported", fmt.Errorf("%w: author %q", core.ErrInvalidInput, src.Author))
+			return
+		}
+		post.AuthorID = author.id
+	}
+	for _, term := range src.Categories {
+		if ref := run.term(ctx, &run.categories, term); ref.ok && ref.id != 0 {
+			id := ref.id
+			post.CategoryID = &id
+			break
+		}
+	}
+	for _, term := range src.Tags {
+		if ref := run.term(ctx, &run.tags, term); ref.ok && ref.id != 0 {
+			post.Tags = append(post.Tags, entity.Tag{ID: ref.id})
+		}
+	}
+	if src.Series != nil {
+		if ref := run.seriesRef(ctx, *src.Series); ref.ok && ref.id != 0 {
+			position, err := run.seriesPosition(ctx, ref.id, src.SeriesPosition)
+			if err != nil {
+				fail("import.post.series", "list series parts failed", err)
+				return
+			}
+			id := ref.id
+			post.SeriesID, post.SeriesPosition = &id, position
+		}
+	}
+
+	if run.req.DryRun {
+		for _, ref := range postImageRefs(post.Content, post.Cover) {
+			run.report.Add(entity.ImportKindMedia, ref, entity.ImportActionCreate, 0, nil)
+		}
+		run.report.Add(entity.ImportKindPost, src.Source, entity.ImportActionCreate, 0, nil)
+		return
+	}
+
+	if run.media != nil {
+		owner := post.AuthorID
+		local := make(map[string]string)
+		for _, ref := range postImageRefs(post.Content, post.Cover) {
+			if u, ok := run.copyImage(ctx, src, ref, owner); ok {
+				local[ref] = u
+			}
+		}
+		post.Content = rewriteImageRefs(post.Content, local)
+		if u, ok := local[post.Cover]; ok {
+			post.Cover = u
+		}
+	}
+
+	created, err := run.posts.Create(ctx, post)
+	if errors.Is(err, core.ErrDuplicate) {
+		run.report.Add(entity.ImportKindPost, src.Source, entity.ImportActionExists, 0, nil)
+		return
+	}
+	if err != nil {
+		fail("import.post.create", "create post failed", err)
+		return
+	}
+	if run.media != nil {
+		if err := run.media.SyncPostReferences(ctx, created.ID, created.Content, created.Cover); err != nil {
+			fail("import.post.media_refs", "sync media references failed", err)
+			return
+		}
+	}
+	if src.TranslationKey != "" {
+		run.translations[src.TranslationKey] = append(run.translations[src.TranslationKey], created.ID)
+	}
+	run.report.Add(entity.ImportKindPost, src.Source, entity.ImportActionCreate, created.ID, nil)
+}
+
+// copyImage stores one referenced image and returns its local URL.
+func (run *importRun) copyImage(ctx context.Context, post entity.ImportPost, ref string, owner uint) (string, bool) {
+	rc, name, err := run.assets.Open(ctx, post, ref)
+	if err != nil && errors.Is(err, core.ErrNotFound) && reAssetURL.MatchString(ref) {
+		// A media URL of this system that the export does not carry; on a re-import into the
+		// same site it still resolves, so it is kept as it is.
+		return "", false
+	}
+	if err != nil {
+		run.report.Add(entity.ImportKindMedia, ref, entity.ImportActionError, 0, normalizeServiceErrorWithOpMsg("import.media.open", "open image failed", err))
+		return "", false
+	}
+	defer rc.Close()
+
+	asset, created, err := run.media.ImportAsset(ctx, owner, name, rc)
+	if err != nil {
+		run.report.Add(entity.ImportKindMedia, ref, entity.ImportActionError, 0, normalizeServiceErrorWithOpMsg("import.media.store", "store image failed", err))
+		return "", false
+	}
+	action := entity.ImportActionExists
+	if created {
+		action = entity.ImportActionCreate
+	}
+	run.report.Add(entity.ImportKindMedia, ref, action, asset.ID, nil)
+	return asset.Url, true
+}
+
+// imageRefPatterns find image references in Markdown (![alt](src "title")) and in the HTML
+// that WordPress stores (<img src="...">). The first group is the reference.
+var imageRefPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+["'][^"']*["'])?\s*\)`),
+	regexp.MustCompile(`(?i)<img\b[^>]*?\bsrc\s*=\s*["']([^"']+)["']`),
+}
+
+// postImageRefs lists the distinct images a post references. Media URLs of this system are
+// included: a site export carries those files, and they are copied like any other image.
+func postImageRefs(content string, cover string) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	add := func(ref string) {
+		if ref == "" || seen[ref] || !importableImageRef(ref) {
+			return
+		}
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+	for _, re := range imageRefPatterns {
+		for _, m := range re.FindAllStringSubmatch(content, -1) {
+			add(m[1])
+		}
+	}
+	add(cover)
+	return refs
+}
+
+func importableImageRef(ref string) bool {
+	return !strings.HasPrefix(ref, "data:") && !strings.HasPrefix(ref, "#")
+}
+
+// rewriteImageRefs replaces the image references found in local; other text is untouched,
+// so a reference that also appears in prose or a link keeps its original form there.
+func rewriteImageRefs(content string, local map[string]string) string {
+	if len(local) == 0 {
+		return content
+	}
+	for _, re := range imageRefPatterns {
+		content = re.ReplaceAllStringFunc(content, func(match string) string {
+			loc := re.FindStringSubmatchIndex(match)
+			ref := match[loc[2]:loc[3]]
+			u, ok := local[ref]
+			if !ok {
+				return match
+			}
+			return match[:loc[2]] + u + match[loc[3]:]
+		})
+	}
+	return content
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,443 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// stubDecoder returns a fixed bundle; stubAssets serves files by reference.
+type stubDecoder struct {
+	bundle entity.ImportBundle
+	assets stubAssets
+}
+
+func (d stubDecoder) Decode(format entity.ImportFormat, r io.ReaderAt, size int64) (entity.ImportBundle, core.ImportAssets, error) {
+	return d.bundle, d.assets, nil
+}
+
+type stubAssets map[string][]byte
+
+func (a stubAssets) Open(ctx context.Context, post entity.ImportPost, ref string) (io.ReadCloser, string, error) {
+	data, ok := a[ref]
+	if !ok {
+		return nil, "", core.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), filepath.Base(ref), nil
+}
+
+// memMediaRepo keeps assets in memory; only the calls the import makes are implemented.
+type memMediaRepo struct {
+	fakeMediaRepoNoOp
+	assets []entity.MediaAsset
+	refs   map[uint][]uint
+}
+
+func (r *memMediaRepo) Create(ctx context.Context, asset *entity.MediaAsset) error {
+	asset.ID = uint(len(r.assets) + 1)
+	r.assets = append(r.assets, *asset)
+	return nil
+}
+func (r *memMediaRepo) GetBySHA256(ctx context.Context, sum string) (entity.MediaAsset, error) {
+	for _, a := range r.assets {
+		if a.SHA256 == sum && a.Status == entity.MediaStatusUploaded {
+			return a, nil
+		}
+	}
+	return entity.MediaAsset{}, core.ErrNotFound
+}
+func (r *memMediaRepo) UpdateAssetFields(ctx context.Context, assetID uint, fields map[string]any) error {
+	a := &r.assets[assetID-1]
+	a.ObjectKey = fields["object_key"].(string)
+	a.Url = fields["url"].(string)
+	a.Status = entity.MediaStatus(fields["status"].(int))
+	return nil
+}
+func (r *memMediaRepo) UpsertPostReferences(ctx context.Context, postID uint, purpose string, assetIDs []uint) error {
+	if purpose == "content" {
+		r.refs[postID] = assetIDs
+	}
+	return nil
+}
+
+// importSite is an in-memory site behind the fake repositories.
+type importSite struct {
+	users      []entity.User
+	categories []entity.Category
+	tags       []entity.Tag
+	posts      []entity.Post
+	media      *memMediaRepo
+	// series is optional; without it the import runs without a series repository.
+	series *memSeriesRepo
+}
+
+func (s *importSite) service(t *testing.T, decoder core.ImportDecoder) core.ImportService {
+	users := &fakeUserRepo{
+		getByUsernameFn: func(ctx context.Context, username string) (entity.User, error) {
+			for _, u := range s.users {
+				if u.Username == username {
+					return u, nil
+				}
+			}
+			return entity.User{}, core.ErrNotFound
+		},
+		createFn: func(ctx context.Context, user entity.User) error {
+			user.ID = uint(100 + len(s.users))
+			s.users = append(s.users, user)
+			return nil
+		},
+	}
+	categories := &fakeCategoryRepo{
+		getBySlugFn: func(ctx context.Context, slug string) (entity.Category, error) {
+			for _, c := range s.categories {
+				if c.Slug == slug {
+					return c, nil
+				}
+			}
+			return entity.Category{}, core.ErrNotFound
+		},
+		getByNameFn: func(ctx context.Context, name string) (entity.Category, error) {
+			for _, c := range s.categories {
+				if c.Name == name {
+					return c, nil
+				}
+			}
+			return entity.Category{}, core.ErrNotFound
+		},
+		createFn: func(ctx context.Context, c entity.Category) (entity.Category, error) {
+			c.ID = uint(len(s.categories) + 1)
+			s.categories = append(s.categories, c)
+			return c, nil
+		},
+	}
+	tags := &fakeTagRepo{
+		getBySlugFn: func(ctx context.Context, slug string) (entity.Tag, error) {
+			for _, tag := range s.tags {
+				if tag.Slug == slug {
+					return tag, nil
+				}
+			}
+			return entity.Tag{}, core.ErrNotFound
+		},
+		getByNameFn: func(ctx context.Context, name string) (entity.Tag, error) {
+			return entity.Tag{}, core.ErrNotFound
+		},
+		createFn: func(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
+			tag.ID = uint(len(s.tags) + 1)
+			s.tags = append(s.tags, tag)
+			return tag, nil
+		},
+	}
+	posts := &fakePostRepo{
+		isSlugExistsFn: func(ctx context.Context, locale string, slug string) (bool, error) {
+			for _, p := range s.posts {
+				if p.Locale == locale && p.Slug == slug {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		createFn: func(ctx context.Context, post entity.Post) (entity.Post, error) {
+			post.ID = uint(len(s.posts) + 1)
+			s.posts = append(s.posts, post)
+			if post.SeriesID != nil {
+				s.series.parts[*post.SeriesID] = append(s.series.parts[*post.SeriesID], entity.SeriesPart{ID: post.ID, Position: post.SeriesPosition})
+			}
+			return post, nil
+		},
+		setTranslationGroupFn: func(ctx context.Context, ids []uint, groupID *uint) error {
+			for _, id := range ids {
+				s.posts[id-1].TranslationGroupID = groupID
+			}
+			return nil
+		},
+	}
+	media := NewMediaService(s.media, MediaConfig{UploadDir: t.TempDir()})
+	var series core.SeriesRepository
+	if s.series != nil {
+		series = s.series
+	}
+	return NewImportService(decoder, users, categories, tags, series, posts, media)
+}
+
+func tinyPNG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 3, 2))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func importBundle() entity.ImportBundle {
+	created := time.Date(2019, 5, 6, 7, 8, 9, 0, time.UTC)
+	return entity.ImportBundle{
+		Authors:    []entity.ImportAuthor{{Login: "jane", Email: "jane@example.com"}, {Login: "wpadmin"}},
+		Categories: []entity.ImportTerm{{Name: "News", Slug: "news"}},
+		Tags:       []entity.ImportTerm{{Name: "Go", Slug: "go"}},
+		Posts: []entity.ImportPost{
+			{
+				Source:     "post 1",
+				Title:      "Hello",
+				Slug:       "hello-world",
+				Content:    "Intro\n\n![cat](https://old.example.com/cat.png)\n\n<img src=\"https://old.example.com/cat.png\" alt=\"\">",
+				Cover:      "https://old.example.com/cat.png",
+				Author:     "jane",
+				CreatedAt:  created,
+				Status:     entity.StatusPublished,
+				Categories: []entity.ImportTerm{{Name: "News", Slug: "news"}},
+				Tags:       []entity.ImportTerm{{Name: "Go", Slug: "go"}, {Name: "Misc", Slug: "misc"}},
+			},
+			{Source: "post 2", Title: "Draft", Slug: "draft", Author: "wpadmin", CreatedAt: created, Status: entity.StatusDraft},
+		},
+	}
+}
+
+func TestImportService_Import(t *testing.T) {
+	ctx := context.Background()
+	site := &importSite{
+		users: []entity.User{{ID: 1, Username: "admin"}, {ID: 2, Username: "alice"}},
+		media: &memMediaRepo{refs: make(map[uint][]uint)},
+	}
+	decoder := stubDecoder{bundle: importBundle(), assets: stubAssets{"https://old.example.com/cat.png": tinyPNG(t)}}
+	svc := site.service(t, decoder)
+	req := entity.ImportRequest{
+		Format:      entity.ImportFormatWXR,
+		ActorUserID: 1,
+		AuthorMap:   map[string]string{"wpadmin": "alice"},
+		Locale:      "en",
+	}
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		dry := req
+		dry.DryRun = true
+		report, err := svc.Import(ctx, dry, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(site.users) != 2 || len(site.categories) != 0 || len(site.tags) != 0 || len(site.posts) != 0 || len(site.media.assets) != 0 {
+			t.Fatalf("dry run wrote: %+v", site)
+		}
+		if n := report.Count(entity.ImportKindPost, entity.ImportActionCreate); n != 2 {
+			t.Fatalf("want 2 posts to create, got %d", n)
+		}
+		if n := report.Count(entity.ImportKindMedia, entity.ImportActionCreate); n != 1 {
+			t.Fatalf("want 1 image to copy, got %d", n)
+		}
+		if n := report.Count(entity.ImportKindUser, entity.ImportActionExists); n != 1 {
+			t.Fatalf("want the mapped author to exist, got %d", n)
+		}
+	})
+
+	t.Run("import creates content with original dates and local images", func(t *testing.T) {
+		report, err := svc.Import(ctx, req, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, item := range report.Items {
+			if item.Action == entity.ImportActionError {
+				t.Fatalf("unexpected failure: %+v", item)
+			}
+		}
+		if len(site.posts) != 2 || len(site.media.assets) != 1 {
+			t.Fatalf("posts=%d assets=%d", len(site.posts), len(site.media.assets))
+		}
+
+		post := site.posts[0]
+		if post.Slug != "hello-world" || post.Locale != "en" || post.Status != entity.StatusPublished {
+			t.Fatalf("post = %+v", post)
+		}
+		if !post.CreatedAt.Equal(time.Date(2019, 5, 6, 7, 8, 9, 0, time.UTC)) || !post.UpdatedAt.Equal(post.CreatedAt) {
+			t.Fatalf("dates not kept: %v %v", post.CreatedAt, post.UpdatedAt)
+		}
+		jane := site.users[2]
+		if jane.Username != "jane" || jane.Email != "jane@example.com" || jane.Role != "user" || post.AuthorID != jane.ID {
+			t.Fatalf("author = %+v, post author %d", jane, post.AuthorID)
+		}
+		if jane.Password == "" {
+			t.Fatal("created author has no password hash")
+		}
+		if site.posts[1].AuthorID != 2 {
+			t.Fatalf("mapped author: got %d, want alice", site.posts[1].AuthorID)
+		}
+		if post.CategoryID == nil || *post.CategoryID != site.categories[0].ID || len(post.Tags) != 2 {
+			t.Fatalf("terms: category %v tags %+v", post.CategoryID, post.Tags)
+		}
+
+		asset := site.media.assets[0]
+		if asset.Url != "/media/a/1/cat.png" || asset.SHA256 == "" || asset.Width == nil || *asset.Width != 3 {
+			t.Fatalf("asset = %+v", asset)
+		}
+		if strings.Contains(post.Content, "old.example.com") || strings.Count(post.Content, asset.Url) != 2 || post.Cover != asset.Url {
+			t.Fatalf("references not rewritten: %q cover %q", post.Content, post.Cover)
+		}
+		if _, err := os.Stat(filepath.Join(svcUploadDir(t, svc), "a", "1", "cat.png")); err != nil {
+			t.Fatalf("file not stored: %v", err)
+		}
+		if got := site.media.refs[post.ID]; len(got) != 1 || got[0] != asset.ID {
+			t.Fatalf("media references = %v", got)
+		}
+	})
+
+	t.Run("re-run creates nothing", func(t *testing.T) {
+		report, err := svc.Import(ctx, req, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, item := range report.Items {
+			if item.Action != entity.ImportActionExists {
+				t.Fatalf("re-run item %+v", item)
+			}
+		}
+		if len(site.users) != 3 || len(site.categories) != 1 || len(site.tags) != 2 || len(site.posts) != 2 || len(site.media.assets) != 1 {
+			t.Fatalf("re-run wrote: users=%d categories=%d tags=%d posts=%d assets=%d",
+				len(site.users), len(site.categories), len(site.tags), len(site.posts), len(site.media.assets))
+		}
+	})
+}
+
+func svcUploadDir(t *testing.T, svc core.ImportService) string {
+	t.Helper()
+	return svc.(*importService).media.cfg.UploadDir
+}
+
+func TestImportService_Import_SeriesAndTranslations(t *testing.T) {
+	ctx := context.Background()
+	site := &importSite{
+		users:  []entity.User{{ID: 1, Username: "admin"}},
+		media:  &memMediaRepo{refs: make(map[uint][]uint)},
+		series: &memSeriesRepo{series: []entity.Series{{ID: 1, Title: "Old", Slug: "old"}}, parts: map[uint][]entity.SeriesPart{1: {{ID: 99, Position: 1}}}},
+	}
+	basics := &entity.ImportSeries{Title: "Go Basics", Slug: "go-basics", Description: "From zero"}
+	bundle := entity.ImportBundle{
+		Series: []entity.ImportSeries{*basics},
+		Posts: []entity.ImportPost{
+			{Source: "en/part-2.md", Title: "Part 2", Locale: "en", Series: basics, SeriesPosition: 2, TranslationKey: "group-7"},
+			{Source: "en/part-1.md", Title: "Part 1", Locale: "en", Series: basics, SeriesPosition: 1},
+			{Source: "zh/part-2.md", Title: "Part 2", Locale: "zh-CN", Series: basics, SeriesPosition: 2, TranslationKey: "group-7"},
+			{Source: "en/clash.md", Title: "Clash", Locale: "en", Series: &entity.ImportSeries{Title: "Old", Slug: "old"}, SeriesPosition: 1},
+			{Source: "en/alone.md", Title: "Alone", Locale: "en", TranslationKey: "group-9"},
+		},
+	}
+	svc := site.service(t, stubDecoder{bundle: bundle})
+	req := entity.ImportRequest{Format: entity.ImportFormatMarkdown, ActorUserID: 1}
+
+	dry := req
+	dry.DryRun = true
+	report, err := svc.Import(ctx, dry, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(site.series.series) != 1 || report.Count(entity.ImportKindSeries, entity.ImportActionCreate) != 1 || report.Count(entity.ImportKindSeries, entity.ImportActionExists) != 1 {
+		t.Fatalf("dry run: series %+v, report %+v", site.series.series, report.Items)
+	}
+
+	report, err = svc.Import(ctx, req, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range report.Items {
+		if item.Action == entity.ImportActionError {
+			t.Fatalf("unexpected failure: %+v", item)
+		}
+	}
+	if len(site.series.series) != 2 || site.series.series[1].Description != "From zero" {
+		t.Fatalf("series = %+v", site.series.series)
+	}
+	positions := make([]int, len(site.posts))
+	for i, p := range site.posts {
+		positions[i] = p.SeriesPosition
+	}
+	// The second Part 2 finds position 2 taken and is appended; Clash finds the old post at 1.
+	if want := []int{2, 1, 3, 2, 0}; fmt.Sprint(positions) != fmt.Sprint(want) {
+		t.Fatalf("positions = %v, want %v", positions, want)
+	}
+	if *site.posts[0].SeriesID != 2 || *site.posts[3].SeriesID != 1 || site.posts[4].SeriesID != nil {
+		t.Fatalf("series IDs not set: %+v", site.posts)
+	}
+	en, zh := site.posts[0].TranslationGroupID, site.posts[2].TranslationGroupID
+	if en == nil || zh == nil || *en != 1 || *zh != 1 || site.posts[1].TranslationGroupID != nil || site.posts[4].TranslationGroupID != nil {
+		t.Fatalf("translation groups: %v %v %v %v", en, zh, site.posts[1].TranslationGroupID, site.posts[4].TranslationGroupID)
+	}
+}
+
+func TestImportService_Import_ItemFailuresAreReported(t *testing.T) {
+	site := &importSite{users: []entity.User{{ID: 1, Username: "admin"}}, media: &memMediaRepo{refs: make(map[uint][]uint)}}
+	bundle := entity.ImportBundle{Posts: []entity.ImportPost{
+		{Source: "a.md", Title: "Broken image", Content: "![x](missing.png) ![y](/media/a/5/kept.png)", CreatedAt: time.Now()},
+		{Source: "b.md", Title: "Mapped to nobody", Author: "ghost", CreatedAt: time.Now()},
+	}}
+	svc := site.service(t, stubDecoder{bundle: bundle})
+	req := entity.ImportRequest{Format: entity.ImportFormatMarkdown, ActorUserID: 1, AuthorMap: map[string]string{"ghost": "nobody"}}
+
+	report, err := svc.Import(context.Background(), req, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := report.Count(entity.ImportKindMedia, entity.ImportActionError); n != 1 {
+		t.Fatalf("want 1 media error (local media URLs missing from the export are kept silently), got %d", n)
+	}
+	if len(site.posts) != 1 || site.posts[0].Slug != "broken-image" || site.posts[0].Content != "![x](missing.png) ![y](/media/a/5/kept.png)" {
+		t.Fatalf("post with a missing image should keep its reference: %+v", site.posts)
+	}
+	if n := report.Count(entity.ImportKindPost, entity.ImportActionError); n != 1 {
+		t.Fatalf("want the unmapped author's post to fail, got %d", n)
+	}
+	for _, item := range report.Items {
+		if item.Kind == entity.ImportKindUser && !errors.Is(item.Err, core.ErrInvalidInput) {
+			t.Fatalf("mapping to a missing user: %v", item.Err)
+		}
+	}
+}
+
+func TestImportService_Import_SynthesizedEmailsAreUnique(t *testing.T) {
+	site := &importSite{users: []entity.User{{ID: 1, Username: "admin"}}, media: &memMediaRepo{refs: make(map[uint][]uint)}}
+	bundle := entity.ImportBundle{Authors: []entity.ImportAuthor{{Login: "Jane Doe"}, {Login: "jane.doe"}}}
+	svc := site.service(t, stubDecoder{bundle: bundle})
+
+	report, err := svc.Import(context.Background(), entity.ImportRequest{Format: entity.ImportFormatWXR, ActorUserID: 1}, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := report.Count(entity.ImportKindUser, entity.ImportActionCreate); n != 2 || len(site.users) != 3 {
+		t.Fatalf("want 2 authors created, got %d: %+v", n, report.Items)
+	}
+	a, b := site.users[1].Email, site.users[2].Email
+	if a == b || !strings.HasPrefix(a, "jane-doe-") || !strings.HasSuffix(b, "@"+importedUserEmailDomain) {
+		t.Fatalf("synthesized emails %q and %q", a, b)
+	}
+}
+
+func TestImportService_Import_RejectsInvalidRequest(t *testing.T) {
+	site := &importSite{media: &memMediaRepo{}}
+	svc := site.service(t, stubDecoder{})
+	_, err := svc.Import(context.Background(), entity.ImportRequest{Format: "csv", ActorUserID: 1}, nil, 0)
+	if !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("want ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestRewriteImageRefs(t *testing.T) {
+	content := "![a](img/a.png \"A\") and [link](img/a.png) <IMG class=x SRC='img/b.png'> ![c](data:image/png;base64,xx)"
+	refs := postImageRefs(content, "/media/a/9/cover.png")
+	if len(refs) != 3 || refs[0] != "img/a.png" || refs[1] != "img/b.png" || refs[2] != "/media/a/9/cover.png" {
+		t.Fatalf("refs = %v", refs)
+	}
+	got := rewriteImageRefs(content, map[string]string{"img/a.png": "/media/a/1/a.png", "img/b.png": "/media/a/2/b.png"})
+	want := "![a](/media/a/1/a.png \"A\") and [link](img/a.png) <IMG class=x SRC='/media/a/2/b.png'> ![c](data:image/png;base64,xx)"
+	if got != want {
+		t.Fatalf("got  %q\nwant %q", got, want)
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"KaldalisCMS/internal/core"
 	"KaldalisCMS/internal/core/entity"
 	repository "KaldalisCMS/internal/infra/repository/postgres"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"image"
@@ -12,6 +15,8 @@ import (
 	_ "image/jpeg"
 	_ "image/png"
 	"io"
+	"io/fs"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"os"
@@ -99,8 +104,6 @@ func (s *MediaService) CreateAssetFromUpload(ctx context.Context, ownerUserID ui
 	}
 	defer f.Close()
 
-	// --- State Machine Step 1: PENDING ---
-	// Insert DB record first to get ID. Status defaults to PENDING (0).
 	asset := entity.MediaAsset{
 		OwnerUserID:  ownerUserID,
 		OriginalName: origName,
@@ -108,19 +111,8 @@ func (s *MediaService) CreateAssetFromUpload(ctx context.Context, ownerUserID ui
 		Ext:          ext,
 		MimeType:     mimeType,
 		SizeBytes:    fileHeader.Size,
-		Storage:      "local",
-		Status:       entity.MediaStatusPending,
-	}
-
-	if err := s.repo.Create(ctx, &asset); err != nil {
-		return entity.MediaAsset{}, normalizeServiceErrorWithOpMsg("media.upload.create_asset", "create media asset record failed", err)
 	}
 
-	// Calculate paths
-	objectKey := filepath.ToSlash(filepath.Join("a", fmt.Sprintf("%d", asset.ID), storedName))
-	asset.ObjectKey = objectKey
-	asset.Url = joinPublicURL(s.cfg.PublicBaseURL, "/media/"+objectKey)
-
 	// Best-effort image config (only for images)
 	if strings.HasPrefix(strings.ToLower(mimeType), "image/") {
 		if w, h := tryReadImageSize(fileHeader); w != nil && h != nil {
@@ -129,6 +121,85 @@ func (s *MediaService) CreateAssetFromUpload(ctx context.Context, ownerUserID ui
 		}
 	}
 
+	return s.storeAsset(ctx, asset, f)
+}
+
+// ImportAsset stores a file copied from another system, e.g. an image referenced by an
+// imported post. Only images are accepted. Files are identified by their SHA-256, so
+// importing the same bytes again returns the existing asset with created set to false.
+func (s *MediaService) ImportAsset(ctx context.Context, ownerUserID uint, name string, src io.Reader) (asset entity.MediaAsset, created bool, err error) {
+	maxBytes := s.cfg.MaxUploadSizeMB * 1024 * 1024
+	data, err := io.ReadAll(io.LimitReader(src, maxBytes+1))
+	if err != nil {
+		return entity.MediaAsset{}, false, normalizeServiceErrorWithOpMsg("media.import.read", "read imported file failed", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return entity.MediaAsset{}, false, ErrUploadTooLarge
+	}
+
+	mimeType := http.DetectContentType(data)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return entity.MediaAsset{}, false, ErrUnsupportedType
+	}
+
+	digest := sha256.Sum256(data)
+	sum := hex.EncodeToString(digest[:])
+	existing, err := s.repo.GetBySHA256(ctx, sum)
+	if err == nil {
+		return existing, false, nil
+	}
+	if !errors.Is(err, core.ErrNotFound) {
+		return entity.MediaAsset{}, false, normalizeServiceErrorWithOpMsg("media.import.lookup", "look up imported file failed", err)
+	}
+
+	if filepath.Ext(name) == "" {
+		if exts, _ := mime.ExtensionsByType(mimeType); len(exts) > 0 {
+			name += exts[0]
+		}
+	}
+	storedName, ext, err := sanitizeFilename(name, s.cfg.MaxFilenameBytes)
+	if err != nil {
+		return entity.MediaAsset{}, false, err
+	}
+
+	asset = entity.MediaAsset{
+		OwnerUserID:  ownerUserID,
+		OriginalName: name,
+		StoredName:   storedName,
+		Ext:          ext,
+		MimeType:     mimeType,
+		SizeBytes:    int64(len(data)),
+		SHA256:       sum,
+	}
+	if cfg, _, err := decodeImageConfig(bytes.NewReader(data)); err == nil {
+		w, h := cfg.Width, cfg.Height
+		asset.Width = &w
+		asset.Height = &h
+	}
+
+	asset, err = s.storeAsset(ctx, asset, bytes.NewReader(data))
+	if err != nil {
+		return entity.MediaAsset{}, false, err
+	}
+	return asset, true, nil
+}
+
+// storeAsset runs the upload state machine for a validated asset and returns it UPLOADED.
+func (s *MediaService) storeAsset(ctx context.Context, asset entity.MediaAsset, src io.Reader) (entity.MediaAsset, error) {
+	// --- State Machine Step 1: PENDING ---
+	// Insert DB record first to get ID. Status defaults to PENDING (0).
+	asset.Storage = "local"
+	asset.Status = entity.MediaStatusPending
+
+	if err := s.repo.Create(ctx, &asset); err != nil {
+		return entity.MediaAsset{}, normalizeServiceErrorWithOpMsg("media.upload.create_asset", "create media asset record failed", err)
+	}
+
+	// Calculate paths
+	objectKey := filepath.ToSlash(filepath.Join("a", fmt.Sprintf("%d", asset.ID), asset.StoredName))
+	asset.ObjectKey = objectKey
+	asset.Url = joinPublicURL(s.cfg.PublicBaseURL, "/media/"+objectKey)
+
 	// --- State Machine Step 2: WRITE FILE ---
 	absPath := filepath.Join(s.cfg.UploadDir, filepath.FromSlash(objectKey))
 	if err := os.MkdirAll(filepath.Dir(absPath), 0o755); err != nil {
@@ -147,7 +218,7 @@ func (s *MediaService) CreateAssetFromUpload(ctx context.Context, ownerUserID ui
 	// so we don't rely on defer alone for the success path
 	copyErr := func() error {
 		defer out.Close()
-		if _, err := io.Copy(out, f); err != nil {
+		if _, err := io.Copy(out, src); err != nil {
 			return err
 		}
 		return nil
@@ -322,6 +393,47 @@ func (s *MediaService) ListPostMedia(ctx context.Context, postID uint, purpose *
 	return assets, nil
 }
 
+// WalkFiles calls fn for every file of the public media tree (UploadDir/a), in lexical order,
+// with its object key such as "a/12/photo.jpg". A missing tree has no files.
+func (s *MediaService) WalkFiles(ctx context.Context, fn func(objectKey string, info fs.FileInfo, r io.Reader) error) error {
+	root := filepath.Join(s.cfg.UploadDir, "a")
+	err := filepath.WalkDir(root, func(absPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if absPath == root && errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.cfg.UploadDir, absPath)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(absPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return fn(filepath.ToSlash(rel), info, f)
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return normalizeServiceErrorWithOpMsg("media.walk_files", "walk media files failed", err)
+	}
+	return nil
+}
+
 // SyncPostReferences parses markdown content and cover URL to update post_assets mappings.
 func (s *MediaService) SyncPostReferences(ctx context.Context, postID uint, content string, cover string) error {
 	contentIDs := extractAssetIDsFromMarkdown(content)
@@ -340,6 +452,23 @@ func (s *MediaService) SyncPostReferences(ctx context.Context, postID uint, cont
 	return nil
 }
 
+// SyncPageReferences updates page_assets mappings the same way SyncPostReferences does for posts.
+func (s *MediaService) SyncPageReferences(ctx context.Context, pageID uint, content string, cover string) error {
+	contentIDs := extractAssetIDsFromMarkdown(content)
+	if err := s.repo.UpsertPageReferences(ctx, pageID, "content", contentIDs); err != nil {
+		return normalizeServiceErrorWithOpMsg("media.sync_page_refs.content", "sync page content media references failed", err)
+	}
+
+	coverIDs := []uint{}
+	if coverID := extractAssetIDFromMediaURL(cover); coverID != 0 {
+		coverIDs = []uint{coverID}
+	}
+	if err := s.repo.UpsertPageReferences(ctx, pageID, "cover", coverIDs); err != nil {
+		return normalizeServiceErrorWithOpMsg("media.sync_page_refs.cover", "sync page cover media references failed", err)
+	}
+	return nil
+}
+
 // --- helpers ---
 
 func joinPublicURL(base, path string) string {
@@ -284,6 +284,9 @@ func (fakeMediaRepoNoOp) Create(ctx context.Context, asset *entity.MediaAsset) e
 func (fakeMediaRepoNoOp) GetByID(ctx context.Context, id uint) (entity.MediaAsset, error) {
 	panic("not impl")
 }
+func (fakeMediaRepoNoOp) GetBySHA256(ctx context.Context, sum string) (entity.MediaAsset, error) {
+	panic("not impl")
+}
 func (fakeMediaRepoNoOp) List(ctx context.Context, owner *uint, offset, limit int, q string) ([]entity.MediaAsset, int64, error) {
 	panic("not impl")
 }
@@ -294,6 +297,12 @@ func (fakeMediaRepoNoOp) CountReferences(ctx context.Context, assetID uint) (int
 func (fakeMediaRepoNoOp) UpsertPostReferences(ctx context.Context, postID uint, purpose string, assetIDs []uint) error {
 	panic("not impl")
 }
+func (fakeMediaRepoNoOp) UpsertPageReferences(ctx context.Context, pageID uint, purpose string, assetIDs []uint) error {
+	panic("not impl")
+}
+func (fakeMediaRepoNoOp) UpsertEntryReferences(ctx context.Context, entryID uint, assetIDs []uint) error {
+	panic("not impl")
+}
 func (fakeMediaRepoNoOp) ListPostMedia(ctx context.Context, postID uint, purpose *string) ([]entity.MediaAsset, error) {
 	panic("not impl")
 }
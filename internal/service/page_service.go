@@ -0,0 +1,546 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// ErrPageHasChildren is returned when deleting a page that still has child pages.
+var ErrPageHasChildren = fmt.Errorf("%w: page has child pages", core.ErrConflict)
+
+// pageService implements core.PageService.
+type pageService struct {
+	repo core.PageRepository
+	// media is optional; when nil, reference sync is skipped.
+	media *MediaService
+	// renderer is optional; when nil, public reads carry raw content only.
+	renderer    core.ContentRenderer
+	renderCache *renderCache
+	authorizer  core.PostAuthorizer
+}
+
+// NewPageService creates a PageService. Page permissions are checked with the post
+// capabilities of authorizer.
+func NewPageService(repo core.PageRepository, media *MediaService, renderer core.ContentRenderer, authorizer core.PostAuthorizer) core.PageService {
+	s := &pageService{repo: repo, media: media, renderer: renderer, authorizer: authorizer}
+	if renderer != nil {
+		s.renderCache = newRenderCache(renderCacheSize)
+	}
+	return s
+}
+
+// ListPublicPages returns the published pages whose ancestors are all published.
+func (s *pageService) ListPublicPages(ctx context.Context) ([]entity.Page, error) {
+	published := entity.StatusPublished
+	pages, err := s.repo.List(ctx, entity.PageQuery{Status: &published})
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("page.list_public", "list public pages failed", err)
+	}
+
+	paths := make(map[string]struct{}, len(pages))
+	for _, p := range pages {
+		paths[p.Path] = struct{}{}
+	}
+	visible := make([]entity.Page, 0, len(pages))
+	for _, p := range pages {
+		if slices.ContainsFunc(entity.PageAncestorPaths(p.Path), func(a string) bool {
+			_, ok := paths[a]
+			return !ok
+		}) {
+			continue
+		}
+		visible = append(visible, p)
+	}
+	return visible, nil
+}
+
+// GetPublicPageByPath resolves a published page by its full path. A page under an
+// unpublished ancestor is reported as not found, like a draft.
+func (s *pageService) GetPublicPageByPath(ctx context.Context, rawPath string) (entity.Page, error) {
+	path, err := entity.NormalizePagePath(rawPath)
+	if err != nil {
+		return entity.Page{}, fmt.Errorf("%w: %v", core.ErrInvalidInput, err)
+	}
+	page, err := s.repo.GetByPath(ctx, path)
+	if err != nil {
+		return entity.Page{}, normalizeServiceErrorWithOpMsg("page.get_public", "get public page failed", err)
+	}
+	if page.Status != entity.StatusPublished {
+		return entity.Page{}, core.ErrNotFound
+	}
+
+	ancestors, err := s.ancestors(ctx, page)
+	if err != nil {
+		return entity.Page{}, err
+	}
+	for _, a := range ancestors {
+		if a.Status != entity.StatusPublished {
+			return entity.Page{}, core.ErrNotFound
+		}
+		page.Breadcrumbs = append(page.Breadcrumbs, a.Ref())
+	}
+
+	published := entity.StatusPublished
+	if err := s.attachChildren(ctx, &page, entity.PageQuery{Status: &published}); err != nil {
+		return entity.Page{}, err
+	}
+	s.attachRendered(&page)
+	return page, nil
+}
+
+// ListAdminPages lists every page for roles that may list any post,
+// and only the actor's own drafts otherwise.
+func (s *pageService) ListAdminPages(ctx context.Context, actorUserID uint, actorRole string) ([]entity.Page, error) {
+	var query entity.PageQuery
+	canListAny, err := s.hasPermission(ctx, actorRole, core.PostPermissionListAnyPost)
+	if err != nil {
+		return nil, err
+	}
+	if !canListAny {
+		if actorUserID == 0 {
+			return nil, core.ErrPermission
+		}
+		if err := s.authorize(ctx, actorRole, core.PostPermissionListOwnDrafts); err != nil {
+			return nil, err
+		}
+		draft := entity.StatusDraft
+		query.AuthorID = &actorUserID
+		query.Status = &draft
+	}
+
+	pages, err := s.repo.List(ctx, query)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("page.list_admin", "list pages failed", err)
+	}
+	return pages, nil
+}
+
+// GetAdminPage returns one page the actor can manage, with its breadcrumbs and children.
+func (s *pageService) GetAdminPage(ctx context.Context, id uint, actorUserID uint, actorRole string) (entity.Page, error) {
+	page, err := s.loadPage(ctx, id, actorUserID, actorRole, core.PostPermissionReadAnyPost, core.PostPermissionReadOwnDraft)
+	if err != nil {
+		return entity.Page{}, err
+	}
+	ancestors, err := s.ancestors(ctx, page)
+	if err != nil {
+		return entity.Page{}, err
+	}
+	for _, a := range ancestors {
+		page.Breadcrumbs = append(page.Breadcrumbs, a.Ref())
+	}
+	if err := s.attachChildren(ctx, &page, entity.PageQuery{}); err != nil {
+		return entity.Page{}, err
+	}
+	return page, nil
+}
+
+// CreateAdminPage stores a new draft owned by the actor, placed after its siblings.
+// The slug is derived from the title when not provided and made unique among the siblings.
+func (s *pageService) CreateAdminPage(ctx context.Context, page entity.Page, actorUserID uint, actorRole string) (entity.Page, error) {
+	if actorUserID == 0 {
+		return entity.Page{}, core.ErrPermission
+	}
+	if err := s.authorize(ctx, actorRole, core.PostPermissionCreateOwnDraft); err != nil {
+		return entity.Page{}, err
+	}
+
+	page.Title = strings.TrimSpace(page.Title)
+	if page.Title == "" {
+		return entity.Page{}, fmt.Errorf("%w: title is required", core.ErrInvalidInput)
+	}
+	parent, err := s.resolveParent(ctx, page.ParentID)
+	if err != nil {
+		return entity.Page{}, err
+	}
+	if parent != nil && entity.PageDepth(parent.Path) >= entity.MaxPageDepth {
+		return entity.Page{}, fmt.Errorf("%w: pages cannot nest deeper than %d levels", core.ErrInvalidInput, entity.MaxPageDepth)
+	}
+
+	base := page.Title
+	if strings.TrimSpace(page.Slug) != "" {
+		base = page.Slug
+	}
+	page.ParentID = nil
+	parentPath := ""
+	if parent != nil {
+		page.ParentID = &parent.ID
+		parentPath = parent.Path
+	}
+	if page.Slug, err = s.uniquePageSlug(ctx, parentPath, slug.Make(base)); err != nil {
+		return entity.Page{}, err
+	}
+	page.Path = joinPagePath(parentPath, page.Slug)
+	if page.SortOrder, err = s.nextSortOrder(ctx, page.ParentID); err != nil {
+		return entity.Page{}, err
+	}
+	page.ID = 0
+	page.AuthorID = actorUserID
+	page.Status = entity.StatusDraft
+
+	created, err := s.repo.Create(ctx, page)
+	if err != nil {
+		return entity.Page{}, normalizeServiceErrorWithOpMsg("page.create", "create page failed", err)
+	}
+	s.syncMedia(ctx, created)
+	return created, nil
+}
+
+// UpdateAdminPage applies a patch to a page the actor can update. Renaming or moving a page
+// changes the paths of its whole subtree, so the new path must be free and the subtree must
+// stay within MaxPageDepth.
+func (s *pageService) UpdateAdminPage(ctx context.Context, id uint, patch entity.PagePatch, actorUserID uint, actorRole string) (entity.Page, error) {
+	page, err := s.loadPage(ctx, id, actorUserID, actorRole, core.PostPermissionUpdateAnyPost, core.PostPermissionUpdateOwnDraft)
+	if err != nil {
+		return entity.Page{}, err
+	}
+
+	if patch.Title != nil {
+		page.Title = strings.TrimSpace(*patch.Title)
+		if page.Title == "" {
+			return entity.Page{}, fmt.Errorf("%w: title is required", core.ErrInvalidInput)
+		}
+	}
+	if patch.Content != nil {
+		page.Content = *patch.Content
+	}
+	if patch.Cover != nil {
+		page.Cover = *patch.Cover
+	}
+	if patch.Slug != nil {
+		page.Slug = slug.Make(*patch.Slug)
+		if page.Slug == "" {
+			return entity.Page{}, fmt.Errorf("%w: slug cannot be empty", core.ErrInvalidInput)
+		}
+	}
+
+	parentPath := ""
+	if i := strings.LastIndexByte(page.Path, '/'); i >= 0 {
+		parentPath = page.Path[:i]
+	}
+	if patch.ParentID != nil && !sameParent(page.ParentID, *patch.ParentID) {
+		if *patch.ParentID == page.ID {
+			return entity.Page{}, fmt.Errorf("%w: a page cannot be its own parent", core.ErrInvalidInput)
+		}
+		parent, err := s.resolveParent(ctx, patch.ParentID)
+		if err != nil {
+			return entity.Page{}, err
+		}
+		page.ParentID, parentPath = nil, ""
+		if parent != nil {
+			if entity.IsPageDescendantPath(parent.Path, page.Path) {
+				return entity.Page{}, fmt.Errorf("%w: a page cannot be moved under its own descendant", core.ErrInvalidInput)
+			}
+			page.ParentID, parentPath = &parent.ID, parent.Path
+		}
+		if page.SortOrder, err = s.nextSortOrder(ctx, page.ParentID); err != nil {
+			return entity.Page{}, err
+		}
+	}
+
+	if newPath := joinPagePath(parentPath, page.Slug); newPath != page.Path {
+		if err := s.checkSubtreeMove(ctx, page.Path, newPath); err != nil {
+			return entity.Page{}, err
+		}
+		page.Path = newPath
+	}
+	page.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, page); err != nil {
+		if errors.Is(err, core.ErrDuplicate) {
+			return entity.Page{}, fmt.Errorf("%w: path %s is already in use", core.ErrDuplicate, page.Path)
+		}
+		return entity.Page{}, normalizeServiceErrorWithOpMsg("page.update", "update page failed", err)
+	}
+	s.syncMedia(ctx, page)
+	return page, nil
+}
+
+// PublishAdminPage performs the Draft -> Published transition. Children of an unpublished
+// page stay hidden until the page itself is published.
+func (s *pageService) PublishAdminPage(ctx context.Context, id uint, actorUserID uint, actorRole string) error {
+	return s.setStatus(ctx, id, actorUserID, actorRole, core.PostPermissionPublishPost, entity.StatusPublished)
+}
+
+// MovePageToDraft takes a published page, and with it its whole subtree, offline.
+func (s *pageService) MovePageToDraft(ctx context.Context, id uint, actorUserID uint, actorRole string) error {
+	return s.setStatus(ctx, id, actorUserID, actorRole, core.PostPermissionUnpublishPost, entity.StatusDraft)
+}
+
+func (s *pageService) setStatus(ctx context.Context, id uint, actorUserID uint, actorRole string, permission core.PostPermission, status int) error {
+	if err := s.authorize(ctx, actorRole, permission); err != nil {
+		return err
+	}
+	page, err := s.loadPage(ctx, id, actorUserID, actorRole, core.PostPermissionReadAnyPost, core.PostPermissionReadOwnDraft)
+	if err != nil {
+		return err
+	}
+	if page.Status == status {
+		if status == entity.StatusPublished {
+			return fmt.Errorf("%w: page is already published", core.ErrConflict)
+		}
+		return fmt.Errorf("%w: page is already draft", core.ErrConflict)
+	}
+
+	page.Status = status
+	page.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, page); err != nil {
+		return normalizeServiceErrorWithOpMsg("page.set_status", "persist page status failed", err)
+	}
+	return nil
+}
+
+// DeleteAdminPage permanently removes a page that has no children.
+func (s *pageService) DeleteAdminPage(ctx context.Context, id uint, actorUserID uint, actorRole string) error {
+	if err := s.authorize(ctx, actorRole, core.PostPermissionDeletePost); err != nil {
+		return err
+	}
+	page, err := s.loadPage(ctx, id, actorUserID, actorRole, core.PostPermissionReadAnyPost, core.PostPermissionReadOwnDraft)
+	if err != nil {
+		return err
+	}
+	children, err := s.repo.ListChildren(ctx, &page.ID, entity.PageQuery{})
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("page.delete.children", "list child pages failed", err)
+	}
+	if len(children) > 0 {
+		return ErrPageHasChildren
+	}
+	if err := s.repo.Delete(ctx, page.ID); err != nil {
+		return normalizeServiceErrorWithOpMsg("page.delete", "delete page failed", err)
+	}
+	return nil
+}
+
+// ReorderAdminPages sets the sibling order of the children of parentID.
+func (s *pageService) ReorderAdminPages(ctx context.Context, parentID *uint, ids []uint, actorRole string) error {
+	if err := s.authorize(ctx, actorRole, core.PostPermissionUpdateAnyPost); err != nil {
+		return err
+	}
+	if parentID != nil && *parentID == 0 {
+		parentID = nil
+	}
+	children, err := s.repo.ListChildren(ctx, parentID, entity.PageQuery{})
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("page.reorder.children", "list child pages failed", err)
+	}
+	if len(ids) != len(children) {
+		return fmt.Errorf("%w: page_ids must list all %d child pages", core.ErrInvalidInput, len(children))
+	}
+	seen := make(map[uint]struct{}, len(ids))
+	for _, id := range ids {
+		if _, dup := seen[id]; dup {
+			return fmt.Errorf("%w: page %d is listed twice", core.ErrInvalidInput, id)
+		}
+		seen[id] = struct{}{}
+		if !slices.ContainsFunc(children, func(p entity.Page) bool { return p.ID == id }) {
+			return fmt.Errorf("%w: page %d is not a child of the given parent", core.ErrInvalidInput, id)
+		}
+	}
+	if err := s.repo.Reorder(ctx, parentID, ids); err != nil {
+		return normalizeServiceErrorWithOpMsg("page.reorder", "reorder pages failed", err)
+	}
+	return nil
+}
+
+// loadPage resolves a page. With the any-scope permission every page is reachable;
+// otherwise the own-scope permission grants the actor's own drafts only, as for posts.
+func (s *pageService) loadPage(ctx context.Context, id uint, actorUserID uint, actorRole string, anyScope, ownScope core.PostPermission) (entity.Page, error) {
+	canAny, err := s.hasPermission(ctx, actorRole, anyScope)
+	if err != nil {
+		return entity.Page{}, err
+	}
+	if !canAny {
+		if actorUserID == 0 {
+			return entity.Page{}, core.ErrPermission
+		}
+		if err := s.authorize(ctx, actorRole, ownScope); err != nil {
+			return entity.Page{}, err
+		}
+	}
+
+	page, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return entity.Page{}, normalizeServiceErrorWithOpMsg("page.load", "load page failed", err)
+	}
+	if !canAny && (page.AuthorID != actorUserID || page.Status != entity.StatusDraft) {
+		return entity.Page{}, core.ErrNotFound
+	}
+	return page, nil
+}
+
+// resolveParent loads the requested parent page; nil or 0 means the top level.
+func (s *pageService) resolveParent(ctx context.Context, parentID *uint) (*entity.Page, error) {
+	if parentID == nil || *parentID == 0 {
+		return nil, nil
+	}
+	parent, err := s.repo.GetByID(ctx, *parentID)
+	if errors.Is(err, core.ErrNotFound) {
+		return nil, fmt.Errorf("%w: parent page %d does not exist", core.ErrInvalidInput, *parentID)
+	}
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("page.load_parent", "load parent page failed", err)
+	}
+	return &parent, nil
+}
+
+// ancestors returns the ancestors of page from the top level down.
+func (s *pageService) ancestors(ctx context.Context, page entity.Page) ([]entity.Page, error) {
+	paths := entity.PageAncestorPaths(page.Path)
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	found, err := s.repo.ListByPaths(ctx, paths)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("page.ancestors", "load page ancestors failed", err)
+	}
+	out := make([]entity.Page, 0, len(paths))
+	for _, path := range paths {
+		if i := slices.IndexFunc(found, func(p entity.Page) bool { return p.Path == path }); i >= 0 {
+			out = append(out, found[i])
+		}
+	}
+	return out, nil
+}
+
+func (s *pageService) attachChildren(ctx context.Context, page *entity.Page, query entity.PageQuery) error {
+	children, err := s.repo.ListChildren(ctx, &page.ID, query)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("page.children", "list child pages failed", err)
+	}
+	page.Children = make([]entity.PageRef, 0, len(children))
+	for _, c := range children {
+		page.Children = append(page.Children, c.Ref())
+	}
+	return nil
+}
+
+// checkSubtreeMove makes sure the subtree at oldPath can be moved to newPath.
+func (s *pageService) checkSubtreeMove(ctx context.Context, oldPath string, newPath string) error {
+	exists, err := s.repo.IsPathExists(ctx, newPath)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("page.check_path", "check page path uniqueness failed", err)
+	}
+	if exists {
+		return fmt.Errorf("%w: path %s is already in use", core.ErrDuplicate, newPath)
+	}
+
+	all, err := s.repo.List(ctx, entity.PageQuery{})
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("page.check_depth", "list pages failed", err)
+	}
+	deepest := entity.PageDepth(oldPath)
+	for _, p := range all {
+		if entity.IsPageDescendantPath(p.Path, oldPath) {
+			deepest = max(deepest, entity.PageDepth(p.Path))
+		}
+	}
+	if entity.PageDepth(newPath)+deepest-entity.PageDepth(oldPath) > entity.MaxPageDepth {
+		return fmt.Errorf("%w: pages cannot nest deeper than %d levels", core.ErrInvalidInput, entity.MaxPageDepth)
+	}
+	return nil
+}
+
+func (s *pageService) uniquePageSlug(ctx context.Context, parentPath string, base string) (string, error) {
+	if base == "" {
+		return "", fmt.Errorf("%w: cannot generate a valid slug", core.ErrInvalidInput)
+	}
+	candidate := base
+	for i := 1; i <= 100; i++ {
+		exists, err := s.repo.IsPathExists(ctx, joinPagePath(parentPath, candidate))
+		if err != nil {
+			return "", normalizeServiceErrorWithOpMsg("page.unique_slug", "check page path uniqueness failed", err)
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+	return "", fmt.Errorf("%w: unable to generate unique page slug", core.ErrConflict)
+}
+
+// nextSortOrder places a new or moved page after its future siblings.
+func (s *pageService) nextSortOrder(ctx context.Context, parentID *uint) (int, error) {
+	siblings, err := s.repo.ListChildren(ctx, parentID, entity.PageQuery{})
+	if err != nil {
+		return 0, normalizeServiceErrorWithOpMsg("page.sort_order", "list sibling pages failed", err)
+	}
+	next := 0
+	for _, p := range siblings {
+		next = max(next, p.SortOrder+1)
+	}
+	return next, nil
+}
+
+func (s *pageService) syncMedia(ctx context.Context, page entity.Page) {
+	if s.media == nil {
+		return
+	}
+	syncCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.media.SyncPageReferences(syncCtx, page.ID, page.Content, page.Cover); err != nil {
+		log.Printf("[WARN] Page saved (ID: %d) but failed to sync media references: %v", page.ID, err)
+	}
+}
+
+// attachRendered fills page.Rendered, reusing the cached result while UpdatedAt is unchanged.
+func (s *pageService) attachRendered(page *entity.Page) {
+	if s.renderer == nil {
+		return
+	}
+	if cached, ok := s.renderCache.get(page.ID, page.UpdatedAt); ok {
+		page.Rendered = &cached
+		return
+	}
+	rendered, err := s.renderer.Render(page.Content)
+	if err != nil {
+		log.Printf("[WARN] render page content failed (ID: %d): %v", page.ID, err)
+		return
+	}
+	s.renderCache.put(page.ID, page.UpdatedAt, rendered)
+	page.Rendered = &rendered
+}
+
+func (s *pageService) authorize(ctx context.Context, actorRole string, permission core.PostPermission) error {
+	if s.authorizer == nil {
+		return core.ErrPermission
+	}
+	if err := s.authorizer.AuthorizePostAction(ctx, actorRole, permission); err != nil {
+		return normalizeServiceErrorWithOpMsg("page.authorize", "authorize page action failed", err)
+	}
+	return nil
+}
+
+func (s *pageService) hasPermission(ctx context.Context, actorRole string, permission core.PostPermission) (bool, error) {
+	if err := s.authorize(ctx, actorRole, permission); err != nil {
+		if errors.Is(err, core.ErrPermission) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func joinPagePath(parentPath string, slugValue string) string {
+	if parentPath == "" {
+		return slugValue
+	}
+	return parentPath + "/" + slugValue
+}
+
+// sameParent reports whether requested (0 for the top level) names the current parent.
+func sameParent(current *uint, requested uint) bool {
+	if current == nil {
+		return requested == 0
+	}
+	return *current == requested
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// memPageRepo is an in-memory PageRepository that rewrites descendant paths like the
+// Postgres implementation does.
+type memPageRepo struct {
+	pages []entity.Page
+}
+
+func (r *memPageRepo) Create(ctx context.Context, p entity.Page) (entity.Page, error) {
+	if ok, _ := r.IsPathExists(ctx, p.Path); ok {
+		return entity.Page{}, core.ErrDuplicate
+	}
+	p.ID = uint(len(r.pages) + 1)
+	r.pages = append(r.pages, p)
+	return p, nil
+}
+
+func (r *memPageRepo) GetByID(ctx context.Context, id uint) (entity.Page, error) {
+	for _, p := range r.pages {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return entity.Page{}, core.ErrNotFound
+}
+
+func (r *memPageRepo) GetByPath(ctx context.Context, path string) (entity.Page, error) {
+	for _, p := range r.pages {
+		if p.Path == path {
+			return p, nil
+		}
+	}
+	return entity.Page{}, core.ErrNotFound
+}
+
+func (r *memPageRepo) ListByPaths(ctx context.Context, paths []string) ([]entity.Page, error) {
+	var out []entity.Page
+	for _, p := range r.pages {
+		if slices.Contains(paths, p.Path) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (r *memPageRepo) List(ctx context.Context, q entity.PageQuery) ([]entity.Page, error) {
+	var out []entity.Page
+	for _, p := range r.pages {
+		if (q.AuthorID == nil || p.AuthorID == *q.AuthorID) && (q.Status == nil || p.Status == *q.Status) {
+			out = append(out, p)
+		}
+	}
+	slices.SortStableFunc(out, func(a, b entity.Page) int { return a.SortOrder - b.SortOrder })
+	return out, nil
+}
+
+func (r *memPageRepo) ListChildren(ctx context.Context, parentID *uint, q entity.PageQuery) ([]entity.Page, error) {
+	all, _ := r.List(ctx, q)
+	var out []entity.Page
+	for _, p := range all {
+		if (parentID == nil && p.ParentID == nil) || (parentID != nil && p.ParentID != nil && *p.ParentID == *parentID) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (r *memPageRepo) IsPathExists(ctx context.Context, path string) (bool, error) {
+	_, err := r.GetByPath(ctx, path)
+	return err == nil, nil
+}
+
+func (r *memPageRepo) Update(ctx context.Context, page entity.Page) error {
+	i := slices.IndexFunc(r.pages, func(p entity.Page) bool { return p.ID == page.ID })
+	if i < 0 {
+		return core.ErrNotFound
+	}
+	oldPath := r.pages[i].Path
+	r.pages[i] = page
+	for j := range r.pages {
+		if strings.HasPrefix(r.pages[j].Path, oldPath+"/") {
+			r.pages[j].Path = page.Path + strings.TrimPrefix(r.pages[j].Path, oldPath)
+		}
+	}
+	return nil
+}
+
+func (r *memPageRepo) Reorder(ctx context.Context, parentID *uint, ids []uint) error {
+	for pos, id := range ids {
+		i := slices.IndexFunc(r.pages, func(p entity.Page) bool { return p.ID == id })
+		if i < 0 {
+			return core.ErrNotFound
+		}
+		r.pages[i].SortOrder = pos
+	}
+	return nil
+}
+
+func (r *memPageRepo) Delete(ctx context.Context, id uint) error {
+	i := slices.IndexFunc(r.pages, func(p entity.Page) bool { return p.ID == id })
+	if i < 0 {
+		return core.ErrNotFound
+	}
+	r.pages = slices.Delete(r.pages, i, i+1)
+	return nil
+}
+
+func (r *memPageRepo) path(id uint) string {
+	p, _ := r.GetByID(context.Background(), id)
+	return p.Path
+}
+
+// seedPages creates docs, docs/install and docs/install/linux as admin.
+func seedPages(t *testing.T, svc core.PageService) (docs, install, linux entity.Page) {
+	t.Helper()
+	ctx := context.Background()
+	var err error
+	if docs, err = svc.CreateAdminPage(ctx, entity.Page{Title: "Docs"}, 1, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if install, err = svc.CreateAdminPage(ctx, entity.Page{Title: "Install", ParentID: &docs.ID}, 1, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if linux, err = svc.CreateAdminPage(ctx, entity.Page{Title: "Linux", ParentID: &install.ID}, 1, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	return docs, install, linux
+}
+
+func TestPageService_CreateAdminPage(t *testing.T) {
+	ctx := context.Background()
+	repo := &memPageRepo{}
+	svc := NewPageService(repo, nil, nil, allowAll())
+	docs, _, linux := seedPages(t, svc)
+
+	if linux.Path != "docs/install/linux" || linux.Status != entity.StatusDraft || linux.AuthorID != 1 {
+		t.Fatalf("unexpected page: %+v", linux)
+	}
+	second, err := svc.CreateAdminPage(ctx, entity.Page{Title: "Install", ParentID: &docs.ID}, 1, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Path != "docs/install-1" || second.SortOrder != 1 {
+		t.Fatalf("want a unique slug after the first sibling, got %q at %d", second.Path, second.SortOrder)
+	}
+	// The same slug is fine under another parent.
+	top, err := svc.CreateAdminPage(ctx, entity.Page{Title: "Install"}, 1, "admin")
+	if err != nil || top.Path != "install" || top.ParentID != nil {
+		t.Fatalf("unexpected top-level page: %+v %v", top, err)
+	}
+
+	missing := uint(99)
+	if _, err := svc.CreateAdminPage(ctx, entity.Page{Title: "x", ParentID: &missing}, 1, "admin"); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("missing parent: want ErrInvalidInput, got %v", err)
+	}
+	if _, err := svc.CreateAdminPage(ctx, entity.Page{Title: "x"}, 0, "admin"); !errors.Is(err, core.ErrPermission) {
+		t.Fatalf("anonymous: want ErrPermission, got %v", err)
+	}
+}
+
+func TestPageService_CreateAdminPage_DepthLimit(t *testing.T) {
+	ctx := context.Background()
+	svc := NewPageService(&memPageRepo{}, nil, nil, allowAll())
+	var parent *uint
+	for i := 0; i < entity.MaxPageDepth; i++ {
+		p, err := svc.CreateAdminPage(ctx, entity.Page{Title: "level", ParentID: parent}, 1, "admin")
+		if err != nil {
+			t.Fatalf("level %d: %v", i+1, err)
+		}
+		parent = &p.ID
+	}
+	if _, err := svc.CreateAdminPage(ctx, entity.Page{Title: "too deep", ParentID: parent}, 1, "admin"); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("want ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestPageService_UpdateAdminPage_MoveSubtree(t *testing.T) {
+	ctx := context.Background()
+	repo := &memPageRepo{}
+	svc := NewPageService(repo, nil, nil, allowAll())
+	docs, install, linux := seedPages(t, svc)
+	guides, err := svc.CreateAdminPage(ctx, entity.Page{Title: "Guides"}, 1, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := svc.UpdateAdminPage(ctx, install.ID, entity.PagePatch{ParentID: &guides.ID}, 1, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved.Path != "guides/install" || repo.path(linux.ID) != "guides/install/linux" {
+		t.Fatalf("subtree not moved: %q %q", moved.Path, repo.path(linux.ID))
+	}
+
+	newSlug := "setup"
+	if _, err := svc.UpdateAdminPage(ctx, install.ID, entity.PagePatch{Slug: &newSlug}, 1, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if got := repo.path(linux.ID); got != "guides/setup/linux" {
+		t.Fatalf("rename not applied to descendants: %q", got)
+	}
+
+	top := uint(0)
+	if moved, err = svc.UpdateAdminPage(ctx, install.ID, entity.PagePatch{ParentID: &top}, 1, "admin"); err != nil || moved.Path != "setup" || moved.ParentID != nil {
+		t.Fatalf("move to top level: %+v %v", moved, err)
+	}
+
+	if _, err := svc.UpdateAdminPage(ctx, install.ID, entity.PagePatch{ParentID: &linux.ID}, 1, "admin"); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("move under descendant: want ErrInvalidInput, got %v", err)
+	}
+	if _, err := svc.UpdateAdminPage(ctx, install.ID, entity.PagePatch{ParentID: &install.ID}, 1, "admin"); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("move under itself: want ErrInvalidInput, got %v", err)
+	}
+	taken := "docs"
+	if _, err := svc.UpdateAdminPage(ctx, install.ID, entity.PagePatch{Slug: &taken}, 1, "admin"); !errors.Is(err, core.ErrDuplicate) {
+		t.Fatalf("path taken by %d: want ErrDuplicate, got %v", docs.ID, err)
+	}
+}
+
+func TestPageService_GetPublicPageByPath(t *testing.T) {
+	ctx := context.Background()
+	repo := &memPageRepo{}
+	svc := NewPageService(repo, nil, nil, allowAll())
+	docs, install, linux := seedPages(t, svc)
+	for _, id := range []uint{docs.ID, linux.ID} {
+		if err := svc.PublishAdminPage(ctx, id, 1, "admin"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// install is still a draft, so linux is hidden with it.
+	if _, err := svc.GetPublicPageByPath(ctx, "/docs/install/linux"); !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("want ErrNotFound under a draft ancestor, got %v", err)
+	}
+	public, err := svc.ListPublicPages(ctx)
+	if err != nil || len(public) != 1 || public[0].ID != docs.ID {
+		t.Fatalf("want only docs to be public, got %+v %v", public, err)
+	}
+
+	if err := svc.PublishAdminPage(ctx, install.ID, 1, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := svc.GetPublicPageByPath(ctx, "/docs/install/linux/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Breadcrumbs) != 2 || got.Breadcrumbs[0].Path != "docs" || got.Breadcrumbs[1].Path != "docs/install" {
+		t.Fatalf("unexpected breadcrumbs: %+v", got.Breadcrumbs)
+	}
+	got, err = svc.GetPublicPageByPath(ctx, "docs")
+	if err != nil || len(got.Children) != 1 || got.Children[0].ID != install.ID {
+		t.Fatalf("unexpected children: %+v %v", got.Children, err)
+	}
+	if _, err := svc.GetPublicPageByPath(ctx, "docs//install"); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("want ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestPageService_DeleteAdminPage_HasChildren(t *testing.T) {
+	ctx := context.Background()
+	repo := &memPageRepo{}
+	svc := NewPageService(repo, nil, nil, allowAll())
+	docs, install, linux := seedPages(t, svc)
+
+	if err := svc.DeleteAdminPage(ctx, docs.ID, 1, "admin"); !errors.Is(err, ErrPageHasChildren) || !errors.Is(err, core.ErrConflict) {
+		t.Fatalf("want ErrPageHasChildren, got %v", err)
+	}
+	for _, id := range []uint{linux.ID, install.ID, docs.ID} {
+		if err := svc.DeleteAdminPage(ctx, id, 1, "admin"); err != nil {
+			t.Fatalf("delete %d: %v", id, err)
+		}
+	}
+	if len(repo.pages) != 0 {
+		t.Fatalf("pages left: %+v", repo.pages)
+	}
+}
+
+func TestPageService_ReorderAdminPages(t *testing.T) {
+	ctx := context.Background()
+	repo := &memPageRepo{}
+	svc := NewPageService(repo, nil, nil, allowAll())
+	var ids []uint
+	for _, title := range []string{"About", "Contact", "Docs"} {
+		p, err := svc.CreateAdminPage(ctx, entity.Page{Title: title}, 1, "admin")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, p.ID)
+	}
+
+	order := []uint{ids[2], ids[0], ids[1]}
+	if err := svc.ReorderAdminPages(ctx, nil, order, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	top, _ := repo.ListChildren(ctx, nil, entity.PageQuery{})
+	var got []uint
+	for _, p := range top {
+		got = append(got, p.ID)
+	}
+	if !slices.Equal(got, order) {
+		t.Fatalf("want order %v, got %v", order, got)
+	}
+
+	if err := svc.ReorderAdminPages(ctx, nil, ids[:2], "admin"); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("partial order: want ErrInvalidInput, got %v", err)
+	}
+	if err := svc.ReorderAdminPages(ctx, nil, []uint{ids[0], ids[0], ids[1]}, "admin"); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("duplicate id: want ErrInvalidInput, got %v", err)
+	}
+	editor := &fakeAuthorizer{allow: map[core.PostPermission]bool{core.PostPermissionUpdateOwnDraft: true}}
+	if err := NewPageService(repo, nil, nil, editor).ReorderAdminPages(ctx, nil, order, "user"); !errors.Is(err, core.ErrPermission) {
+		t.Fatalf("want ErrPermission, got %v", err)
+	}
+}
+
+func TestPageService_OwnDraftScope(t *testing.T) {
+	ctx := context.Background()
+	repo := &memPageRepo{}
+	admin := NewPageService(repo, nil, nil, allowAll())
+	docs, _, _ := seedPages(t, admin)
+
+	author := &fakeAuthorizer{allow: map[core.PostPermission]bool{
+		core.PostPermissionCreateOwnDraft: true,
+		core.PostPermissionListOwnDrafts:  true,
+		core.PostPermissionReadOwnDraft:   true,
+		core.PostPermissionUpdateOwnDraft: true,
+	}}
+	svc := NewPageService(repo, nil, nil, author)
+	own, err := svc.CreateAdminPage(ctx, entity.Page{Title: "Notes", ParentID: &docs.ID}, 7, "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listed, err := svc.ListAdminPages(ctx, 7, "user")
+	if err != nil || len(listed) != 1 || listed[0].ID != own.ID {
+		t.Fatalf("want only the own draft, got %+v %v", listed, err)
+	}
+	if _, err := svc.GetAdminPage(ctx, docs.ID, 7, "user"); !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("other author's page: want ErrNotFound, got %v", err)
+	}
+	got, err := svc.GetAdminPage(ctx, own.ID, 7, "user")
+	if err != nil || len(got.Breadcrumbs) != 1 || got.Breadcrumbs[0].ID != docs.ID {
+		t.Fatalf("unexpected own page: %+v %v", got, err)
+	}
+	if err := svc.PublishAdminPage(ctx, own.ID, 7, "user"); !errors.Is(err, core.ErrPermission) {
+		t.Fatalf("publish: want ErrPermission, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+var errAutosaveDisabled = fmt.Errorf("%w: post autosave is not enabled", core.ErrNotFound)
+
+// SaveAdminPostAutosave stores the actor's unsaved title and content for a post they can edit.
+// The post row, its UpdatedAt and its media references are left untouched, so editors can call
+// this as often as they like. A zero BaseVersion records the post's current version.
+func (s *PostService) SaveAdminPostAutosave(ctx context.Context, id uint, autosave entity.PostAutosave, actorUserID uint, actorRole string) (entity.PostAutosave, error) {
+	post, err := s.loadUpdatablePost(ctx, id, actorUserID, actorRole)
+	if err != nil {
+		return entity.PostAutosave{}, err
+	}
+	if s.autosaves == nil {
+		return entity.PostAutosave{}, errAutosaveDisabled
+	}
+	if autosave.BaseVersion == 0 {
+		autosave.BaseVersion = post.Version
+	}
+	autosave.PostID = id
+	autosave.UserID = actorUserID
+
+	saved, err := s.autosaves.Save(ctx, autosave)
+	if err != nil {
+		return entity.PostAutosave{}, normalizeServiceErrorWithOpMsg("post.autosave.save", "save post autosave failed", err)
+	}
+	return saved, nil
+}
+
+// GetAdminPostAutosave returns the actor's latest autosave for a post they can manage.
+func (s *PostService) GetAdminPostAutosave(ctx context.Context, id uint, actorUserID uint, actorRole string) (entity.PostAutosave, error) {
+	if _, err := s.loadManageablePost(ctx, id, actorUserID, actorRole); err != nil {
+		return entity.PostAutosave{}, err
+	}
+	return s.loadAutosave(ctx, id, actorUserID)
+}
+
+// PromoteAdminPostAutosave applies the actor's autosave as a regular update and then drops it.
+// It fails with a version conflict when the post changed after the editor started from
+// BaseVersion, so an autosave can never silently overwrite someone else's save.
+func (s *PostService) PromoteAdminPostAutosave(ctx context.Context, id uint, ifVersion uint, actorUserID uint, actorRole string) error {
+	post, err := s.loadUpdatablePost(ctx, id, actorUserID, actorRole)
+	if err != nil {
+		return err
+	}
+	autosave, err := s.loadAutosave(ctx, id, actorUserID)
+	if err != nil {
+		return err
+	}
+	if err := checkPostVersion(post, ifVersion); err != nil {
+		return err
+	}
+	if err := checkPostVersion(post, autosave.BaseVersion); err != nil {
+		return err
+	}
+
+	patch := entity.PostPatch{Title: &autosave.Title, Content: &autosave.Content}
+	if err := s.applyPostPatch(ctx, post, patch, actorUserID, entity.RevisionActionUpdate, nil); err != nil {
+		return err
+	}
+	s.clearAutosave(ctx, id, actorUserID)
+	return nil
+}
+
+// DiscardAdminPostAutosave drops the actor's autosave for a post they can manage.
+func (s *PostService) DiscardAdminPostAutosave(ctx context.Context, id uint, actorUserID uint, actorRole string) error {
+	if _, err := s.loadManageablePost(ctx, id, actorUserID, actorRole); err != nil {
+		return err
+	}
+	if s.autosaves == nil {
+		return nil
+	}
+	if err := s.autosaves.Delete(ctx, id, actorUserID); err != nil {
+		return normalizeServiceErrorWithOpMsg("post.autosave.discard", "discard post autosave failed", err)
+	}
+	return nil
+}
+
+// PurgeStaleAutosaves deletes autosaves that have not been written since cutoff.
+// It is meant to be called periodically by a background job.
+func (s *PostService) PurgeStaleAutosaves(ctx context.Context, cutoff time.Time) error {
+	if s.autosaves == nil {
+		return nil
+	}
+	if _, err := s.autosaves.DeleteOlderThan(ctx, cutoff); err != nil {
+		return normalizeServiceErrorWithOpMsg("post.autosave.purge_stale", "purge stale post autosaves failed", err)
+	}
+	return nil
+}
+
+func (s *PostService) loadAutosave(ctx context.Context, id uint, actorUserID uint) (entity.PostAutosave, error) {
+	if s.autosaves == nil {
+		return entity.PostAutosave{}, errAutosaveDisabled
+	}
+	autosave, err := s.autosaves.Get(ctx, id, actorUserID)
+	if err != nil {
+		return entity.PostAutosave{}, normalizeServiceErrorWithOpMsg("post.autosave.get", "load post autosave failed", err)
+	}
+	return autosave, nil
+}
+
+// clearAutosave drops the actor's autosave after a save made it obsolete. Failures are logged
+// rather than returned because the save itself has already been committed.
+func (s *PostService) clearAutosave(ctx context.Context, id uint, actorUserID uint) {
+	if s.autosaves == nil {
+		return
+	}
+	if err := s.autosaves.Delete(ctx, id, actorUserID); err != nil {
+		log.Printf("[WARN] Post updated (ID: %d) but failed to clear autosave: %v", id, err)
+	}
+}
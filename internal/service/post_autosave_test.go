@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// memAutosaveRepo is an in-memory core.PostAutosaveRepository keyed by (post, user).
+type memAutosaveRepo struct {
+	rows map[[2]uint]entity.PostAutosave
+}
+
+func newMemAutosaveRepo() *memAutosaveRepo {
+	return &memAutosaveRepo{rows: map[[2]uint]entity.PostAutosave{}}
+}
+
+func (m *memAutosaveRepo) Save(ctx context.Context, a entity.PostAutosave) (entity.PostAutosave, error) {
+	a.UpdatedAt = time.Now()
+	m.rows[[2]uint{a.PostID, a.UserID}] = a
+	return a, nil
+}
+
+func (m *memAutosaveRepo) Get(ctx context.Context, postID uint, userID uint) (entity.PostAutosave, error) {
+	a, ok := m.rows[[2]uint{postID, userID}]
+	if !ok {
+		return entity.PostAutosave{}, core.ErrNotFound
+	}
+	return a, nil
+}
+
+func (m *memAutosaveRepo) Delete(ctx context.Context, postID uint, userID uint) error {
+	delete(m.rows, [2]uint{postID, userID})
+	return nil
+}
+
+func (m *memAutosaveRepo) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	var n int64
+	for k, a := range m.rows {
+		if a.UpdatedAt.Before(cutoff) {
+			delete(m.rows, k)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func TestPostService_SaveAdminPostAutosave_LeavesPostUntouched(t *testing.T) {
+	ctx := context.Background()
+	// updateFn is nil: any write to the post row would panic.
+	repo := &fakePostRepo{getByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+		return entity.Post{ID: id, Title: "t", AuthorID: 9, Version: 4}, nil
+	}}
+	autosaves := newMemAutosaveRepo()
+	svc := NewPostService(repo, allowAll())
+	svc.SetAutosaveRepository(autosaves)
+
+	saved, err := svc.SaveAdminPostAutosave(ctx, 1, entity.PostAutosave{Title: "t", Content: "draft"}, 9, "admin")
+	if err != nil {
+		t.Fatalf("SaveAdminPostAutosave: %v", err)
+	}
+	if saved.PostID != 1 || saved.UserID != 9 || saved.BaseVersion != 4 {
+		t.Fatalf("saved: %+v", saved)
+	}
+
+	got, err := svc.GetAdminPostAutosave(ctx, 1, 9, "admin")
+	if err != nil || got.Content != "draft" {
+		t.Fatalf("GetAdminPostAutosave: %+v %v", got, err)
+	}
+	if _, err := svc.GetAdminPostAutosave(ctx, 1, 10, "admin"); !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("another user's autosave: want ErrNotFound, got %v", err)
+	}
+}
+
+func TestPostService_SaveAdminPostAutosave_PendingReview(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "t", AuthorID: 5, Status: entity.StatusPendingReview}
+	svc := NewPostService(ownDraftRepo(post), authorScope())
+	svc.SetAutosaveRepository(newMemAutosaveRepo())
+
+	_, err := svc.SaveAdminPostAutosave(ctx, 1, entity.PostAutosave{Content: "x"}, 5, "user")
+	if !errors.Is(err, ErrPostUnderReview) {
+		t.Fatalf("want ErrPostUnderReview, got %v", err)
+	}
+}
+
+func TestPostService_PromoteAdminPostAutosave(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "old", Slug: "old", Content: "a", AuthorID: 9, Version: 2}
+	autosaves := newMemAutosaveRepo()
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+	svc.SetAutosaveRepository(autosaves)
+
+	if _, err := svc.SaveAdminPostAutosave(ctx, 1, entity.PostAutosave{Title: "new", Content: "b"}, 9, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.PromoteAdminPostAutosave(ctx, 1, 0, 9, "admin"); err != nil {
+		t.Fatalf("PromoteAdminPostAutosave: %v", err)
+	}
+	if post.Title != "new" || post.Content != "b" {
+		t.Fatalf("autosave not applied: %+v", post)
+	}
+	if len(autosaves.rows) != 0 {
+		t.Fatal("promoted autosave should be removed")
+	}
+}
+
+func TestPostService_PromoteAdminPostAutosave_StaleBase(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "old", Slug: "old", Content: "a", AuthorID: 9, Version: 3}
+	autosaves := newMemAutosaveRepo()
+	autosaves.rows[[2]uint{1, 9}] = entity.PostAutosave{PostID: 1, UserID: 9, Title: "new", Content: "b", BaseVersion: 2}
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+	svc.SetAutosaveRepository(autosaves)
+
+	err := svc.PromoteAdminPostAutosave(ctx, 1, 0, 9, "admin")
+	var conflict *PostVersionConflictError
+	if !errors.As(err, &conflict) || conflict.CurrentVersion != 3 {
+		t.Fatalf("want PostVersionConflictError{3}, got %v", err)
+	}
+	if post.Title != "old" || len(autosaves.rows) != 1 {
+		t.Fatal("a stale autosave must neither be applied nor dropped")
+	}
+}
+
+func TestPostService_UpdateAdminPost_ClearsAutosave(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "old", Slug: "old", AuthorID: 9}
+	autosaves := newMemAutosaveRepo()
+	autosaves.rows[[2]uint{1, 9}] = entity.PostAutosave{PostID: 1, UserID: 9, Content: "stale"}
+	autosaves.rows[[2]uint{1, 10}] = entity.PostAutosave{PostID: 1, UserID: 10, Content: "other editor"}
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+	svc.SetAutosaveRepository(autosaves)
+
+	title := "new"
+	if err := svc.UpdateAdminPost(ctx, 1, entity.PostPatch{Title: &title}, 0, 9, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := autosaves.rows[[2]uint{1, 9}]; ok {
+		t.Fatal("saving should drop the actor's autosave")
+	}
+	if _, ok := autosaves.rows[[2]uint{1, 10}]; !ok {
+		t.Fatal("other users' autosaves must be kept")
+	}
+}
+
+func TestPostService_PurgeStaleAutosaves(t *testing.T) {
+	ctx := context.Background()
+	autosaves := newMemAutosaveRepo()
+	autosaves.rows[[2]uint{1, 9}] = entity.PostAutosave{PostID: 1, UserID: 9, UpdatedAt: time.Now().AddDate(0, 0, -10)}
+	autosaves.rows[[2]uint{2, 9}] = entity.PostAutosave{PostID: 2, UserID: 9, UpdatedAt: time.Now()}
+	svc := NewPostService(&fakePostRepo{}, allowAll())
+	svc.SetAutosaveRepository(autosaves)
+
+	if err := svc.PurgeStaleAutosaves(ctx, time.Now().AddDate(0, 0, -7)); err != nil {
+		t.Fatal(err)
+	}
+	if len(autosaves.rows) != 1 {
+		t.Fatalf("remaining autosaves: %v", autosaves.rows)
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+var (
+	errBulkNoTransactions = fmt.Errorf("%w: atomic bulk actions are not enabled", core.ErrInvalidInput)
+	// errBulkAborted stops an atomic run at its first failed item so the transaction rolls back.
+	errBulkAborted = errors.New("bulk action aborted")
+)
+
+// SetTransactor makes every post write commit together with its revision, and enables atomic
+// bulk actions.
+func (s *PostService) SetTransactor(tx core.Transactor) {
+	s.tx = tx
+}
+
+// BulkAdminPosts applies one action to many posts, each through the same permission checks
+// as the single-post endpoints: publish, unpublish and delete load the post with
+// loadManageablePost, retag and set_category with loadUpdatablePost. A post listed in
+// req.Versions must still be at that version. Duplicate IDs are applied once. Per-item failures are reported in the result; the returned error is only
+// set for an invalid request or when the run itself fails.
+func (s *PostService) BulkAdminPosts(ctx context.Context, req entity.PostBulkRequest, actorUserID uint, actorRole string) (entity.PostBulkResult, error) {
+	if err := req.Validate(); err != nil {
+		return entity.PostBulkResult{}, fmt.Errorf("%w: %v", core.ErrInvalidInput, err)
+	}
+	if req.Atomic && s.tx == nil {
+		return entity.PostBulkResult{}, errBulkNoTransactions
+	}
+
+	ids := make([]uint, 0, len(req.PostIDs))
+	seen := make(map[uint]struct{}, len(req.PostIDs))
+	for _, id := range req.PostIDs {
+		if _, dup := seen[id]; !dup {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	result := entity.PostBulkResult{Action: req.Action, Atomic: req.Atomic, Items: make([]entity.PostBulkItemResult, len(ids))}
+	run := func(ctx context.Context) error {
+		for i, id := range ids {
+			if err := s.applyBulkAction(ctx, req, id, actorUserID, actorRole); err != nil {
+				result.Items[i] = entity.PostBulkItemResult{PostID: id, Outcome: entity.PostBulkFailed, Err: err}
+				if req.Atomic {
+					for j := i + 1; j < len(ids); j++ {
+						result.Items[j] = entity.PostBulkItemResult{PostID: ids[j], Outcome: entity.PostBulkSkipped}
+					}
+					return errBulkAborted
+				}
+				continue
+			}
+			result.Items[i] = entity.PostBulkItemResult{PostID: id, Outcome: entity.PostBulkApplied}
+		}
+		return nil
+	}
+
+	if !req.Atomic {
+		_ = run(ctx)
+		return result, nil
+	}
+	if err := s.withinTx(ctx, run); err != nil {
+		result.RolledBack = true
+		for i := range result.Items {
+			if result.Items[i].Outcome == entity.PostBulkApplied {
+				result.Items[i].Outcome = entity.PostBulkRolledBack
+			}
+		}
+		if !errors.Is(err, errBulkAborted) {
+			return result, normalizeServiceErrorWithOpMsg("post.bulk", "commit bulk action failed", err)
+		}
+	}
+	return result, nil
+}
+
+func (s *PostService) applyBulkAction(ctx context.Context, req entity.PostBulkRequest, id uint, actorUserID uint, actorRole string) error {
+	ifVersion := req.Versions[id]
+	switch req.Action {
+	case entity.PostBulkPublish:
+		return s.PublishAdminPost(ctx, id, ifVersion, actorUserID, actorRole)
+	case entity.PostBulkUnpublish:
+		return s.MovePostToDraft(ctx, id, ifVersion, actorUserID, actorRole)
+	case entity.PostBulkDelete:
+		return s.DeleteAdminPost(ctx, id, ifVersion, actorUserID, actorRole)
+	case entity.PostBulkRetag:
+		return s.bulkPatch(ctx, id, ifVersion, entity.PostPatch{Tags: req.Tags}, actorUserID, actorRole)
+	case entity.PostBulkSetCategory:
+		return s.bulkPatch(ctx, id, ifVersion, entity.PostPatch{CategoryID: req.CategoryID}, actorUserID, actorRole)
+	default:
+		return fmt.Errorf("%w: unknown bulk action %q", core.ErrInvalidInput, req.Action)
+	}
+}
+
+// bulkPatch updates one post like UpdateAdminPost does, but leaves the actor's autosave
+// alone: a bulk retag is not an edit of the post body.
+func (s *PostService) bulkPatch(ctx context.Context, id uint, ifVersion uint, patch entity.PostPatch, actorUserID uint, actorRole string) error {
+	post, err := s.loadUpdatablePost(ctx, id, actorUserID, actorRole)
+	if err != nil {
+		return err
+	}
+	if err := checkPostVersion(post, ifVersion); err != nil {
+		return err
+	}
+	return s.applyPostPatch(ctx, post, patch, actorUserID, entity.RevisionActionUpdate, nil)
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// fakeTransactor runs fn directly and counts how runs ended; rolling back is left to the
+// test, which inspects the reported outcomes.
+type fakeTransactor struct {
+	commits, rollbacks int
+}
+
+func (f *fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		f.rollbacks++
+		return err
+	}
+	f.commits++
+	return nil
+}
+
+// bulkPostRepo serves drafts 1-3, a published post 4, and nothing else.
+func bulkPostRepo(updated *[]uint) *fakePostRepo {
+	return &fakePostRepo{
+		getByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+			switch {
+			case id == 4:
+				return entity.Post{ID: id, Title: "live", Status: entity.StatusPublished}, nil
+			case id >= 1 && id <= 3:
+				return entity.Post{ID: id, Title: "draft", Status: entity.StatusDraft}, nil
+			}
+			return entity.Post{}, core.ErrNotFound
+		},
+		updateFn: func(ctx context.Context, p entity.Post) error {
+			*updated = append(*updated, p.ID)
+			return nil
+		},
+	}
+}
+
+func TestPostService_BulkAdminPosts(t *testing.T) {
+	ctx := context.Background()
+	var updated []uint
+	svc := NewPostService(bulkPostRepo(&updated), allowAll())
+
+	result, err := svc.BulkAdminPosts(ctx, entity.PostBulkRequest{Action: entity.PostBulkPublish, PostIDs: []uint{1, 4, 9, 2, 1}}, 9, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		id      uint
+		outcome string
+		err     error
+	}{
+		{1, entity.PostBulkApplied, nil},
+		{4, entity.PostBulkFailed, core.ErrConflict},
+		{9, entity.PostBulkFailed, core.ErrNotFound},
+		{2, entity.PostBulkApplied, nil},
+	}
+	if len(result.Items) != len(want) {
+		t.Fatalf("want %d items, got %+v", len(want), result.Items)
+	}
+	for i, w := range want {
+		item := result.Items[i]
+		if item.PostID != w.id || item.Outcome != w.outcome || (w.err != nil && !errors.Is(item.Err, w.err)) {
+			t.Fatalf("item %d: %+v, want %+v", i, item, w)
+		}
+	}
+	if len(updated) != 2 {
+		t.Fatalf("want 2 posts updated, got %v", updated)
+	}
+
+	// Every item goes through the authorizer: without publish rights nothing is touched.
+	updated = nil
+	author := NewPostService(bulkPostRepo(&updated), &fakeAuthorizer{allow: map[core.PostPermission]bool{core.PostPermissionReadOwnDraft: true}})
+	result, err = author.BulkAdminPosts(ctx, entity.PostBulkRequest{Action: entity.PostBulkPublish, PostIDs: []uint{1}}, 5, "user")
+	if err != nil || !errors.Is(result.Items[0].Err, core.ErrPermission) || len(updated) != 0 {
+		t.Fatalf("unexpected result: %+v %v %v", result.Items, err, updated)
+	}
+}
+
+func TestPostService_BulkAdminPosts_Atomic(t *testing.T) {
+	ctx := context.Background()
+	var updated []uint
+	svc := NewPostService(bulkPostRepo(&updated), allowAll())
+	req := entity.PostBulkRequest{Action: entity.PostBulkUnpublish, PostIDs: []uint{4, 1, 2}, Atomic: true}
+
+	if _, err := svc.BulkAdminPosts(ctx, req, 9, "admin"); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("atomic without transactor: want ErrInvalidInput, got %v", err)
+	}
+
+	tx := &fakeTransactor{}
+	svc.SetTransactor(tx)
+	result, err := svc.BulkAdminPosts(ctx, req, 9, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Post 1 is already a draft, so unpublishing it fails and the whole run is rolled back.
+	outcomes := []string{entity.PostBulkRolledBack, entity.PostBulkFailed, entity.PostBulkSkipped}
+	for i, want := range outcomes {
+		if result.Items[i].Outcome != want {
+			t.Fatalf("item %d: outcome %q, want %q", i, result.Items[i].Outcome, want)
+		}
+	}
+	if !result.RolledBack || tx.rollbacks != 1 || tx.commits != 0 {
+		t.Fatalf("want one rollback, got %+v commits=%d rollbacks=%d", result, tx.commits, tx.rollbacks)
+	}
+
+	result, err = svc.BulkAdminPosts(ctx, entity.PostBulkRequest{Action: entity.PostBulkUnpublish, PostIDs: []uint{4}, Atomic: true}, 9, "admin")
+	if err != nil || result.RolledBack || result.Items[0].Outcome != entity.PostBulkApplied || tx.commits != 1 {
+		t.Fatalf("unexpected result: %+v %v", result, err)
+	}
+}
+
+func TestPostService_BulkAdminPosts_SetCategory(t *testing.T) {
+	ctx := context.Background()
+	var updated []uint
+	var category *uint
+	repo := bulkPostRepo(&updated)
+	update := repo.updateFn
+	repo.updateFn = func(ctx context.Context, p entity.Post) error {
+		category = p.CategoryID
+		return update(ctx, p)
+	}
+	svc := NewPostService(repo, allowAll())
+
+	if _, err := svc.BulkAdminPosts(ctx, entity.PostBulkRequest{Action: entity.PostBulkSetCategory, PostIDs: []uint{1}}, 9, "admin"); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("missing category: want ErrInvalidInput, got %v", err)
+	}
+	cat := uint(6)
+	result, err := svc.BulkAdminPosts(ctx, entity.PostBulkRequest{Action: entity.PostBulkSetCategory, PostIDs: []uint{3}, CategoryID: &cat}, 9, "admin")
+	if err != nil || result.Items[0].Outcome != entity.PostBulkApplied || category == nil || *category != 6 {
+		t.Fatalf("unexpected result: %+v %v category=%v", result, err, category)
+	}
+}
+
+func TestPostService_BulkAdminPosts_Versions(t *testing.T) {
+	ctx := context.Background()
+	var updated []uint
+	repo := bulkPostRepo(&updated)
+	get := repo.getByIDFn
+	repo.getByIDFn = func(ctx context.Context, id uint) (entity.Post, error) {
+		post, err := get(ctx, id)
+		post.Version = 2
+		return post, err
+	}
+	svc := NewPostService(repo, allowAll())
+
+	cat := uint(6)
+	for _, action := range []entity.PostBulkAction{entity.PostBulkPublish, entity.PostBulkSetCategory} {
+		updated = nil
+		req := entity.PostBulkRequest{Action: action, PostIDs: []uint{1, 2, 3}, CategoryID: &cat, Versions: map[uint]uint{1: 2, 2: 1}}
+		result, err := svc.BulkAdminPosts(ctx, req, 9, "admin")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Post 2 changed since the caller read it; post 3 carries no version and is written anyway.
+		var conflict *PostVersionConflictError
+		if result.Items[0].Outcome != entity.PostBulkApplied || result.Items[2].Outcome != entity.PostBulkApplied ||
+			!errors.As(result.Items[1].Err, &conflict) || conflict.CurrentVersion != 2 {
+			t.Fatalf("%s: unexpected result: %+v", action, result.Items)
+		}
+		if len(updated) != 2 {
+			t.Fatalf("%s: want posts 1 and 3 updated, got %v", action, updated)
+		}
+	}
+}
+
+type failingRefsMediaRepo struct{ fakeMediaRepoNoOp }
+
+func (failingRefsMediaRepo) UpsertPostReferences(ctx context.Context, postID uint, purpose string, assetIDs []uint) error {
+	return errors.New("db down")
+}
+
+func TestPostService_BulkAdminPosts_AtomicMediaSyncFailureRollsBack(t *testing.T) {
+	ctx := context.Background()
+	var updated []uint
+	svc := NewPostServiceWithMedia(bulkPostRepo(&updated), NewMediaService(failingRefsMediaRepo{}, MediaConfig{}), allowAll())
+	tx := &fakeTransactor{}
+	svc.SetTransactor(tx)
+
+	cat := uint(6)
+	result, err := svc.BulkAdminPosts(ctx, entity.PostBulkRequest{Action: entity.PostBulkSetCategory, PostIDs: []uint{1, 2}, CategoryID: &cat, Atomic: true}, 9, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.RolledBack || result.Items[0].Outcome != entity.PostBulkFailed || tx.rollbacks != 1 {
+		t.Fatalf("media sync failure did not roll back: %+v", result)
+	}
+}
+
+// invalidationCheckingTransactor records whether the related cache was cleared before fn's
+// transaction ended.
+type invalidationCheckingTransactor struct {
+	cache        *relatedCache
+	clearedEarly bool
+}
+
+func (f *invalidationCheckingTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	before := f.cache.generation()
+	err := fn(ctx)
+	f.clearedEarly = f.cache.generation() != before
+	return err
+}
+
+func TestPostService_BulkAdminPosts_AtomicInvalidatesAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	var updated []uint
+	svc := NewPostService(bulkPostRepo(&updated), allowAll())
+	svc.SetRelatedPostsConfig(RelatedPostsConfig{})
+	tx := &invalidationCheckingTransactor{cache: svc.relatedCache}
+	svc.SetTransactor(tx)
+
+	before := svc.relatedCache.generation()
+	result, err := svc.BulkAdminPosts(ctx, entity.PostBulkRequest{Action: entity.PostBulkPublish, PostIDs: []uint{1, 2}, Atomic: true}, 9, "admin")
+	if err != nil || result.RolledBack {
+		t.Fatalf("unexpected result: %+v %v", result, err)
+	}
+	if tx.clearedEarly {
+		t.Fatal("related cache cleared inside the transaction")
+	}
+	if svc.relatedCache.generation() == before {
+		t.Fatal("related cache not cleared after commit")
+	}
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+var (
+	errPreviewDisabled = fmt.Errorf("%w: post previews are not enabled", core.ErrNotFound)
+	errPreviewInvalid  = fmt.Errorf("%w: preview link is invalid, expired or revoked", core.ErrPermission)
+)
+
+// SetPreviewTokens enables shareable preview links. Links are signed by signer and can be
+// revoked through the records kept in tokens.
+func (s *PostService) SetPreviewTokens(tokens core.PostPreviewTokenRepository, signer core.PreviewTokenSigner) {
+	s.previews = tokens
+	s.previewSigner = signer
+}
+
+// CreatePreviewLink mints a preview link valid for ttl for a post the actor can manage.
+// A zero ttl uses DefaultPreviewTTL. The returned token is the only copy of the link secret.
+func (s *PostService) CreatePreviewLink(ctx context.Context, postID uint, ttl time.Duration, actorUserID uint, actorRole string) (entity.PostPreviewToken, string, error) {
+	if _, err := s.loadManageablePost(ctx, postID, actorUserID, actorRole); err != nil {
+		return entity.PostPreviewToken{}, "", err
+	}
+	if s.previews == nil || s.previewSigner == nil {
+		return entity.PostPreviewToken{}, "", errPreviewDisabled
+	}
+	if ttl == 0 {
+		ttl = entity.DefaultPreviewTTL
+	}
+	if ttl < 0 || ttl > entity.MaxPreviewTTL {
+		return entity.PostPreviewToken{}, "", fmt.Errorf("%w: preview lifetime must be at most %s", core.ErrInvalidInput, entity.MaxPreviewTTL)
+	}
+
+	record, err := s.previews.Create(ctx, entity.PostPreviewToken{
+		PostID:    postID,
+		CreatedBy: actorUserID,
+		ExpiresAt: time.Now().Add(ttl).Truncate(time.Second),
+	})
+	if err != nil {
+		return entity.PostPreviewToken{}, "", normalizeServiceErrorWithOpMsg("post.preview.create", "create preview token failed", err)
+	}
+	token, err := s.previewSigner.SignPreview(entity.PreviewGrant{TokenID: record.ID, PostID: postID, ExpiresAt: record.ExpiresAt})
+	if err != nil {
+		return entity.PostPreviewToken{}, "", normalizeServiceErrorWithOpMsg("post.preview.sign", "sign preview token failed", err)
+	}
+	return record, token, nil
+}
+
+// ListPreviewLinks returns every preview link minted for a post the actor can manage,
+// including expired and revoked ones. Link secrets are not recoverable.
+func (s *PostService) ListPreviewLinks(ctx context.Context, postID uint, actorUserID uint, actorRole string) ([]entity.PostPreviewToken, error) {
+	if _, err := s.loadManageablePost(ctx, postID, actorUserID, actorRole); err != nil {
+		return nil, err
+	}
+	if s.previews == nil {
+		return nil, errPreviewDisabled
+	}
+	tokens, err := s.previews.ListByPost(ctx, postID)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("post.preview.list", "list preview tokens failed", err)
+	}
+	return tokens, nil
+}
+
+// RevokePreviewLink invalidates a preview link of a post the actor can manage.
+func (s *PostService) RevokePreviewLink(ctx context.Context, postID uint, tokenID uint, actorUserID uint, actorRole string) error {
+	if _, err := s.loadManageablePost(ctx, postID, actorUserID, actorRole); err != nil {
+		return err
+	}
+	if s.previews == nil {
+		return errPreviewDisabled
+	}
+	if err := s.previews.Revoke(ctx, postID, tokenID, time.Now()); err != nil {
+		return normalizeServiceErrorWithOpMsg("post.preview.revoke", "revoke preview token failed", err)
+	}
+	return nil
+}
+
+// GetPreviewPost serves the post behind a preview link, whatever its status, with its
+// referenced media. Tampered, expired and revoked links all fail with ErrPermission so
+// callers cannot probe which posts exist.
+func (s *PostService) GetPreviewPost(ctx context.Context, token string) (entity.PostPreview, error) {
+	if s.previews == nil || s.previewSigner == nil {
+		return entity.PostPreview{}, errPreviewDisabled
+	}
+	grant, err := s.previewSigner.VerifyPreview(token)
+	if err != nil {
+		return entity.PostPreview{}, errPreviewInvalid
+	}
+	record, err := s.previews.GetByID(ctx, grant.TokenID)
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			return entity.PostPreview{}, errPreviewInvalid
+		}
+		return entity.PostPreview{}, normalizeServiceErrorWithOpMsg("post.preview.load_token", "load preview token failed", err)
+	}
+	if record.PostID != grant.PostID || !record.Active(time.Now()) {
+		return entity.PostPreview{}, errPreviewInvalid
+	}
+
+	// Trashed posts are not found here, so trashing a post also stops its previews.
+	post, err := s.repo.GetByID(ctx, record.PostID)
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			return entity.PostPreview{}, errPreviewInvalid
+		}
+		return entity.PostPreview{}, normalizeServiceErrorWithOpMsg("post.preview.load_post", "load preview post failed", err)
+	}
+	s.attachRendered(&post)
+
+	preview := entity.PostPreview{Post: post, Media: []entity.MediaAsset{}, ExpiresAt: record.ExpiresAt}
+	if s.media != nil {
+		media, err := s.media.ListPostMedia(ctx, post.ID, nil)
+		if err != nil {
+			return entity.PostPreview{}, normalizeServiceErrorWithOpMsg("post.preview.media", "list preview media failed", err)
+		}
+		preview.Media = media
+	}
+	return preview, nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// memPreviewTokenRepo keeps preview token records in memory.
+type memPreviewTokenRepo struct {
+	tokens []entity.PostPreviewToken
+}
+
+func (m *memPreviewTokenRepo) Create(ctx context.Context, t entity.PostPreviewToken) (entity.PostPreviewToken, error) {
+	t.ID = uint(len(m.tokens) + 1)
+	t.CreatedAt = time.Now()
+	m.tokens = append(m.tokens, t)
+	return t, nil
+}
+func (m *memPreviewTokenRepo) GetByID(ctx context.Context, id uint) (entity.PostPreviewToken, error) {
+	for _, t := range m.tokens {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return entity.PostPreviewToken{}, core.ErrNotFound
+}
+func (m *memPreviewTokenRepo) ListByPost(ctx context.Context, postID uint) ([]entity.PostPreviewToken, error) {
+	var out []entity.PostPreviewToken
+	for _, t := range m.tokens {
+		if t.PostID == postID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+func (m *memPreviewTokenRepo) Revoke(ctx context.Context, postID uint, id uint, at time.Time) error {
+	for i := range m.tokens {
+		if m.tokens[i].ID == id && m.tokens[i].PostID == postID && m.tokens[i].RevokedAt == nil {
+			m.tokens[i].RevokedAt = &at
+			return nil
+		}
+	}
+	return core.ErrNotFound
+}
+
+// plainPreviewSigner "signs" grants as readable text; only the service logic is under test.
+type plainPreviewSigner struct{}
+
+func (plainPreviewSigner) SignPreview(g entity.PreviewGrant) (string, error) {
+	return fmt.Sprintf("%d.%d", g.TokenID, g.PostID), nil
+}
+func (plainPreviewSigner) VerifyPreview(token string) (entity.PreviewGrant, error) {
+	var g entity.PreviewGrant
+	if _, err := fmt.Sscanf(token, "%d.%d", &g.TokenID, &g.PostID); err != nil {
+		return entity.PreviewGrant{}, core.ErrPermission
+	}
+	return g, nil
+}
+
+func TestPostService_CreatePreviewLink(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakePostRepo{
+		getByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+			return entity.Post{ID: id, Status: entity.StatusDraft}, nil
+		},
+		getDraftByIDAndAuthorFn: func(ctx context.Context, id uint, authorID uint) (entity.Post, error) {
+			return entity.Post{}, core.ErrNotFound
+		},
+	}
+	tokens := &memPreviewTokenRepo{}
+	svc := NewPostService(repo, allowAll())
+
+	if _, _, err := svc.CreatePreviewLink(ctx, 3, 0, 9, "admin"); !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("previews disabled: want ErrNotFound, got %v", err)
+	}
+	svc.SetPreviewTokens(tokens, plainPreviewSigner{})
+
+	record, token, err := svc.CreatePreviewLink(ctx, 3, 0, 9, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "1.3" || record.PostID != 3 || record.CreatedBy != 9 {
+		t.Fatalf("unexpected link: %+v %q", record, token)
+	}
+	if ttl := time.Until(record.ExpiresAt); ttl < entity.DefaultPreviewTTL-time.Minute || ttl > entity.DefaultPreviewTTL {
+		t.Fatalf("unexpected default lifetime: %s", ttl)
+	}
+	if _, _, err := svc.CreatePreviewLink(ctx, 3, entity.MaxPreviewTTL+time.Hour, 9, "admin"); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("lifetime too long: want ErrInvalidInput, got %v", err)
+	}
+
+	// Authors can only mint links for their own drafts.
+	author := &fakeAuthorizer{allow: map[core.PostPermission]bool{core.PostPermissionReadOwnDraft: true}}
+	own := NewPostService(repo, author)
+	own.SetPreviewTokens(tokens, plainPreviewSigner{})
+	if _, _, err := own.CreatePreviewLink(ctx, 3, 0, 5, "user"); !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("foreign post: want ErrNotFound, got %v", err)
+	}
+}
+
+func TestPostService_GetPreviewPost(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakePostRepo{getByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+		if id != 3 {
+			return entity.Post{}, core.ErrNotFound
+		}
+		return entity.Post{ID: 3, Title: "draft", Status: entity.StatusDraft}, nil
+	}}
+	revoked := time.Now().Add(-time.Minute)
+	tokens := &memPreviewTokenRepo{tokens: []entity.PostPreviewToken{
+		{ID: 1, PostID: 3, ExpiresAt: time.Now().Add(time.Hour)},
+		{ID: 2, PostID: 3, ExpiresAt: time.Now().Add(-time.Second)},
+		{ID: 3, PostID: 3, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revoked},
+		{ID: 4, PostID: 8, ExpiresAt: time.Now().Add(time.Hour)},
+	}}
+	svc := NewPostService(repo, allowAll())
+	svc.SetPreviewTokens(tokens, plainPreviewSigner{})
+
+	preview, err := svc.GetPreviewPost(ctx, "1.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Post.ID != 3 || preview.Post.Status != entity.StatusDraft || preview.Media == nil {
+		t.Fatalf("unexpected preview: %+v", preview)
+	}
+
+	for name, token := range map[string]string{
+		"garbage":      "nope",
+		"unknown":      "9.3",
+		"expired":      "2.3",
+		"revoked":      "3.3",
+		"other post":   "1.8",
+		"deleted post": "4.8",
+	} {
+		if _, err := svc.GetPreviewPost(ctx, token); !errors.Is(err, core.ErrPermission) {
+			t.Errorf("%s: want ErrPermission, got %v", name, err)
+		}
+	}
+}
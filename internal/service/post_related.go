@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"KaldalisCMS/internal/core/entity"
+)
+
+const (
+	// defaultRelatedCacheSize bounds how many (post, limit) results are cached.
+	defaultRelatedCacheSize = 1024
+	// defaultRelatedCacheTTL bounds how stale a cached result can get through edits that do
+	// not invalidate the cache, such as retagging a published post.
+	defaultRelatedCacheTTL = 10 * time.Minute
+)
+
+// RelatedPostsConfig tunes related post recommendations.
+type RelatedPostsConfig struct {
+	// TextSimilarity adds full-text similarity to the tag and category score.
+	TextSimilarity bool
+	// CacheSize and CacheTTL size the result cache; zero values use the defaults.
+	CacheSize int
+	CacheTTL  time.Duration
+}
+
+// SetRelatedPostsConfig enables text similarity and sizes the related posts cache.
+func (s *PostService) SetRelatedPostsConfig(cfg RelatedPostsConfig) {
+	if cfg.CacheSize <= 0 {
+		cfg.CacheSize = defaultRelatedCacheSize
+	}
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = defaultRelatedCacheTTL
+	}
+	s.relatedText = cfg.TextSimilarity
+	s.relatedCache = newRelatedCache(cfg.CacheSize, cfg.CacheTTL)
+}
+
+// ListRelatedPosts returns up to limit published posts related to the published post id.
+// Results are cached until a post is published or taken offline, or the cache TTL passes.
+func (s *PostService) ListRelatedPosts(ctx context.Context, id uint, limit int) ([]entity.Post, error) {
+	if _, err := s.repo.GetPublishedByID(ctx, id); err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("post.related.load", "load published post failed", err)
+	}
+
+	query := entity.RelatedPostQuery{PostID: id, Limit: limit, TextSimilarity: s.relatedText}.Normalized()
+	key := relatedCacheKey{postID: id, limit: query.Limit}
+	if posts, ok := s.relatedCache.get(key, time.Now()); ok {
+		return posts, nil
+	}
+
+	generation := s.relatedCache.generation()
+	posts, err := s.repo.GetRelatedPublished(ctx, query)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("post.related.list", "list related posts failed", err)
+	}
+	s.attachRenderedAll(posts)
+	s.relatedCache.put(key, posts, generation, time.Now())
+	return posts, nil
+}
+
+// InvalidateRelated drops every cached recommendation. It is called whenever the set of
+// published posts changes, since any post may be a candidate for any other, and by other
+// services that change posts behind the post service's back, e.g. moving them to another
+// category.
+func (s *PostService) InvalidateRelated() {
+	s.relatedCache.clear()
+}
+
+type relatedCacheKey struct {
+	postID uint
+	limit  int
+}
+
+type relatedCacheEntry struct {
+	posts   []entity.Post
+	expires time.Time
+}
+
+// relatedCache holds recommendation results. A nil cache is valid and caches nothing.
+// clear bumps a generation counter so results computed before an invalidation are not stored.
+type relatedCache struct {
+	mu      sync.Mutex
+	max     int
+	ttl     time.Duration
+	gen     uint64
+	entries map[relatedCacheKey]relatedCacheEntry
+}
+
+func newRelatedCache(max int, ttl time.Duration) *relatedCache {
+	return &relatedCache{max: max, ttl: ttl, entries: make(map[relatedCacheKey]relatedCacheEntry)}
+}
+
+func (c *relatedCache) get(key relatedCacheKey, now time.Time) ([]entity.Post, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || !now.Before(e.expires) {
+		return nil, false
+	}
+	return e.posts, true
+}
+
+func (c *relatedCache) generation() uint64 {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+func (c *relatedCache) put(key relatedCacheKey, posts []entity.Post, generation uint64, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.gen {
+		return
+	}
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.max {
+		// Drop an arbitrary entry, as the render cache does.
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+	c.entries[key] = relatedCacheEntry{posts: posts, expires: now.Add(c.ttl)}
+}
+
+func (c *relatedCache) clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	clear(c.entries)
+}
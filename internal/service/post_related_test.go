@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+func TestPostService_ListRelatedPosts(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	var gotQuery entity.RelatedPostQuery
+	repo := &fakePostRepo{
+		getPublishedByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+			if id != 1 {
+				return entity.Post{}, core.ErrNotFound
+			}
+			return entity.Post{ID: id, Status: entity.StatusPublished}, nil
+		},
+		getByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+			return entity.Post{ID: id, Title: "draft", Status: entity.StatusDraft}, nil
+		},
+		updateFn: func(ctx context.Context, p entity.Post) error { return nil },
+		getRelatedPublishedFn: func(ctx context.Context, q entity.RelatedPostQuery) ([]entity.Post, error) {
+			calls++
+			gotQuery = q
+			return []entity.Post{{ID: 2}, {ID: 3}}, nil
+		},
+	}
+	svc := NewPostService(repo, allowAll())
+	svc.SetRelatedPostsConfig(RelatedPostsConfig{TextSimilarity: true})
+
+	got, err := svc.ListRelatedPosts(ctx, 1, 500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || gotQuery.Limit != entity.MaxRelatedPostLimit || !gotQuery.TextSimilarity {
+		t.Fatalf("unexpected result %+v for query %+v", got, gotQuery)
+	}
+
+	if _, err := svc.ListRelatedPosts(ctx, 1, 500); err != nil || calls != 1 {
+		t.Fatalf("second read should be cached: calls=%d err=%v", calls, err)
+	}
+	if _, err := svc.ListRelatedPosts(ctx, 1, 3); err != nil || calls != 2 {
+		t.Fatalf("another limit is a separate entry: calls=%d err=%v", calls, err)
+	}
+
+	// Publishing any post may change every recommendation.
+	if err := svc.PublishAdminPost(ctx, 7, 0, 9, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ListRelatedPosts(ctx, 1, 500); err != nil || calls != 3 {
+		t.Fatalf("publish should invalidate the cache: calls=%d err=%v", calls, err)
+	}
+
+	if _, err := svc.ListRelatedPosts(ctx, 8, 5); !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("unpublished source: want ErrNotFound, got %v", err)
+	}
+}
+
+func TestRelatedCache_DropsResultsComputedBeforeInvalidation(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := newRelatedCache(4, defaultRelatedCacheTTL)
+	key := relatedCacheKey{postID: 1, limit: 5}
+	gen := c.generation()
+	c.clear()
+	c.put(key, []entity.Post{{ID: 2}}, gen, now)
+	if _, ok := c.get(key, now); ok {
+		t.Fatal("stale result must not be cached")
+	}
+
+	c.put(key, []entity.Post{{ID: 2}}, c.generation(), now)
+	if _, ok := c.get(key, now.Add(defaultRelatedCacheTTL)); ok {
+		t.Fatal("entry must expire after the TTL")
+	}
+}
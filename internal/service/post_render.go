@@ -0,0 +1,94 @@
+package service
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"log"
+	"sync"
+	"time"
+)
+
+// renderCacheSize bounds how many rendered posts are kept in memory.
+const renderCacheSize = 512
+
+// SetContentRenderer enables server-side rendering of post content on public reads. Listings
+// are rendered too, so their excerpts are built from visible text rather than Markdown source.
+func (s *PostService) SetContentRenderer(renderer core.ContentRenderer) {
+	s.renderer = renderer
+	s.renderCache = newRenderCache(renderCacheSize)
+}
+
+// attachRendered fills post.Rendered, reusing the cached result while the post's UpdatedAt
+// is unchanged. Rendering failures are logged and leave Rendered nil; raw content is still served.
+func (s *PostService) attachRendered(post *entity.Post) {
+	if s.renderer == nil {
+		return
+	}
+	if cached, ok := s.renderCache.get(post.ID, post.UpdatedAt); ok {
+		post.Rendered = &cached
+		return
+	}
+	rendered, err := s.renderer.Render(post.Content)
+	if err != nil {
+		log.Printf("[WARN] render post content failed (ID: %d): %v", post.ID, err)
+		return
+	}
+	s.renderCache.put(post.ID, post.UpdatedAt, rendered)
+	post.Rendered = &rendered
+}
+
+// attachRenderedAll renders every post of a listing; see attachRendered.
+func (s *PostService) attachRenderedAll(posts []entity.Post) {
+	for i := range posts {
+		s.attachRendered(&posts[i])
+	}
+}
+
+// visibleText is the post's text as readers see it: the rendered text when available, the
+// raw content otherwise.
+func visibleText(post entity.Post) string {
+	if post.Rendered != nil {
+		return post.Rendered.Text
+	}
+	return post.Content
+}
+
+type renderCacheEntry struct {
+	updatedAt time.Time
+	content   entity.RenderedContent
+}
+
+// renderCache keeps one rendered version per post. An entry is valid only for the exact
+// UpdatedAt it was rendered from, so any edit invalidates it without explicit eviction.
+type renderCache struct {
+	mu      sync.Mutex
+	max     int
+	entries map[uint]renderCacheEntry
+}
+
+func newRenderCache(max int) *renderCache {
+	return &renderCache{max: max, entries: make(map[uint]renderCacheEntry, max)}
+}
+
+func (c *renderCache) get(postID uint, updatedAt time.Time) (entity.RenderedContent, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[postID]
+	if !ok || !e.updatedAt.Equal(updatedAt) {
+		return entity.RenderedContent{}, false
+	}
+	return e.content, true
+}
+
+func (c *renderCache) put(postID uint, updatedAt time.Time, content entity.RenderedContent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[postID]; !ok && len(c.entries) >= c.max {
+		// Drop an arbitrary entry; hot posts are re-rendered and re-cached on their next read.
+		for id := range c.entries {
+			delete(c.entries, id)
+			break
+		}
+	}
+	c.entries[postID] = renderCacheEntry{updatedAt: updatedAt, content: content}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/core/entity"
+)
+
+type countingRenderer struct {
+	calls int
+	err   error
+}
+
+func (r *countingRenderer) Render(content string) (entity.RenderedContent, error) {
+	r.calls++
+	if r.err != nil {
+		return entity.RenderedContent{}, r.err
+	}
+	return entity.RenderedContent{HTML: "<p>" + content + "</p>"}, nil
+}
+
+func TestPostService_GetPublicPostByID_RendersAndCaches(t *testing.T) {
+	ctx := context.Background()
+	post := entity.Post{ID: 1, Content: "v1", Status: entity.StatusPublished, UpdatedAt: time.Unix(100, 0)}
+	repo := &fakePostRepo{getPublishedByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+		return post, nil
+	}}
+	renderer := &countingRenderer{}
+	svc := NewPostService(repo, allowAll())
+	svc.SetContentRenderer(renderer)
+
+	for i := 0; i < 3; i++ {
+		got, err := svc.GetPublicPostByID(ctx, 1, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Rendered == nil || got.Rendered.HTML != "<p>v1</p>" {
+			t.Fatalf("rendered: %+v", got.Rendered)
+		}
+	}
+	if renderer.calls != 1 {
+		t.Fatalf("want 1 render for unchanged post, got %d", renderer.calls)
+	}
+
+	post.Content = "v2"
+	post.UpdatedAt = time.Unix(200, 0)
+	got, _ := svc.GetPublicPostByID(ctx, 1, nil)
+	if renderer.calls != 2 || got.Rendered.HTML != "<p>v2</p>" {
+		t.Fatalf("edit did not invalidate cache: calls=%d rendered=%+v", renderer.calls, got.Rendered)
+	}
+}
+
+func TestPostService_GetPublicPostByID_RenderFailureServesRaw(t *testing.T) {
+	repo := &fakePostRepo{getPublishedByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+		return entity.Post{ID: id, Content: "raw"}, nil
+	}}
+	svc := NewPostService(repo, allowAll())
+	svc.SetContentRenderer(&countingRenderer{err: errors.New("boom")})
+
+	got, err := svc.GetPublicPostByID(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Rendered != nil || got.Content != "raw" {
+		t.Fatalf("unexpected post: %+v", got)
+	}
+}
+
+func TestRenderCache_BoundedSize(t *testing.T) {
+	c := newRenderCache(2)
+	at := time.Unix(1, 0)
+	for id := uint(1); id <= 5; id++ {
+		c.put(id, at, entity.RenderedContent{})
+	}
+	if len(c.entries) != 2 {
+		t.Fatalf("cache grew past its bound: %d", len(c.entries))
+	}
+}
+
+func TestPostService_ListPublicPosts_Renders(t *testing.T) {
+	repo := &fakePostRepo{getPublishedFn: func(ctx context.Context, q entity.PostListQuery) ([]entity.Post, int64, error) {
+		return []entity.Post{{ID: 1, Content: "a"}, {ID: 2, Content: "b"}}, 2, nil
+	}}
+	svc := NewPostService(repo, allowAll())
+	svc.SetContentRenderer(&countingRenderer{})
+
+	posts, _, err := svc.ListPublicPosts(context.Background(), entity.PostListQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range posts {
+		if p.Rendered == nil || p.Rendered.HTML != "<p>"+p.Content+"</p>" {
+			t.Fatalf("post %d not rendered: %+v", p.ID, p.Rendered)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// ErrPostUnderReview is returned when an author tries to change a post that is waiting for review.
+var ErrPostUnderReview = fmt.Errorf("%w: post is under review", core.ErrConflict)
+
+// SubmitAdminPostForReview hands an author's draft to the editors. Once submitted, the author
+// can still read the post but can no longer edit it until it is approved or rejected.
+func (s *PostService) SubmitAdminPostForReview(ctx context.Context, id uint, actorUserID uint, actorRole string) error {
+	if actorUserID == 0 {
+		return core.ErrPermission
+	}
+	if err := s.authorizePostAction(ctx, actorRole, core.PostPermissionSubmitOwnDraft); err != nil {
+		return err
+	}
+
+	// Submission is an author action even for editors: only one's own drafts can be submitted.
+	post, err := s.repo.GetDraftByIDAndAuthor(ctx, id, actorUserID)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("post.submit_review.load", "load own draft for review failed", err)
+	}
+	if post.Status == entity.StatusPendingReview {
+		return ErrPostUnderReview
+	}
+	if err := post.SubmitForReview(time.Now()); err != nil {
+		return fmt.Errorf("%w: %v", core.ErrInvalidInput, err)
+	}
+
+	return s.withinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, post); err != nil {
+			return normalizeServiceErrorWithOpMsg("post.submit_review.update", "persist review submission failed", err)
+		}
+		return s.recordRevision(ctx, post, actorUserID, entity.RevisionActionSubmitReview, nil)
+	})
+}
+
+// ListReviewQueue returns every post awaiting review, longest-waiting first.
+func (s *PostService) ListReviewQueue(ctx context.Context, actorRole string) ([]entity.Post, error) {
+	if err := s.authorizePostAction(ctx, actorRole, core.PostPermissionReviewPost); err != nil {
+		return nil, err
+	}
+	posts, err := s.repo.GetPendingReview(ctx)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("post.review_queue", "list review queue failed", err)
+	}
+	return posts, nil
+}
+
+// ApproveAdminPost publishes a post that is awaiting review.
+// A non-zero ifVersion must match the stored version.
+func (s *PostService) ApproveAdminPost(ctx context.Context, id uint, ifVersion uint, actorUserID uint, actorRole string) error {
+	post, err := s.loadReviewablePost(ctx, id, ifVersion, actorRole)
+	if err != nil {
+		return err
+	}
+	if err := post.Publish(); err != nil {
+		return fmt.Errorf("%w: post is not publishable: %v", core.ErrInvalidInput, err)
+	}
+	post.ReviewNote = ""
+
+	err = s.withinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, post); err != nil {
+			if errors.Is(err, core.ErrConflict) {
+				return s.versionConflict(ctx, id)
+			}
+			return normalizeServiceErrorWithOpMsg("post.approve.update", "persist review approval failed", err)
+		}
+		return s.recordRevision(ctx, post, actorUserID, entity.RevisionActionPublish, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.InvalidateRelated()
+	return nil
+}
+
+// RejectAdminPost sends a post awaiting review back to its author as a draft,
+// recording the reason so the author knows what to change before resubmitting.
+// A non-zero ifVersion must match the stored version.
+func (s *PostService) RejectAdminPost(ctx context.Context, id uint, reason string, ifVersion uint, actorUserID uint, actorRole string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fmt.Errorf("%w: rejection reason is required", core.ErrInvalidInput)
+	}
+
+	post, err := s.loadReviewablePost(ctx, id, ifVersion, actorRole)
+	if err != nil {
+		return err
+	}
+	if err := post.RejectReview(reason, time.Now()); err != nil {
+		return fmt.Errorf("%w: %v", core.ErrInvalidInput, err)
+	}
+
+	return s.withinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, post); err != nil {
+			if errors.Is(err, core.ErrConflict) {
+				return s.versionConflict(ctx, id)
+			}
+			return normalizeServiceErrorWithOpMsg("post.reject.update", "persist review rejection failed", err)
+		}
+		return s.recordRevision(ctx, post, actorUserID, entity.RevisionActionReject, nil)
+	})
+}
+
+// loadReviewablePost checks review capability and the client's version precondition, and
+// returns the post only while it awaits review.
+func (s *PostService) loadReviewablePost(ctx context.Context, id uint, ifVersion uint, actorRole string) (entity.Post, error) {
+	if err := s.authorizePostAction(ctx, actorRole, core.PostPermissionReviewPost); err != nil {
+		return entity.Post{}, err
+	}
+	post, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return entity.Post{}, normalizeServiceErrorWithOpMsg("post.load_reviewable", "load post for review failed", err)
+	}
+	if err := checkPostVersion(post, ifVersion); err != nil {
+		return entity.Post{}, err
+	}
+	if post.Status != entity.StatusPendingReview {
+		return entity.Post{}, fmt.Errorf("%w: post is not awaiting review", core.ErrConflict)
+	}
+	return post, nil
+}
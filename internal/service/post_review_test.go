@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// authorScope grants only what a regular author has.
+func authorScope() *fakeAuthorizer {
+	return &fakeAuthorizer{allow: map[core.PostPermission]bool{
+		core.PostPermissionReadOwnDraft:   true,
+		core.PostPermissionUpdateOwnDraft: true,
+		core.PostPermissionSubmitOwnDraft: true,
+	}}
+}
+
+// ownDraftRepo serves post through the author-scoped lookup used by regular users.
+func ownDraftRepo(post *entity.Post) *fakePostRepo {
+	repo := statefulPostRepo(post)
+	repo.getDraftByIDAndAuthorFn = func(ctx context.Context, id uint, authorID uint) (entity.Post, error) {
+		if id != post.ID || authorID != post.AuthorID {
+			return entity.Post{}, core.ErrNotFound
+		}
+		return *post, nil
+	}
+	return repo
+}
+
+func TestPostService_SubmitAdminPostForReview(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "t", AuthorID: 5, Status: entity.StatusDraft, ReviewNote: "fix intro"}
+	revs := &memRevisionRepo{}
+	svc := NewPostService(ownDraftRepo(post), authorScope())
+	svc.SetRevisionRepository(revs)
+
+	if err := svc.SubmitAdminPostForReview(ctx, 1, 5, "user"); err != nil {
+		t.Fatalf("SubmitAdminPostForReview: %v", err)
+	}
+	if post.Status != entity.StatusPendingReview || post.ReviewNote != "" {
+		t.Fatalf("post not submitted: %+v", post)
+	}
+	if len(revs.revs) != 1 || revs.revs[0].Action != entity.RevisionActionSubmitReview {
+		t.Fatalf("revisions: %+v", revs.revs)
+	}
+
+	if err := svc.SubmitAdminPostForReview(ctx, 1, 5, "user"); !errors.Is(err, core.ErrConflict) {
+		t.Fatalf("resubmitting: want ErrConflict, got %v", err)
+	}
+	if err := svc.SubmitAdminPostForReview(ctx, 1, 6, "user"); !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("other author: want ErrNotFound, got %v", err)
+	}
+}
+
+func TestPostService_UpdateAdminPost_LockedDuringReview(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "t", AuthorID: 5, Status: entity.StatusPendingReview}
+	title := "edited"
+
+	err := NewPostService(ownDraftRepo(post), authorScope()).UpdateAdminPost(ctx, 1, entity.PostPatch{Title: &title}, 0, 5, "user")
+	if !errors.Is(err, ErrPostUnderReview) || !errors.Is(err, core.ErrConflict) {
+		t.Fatalf("want ErrPostUnderReview, got %v", err)
+	}
+	if post.Title != "t" {
+		t.Fatalf("post changed while under review: %+v", post)
+	}
+
+	// The author can still read it; editors can still edit it.
+	if _, err := NewPostService(ownDraftRepo(post), authorScope()).GetAdminPostByID(ctx, 1, 5, "user"); err != nil {
+		t.Fatalf("author read: %v", err)
+	}
+	if err := NewPostService(ownDraftRepo(post), allowAll()).UpdateAdminPost(ctx, 1, entity.PostPatch{Title: &title}, 0, 9, "admin"); err != nil {
+		t.Fatalf("editor update: %v", err)
+	}
+}
+
+func TestPostService_ApproveAdminPost(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "t", AuthorID: 5, Status: entity.StatusPendingReview}
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+
+	if err := svc.ApproveAdminPost(ctx, 1, 0, 9, "admin"); err != nil {
+		t.Fatalf("ApproveAdminPost: %v", err)
+	}
+	if post.Status != entity.StatusPublished {
+		t.Fatalf("status: %d", post.Status)
+	}
+	if err := svc.ApproveAdminPost(ctx, 1, 0, 9, "admin"); !errors.Is(err, core.ErrConflict) {
+		t.Fatalf("approving a non-pending post: want ErrConflict, got %v", err)
+	}
+	if err := NewPostService(statefulPostRepo(post), authorScope()).ApproveAdminPost(ctx, 1, 0, 5, "user"); !errors.Is(err, core.ErrPermission) {
+		t.Fatalf("author approving: want ErrPermission, got %v", err)
+	}
+}
+
+func TestPostService_RejectAdminPost(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "t", AuthorID: 5, Status: entity.StatusPendingReview}
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+
+	if err := svc.RejectAdminPost(ctx, 1, "  ", 0, 9, "admin"); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("blank reason: want ErrInvalidInput, got %v", err)
+	}
+	if err := svc.RejectAdminPost(ctx, 1, " needs sources ", 0, 9, "admin"); err != nil {
+		t.Fatalf("RejectAdminPost: %v", err)
+	}
+	if post.Status != entity.StatusDraft || post.ReviewNote != "needs sources" {
+		t.Fatalf("post not returned to draft: %+v", post)
+	}
+	if err := svc.RejectAdminPost(ctx, 1, "again", 0, 9, "admin"); !errors.Is(err, core.ErrConflict) {
+		t.Fatalf("rejecting a draft: want ErrConflict, got %v", err)
+	}
+}
+
+func TestPostService_ListReviewQueue(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakePostRepo{getPendingReviewFn: func(ctx context.Context) ([]entity.Post, error) {
+		return []entity.Post{{ID: 1}, {ID: 2}}, nil
+	}}
+
+	got, err := NewPostService(repo, allowAll()).ListReviewQueue(ctx, "admin")
+	if err != nil || len(got) != 2 {
+		t.Fatalf("unexpected: %+v %v", got, err)
+	}
+	if _, err := NewPostService(repo, authorScope()).ListReviewQueue(ctx, "user"); !errors.Is(err, core.ErrPermission) {
+		t.Fatalf("author listing queue: want ErrPermission, got %v", err)
+	}
+}
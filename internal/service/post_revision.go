@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+var errRevisionsDisabled = fmt.Errorf("%w: post revision history is not enabled", core.ErrNotFound)
+
+// maxLineDiffCells bounds the LCS table used for content diffs. Beyond it the diff degrades to
+// "delete everything, insert everything", which is still correct, just less precise.
+const maxLineDiffCells = 4_000_000
+
+// ListAdminPostRevisions returns the revision history of a post the actor can manage, newest first.
+func (s *PostService) ListAdminPostRevisions(ctx context.Context, postID uint, actorUserID uint, actorRole string) ([]entity.PostRevision, error) {
+	if _, err := s.loadManageablePost(ctx, postID, actorUserID, actorRole); err != nil {
+		return nil, err
+	}
+	if s.revisions == nil {
+		return []entity.PostRevision{}, nil
+	}
+	revisions, err := s.revisions.ListByPost(ctx, postID)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("post.revisions.list", "list post revisions failed", err)
+	}
+	return revisions, nil
+}
+
+// GetAdminPostRevision returns one revision of a post the actor can manage.
+func (s *PostService) GetAdminPostRevision(ctx context.Context, postID uint, revisionID uint, actorUserID uint, actorRole string) (entity.PostRevision, error) {
+	if _, err := s.loadManageablePost(ctx, postID, actorUserID, actorRole); err != nil {
+		return entity.PostRevision{}, err
+	}
+	return s.loadRevision(ctx, postID, revisionID)
+}
+
+// DiffAdminPostRevisions compares two revisions of the same post field by field,
+// with a line-level diff for the Markdown content.
+func (s *PostService) DiffAdminPostRevisions(ctx context.Context, postID uint, fromID uint, toID uint, actorUserID uint, actorRole string) (entity.PostRevisionDiff, error) {
+	if _, err := s.loadManageablePost(ctx, postID, actorUserID, actorRole); err != nil {
+		return entity.PostRevisionDiff{}, err
+	}
+	from, err := s.loadRevision(ctx, postID, fromID)
+	if err != nil {
+		return entity.PostRevisionDiff{}, err
+	}
+	to, err := s.loadRevision(ctx, postID, toID)
+	if err != nil {
+		return entity.PostRevisionDiff{}, err
+	}
+	return diffPostRevisions(from, to), nil
+}
+
+// RestoreAdminPostRevision re-applies a revision's content as a new update.
+// It goes through the same updatable-post authorization as a regular edit and is itself
+// recorded as a new revision, so a restore can also be undone. A non-zero ifVersion must
+// match the stored version.
+func (s *PostService) RestoreAdminPostRevision(ctx context.Context, postID uint, revisionID uint, ifVersion uint, actorUserID uint, actorRole string) error {
+	existing, err := s.loadUpdatablePost(ctx, postID, actorUserID, actorRole)
+	if err != nil {
+		return err
+	}
+	if err := checkPostVersion(existing, ifVersion); err != nil {
+		return err
+	}
+	revision, err := s.loadRevision(ctx, postID, revisionID)
+	if err != nil {
+		return err
+	}
+	restoredFrom := revision.ID
+	return s.applyPostPatch(ctx, existing, revision.ToPatch(), actorUserID, entity.RevisionActionRestore, &restoredFrom)
+}
+
+func (s *PostService) loadRevision(ctx context.Context, postID uint, revisionID uint) (entity.PostRevision, error) {
+	if s.revisions == nil {
+		return entity.PostRevision{}, errRevisionsDisabled
+	}
+	revision, err := s.revisions.GetByID(ctx, postID, revisionID)
+	if err != nil {
+		return entity.PostRevision{}, normalizeServiceErrorWithOpMsg("post.revisions.get", "load post revision failed", err)
+	}
+	return revision, nil
+}
+
+// recordRevision appends a snapshot of post to its history. Callers run it inside withinTx
+// together with the change it records, so a failed insert fails and rolls back the change.
+func (s *PostService) recordRevision(ctx context.Context, post entity.Post, actorUserID uint, action string, restoredFrom *uint) error {
+	if s.revisions == nil {
+		return nil
+	}
+	revision := entity.NewPostRevision(post, actorUserID, action)
+	revision.RestoredFrom = restoredFrom
+	if _, err := s.revisions.Create(ctx, revision); err != nil {
+		return normalizeServiceErrorWithOpMsg("post.revisions.record", "record post revision failed", err)
+	}
+	return nil
+}
+
+func diffPostRevisions(from, to entity.PostRevision) entity.PostRevisionDiff {
+	diff := entity.PostRevisionDiff{From: from, To: to, Fields: []entity.RevisionFieldChange{}}
+	addField := func(field, a, b string) {
+		if a != b {
+			diff.Fields = append(diff.Fields, entity.RevisionFieldChange{Field: field, From: a, To: b})
+		}
+	}
+	addField("title", from.Title, to.Title)
+	addField("slug", from.Slug, to.Slug)
+	addField("cover", from.Cover, to.Cover)
+	addField("category_id", formatOptionalID(from.CategoryID), formatOptionalID(to.CategoryID))
+	addField("tags", formatIDs(from.TagIDs), formatIDs(to.TagIDs))
+	addField("status", strconv.Itoa(from.Status), strconv.Itoa(to.Status))
+	diff.Content = diffLines(from.Content, to.Content)
+	return diff
+}
+
+func formatOptionalID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*id), 10)
+}
+
+func formatIDs(ids []uint) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatUint(uint64(id), 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+// diffLines produces a line-level diff using a longest-common-subsequence table.
+// Common prefix and suffix lines are trimmed first so typical small edits stay cheap.
+func diffLines(a, b string) []entity.DiffLine {
+	al, bl := splitLines(a), splitLines(b)
+
+	prefix := 0
+	for prefix < len(al) && prefix < len(bl) && al[prefix] == bl[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(al)-prefix && suffix < len(bl)-prefix && al[len(al)-1-suffix] == bl[len(bl)-1-suffix] {
+		suffix++
+	}
+
+	out := make([]entity.DiffLine, 0, len(al)+len(bl))
+	for _, line := range al[:prefix] {
+		out = append(out, entity.DiffLine{Op: entity.DiffOpEqual, Text: line})
+	}
+	out = append(out, diffMiddle(al[prefix:len(al)-suffix], bl[prefix:len(bl)-suffix])...)
+	for _, line := range al[len(al)-suffix:] {
+		out = append(out, entity.DiffLine{Op: entity.DiffOpEqual, Text: line})
+	}
+	return out
+}
+
+func diffMiddle(a, b []string) []entity.DiffLine {
+	out := make([]entity.DiffLine, 0, len(a)+len(b))
+	if (len(a)+1)*(len(b)+1) > maxLineDiffCells {
+		for _, line := range a {
+			out = append(out, entity.DiffLine{Op: entity.DiffOpDelete, Text: line})
+		}
+		for _, line := range b {
+			out = append(out, entity.DiffLine{Op: entity.DiffOpInsert, Text: line})
+		}
+		return out
+	}
+
+	// lcs[i][j] = length of the LCS of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, entity.DiffLine{Op: entity.DiffOpEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, entity.DiffLine{Op: entity.DiffOpDelete, Text: a[i]})
+			i++
+		default:
+			out = append(out, entity.DiffLine{Op: entity.DiffOpInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		out = append(out, entity.DiffLine{Op: entity.DiffOpDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		out = append(out, entity.DiffLine{Op: entity.DiffOpInsert, Text: b[j]})
+	}
+	return out
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
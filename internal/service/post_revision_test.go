@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// memRevisionRepo is an in-memory core.PostRevisionRepository.
+type memRevisionRepo struct {
+	revs []entity.PostRevision
+	// createErr, when set, fails every Create.
+	createErr error
+}
+
+func (m *memRevisionRepo) Create(ctx context.Context, rev entity.PostRevision) (entity.PostRevision, error) {
+	if m.createErr != nil {
+		return entity.PostRevision{}, m.createErr
+	}
+	rev.ID = uint(len(m.revs) + 1)
+	m.revs = append(m.revs, rev)
+	return rev, nil
+}
+
+func (m *memRevisionRepo) ListByPost(ctx context.Context, postID uint) ([]entity.PostRevision, error) {
+	out := []entity.PostRevision{}
+	for i := len(m.revs) - 1; i >= 0; i-- {
+		if m.revs[i].PostID == postID {
+			out = append(out, m.revs[i])
+		}
+	}
+	return out, nil
+}
+
+func (m *memRevisionRepo) GetByID(ctx context.Context, postID uint, revisionID uint) (entity.PostRevision, error) {
+	for _, r := range m.revs {
+		if r.ID == revisionID && r.PostID == postID {
+			return r, nil
+		}
+	}
+	return entity.PostRevision{}, core.ErrNotFound
+}
+
+// statefulPostRepo keeps a single post in memory so update/restore round-trips are observable.
+func statefulPostRepo(post *entity.Post) *fakePostRepo {
+	return &fakePostRepo{
+		getByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+			if id != post.ID {
+				return entity.Post{}, core.ErrNotFound
+			}
+			return *post, nil
+		},
+		isSlugExistsFn: func(ctx context.Context, locale string, slug string) (bool, error) { return false, nil },
+		updateFn: func(ctx context.Context, p entity.Post) error {
+			*post = p
+			return nil
+		},
+	}
+}
+
+func TestPostService_UpdateAdminPost_RecordsRevision(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "old", Slug: "old", Content: "a", AuthorID: 9}
+	revs := &memRevisionRepo{}
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+	svc.SetRevisionRepository(revs)
+
+	title := "new"
+	if err := svc.UpdateAdminPost(ctx, 1, entity.PostPatch{Title: &title}, 0, 9, "admin"); err != nil {
+		t.Fatalf("UpdateAdminPost: %v", err)
+	}
+	if len(revs.revs) != 1 {
+		t.Fatalf("expected 1 revision, got %d", len(revs.revs))
+	}
+	got := revs.revs[0]
+	if got.Action != entity.RevisionActionUpdate || got.Title != "new" || got.ActorID != 9 || got.PostID != 1 {
+		t.Fatalf("unexpected revision: %+v", got)
+	}
+}
+
+func TestPostService_UpdateAdminPost_RevisionFailureFailsSave(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "old", Slug: "old", Content: "a", AuthorID: 9}
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+	svc.SetRevisionRepository(&memRevisionRepo{createErr: errors.New("disk full")})
+	tx := &fakeTransactor{}
+	svc.SetTransactor(tx)
+
+	title := "new"
+	err := svc.UpdateAdminPost(ctx, 1, entity.PostPatch{Title: &title}, 0, 9, "admin")
+	if !errors.Is(err, core.ErrInternalError) {
+		t.Fatalf("want ErrInternalError, got %v", err)
+	}
+	if tx.rollbacks != 1 || tx.commits != 0 {
+		t.Fatalf("update and revision not rolled back together: commits=%d rollbacks=%d", tx.commits, tx.rollbacks)
+	}
+}
+
+func TestPostService_RestoreAdminPostRevision(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "v2", Slug: "v2", Content: "two", AuthorID: 9, Status: entity.StatusPublished}
+	revs := &memRevisionRepo{}
+	_, _ = revs.Create(ctx, entity.PostRevision{PostID: 1, Title: "v1", Slug: "v1", Content: "one", Status: entity.StatusDraft})
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+	svc.SetRevisionRepository(revs)
+
+	if err := svc.RestoreAdminPostRevision(ctx, 1, 1, 0, 9, "admin"); err != nil {
+		t.Fatalf("RestoreAdminPostRevision: %v", err)
+	}
+	if post.Title != "v1" || post.Slug != "v1" || post.Content != "one" {
+		t.Fatalf("post not restored: %+v", post)
+	}
+	if post.Status != entity.StatusPublished {
+		t.Fatalf("restore must not change status, got %d", post.Status)
+	}
+	last := revs.revs[len(revs.revs)-1]
+	if last.Action != entity.RevisionActionRestore || last.RestoredFrom == nil || *last.RestoredFrom != 1 {
+		t.Fatalf("unexpected restore revision: %+v", last)
+	}
+}
+
+func TestPostService_RestoreAdminPostRevision_ClearsAndFlags(t *testing.T) {
+	ctx := context.Background()
+	snapshot := entity.NewPostRevision(entity.Post{ID: 1, Title: "v1", Slug: "v1", CommentsDisabled: true}, 9, entity.RevisionActionCreate)
+	cat, series := uint(3), uint(5)
+	post := &entity.Post{ID: 1, Title: "v2", Slug: "v2", AuthorID: 9, CategoryID: &cat, NoIndex: true, SeriesID: &series, SeriesPosition: 2}
+	revs := &memRevisionRepo{}
+	_, _ = revs.Create(ctx, snapshot)
+	// A revision recorded before flags and series were snapshotted leaves them alone.
+	_, _ = revs.Create(ctx, entity.PostRevision{PostID: 1, Title: "legacy", Slug: "legacy"})
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+	svc.SetRevisionRepository(revs)
+
+	if err := svc.RestoreAdminPostRevision(ctx, 1, 1, 0, 9, "admin"); err != nil {
+		t.Fatalf("RestoreAdminPostRevision: %v", err)
+	}
+	if post.CategoryID != nil || post.NoIndex || !post.CommentsDisabled || post.SeriesID != nil || post.SeriesPosition != 0 {
+		t.Fatalf("post not brought back to the snapshot: %+v", post)
+	}
+
+	post.NoIndex, post.CategoryID = true, &cat
+	if err := svc.RestoreAdminPostRevision(ctx, 1, 2, 0, 9, "admin"); err != nil {
+		t.Fatalf("RestoreAdminPostRevision legacy: %v", err)
+	}
+	if post.Title != "legacy" || !post.NoIndex || !post.CommentsDisabled || post.CategoryID != nil {
+		t.Fatalf("legacy restore: %+v", post)
+	}
+}
+
+func TestPostService_RestoreAdminPostRevision_StaleVersion(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "v2", Slug: "v2", Content: "two", AuthorID: 9, Version: 4}
+	revs := &memRevisionRepo{}
+	_, _ = revs.Create(ctx, entity.PostRevision{PostID: 1, Title: "v1", Slug: "v1", Content: "one"})
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+	svc.SetRevisionRepository(revs)
+
+	err := svc.RestoreAdminPostRevision(ctx, 1, 1, 3, 9, "admin")
+	var conflict *PostVersionConflictError
+	if !errors.As(err, &conflict) || conflict.CurrentVersion != 4 {
+		t.Fatalf("want version conflict, got %v", err)
+	}
+	if post.Content != "two" || len(revs.revs) != 1 {
+		t.Fatalf("stale restore was applied: %+v", post)
+	}
+}
+
+func TestPostService_RestoreAdminPostRevision_OtherPost(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "t", AuthorID: 9}
+	revs := &memRevisionRepo{}
+	_, _ = revs.Create(ctx, entity.PostRevision{PostID: 2, Title: "foreign"})
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+	svc.SetRevisionRepository(revs)
+
+	err := svc.RestoreAdminPostRevision(ctx, 1, 1, 0, 9, "admin")
+	if !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPostService_DiffAdminPostRevisions(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "t", AuthorID: 9}
+	revs := &memRevisionRepo{}
+	_, _ = revs.Create(ctx, entity.PostRevision{PostID: 1, Title: "a", Content: "one\ntwo\nthree"})
+	_, _ = revs.Create(ctx, entity.PostRevision{PostID: 1, Title: "b", Content: "one\n2\nthree"})
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+	svc.SetRevisionRepository(revs)
+
+	diff, err := svc.DiffAdminPostRevisions(ctx, 1, 1, 2, 9, "admin")
+	if err != nil {
+		t.Fatalf("DiffAdminPostRevisions: %v", err)
+	}
+	if len(diff.Fields) != 1 || diff.Fields[0].Field != "title" {
+		t.Fatalf("unexpected field changes: %+v", diff.Fields)
+	}
+	want := []entity.DiffLine{
+		{Op: entity.DiffOpEqual, Text: "one"},
+		{Op: entity.DiffOpDelete, Text: "two"},
+		{Op: entity.DiffOpInsert, Text: "2"},
+		{Op: entity.DiffOpEqual, Text: "three"},
+	}
+	if len(diff.Content) != len(want) {
+		t.Fatalf("unexpected content diff: %+v", diff.Content)
+	}
+	for i := range want {
+		if diff.Content[i] != want[i] {
+			t.Fatalf("line %d: got %+v want %+v", i, diff.Content[i], want[i])
+		}
+	}
+}
+
+func TestPostService_ListAdminPostRevisions_Disabled(t *testing.T) {
+	post := &entity.Post{ID: 1, Title: "t", AuthorID: 9}
+	revs, err := NewPostService(statefulPostRepo(post), allowAll()).ListAdminPostRevisions(context.Background(), 1, 9, "admin")
+	if err != nil || len(revs) != 0 {
+		t.Fatalf("expected empty history, got %v, %v", revs, err)
+	}
+}
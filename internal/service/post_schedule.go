@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// scheduledTransitionBatch bounds how many posts one scheduler tick flips per direction.
+// Anything left over is picked up on the next tick.
+const scheduledTransitionBatch = 50
+
+// ScheduleAdminPost sets a future publish time and/or an automatic unpublish time.
+// Scheduling a publish time moves the post to Scheduled, which keeps it off public endpoints
+// until the scheduler promotes it. Only actors with publish capability may schedule.
+// A non-zero ifVersion must match the stored version.
+func (s *PostService) ScheduleAdminPost(ctx context.Context, id uint, schedule entity.PostSchedule, ifVersion uint, actorUserID uint, actorRole string) error {
+	if schedule.PublishAt == nil && schedule.UnpublishAt == nil && !schedule.ClearUnpublishAt {
+		return fmt.Errorf("%w: publish_at, unpublish_at or clear_unpublish_at is required", core.ErrInvalidInput)
+	}
+	if schedule.UnpublishAt != nil && schedule.ClearUnpublishAt {
+		return fmt.Errorf("%w: unpublish_at and clear_unpublish_at are mutually exclusive", core.ErrInvalidInput)
+	}
+	if err := s.authorizePostAction(ctx, actorRole, core.PostPermissionPublishPost); err != nil {
+		return err
+	}
+
+	post, err := s.loadManageablePost(ctx, id, actorUserID, actorRole)
+	if err != nil {
+		return err
+	}
+	if err := checkPostVersion(post, ifVersion); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	// An expiry that is about to be replaced must not fail validation of the new publish time.
+	if schedule.UnpublishAt != nil || schedule.ClearUnpublishAt {
+		post.UnpublishAt = nil
+		post.UpdatedAt = now
+	}
+	if schedule.PublishAt != nil {
+		if post.Status == entity.StatusPublished {
+			return fmt.Errorf("%w: post is already published", core.ErrConflict)
+		}
+		// Schedule checks a stored expiry against the new publish time.
+		if err := post.Schedule(*schedule.PublishAt, now); err != nil {
+			return fmt.Errorf("%w: %v", core.ErrInvalidInput, err)
+		}
+	}
+	if schedule.UnpublishAt != nil {
+		if err := post.SetExpiry(*schedule.UnpublishAt, now); err != nil {
+			return fmt.Errorf("%w: %v", core.ErrInvalidInput, err)
+		}
+	}
+
+	return s.withinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, post); err != nil {
+			if errors.Is(err, core.ErrConflict) {
+				return s.versionConflict(ctx, id)
+			}
+			return normalizeServiceErrorWithOpMsg("post.schedule.update", "persist post schedule failed", err)
+		}
+		return s.recordRevision(ctx, post, actorUserID, entity.RevisionActionSchedule, nil)
+	})
+}
+
+// RunScheduledTransitions publishes scheduled posts whose PublishAt has passed and takes
+// published posts offline once their UnpublishAt has passed. It is driven by a background
+// ticker; revisions it records carry actor ID 0 to mark them as system changes.
+func (s *PostService) RunScheduledTransitions(ctx context.Context, now time.Time) error {
+	due, err := s.repo.GetDueScheduled(ctx, now, scheduledTransitionBatch)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("post.scheduler.list_due", "list due scheduled posts failed", err)
+	}
+	for _, post := range due {
+		action := entity.RevisionActionPublish
+		if err := post.Publish(); err != nil {
+			// Fall back to Draft so an unpublishable post is not retried on every tick.
+			log.Printf("[WARN] Scheduled post (ID: %d) failed validation and was moved to draft: %v", post.ID, err)
+			action = entity.RevisionActionDraft
+			if err := post.Draft(); err != nil {
+				continue
+			}
+		}
+		if err := s.saveScheduledTransition(ctx, post, action); err != nil {
+			log.Printf("[WARN] Scheduled post (ID: %d) could not be updated: %v", post.ID, err)
+			continue
+		}
+		s.InvalidateRelated()
+	}
+
+	expired, err := s.repo.GetDueExpired(ctx, now, scheduledTransitionBatch)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("post.scheduler.list_expired", "list expired posts failed", err)
+	}
+	for _, post := range expired {
+		if err := post.Draft(); err != nil {
+			continue
+		}
+		if err := s.saveScheduledTransition(ctx, post, entity.RevisionActionDraft); err != nil {
+			log.Printf("[WARN] Expired post (ID: %d) could not be taken offline: %v", post.ID, err)
+			continue
+		}
+		s.InvalidateRelated()
+	}
+	return nil
+}
+
+// saveScheduledTransition persists a scheduler change together with its system revision.
+func (s *PostService) saveScheduledTransition(ctx context.Context, post entity.Post, action string) error {
+	return s.withinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, post); err != nil {
+			return err
+		}
+		return s.recordRevision(ctx, post, 0, action, nil)
+	})
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+func TestPostService_ScheduleAdminPost(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "t", AuthorID: 9, Status: entity.StatusDraft}
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+
+	publishAt := time.Now().Add(time.Hour)
+	unpublishAt := publishAt.Add(24 * time.Hour)
+	err := svc.ScheduleAdminPost(ctx, 1, entity.PostSchedule{PublishAt: &publishAt, UnpublishAt: &unpublishAt}, 0, 9, "admin")
+	if err != nil {
+		t.Fatalf("ScheduleAdminPost: %v", err)
+	}
+	if post.Status != entity.StatusScheduled {
+		t.Fatalf("status: %d", post.Status)
+	}
+	if post.PublishAt == nil || post.UnpublishAt == nil {
+		t.Fatalf("schedule not persisted: %+v", post)
+	}
+}
+
+func TestPostService_ScheduleAdminPost_Validation(t *testing.T) {
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour)
+
+	cases := []struct {
+		name     string
+		status   int
+		schedule entity.PostSchedule
+		want     error
+	}{
+		{"empty schedule", entity.StatusDraft, entity.PostSchedule{}, core.ErrInvalidInput},
+		{"past publish time", entity.StatusDraft, entity.PostSchedule{PublishAt: &past}, core.ErrInvalidInput},
+		{"already published", entity.StatusPublished, entity.PostSchedule{PublishAt: ptrTime(time.Now().Add(time.Hour))}, core.ErrConflict},
+		{"expiry on draft", entity.StatusDraft, entity.PostSchedule{UnpublishAt: ptrTime(time.Now().Add(time.Hour))}, core.ErrInvalidInput},
+		{"set and clear expiry", entity.StatusScheduled, entity.PostSchedule{UnpublishAt: ptrTime(time.Now().Add(time.Hour)), ClearUnpublishAt: true}, core.ErrInvalidInput},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			post := &entity.Post{ID: 1, Title: "t", AuthorID: 9, Status: tc.status}
+			err := NewPostService(statefulPostRepo(post), allowAll()).ScheduleAdminPost(ctx, 1, tc.schedule, 0, 9, "admin")
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("want %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestPostService_ScheduleAdminPost_KeepsStoredExpiry(t *testing.T) {
+	ctx := context.Background()
+	expiry := time.Now().Add(48 * time.Hour)
+	post := &entity.Post{ID: 1, Title: "t", AuthorID: 9, Status: entity.StatusScheduled, PublishAt: ptrTime(time.Now().Add(time.Hour)), UnpublishAt: &expiry}
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+
+	if err := svc.ScheduleAdminPost(ctx, 1, entity.PostSchedule{PublishAt: ptrTime(time.Now().Add(2 * time.Hour))}, 0, 9, "admin"); err != nil {
+		t.Fatalf("ScheduleAdminPost: %v", err)
+	}
+	if post.UnpublishAt == nil || !post.UnpublishAt.Equal(expiry) {
+		t.Fatalf("stored expiry dropped: %v", post.UnpublishAt)
+	}
+
+	// The kept expiry is validated against the new publish time.
+	err := svc.ScheduleAdminPost(ctx, 1, entity.PostSchedule{PublishAt: ptrTime(time.Now().Add(72 * time.Hour))}, 0, 9, "admin")
+	if !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("want ErrInvalidInput, got %v", err)
+	}
+
+	if err := svc.ScheduleAdminPost(ctx, 1, entity.PostSchedule{PublishAt: ptrTime(time.Now().Add(72 * time.Hour)), ClearUnpublishAt: true}, 0, 9, "admin"); err != nil {
+		t.Fatalf("ScheduleAdminPost with clear: %v", err)
+	}
+	if post.UnpublishAt != nil {
+		t.Fatalf("expiry not cleared: %v", post.UnpublishAt)
+	}
+}
+
+func TestPostService_ScheduleAdminPost_VersionConflict(t *testing.T) {
+	post := &entity.Post{ID: 1, Title: "t", AuthorID: 9, Status: entity.StatusDraft, Version: 4}
+	repo := statefulPostRepo(post)
+	repo.updateFn = func(ctx context.Context, p entity.Post) error { return core.ErrConflict }
+	svc := NewPostService(repo, allowAll())
+
+	err := svc.ScheduleAdminPost(context.Background(), 1, entity.PostSchedule{PublishAt: ptrTime(time.Now().Add(time.Hour))}, 0, 9, "admin")
+	var conflict *PostVersionConflictError
+	if !errors.As(err, &conflict) || conflict.CurrentVersion != 4 {
+		t.Fatalf("want version conflict with current version 4, got %v", err)
+	}
+}
+
+func TestPostService_ScheduleAdminPost_RequiresPublishPermission(t *testing.T) {
+	at := time.Now().Add(time.Hour)
+	svc := NewPostService(&fakePostRepo{}, &fakeAuthorizer{})
+	err := svc.ScheduleAdminPost(context.Background(), 1, entity.PostSchedule{PublishAt: &at}, 0, 9, "user")
+	if !errors.Is(err, core.ErrPermission) {
+		t.Fatalf("want ErrPermission, got %v", err)
+	}
+}
+
+func TestPostService_RunScheduledTransitions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	updated := map[uint]entity.Post{}
+	revs := &memRevisionRepo{}
+
+	repo := &fakePostRepo{
+		getDueScheduledFn: func(ctx context.Context, at time.Time, limit int) ([]entity.Post, error) {
+			return []entity.Post{
+				{ID: 1, Title: "ok", Status: entity.StatusScheduled, PublishAt: &past},
+				{ID: 2, Title: "", Status: entity.StatusScheduled, PublishAt: &past},
+			}, nil
+		},
+		getDueExpiredFn: func(ctx context.Context, at time.Time, limit int) ([]entity.Post, error) {
+			return []entity.Post{{ID: 3, Title: "old", Status: entity.StatusPublished, UnpublishAt: &past}}, nil
+		},
+		updateFn: func(ctx context.Context, p entity.Post) error {
+			updated[p.ID] = p
+			return nil
+		},
+	}
+	svc := NewPostService(repo, allowAll())
+	svc.SetRevisionRepository(revs)
+
+	if err := svc.RunScheduledTransitions(ctx, now); err != nil {
+		t.Fatalf("RunScheduledTransitions: %v", err)
+	}
+	if p := updated[1]; p.Status != entity.StatusPublished || p.PublishAt != nil {
+		t.Fatalf("post 1 not published: %+v", p)
+	}
+	if p := updated[2]; p.Status != entity.StatusDraft {
+		t.Fatalf("invalid post 2 should fall back to draft: %+v", p)
+	}
+	if p := updated[3]; p.Status != entity.StatusDraft || p.UnpublishAt != nil {
+		t.Fatalf("post 3 not expired: %+v", p)
+	}
+	if len(revs.revs) != 3 || revs.revs[0].ActorID != 0 {
+		t.Fatalf("expected 3 system revisions, got %+v", revs.revs)
+	}
+}
+
+func ptrTime(t time.Time) *time.Time { return &t }
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// searchSnippetRadius is the number of characters kept on each side of the first match.
+const searchSnippetRadius = 60
+
+// SearchPublicPosts runs a full-text search over published posts and attaches highlighted excerpts.
+func (s *PostService) SearchPublicPosts(ctx context.Context, query entity.PostSearchQuery) ([]entity.PostSearchHit, int64, error) {
+	query = query.Normalized()
+	if query.Q == "" {
+		return nil, 0, fmt.Errorf("%w: search query is required", core.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(query.Q) > entity.MaxPostSearchQueryRunes {
+		return nil, 0, fmt.Errorf("%w: search query is too long", core.ErrInvalidInput)
+	}
+
+	posts, total, err := s.repo.SearchPublished(ctx, query)
+	if err != nil {
+		return nil, 0, normalizeServiceErrorWithOpMsg("post.search_public", "search published posts failed", err)
+	}
+
+	s.attachRenderedAll(posts)
+	terms := query.Terms()
+	hits := make([]entity.PostSearchHit, len(posts))
+	for i, post := range posts {
+		hits[i] = entity.PostSearchHit{
+			Post:           post,
+			TitleHighlight: highlightTerms([]rune(post.Title), terms),
+			Snippet:        searchSnippet(visibleText(post), terms),
+		}
+	}
+	return hits, total, nil
+}
+
+// searchSnippet cuts a window of text around the first matched term and highlights all terms in it.
+// Whitespace is collapsed so line structure does not leak into the excerpt.
+func searchSnippet(content string, terms []string) string {
+	text := []rune(strings.Join(strings.Fields(content), " "))
+	start := 0
+	if pos := firstMatch(text, terms); pos > searchSnippetRadius {
+		start = pos - searchSnippetRadius
+	}
+	end := min(len(text), start+2*searchSnippetRadius)
+
+	snippet := highlightTerms(text[start:end], terms)
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(text) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// highlightTerms HTML-escapes text and wraps every case-insensitive occurrence of a term in <mark>.
+func highlightTerms(text []rune, terms []string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		if n := matchAt(text, i, terms); n > 0 {
+			b.WriteString("<mark>")
+			b.WriteString(html.EscapeString(string(text[i : i+n])))
+			b.WriteString("</mark>")
+			i += n
+			continue
+		}
+		b.WriteString(html.EscapeString(string(text[i])))
+		i++
+	}
+	return b.String()
+}
+
+func firstMatch(text []rune, terms []string) int {
+	for i := range text {
+		if matchAt(text, i, terms) > 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchAt returns the rune length of the longest term matching text at position i, or 0.
+func matchAt(text []rune, i int, terms []string) int {
+	best := 0
+	for _, term := range terms {
+		tr := []rune(term)
+		if len(tr) <= best || i+len(tr) > len(text) {
+			continue
+		}
+		matched := true
+		for j, r := range tr {
+			if unicode.ToLower(text[i+j]) != unicode.ToLower(r) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			best = len(tr)
+		}
+	}
+	return best
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+func TestPostService_SearchPublicPosts_Highlights(t *testing.T) {
+	repo := &fakePostRepo{
+		searchPublishedFn: func(ctx context.Context, q entity.PostSearchQuery) ([]entity.Post, int64, error) {
+			if q.Q != "Go 编程" || q.PageSize != entity.DefaultPostPageSize {
+				t.Fatalf("query not normalized: %+v", q)
+			}
+			return []entity.Post{{ID: 1, Title: "学习 go", Content: "用 <b>Go</b> 进行\n\n并发编程"}}, 1, nil
+		},
+	}
+	hits, total, err := NewPostService(repo, allowAll()).SearchPublicPosts(context.Background(), entity.PostSearchQuery{Q: "  Go 编程 "})
+	if err != nil {
+		t.Fatalf("SearchPublicPosts: %v", err)
+	}
+	if total != 1 || len(hits) != 1 {
+		t.Fatalf("unexpected result: %d %+v", total, hits)
+	}
+	if hits[0].TitleHighlight != "学习 <mark>go</mark>" {
+		t.Fatalf("title highlight: %q", hits[0].TitleHighlight)
+	}
+	want := "用 &lt;b&gt;<mark>Go</mark>&lt;/b&gt; 进行 并发<mark>编程</mark>"
+	if hits[0].Snippet != want {
+		t.Fatalf("snippet:\n got %q\nwant %q", hits[0].Snippet, want)
+	}
+}
+
+func TestPostService_SearchPublicPosts_Validation(t *testing.T) {
+	svc := NewPostService(&fakePostRepo{}, allowAll())
+	for _, q := range []string{"   ", strings.Repeat("长", entity.MaxPostSearchQueryRunes+1)} {
+		if _, _, err := svc.SearchPublicPosts(context.Background(), entity.PostSearchQuery{Q: q}); !errors.Is(err, core.ErrInvalidInput) {
+			t.Fatalf("want ErrInvalidInput for %q, got %v", q, err)
+		}
+	}
+}
+
+func TestSearchSnippet_Window(t *testing.T) {
+	content := strings.Repeat("x", 200) + "needle" + strings.Repeat("y", 200)
+	got := searchSnippet(content, []string{"needle"})
+	if !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") || !strings.Contains(got, "<mark>needle</mark>") {
+		t.Fatalf("unexpected snippet: %q", got)
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// placeInSeries sets the series membership of post. A nil or zero seriesID takes the post out
+// of its series. Position 0 keeps the post's current position in the series, or appends it
+// after the last part; any other position must not be held by another post of the series.
+func (s *PostService) placeInSeries(ctx context.Context, post *entity.Post, seriesID *uint, position int) error {
+	if seriesID == nil || *seriesID == 0 {
+		post.SeriesID, post.SeriesPosition = nil, 0
+		return nil
+	}
+	if s.series == nil {
+		return fmt.Errorf("%w: series are not enabled", core.ErrInvalidInput)
+	}
+	if position < 0 {
+		return fmt.Errorf("%w: series position must not be negative", core.ErrInvalidInput)
+	}
+	if _, err := s.series.GetByID(ctx, *seriesID); err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			return fmt.Errorf("%w: series does not exist", core.ErrInvalidInput)
+		}
+		return normalizeServiceErrorWithOpMsg("post.series.load", "load series failed", err)
+	}
+
+	parts, err := s.series.ListParts(ctx, *seriesID, false)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("post.series.list_parts", "list series parts failed", err)
+	}
+	last := 0
+	for _, p := range parts {
+		if p.ID == post.ID && post.ID != 0 {
+			if position == 0 {
+				position = p.Position
+			}
+			continue
+		}
+		if position != 0 && p.Position == position {
+			return fmt.Errorf("%w: position %d of the series is already taken", core.ErrConflict, position)
+		}
+		last = max(last, p.Position)
+	}
+	if position == 0 {
+		position = last + 1
+	}
+
+	id := *seriesID
+	post.SeriesID, post.SeriesPosition = &id, position
+	return nil
+}
+
+// attachSeries fills post.Series with the series and the neighbouring published parts.
+// A series that disappeared in the meantime is treated as no series.
+func (s *PostService) attachSeries(ctx context.Context, post *entity.Post) error {
+	if s.series == nil || post.SeriesID == nil {
+		return nil
+	}
+	series, err := s.series.GetByID(ctx, *post.SeriesID)
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			return nil
+		}
+		return normalizeServiceErrorWithOpMsg("post.series.load", "load post series failed", err)
+	}
+	parts, err := s.series.ListParts(ctx, series.ID, true)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("post.series.list_parts", "list series parts failed", err)
+	}
+
+	nav := &entity.PostSeries{Series: series, Position: post.SeriesPosition, Total: len(parts)}
+	for i, p := range parts {
+		if p.ID != post.ID {
+			continue
+		}
+		if i > 0 {
+			prev := parts[i-1]
+			nav.Prev = &prev
+		}
+		if i+1 < len(parts) {
+			next := parts[i+1]
+			nav.Next = &next
+		}
+		break
+	}
+	post.Series = nav
+	return nil
+}
+
+func sameSeries(current *uint, requested uint) bool {
+	if current == nil {
+		return requested == 0
+	}
+	return *current == requested
+}
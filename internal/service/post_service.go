@@ -21,6 +21,26 @@ type PostService struct {
 	authorizer core.PostAuthorizer
 	// media is optional; when nil, reference sync is skipped.
 	media *MediaService
+	// revisions is optional; when nil, no revision history is recorded.
+	revisions core.PostRevisionRepository
+	// autosaves is optional; when nil, the autosave endpoints report not found.
+	autosaves core.PostAutosaveRepository
+	// tags is optional; when nil, posts can only reference tags by ID.
+	tags core.TagService
+	// series is optional; when nil, posts cannot join a series.
+	series core.SeriesRepository
+	// renderer is optional; when nil, public reads carry raw content only.
+	renderer    core.ContentRenderer
+	renderCache *renderCache
+	// relatedCache is optional; when nil, related posts are computed on every request.
+	relatedCache *relatedCache
+	relatedText  bool
+	// previews and previewSigner are optional; when nil, preview links report not found.
+	previews      core.PostPreviewTokenRepository
+	previewSigner core.PreviewTokenSigner
+	// tx is optional; when nil, a post write and its revision are not atomic and atomic bulk
+	// actions are rejected.
+	tx core.Transactor
 }
 
 func NewPostService(repo core.PostRepository, authorizer core.PostAuthorizer) *PostService {
@@ -33,22 +53,119 @@ func NewPostServiceWithMedia(repo core.PostRepository, media *MediaService, auth
 	return &PostService{repo: repo, media: media, authorizer: authorizer}
 }
 
-// ListPublicPosts returns only published posts.
-func (s *PostService) ListPublicPosts(ctx context.Context) ([]entity.Post, error) {
-	posts, err := s.repo.GetPublished(ctx)
+// SetRevisionRepository enables revision history for every content change and status transition.
+func (s *PostService) SetRevisionRepository(revisions core.PostRevisionRepository) {
+	s.revisions = revisions
+}
+
+// SetAutosaveRepository enables per-user autosave buffers for post edits.
+func (s *PostService) SetAutosaveRepository(autosaves core.PostAutosaveRepository) {
+	s.autosaves = autosaves
+}
+
+// SetTagService lets post payloads reference tags by name, creating missing tags on the fly.
+func (s *PostService) SetTagService(tags core.TagService) {
+	s.tags = tags
+}
+
+// SetSeriesRepository lets posts join series and adds series navigation to public reads.
+func (s *PostService) SetSeriesRepository(series core.SeriesRepository) {
+	s.series = series
+}
+
+// resolvePostTags replaces name-only tags (ID 0) with stored tags, creating them as needed,
+// and drops duplicates so the same tag given by ID and by name is attached once.
+func (s *PostService) resolvePostTags(ctx context.Context, tags []entity.Tag) ([]entity.Tag, error) {
+	var names []string
+	for _, t := range tags {
+		if t.ID == 0 {
+			names = append(names, t.Name)
+		}
+	}
+	if len(names) == 0 {
+		return tags, nil
+	}
+	if s.tags == nil {
+		return nil, fmt.Errorf("%w: tags must be referenced by id", core.ErrInvalidInput)
+	}
+	named, err := s.tags.ResolveNames(ctx, names)
 	if err != nil {
-		return nil, normalizeServiceErrorWithOpMsg("post.list_public", "list published posts failed", err)
+		return nil, err
 	}
-	return posts, nil
+
+	resolved := make([]entity.Tag, 0, len(tags))
+	seen := make(map[uint]struct{}, len(tags))
+	for _, group := range [][]entity.Tag{tags, named} {
+		for _, t := range group {
+			if t.ID == 0 {
+				continue
+			}
+			if _, dup := seen[t.ID]; dup {
+				continue
+			}
+			seen[t.ID] = struct{}{}
+			resolved = append(resolved, t)
+		}
+	}
+	return resolved, nil
+}
+
+// ListPublicPosts returns one page of published posts together with the total match count.
+// The query is normalized here so callers cannot request unbounded pages.
+func (s *PostService) ListPublicPosts(ctx context.Context, query entity.PostListQuery) ([]entity.Post, int64, error) {
+	posts, total, err := s.repo.GetPublished(ctx, query.Normalized())
+	if err != nil {
+		return nil, 0, normalizeServiceErrorWithOpMsg("post.list_public", "list published posts failed", err)
+	}
+	if err := s.attachTranslations(ctx, posts, true); err != nil {
+		return nil, 0, err
+	}
+	s.attachRenderedAll(posts)
+	return posts, total, nil
 }
 
 // GetPublicPostByID returns a single published post for anonymous/public readers.
-func (s *PostService) GetPublicPostByID(ctx context.Context, id uint) (entity.Post, error) {
+// With a locale chain, the best published translation of the post is returned instead.
+func (s *PostService) GetPublicPostByID(ctx context.Context, id uint, locales []string) (entity.Post, error) {
 	post, err := s.repo.GetPublishedByID(ctx, id)
 	if err != nil {
 		return entity.Post{}, normalizeServiceErrorWithOpMsg("post.get_public_by_id", "get published post by id failed", err)
 	}
-	return post, nil
+	return s.finishPublicPost(ctx, post, locales)
+}
+
+// GetPublicPostBySlug resolves a published post by its current slug, falling back to slug history.
+// When the slug is historical, or a translation in a preferred locale has another slug, the
+// returned post carries that Slug; callers compare it with the requested slug to decide
+// whether to redirect.
+func (s *PostService) GetPublicPostBySlug(ctx context.Context, slugValue string, locales []string) (entity.Post, error) {
+	if slugValue == "" {
+		return entity.Post{}, fmt.Errorf("%w: slug is required", core.ErrInvalidInput)
+	}
+
+	post, err := s.repo.GetPublishedBySlug(ctx, slugValue, locales)
+	if err == nil {
+		return s.finishPublicPost(ctx, post, locales)
+	}
+	if !errors.Is(err, core.ErrNotFound) {
+		return entity.Post{}, normalizeServiceErrorWithOpMsg("post.get_public_by_slug", "get published post by slug failed", err)
+	}
+
+	// Old slugs only redirect within their own locale; without a chain, the URL is the
+	// unprefixed one of the default locale.
+	historyLocales := locales
+	if len(historyLocales) == 0 {
+		historyLocales = []string{entity.DefaultLocale}
+	}
+	postID, err := s.repo.GetPostIDBySlugHistory(ctx, slugValue, historyLocales)
+	if err != nil {
+		return entity.Post{}, normalizeServiceErrorWithOpMsg("post.get_public_by_slug.history", "resolve historical slug failed", err)
+	}
+	post, err = s.repo.GetPublishedByID(ctx, postID)
+	if err != nil {
+		return entity.Post{}, normalizeServiceErrorWithOpMsg("post.get_public_by_slug.redirect", "load post for historical slug failed", err)
+	}
+	return s.finishPublicPost(ctx, post, locales)
 }
 
 // ListAdminPosts returns the management view of posts for the acting user.
@@ -62,6 +179,9 @@ func (s *PostService) ListAdminPosts(ctx context.Context, actorUserID uint, acto
 		if err != nil {
 			return nil, normalizeServiceErrorWithOpMsg("post.list_admin_all", "list all posts in admin scope failed", err)
 		}
+		if err := s.attachTranslations(ctx, posts, false); err != nil {
+			return nil, err
+		}
 		return posts, nil
 	}
 
@@ -76,6 +196,9 @@ func (s *PostService) ListAdminPosts(ctx context.Context, actorUserID uint, acto
 	if err != nil {
 		return nil, normalizeServiceErrorWithOpMsg("post.list_admin_own", "list own draft posts failed", err)
 	}
+	if err := s.attachTranslations(ctx, posts, false); err != nil {
+		return nil, err
+	}
 	return posts, nil
 }
 
@@ -85,7 +208,11 @@ func (s *PostService) GetAdminPostByID(ctx context.Context, id uint, actorUserID
 	if err != nil {
 		return entity.Post{}, err
 	}
-	return post, nil
+	posts := []entity.Post{post}
+	if err := s.attachTranslations(ctx, posts, false); err != nil {
+		return entity.Post{}, err
+	}
+	return posts[0], nil
 }
 
 // CreateAdminPost persists a new post as Draft.
@@ -99,6 +226,14 @@ func (s *PostService) CreateAdminPost(ctx context.Context, actorUserID uint, act
 
 	post.AuthorID = actorUserID
 	post.Status = entity.StatusDraft
+	// Translation groups are only formed through the dedicated link endpoint.
+	post.TranslationGroupID = nil
+
+	locale, err := normalizePostLocale(post.Locale)
+	if err != nil {
+		return entity.Post{}, err
+	}
+	post.Locale = locale
 
 	if err := post.CheckValidity(); err != nil {
 		return entity.Post{}, fmt.Errorf("%w: invalid post payload: %v", core.ErrInvalidInput, err)
@@ -109,16 +244,32 @@ func (s *PostService) CreateAdminPost(ctx context.Context, actorUserID uint, act
 		return entity.Post{}, fmt.Errorf("%w: title cannot generate a valid slug", core.ErrInvalidInput)
 	}
 
-	finalSlug, err := s.generateUniqueSlug(ctx, generatedSlug)
+	finalSlug, err := s.generateUniqueSlug(ctx, post.Locale, generatedSlug)
 	if err != nil {
 		return entity.Post{}, err
 	}
 
 	post.Slug = finalSlug
 
-	created, err := s.repo.Create(ctx, post)
+	if err := s.placeInSeries(ctx, &post, post.SeriesID, post.SeriesPosition); err != nil {
+		return entity.Post{}, err
+	}
+
+	var created entity.Post
+	err = s.withinTx(ctx, func(ctx context.Context) error {
+		// Tags named for the first time are created in the post's transaction, so a failed
+		// save does not leave them behind.
+		var err error
+		if post.Tags, err = s.resolvePostTags(ctx, post.Tags); err != nil {
+			return err
+		}
+		if created, err = s.repo.Create(ctx, post); err != nil {
+			return normalizeServiceErrorWithOpMsg("post.create_admin", "create admin draft post failed", err)
+		}
+		return s.recordRevision(ctx, created, actorUserID, entity.RevisionActionCreate, nil)
+	})
 	if err != nil {
-		return entity.Post{}, normalizeServiceErrorWithOpMsg("post.create_admin", "create admin draft post failed", err)
+		return entity.Post{}, err
 	}
 
 	if s.media != nil {
@@ -132,13 +283,13 @@ func (s *PostService) CreateAdminPost(ctx context.Context, actorUserID uint, act
 	return created, nil
 }
 
-func (s *PostService) generateUniqueSlug(ctx context.Context, initialSlug string) (string, error) {
+func (s *PostService) generateUniqueSlug(ctx context.Context, locale string, initialSlug string) (string, error) {
 	currentSlug := initialSlug
 	counter := 0
 	maxAttempts := 100
 
 	for {
-		exists, err := s.repo.IsSlugExists(ctx, currentSlug)
+		exists, err := s.repo.IsSlugExists(ctx, locale, currentSlug)
 		if err != nil {
 			return "", normalizeServiceErrorWithOpMsg("post.generate_unique_slug", "check slug uniqueness failed", err)
 		}
@@ -157,15 +308,61 @@ func (s *PostService) generateUniqueSlug(ctx context.Context, initialSlug string
 }
 
 // UpdateAdminPost updates editable post content fields.
-func (s *PostService) UpdateAdminPost(ctx context.Context, id uint, patch entity.PostPatch, actorUserID uint, actorRole string) error {
+// A non-zero ifVersion must match the stored version, otherwise a PostVersionConflictError is returned.
+func (s *PostService) UpdateAdminPost(ctx context.Context, id uint, patch entity.PostPatch, ifVersion uint, actorUserID uint, actorRole string) error {
 	existingEntity, err := s.loadUpdatablePost(ctx, id, actorUserID, actorRole)
 	if err != nil {
 		return err
 	}
+	if err := checkPostVersion(existingEntity, ifVersion); err != nil {
+		return err
+	}
+	if err := s.applyPostPatch(ctx, existingEntity, patch, actorUserID, entity.RevisionActionUpdate, nil); err != nil {
+		return err
+	}
+	// A regular save supersedes whatever the editor had autosaved.
+	s.clearAutosave(ctx, id, actorUserID)
+	return nil
+}
+
+// applyPostPatch applies a patch to a post the actor has already been authorized to update,
+// persists it, and records the resulting state as a revision with the given action.
+func (s *PostService) applyPostPatch(ctx context.Context, existingEntity entity.Post, patch entity.PostPatch, actorUserID uint, action string, restoredFrom *uint) error {
+	id := existingEntity.ID
 
 	if patch.Title != nil {
 		existingEntity.Title = *patch.Title
 	}
+	newLocale, newSlug := existingEntity.Locale, existingEntity.Slug
+	if patch.Locale != nil {
+		locale, err := normalizePostLocale(*patch.Locale)
+		if err != nil {
+			return err
+		}
+		if locale != existingEntity.Locale {
+			if err := s.checkGroupLocaleFree(ctx, existingEntity, locale); err != nil {
+				return err
+			}
+		}
+		newLocale = locale
+	}
+	if patch.Slug != nil {
+		newSlug = slug.Make(*patch.Slug)
+		if newSlug == "" {
+			return fmt.Errorf("%w: slug cannot be empty", core.ErrInvalidInput)
+		}
+	}
+	// Slugs are unique per locale, so moving a post to another locale re-checks its slug too.
+	if newLocale != existingEntity.Locale || newSlug != existingEntity.Slug {
+		exists, err := s.repo.IsSlugExists(ctx, newLocale, newSlug)
+		if err != nil {
+			return normalizeServiceErrorWithOpMsg("post.update_admin.check_slug", "check slug uniqueness failed", err)
+		}
+		if exists {
+			return fmt.Errorf("%w: slug is already in use", core.ErrDuplicate)
+		}
+		existingEntity.Locale, existingEntity.Slug = newLocale, newSlug
+	}
 	if patch.Content != nil {
 		existingEntity.Content = *patch.Content
 	}
@@ -174,36 +371,83 @@ func (s *PostService) UpdateAdminPost(ctx context.Context, id uint, patch entity
 	}
 	if patch.CategoryID != nil {
 		existingEntity.CategoryID = patch.CategoryID
+		if *patch.CategoryID == 0 {
+			existingEntity.CategoryID = nil
+		}
+	}
+	if patch.NoIndex != nil {
+		existingEntity.NoIndex = *patch.NoIndex
+	}
+	if patch.CommentsDisabled != nil {
+		existingEntity.CommentsDisabled = *patch.CommentsDisabled
 	}
 	if patch.Tags != nil {
 		existingEntity.Tags = patch.Tags
 	}
-	existingEntity.ID = id
+	if patch.SeriesID != nil || patch.SeriesPosition != nil {
+		seriesID, position := existingEntity.SeriesID, existingEntity.SeriesPosition
+		if patch.SeriesID != nil && !sameSeries(existingEntity.SeriesID, *patch.SeriesID) {
+			seriesID, position = patch.SeriesID, 0
+		}
+		if patch.SeriesPosition != nil {
+			position = *patch.SeriesPosition
+		}
+		if err := s.placeInSeries(ctx, &existingEntity, seriesID, position); err != nil {
+			return err
+		}
+	}
 
 	if err := existingEntity.CheckValidity(); err != nil {
 		return fmt.Errorf("%w: invalid updated post payload: %v", core.ErrInvalidInput, err)
 	}
 
-	if err := s.repo.Update(ctx, existingEntity); err != nil {
-		return normalizeServiceErrorWithOpMsg("post.update_admin", "update admin post failed", err)
-	}
-
-	if s.media != nil {
-		syncCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+	err := s.withinTx(ctx, func(ctx context.Context) error {
+		// Tags named for the first time are created in the post's transaction, so a failed
+		// save does not leave them behind.
+		if patch.Tags != nil {
+			tags, err := s.resolvePostTags(ctx, patch.Tags)
+			if err != nil {
+				return err
+			}
+			existingEntity.Tags = tags
+		}
+		if err := s.repo.Update(ctx, existingEntity); err != nil {
+			if errors.Is(err, core.ErrConflict) {
+				return s.versionConflict(ctx, id)
+			}
+			return normalizeServiceErrorWithOpMsg("post.update_admin", "update admin post failed", err)
+		}
 
-		if err := s.media.SyncPostReferences(syncCtx, id, existingEntity.Content, existingEntity.Cover); err != nil {
-			log.Printf("[WARN] Post updated (ID: %d) but failed to sync media references: %v", id, err)
+		if s.media != nil {
+			syncCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+
+			if err := s.media.SyncPostReferences(syncCtx, id, existingEntity.Content, existingEntity.Cover); err != nil {
+				// Inside a transaction the references must not outlive a rollback of the post.
+				if inTransaction(ctx) {
+					return normalizeServiceErrorWithOpMsg("post.update_admin.sync_media", "sync media references failed", err)
+				}
+				log.Printf("[WARN] Post updated (ID: %d) but failed to sync media references: %v", id, err)
+			}
 		}
+		return s.recordRevision(ctx, existingEntity, actorUserID, action, restoredFrom)
+	})
+	if err != nil {
+		return err
 	}
 
+	// Tag and category edits of a live post change its relatedness to every other post.
+	if existingEntity.Status == entity.StatusPublished {
+		afterCommit(ctx, s.InvalidateRelated)
+	}
 	return nil
 }
 
 // PublishAdminPost performs the Draft -> Published transition.
 // The actor must have publish capability AND must be able to manage the target post
 // (admin can manage any post; regular users can only manage their own drafts).
-func (s *PostService) PublishAdminPost(ctx context.Context, id uint, actorUserID uint, actorRole string) error {
+// A non-zero ifVersion must match the stored version.
+func (s *PostService) PublishAdminPost(ctx context.Context, id uint, ifVersion uint, actorUserID uint, actorRole string) error {
 	if err := s.authorizePostAction(ctx, actorRole, core.PostPermissionPublishPost); err != nil {
 		return err
 	}
@@ -212,6 +456,9 @@ func (s *PostService) PublishAdminPost(ctx context.Context, id uint, actorUserID
 	if err != nil {
 		return err
 	}
+	if err := checkPostVersion(post, ifVersion); err != nil {
+		return err
+	}
 	if post.Status == entity.StatusPublished {
 		return fmt.Errorf("%w: post is already published", core.ErrConflict)
 	}
@@ -220,18 +467,31 @@ func (s *PostService) PublishAdminPost(ctx context.Context, id uint, actorUserID
 	}
 
 	post.Status = entity.StatusPublished
+	post.PublishAt = nil
 	post.UpdatedAt = time.Now()
 
-	if err := s.repo.Update(ctx, post); err != nil {
-		return normalizeServiceErrorWithOpMsg("post.publish.update", "persist publish status failed", err)
+	err = s.withinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, post); err != nil {
+			if errors.Is(err, core.ErrConflict) {
+				return s.versionConflict(ctx, id)
+			}
+			return normalizeServiceErrorWithOpMsg("post.publish.update", "persist publish status failed", err)
+		}
+		return s.recordRevision(ctx, post, actorUserID, entity.RevisionActionPublish, nil)
+	})
+	if err != nil {
+		return err
 	}
 
+	afterCommit(ctx, s.InvalidateRelated)
 	return nil
 }
 
 // MovePostToDraft performs the minimal "offline" step by moving a post back to Draft.
+// For a Scheduled post this cancels the pending publication; any schedule is cleared.
 // The actor must have unpublish capability AND must be able to manage the target post.
-func (s *PostService) MovePostToDraft(ctx context.Context, id uint, actorUserID uint, actorRole string) error {
+// A non-zero ifVersion must match the stored version.
+func (s *PostService) MovePostToDraft(ctx context.Context, id uint, ifVersion uint, actorUserID uint, actorRole string) error {
 	if err := s.authorizePostAction(ctx, actorRole, core.PostPermissionUnpublishPost); err != nil {
 		return err
 	}
@@ -240,33 +500,54 @@ func (s *PostService) MovePostToDraft(ctx context.Context, id uint, actorUserID
 	if err != nil {
 		return err
 	}
+	if err := checkPostVersion(post, ifVersion); err != nil {
+		return err
+	}
 	if post.Status == entity.StatusDraft {
 		return fmt.Errorf("%w: post is already draft", core.ErrConflict)
 	}
 
 	post.Status = entity.StatusDraft
+	post.PublishAt = nil
+	post.UnpublishAt = nil
 	post.UpdatedAt = time.Now()
 
-	if err := s.repo.Update(ctx, post); err != nil {
-		return normalizeServiceErrorWithOpMsg("post.move_draft.update", "persist move-to-draft status failed", err)
+	err = s.withinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, post); err != nil {
+			if errors.Is(err, core.ErrConflict) {
+				return s.versionConflict(ctx, id)
+			}
+			return normalizeServiceErrorWithOpMsg("post.move_draft.update", "persist move-to-draft status failed", err)
+		}
+		return s.recordRevision(ctx, post, actorUserID, entity.RevisionActionDraft, nil)
+	})
+	if err != nil {
+		return err
 	}
 
+	afterCommit(ctx, s.InvalidateRelated)
 	return nil
 }
 
-// DeleteAdminPost permanently removes a post record.
+// DeleteAdminPost moves a post to the trash, from where it can be restored or purged.
 // The actor must have delete capability AND must be able to manage the target post.
-func (s *PostService) DeleteAdminPost(ctx context.Context, id uint, actorUserID uint, actorRole string) error {
+// A non-zero ifVersion must match the stored version.
+func (s *PostService) DeleteAdminPost(ctx context.Context, id uint, ifVersion uint, actorUserID uint, actorRole string) error {
 	if err := s.authorizePostAction(ctx, actorRole, core.PostPermissionDeletePost); err != nil {
 		return err
 	}
 	// Verify the actor can access this post (ownership or admin scope).
-	if _, err := s.loadManageablePost(ctx, id, actorUserID, actorRole); err != nil {
+	post, err := s.loadManageablePost(ctx, id, actorUserID, actorRole)
+	if err != nil {
+		return err
+	}
+	if err := checkPostVersion(post, ifVersion); err != nil {
 		return err
 	}
 	if err := s.repo.Delete(ctx, id); err != nil {
 		return normalizeServiceErrorWithOpMsg("post.delete_admin", "delete admin post failed", err)
 	}
+	afterCommit(ctx, s.InvalidateRelated)
 	return nil
 }
 
@@ -321,6 +602,10 @@ func (s *PostService) loadUpdatablePost(ctx context.Context, id uint, actorUserI
 	if err != nil {
 		return entity.Post{}, normalizeServiceErrorWithOpMsg("post.load_updatable_own", "load own updatable draft failed", err)
 	}
+	// Authors give up edit rights while editors review the post.
+	if post.Status == entity.StatusPendingReview {
+		return entity.Post{}, ErrPostUnderReview
+	}
 	return post, nil
 }
 
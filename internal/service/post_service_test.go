@@ -4,22 +4,37 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"KaldalisCMS/internal/core"
 	"KaldalisCMS/internal/core/entity"
 )
 
 type fakePostRepo struct {
-	getByIDFn              func(ctx context.Context, id uint) (entity.Post, error)
-	getPublishedByIDFn     func(ctx context.Context, id uint) (entity.Post, error)
+	getByIDFn               func(ctx context.Context, id uint) (entity.Post, error)
+	getPublishedByIDFn      func(ctx context.Context, id uint) (entity.Post, error)
+	getPublishedBySlugFn    func(ctx context.Context, slug string, locales []string) (entity.Post, error)
+	getPostIDBySlugHistFn   func(ctx context.Context, slug string, locales []string) (uint, error)
 	getDraftByIDAndAuthorFn func(ctx context.Context, id uint, authorID uint) (entity.Post, error)
-	createFn               func(ctx context.Context, post entity.Post) (entity.Post, error)
-	updateFn               func(ctx context.Context, post entity.Post) error
-	deleteFn               func(ctx context.Context, id uint) error
-	getAllFn               func(ctx context.Context) ([]entity.Post, error)
-	getPublishedFn         func(ctx context.Context) ([]entity.Post, error)
-	getDraftsByAuthorFn    func(ctx context.Context, authorID uint) ([]entity.Post, error)
-	isSlugExistsFn         func(ctx context.Context, slug string) (bool, error)
+	createFn                func(ctx context.Context, post entity.Post) (entity.Post, error)
+	updateFn                func(ctx context.Context, post entity.Post) error
+	deleteFn                func(ctx context.Context, id uint) error
+	getAllFn                func(ctx context.Context) ([]entity.Post, error)
+	getAllAfterFn           func(ctx context.Context, afterID uint, limit int) ([]entity.Post, error)
+	getPublishedFn          func(ctx context.Context, q entity.PostListQuery) ([]entity.Post, int64, error)
+	getDraftsByAuthorFn     func(ctx context.Context, authorID uint) ([]entity.Post, error)
+	getPendingReviewFn      func(ctx context.Context) ([]entity.Post, error)
+	getTrashedFn            func(ctx context.Context) ([]entity.Post, error)
+	getTrashedBeforeFn      func(ctx context.Context, cutoff time.Time, limit int) ([]entity.Post, error)
+	restoreFn               func(ctx context.Context, id uint) error
+	purgeFn                 func(ctx context.Context, id uint) error
+	isSlugExistsFn          func(ctx context.Context, locale string, slug string) (bool, error)
+	getDueScheduledFn       func(ctx context.Context, now time.Time, limit int) ([]entity.Post, error)
+	getDueExpiredFn         func(ctx context.Context, now time.Time, limit int) ([]entity.Post, error)
+	searchPublishedFn       func(ctx context.Context, q entity.PostSearchQuery) ([]entity.Post, int64, error)
+	getRelatedPublishedFn   func(ctx context.Context, q entity.RelatedPostQuery) ([]entity.Post, error)
+	getTranslationsFn       func(ctx context.Context, groupIDs []uint, publishedOnly bool) ([]entity.Post, error)
+	setTranslationGroupFn   func(ctx context.Context, ids []uint, groupID *uint) error
 }
 
 func (f *fakePostRepo) GetByID(ctx context.Context, id uint) (entity.Post, error) {
@@ -28,6 +43,12 @@ func (f *fakePostRepo) GetByID(ctx context.Context, id uint) (entity.Post, error
 func (f *fakePostRepo) GetPublishedByID(ctx context.Context, id uint) (entity.Post, error) {
 	return f.getPublishedByIDFn(ctx, id)
 }
+func (f *fakePostRepo) GetPublishedBySlug(ctx context.Context, slug string, locales []string) (entity.Post, error) {
+	return f.getPublishedBySlugFn(ctx, slug, locales)
+}
+func (f *fakePostRepo) GetPostIDBySlugHistory(ctx context.Context, slug string, locales []string) (uint, error) {
+	return f.getPostIDBySlugHistFn(ctx, slug, locales)
+}
 func (f *fakePostRepo) GetDraftByIDAndAuthor(ctx context.Context, id uint, authorID uint) (entity.Post, error) {
 	return f.getDraftByIDAndAuthorFn(ctx, id, authorID)
 }
@@ -41,14 +62,46 @@ func (f *fakePostRepo) Delete(ctx context.Context, id uint) error { return f.del
 func (f *fakePostRepo) GetAll(ctx context.Context) ([]entity.Post, error) {
 	return f.getAllFn(ctx)
 }
-func (f *fakePostRepo) GetPublished(ctx context.Context) ([]entity.Post, error) {
-	return f.getPublishedFn(ctx)
+func (f *fakePostRepo) GetAllAfter(ctx context.Context, afterID uint, limit int) ([]entity.Post, error) {
+	return f.getAllAfterFn(ctx, afterID, limit)
+}
+func (f *fakePostRepo) GetPublished(ctx context.Context, q entity.PostListQuery) ([]entity.Post, int64, error) {
+	return f.getPublishedFn(ctx, q)
 }
 func (f *fakePostRepo) GetDraftsByAuthor(ctx context.Context, authorID uint) ([]entity.Post, error) {
 	return f.getDraftsByAuthorFn(ctx, authorID)
 }
-func (f *fakePostRepo) IsSlugExists(ctx context.Context, slug string) (bool, error) {
-	return f.isSlugExistsFn(ctx, slug)
+func (f *fakePostRepo) GetPendingReview(ctx context.Context) ([]entity.Post, error) {
+	return f.getPendingReviewFn(ctx)
+}
+func (f *fakePostRepo) GetTrashed(ctx context.Context) ([]entity.Post, error) {
+	return f.getTrashedFn(ctx)
+}
+func (f *fakePostRepo) GetTrashedBefore(ctx context.Context, cutoff time.Time, limit int) ([]entity.Post, error) {
+	return f.getTrashedBeforeFn(ctx, cutoff, limit)
+}
+func (f *fakePostRepo) Restore(ctx context.Context, id uint) error { return f.restoreFn(ctx, id) }
+func (f *fakePostRepo) Purge(ctx context.Context, id uint) error   { return f.purgeFn(ctx, id) }
+func (f *fakePostRepo) IsSlugExists(ctx context.Context, locale string, slug string) (bool, error) {
+	return f.isSlugExistsFn(ctx, locale, slug)
+}
+func (f *fakePostRepo) GetDueScheduled(ctx context.Context, now time.Time, limit int) ([]entity.Post, error) {
+	return f.getDueScheduledFn(ctx, now, limit)
+}
+func (f *fakePostRepo) GetDueExpired(ctx context.Context, now time.Time, limit int) ([]entity.Post, error) {
+	return f.getDueExpiredFn(ctx, now, limit)
+}
+func (f *fakePostRepo) SearchPublished(ctx context.Context, q entity.PostSearchQuery) ([]entity.Post, int64, error) {
+	return f.searchPublishedFn(ctx, q)
+}
+func (f *fakePostRepo) GetRelatedPublished(ctx context.Context, q entity.RelatedPostQuery) ([]entity.Post, error) {
+	return f.getRelatedPublishedFn(ctx, q)
+}
+func (f *fakePostRepo) GetTranslations(ctx context.Context, groupIDs []uint, publishedOnly bool) ([]entity.Post, error) {
+	return f.getTranslationsFn(ctx, groupIDs, publishedOnly)
+}
+func (f *fakePostRepo) SetTranslationGroup(ctx context.Context, ids []uint, groupID *uint) error {
+	return f.setTranslationGroupFn(ctx, ids, groupID)
 }
 
 // fakeAuthorizer grants the exact permissions in `allow`. Others return ErrPermission.
@@ -73,23 +126,31 @@ func allowAll() *fakeAuthorizer {
 		core.PostPermissionListOwnDrafts:  true,
 		core.PostPermissionReadOwnDraft:   true,
 		core.PostPermissionUpdateOwnDraft: true,
+		core.PostPermissionSubmitOwnDraft: true,
 		core.PostPermissionListAnyPost:    true,
 		core.PostPermissionReadAnyPost:    true,
 		core.PostPermissionUpdateAnyPost:  true,
 		core.PostPermissionPublishPost:    true,
 		core.PostPermissionUnpublishPost:  true,
 		core.PostPermissionDeletePost:     true,
+		core.PostPermissionReviewPost:     true,
+		core.PostPermissionPurgePost:      true,
 	}}
 }
 
 func TestPostService_ListPublicPosts(t *testing.T) {
 	ctx := context.Background()
-	repo := &fakePostRepo{getPublishedFn: func(ctx context.Context) ([]entity.Post, error) {
-		return []entity.Post{{ID: 1}}, nil
+	var seen entity.PostListQuery
+	repo := &fakePostRepo{getPublishedFn: func(ctx context.Context, q entity.PostListQuery) ([]entity.Post, int64, error) {
+		seen = q
+		return []entity.Post{{ID: 1}}, 11, nil
 	}}
-	got, err := NewPostService(repo, allowAll()).ListPublicPosts(ctx)
-	if err != nil || len(got) != 1 {
-		t.Fatalf("unexpected: %+v %v", got, err)
+	got, total, err := NewPostService(repo, allowAll()).ListPublicPosts(ctx, entity.PostListQuery{PageSize: 500})
+	if err != nil || len(got) != 1 || total != 11 {
+		t.Fatalf("unexpected: %+v %d %v", got, total, err)
+	}
+	if seen.Page != 1 || seen.PageSize != entity.MaxPostPageSize || seen.SortBy != entity.PostSortCreatedAt || seen.Order != entity.SortOrderDesc {
+		t.Fatalf("query not normalized before reaching repo: %+v", seen)
 	}
 }
 
@@ -98,7 +159,7 @@ func TestPostService_GetPublicPostByID_NotFound(t *testing.T) {
 	repo := &fakePostRepo{getPublishedByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
 		return entity.Post{}, core.ErrNotFound
 	}}
-	_, err := NewPostService(repo, allowAll()).GetPublicPostByID(ctx, 1)
+	_, err := NewPostService(repo, allowAll()).GetPublicPostByID(ctx, 1, nil)
 	if !errors.Is(err, core.ErrNotFound) {
 		t.Fatalf("want ErrNotFound, got %v", err)
 	}
@@ -146,7 +207,7 @@ func TestPostService_CreateAdminPost_HappyPath(t *testing.T) {
 	slugExists := map[string]bool{"hello-world": true} // force one retry
 	var created entity.Post
 	repo := &fakePostRepo{
-		isSlugExistsFn: func(ctx context.Context, slug string) (bool, error) {
+		isSlugExistsFn: func(ctx context.Context, locale string, slug string) (bool, error) {
 			return slugExists[slug], nil
 		},
 		createFn: func(ctx context.Context, p entity.Post) (entity.Post, error) {
@@ -176,6 +237,128 @@ func TestPostService_CreateAdminPost_HappyPath(t *testing.T) {
 	}
 }
 
+func TestPostService_CreateAdminPost_TagNames(t *testing.T) {
+	ctx := context.Background()
+	var created entity.Post
+	repo := &fakePostRepo{
+		isSlugExistsFn: func(ctx context.Context, locale string, slug string) (bool, error) { return false, nil },
+		createFn: func(ctx context.Context, p entity.Post) (entity.Post, error) {
+			created = p
+			return p, nil
+		},
+	}
+	tags := &fakeTagRepo{
+		getByNameFn: func(ctx context.Context, name string) (entity.Tag, error) {
+			if name == "go" {
+				return entity.Tag{ID: 3, Name: "go"}, nil
+			}
+			return entity.Tag{}, core.ErrNotFound
+		},
+		getBySlugFn: func(ctx context.Context, slug string) (entity.Tag, error) { return entity.Tag{}, core.ErrNotFound },
+		createFn: func(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
+			tag.ID = 8
+			return tag, nil
+		},
+	}
+	svc := NewPostService(repo, allowAll())
+	svc.SetTagService(NewTagService(tags))
+
+	_, err := svc.CreateAdminPost(ctx, 7, "admin", entity.Post{
+		Title: "Tagged",
+		Tags:  []entity.Tag{{ID: 3}, {Name: "go"}, {Name: "new"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created.Tags) != 2 || created.Tags[0].ID != 3 || created.Tags[1].ID != 8 {
+		t.Fatalf("tags not resolved and de-duplicated: %+v", created.Tags)
+	}
+}
+
+func TestPostService_TagNamesResolvedInPostTx(t *testing.T) {
+	ctx := context.Background()
+	// newTagRepo fails the test when a tag is created outside the post transaction.
+	newTagRepo := func(created *int) *fakeTagRepo {
+		return &fakeTagRepo{
+			getByNameFn: func(ctx context.Context, name string) (entity.Tag, error) { return entity.Tag{}, core.ErrNotFound },
+			getBySlugFn: func(ctx context.Context, slug string) (entity.Tag, error) { return entity.Tag{}, core.ErrNotFound },
+			createFn: func(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
+				if !inTransaction(ctx) {
+					t.Fatal("tag created outside the post transaction")
+				}
+				*created++
+				tag.ID = 8
+				return tag, nil
+			},
+		}
+	}
+
+	t.Run("create", func(t *testing.T) {
+		created := 0
+		repo := &fakePostRepo{
+			isSlugExistsFn: func(ctx context.Context, locale string, slug string) (bool, error) { return false, nil },
+			createFn: func(ctx context.Context, p entity.Post) (entity.Post, error) {
+				return entity.Post{}, errors.New("db down")
+			},
+		}
+		tx := &fakeTransactor{}
+		svc := NewPostService(repo, allowAll())
+		svc.SetTagService(NewTagService(newTagRepo(&created)))
+		svc.SetTransactor(tx)
+
+		if _, err := svc.CreateAdminPost(ctx, 7, "admin", entity.Post{Title: "Tagged", Tags: []entity.Tag{{Name: "new"}}}); err == nil {
+			t.Fatal("expected an error")
+		}
+		if created != 1 || tx.rollbacks != 1 {
+			t.Fatalf("tag creates %d, rollbacks %d", created, tx.rollbacks)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		created := 0
+		repo := &fakePostRepo{
+			getByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+				return entity.Post{ID: id, Title: "t", Status: entity.StatusDraft, AuthorID: 9, Version: 2}, nil
+			},
+			updateFn: func(ctx context.Context, p entity.Post) error { return core.ErrConflict },
+		}
+		tx := &fakeTransactor{}
+		svc := NewPostService(repo, allowAll())
+		svc.SetTagService(NewTagService(newTagRepo(&created)))
+		svc.SetTransactor(tx)
+
+		err := svc.UpdateAdminPost(ctx, 1, entity.PostPatch{Tags: []entity.Tag{{Name: "new"}}}, 0, 9, "admin")
+		if !errors.Is(err, ErrPostVersionConflict) {
+			t.Fatalf("want ErrPostVersionConflict, got %v", err)
+		}
+		if created != 1 || tx.rollbacks != 1 {
+			t.Fatalf("tag creates %d, rollbacks %d", created, tx.rollbacks)
+		}
+
+		// An invalid patch fails before any tag is created.
+		empty := ""
+		if err := svc.UpdateAdminPost(ctx, 1, entity.PostPatch{Title: &empty, Tags: []entity.Tag{{Name: "new"}}}, 0, 9, "admin"); !errors.Is(err, core.ErrInvalidInput) {
+			t.Fatalf("want ErrInvalidInput, got %v", err)
+		}
+		if created != 1 {
+			t.Fatalf("tag created for an invalid patch")
+		}
+	})
+}
+
+func TestPostService_CreateAdminPost_TagNamesWithoutTagService(t *testing.T) {
+	repo := &fakePostRepo{
+		isSlugExistsFn: func(ctx context.Context, locale string, slug string) (bool, error) { return false, nil },
+	}
+	_, err := NewPostService(repo, allowAll()).CreateAdminPost(context.Background(), 7, "admin", entity.Post{
+		Title: "Tagged",
+		Tags:  []entity.Tag{{Name: "go"}},
+	})
+	if !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("want ErrInvalidInput, got %v", err)
+	}
+}
+
 func TestPostService_CreateAdminPost_EmptyTitle(t *testing.T) {
 	ctx := context.Background()
 	_, err := NewPostService(&fakePostRepo{}, allowAll()).CreateAdminPost(ctx, 1, "admin", entity.Post{})
@@ -206,7 +389,7 @@ func TestPostService_PublishAdminPost_AlreadyPublished(t *testing.T) {
 	repo := &fakePostRepo{getByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
 		return entity.Post{ID: id, Title: "t", Status: entity.StatusPublished}, nil
 	}}
-	err := NewPostService(repo, allowAll()).PublishAdminPost(ctx, 1, 9, "admin")
+	err := NewPostService(repo, allowAll()).PublishAdminPost(ctx, 1, 0, 9, "admin")
 	if !errors.Is(err, core.ErrConflict) {
 		t.Fatalf("want ErrConflict, got %v", err)
 	}
@@ -224,7 +407,7 @@ func TestPostService_PublishAdminPost_Success(t *testing.T) {
 			return nil
 		},
 	}
-	if err := NewPostService(repo, allowAll()).PublishAdminPost(ctx, 1, 9, "admin"); err != nil {
+	if err := NewPostService(repo, allowAll()).PublishAdminPost(ctx, 1, 0, 9, "admin"); err != nil {
 		t.Fatal(err)
 	}
 	if updated.Status != entity.StatusPublished {
@@ -235,7 +418,7 @@ func TestPostService_PublishAdminPost_Success(t *testing.T) {
 func TestPostService_PublishAdminPost_NoPermission(t *testing.T) {
 	ctx := context.Background()
 	auth := &fakeAuthorizer{allow: map[core.PostPermission]bool{}}
-	err := NewPostService(&fakePostRepo{}, auth).PublishAdminPost(ctx, 1, 9, "guest")
+	err := NewPostService(&fakePostRepo{}, auth).PublishAdminPost(ctx, 1, 0, 9, "guest")
 	if !errors.Is(err, core.ErrPermission) {
 		t.Fatalf("want ErrPermission, got %v", err)
 	}
@@ -246,7 +429,7 @@ func TestPostService_MovePostToDraft_AlreadyDraft(t *testing.T) {
 	repo := &fakePostRepo{getByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
 		return entity.Post{ID: id, Title: "t", Status: entity.StatusDraft}, nil
 	}}
-	err := NewPostService(repo, allowAll()).MovePostToDraft(ctx, 1, 9, "admin")
+	err := NewPostService(repo, allowAll()).MovePostToDraft(ctx, 1, 0, 9, "admin")
 	if !errors.Is(err, core.ErrConflict) {
 		t.Fatalf("want ErrConflict, got %v", err)
 	}
@@ -264,7 +447,7 @@ func TestPostService_DeleteAdminPost_Success(t *testing.T) {
 			return nil
 		},
 	}
-	if err := NewPostService(repo, allowAll()).DeleteAdminPost(ctx, 42, 9, "admin"); err != nil {
+	if err := NewPostService(repo, allowAll()).DeleteAdminPost(ctx, 42, 0, 9, "admin"); err != nil {
 		t.Fatal(err)
 	}
 	if deletedID != 42 {
@@ -289,7 +472,7 @@ func TestPostService_UpdateAdminPost_PatchApplied(t *testing.T) {
 	err := NewPostService(repo, allowAll()).UpdateAdminPost(ctx, 1, entity.PostPatch{
 		Title:   &newTitle,
 		Content: &newContent,
-	}, 9, "admin")
+	}, 0, 9, "admin")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -316,3 +499,100 @@ func TestPostService_GetAdminPostByID_OwnDraftScope(t *testing.T) {
 		t.Fatalf("unexpected: %+v %v", got, err)
 	}
 }
+
+func TestPostService_GetPublicPostBySlug(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("current slug", func(t *testing.T) {
+		repo := &fakePostRepo{getPublishedBySlugFn: func(ctx context.Context, slug string, locales []string) (entity.Post, error) {
+			return entity.Post{ID: 1, Slug: slug}, nil
+		}}
+		got, err := NewPostService(repo, allowAll()).GetPublicPostBySlug(ctx, "hello", nil)
+		if err != nil || got.Slug != "hello" {
+			t.Fatalf("unexpected: %+v %v", got, err)
+		}
+	})
+
+	t.Run("historical slug resolves to current post", func(t *testing.T) {
+		repo := &fakePostRepo{
+			getPublishedBySlugFn: func(ctx context.Context, slug string, locales []string) (entity.Post, error) {
+				return entity.Post{}, core.ErrNotFound
+			},
+			getPostIDBySlugHistFn: func(ctx context.Context, slug string, locales []string) (uint, error) {
+				if len(locales) != 1 || locales[0] != entity.DefaultLocale {
+					t.Fatalf("history not scoped to the default locale: %v", locales)
+				}
+				return 7, nil
+			},
+			getPublishedByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+				return entity.Post{ID: id, Slug: "new-name"}, nil
+			},
+		}
+		got, err := NewPostService(repo, allowAll()).GetPublicPostBySlug(ctx, "old-name", nil)
+		if err != nil || got.ID != 7 || got.Slug != "new-name" {
+			t.Fatalf("unexpected: %+v %v", got, err)
+		}
+	})
+
+	t.Run("unknown slug", func(t *testing.T) {
+		repo := &fakePostRepo{
+			getPublishedBySlugFn: func(ctx context.Context, slug string, locales []string) (entity.Post, error) {
+				return entity.Post{}, core.ErrNotFound
+			},
+			getPostIDBySlugHistFn: func(ctx context.Context, slug string, locales []string) (uint, error) {
+				return 0, core.ErrNotFound
+			},
+		}
+		_, err := NewPostService(repo, allowAll()).GetPublicPostBySlug(ctx, "nope", nil)
+		if !errors.Is(err, core.ErrNotFound) {
+			t.Fatalf("want ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func TestPostService_UpdateAdminPost_Slug(t *testing.T) {
+	ctx := context.Background()
+	load := func(ctx context.Context, id uint) (entity.Post, error) {
+		return entity.Post{ID: id, Title: "t", Slug: "old"}, nil
+	}
+
+	t.Run("normalized and applied", func(t *testing.T) {
+		var updated entity.Post
+		repo := &fakePostRepo{
+			getByIDFn:      load,
+			isSlugExistsFn: func(ctx context.Context, locale string, slug string) (bool, error) { return false, nil },
+			updateFn: func(ctx context.Context, p entity.Post) error {
+				updated = p
+				return nil
+			},
+		}
+		s := "New Name"
+		if err := NewPostService(repo, allowAll()).UpdateAdminPost(ctx, 1, entity.PostPatch{Slug: &s}, 0, 9, "admin"); err != nil {
+			t.Fatal(err)
+		}
+		if updated.Slug != "new-name" {
+			t.Fatalf("slug: %q", updated.Slug)
+		}
+	})
+
+	t.Run("taken slug rejected", func(t *testing.T) {
+		repo := &fakePostRepo{
+			getByIDFn:      load,
+			isSlugExistsFn: func(ctx context.Context, locale string, slug string) (bool, error) { return true, nil },
+		}
+		s := "taken"
+		err := NewPostService(repo, allowAll()).UpdateAdminPost(ctx, 1, entity.PostPatch{Slug: &s}, 0, 9, "admin")
+		if !errors.Is(err, core.ErrDuplicate) {
+			t.Fatalf("want ErrDuplicate, got %v", err)
+		}
+	})
+
+	t.Run("unsluggable value rejected", func(t *testing.T) {
+		repo := &fakePostRepo{getByIDFn: load}
+		s := "!!!"
+		err := NewPostService(repo, allowAll()).UpdateAdminPost(ctx, 1, entity.PostPatch{Slug: &s}, 0, 9, "admin")
+		if !errors.Is(err, core.ErrInvalidInput) {
+			t.Fatalf("want ErrInvalidInput, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// normalizePostLocale validates the locale of a post payload; blank means DefaultLocale.
+func normalizePostLocale(raw string) (string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return entity.DefaultLocale, nil
+	}
+	locale, err := entity.NormalizeLocale(raw)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", core.ErrInvalidInput, err)
+	}
+	return locale, nil
+}
+
+// localizedPostURL mirrors the frontend routing: posts are addressed by slug, the default
+// locale is served without a prefix, every other locale under /<locale>/.
+func localizedPostURL(siteURL string, locale string, slugValue string) string {
+	if locale == "" || locale == entity.DefaultLocale {
+		return siteURL + "/posts/" + url.PathEscape(slugValue)
+	}
+	return siteURL + "/" + locale + "/posts/" + url.PathEscape(slugValue)
+}
+
+// finishPublicPost swaps a published post for its best translation under the locale chain,
+// then attaches its published translations, series navigation and rendered content.
+func (s *PostService) finishPublicPost(ctx context.Context, post entity.Post, locales []string) (entity.Post, error) {
+	if len(locales) > 0 && post.TranslationGroupID != nil && post.Locale != locales[0] {
+		members, err := s.repo.GetTranslations(ctx, []uint{*post.TranslationGroupID}, true)
+		if err != nil {
+			return entity.Post{}, normalizeServiceErrorWithOpMsg("post.translation.resolve", "list post translations failed", err)
+		}
+		if best, ok := preferredTranslation(members, locales); ok && best.ID != post.ID {
+			translated, err := s.repo.GetPublishedByID(ctx, best.ID)
+			switch {
+			case err == nil:
+				post = translated
+			case !errors.Is(err, core.ErrNotFound):
+				return entity.Post{}, normalizeServiceErrorWithOpMsg("post.translation.load", "load post translation failed", err)
+			}
+		}
+	}
+
+	posts := []entity.Post{post}
+	if err := s.attachTranslations(ctx, posts, true); err != nil {
+		return entity.Post{}, err
+	}
+	if err := s.attachSeries(ctx, &posts[0]); err != nil {
+		return entity.Post{}, err
+	}
+	s.attachRendered(&posts[0])
+	return posts[0], nil
+}
+
+// preferredTranslation returns the member whose locale comes first in the chain.
+func preferredTranslation(members []entity.Post, locales []string) (entity.Post, bool) {
+	best, bestRank := entity.Post{}, len(locales)
+	for _, m := range members {
+		if rank := slices.Index(locales, m.Locale); rank >= 0 && rank < bestRank {
+			best, bestRank = m, rank
+		}
+	}
+	return best, bestRank < len(locales)
+}
+
+// attachTranslations fills Translations of every grouped post with the other members of its
+// group. Public reads pass publishedOnly so unpublished translations are never disclosed.
+func (s *PostService) attachTranslations(ctx context.Context, posts []entity.Post, publishedOnly bool) error {
+	var groupIDs []uint
+	for _, p := range posts {
+		if p.TranslationGroupID != nil && !slices.Contains(groupIDs, *p.TranslationGroupID) {
+			groupIDs = append(groupIDs, *p.TranslationGroupID)
+		}
+	}
+	if len(groupIDs) == 0 {
+		return nil
+	}
+	members, err := s.repo.GetTranslations(ctx, groupIDs, publishedOnly)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("post.translation.list", "list post translations failed", err)
+	}
+	for i := range posts {
+		if posts[i].TranslationGroupID == nil {
+			continue
+		}
+		for _, m := range members {
+			if m.ID == posts[i].ID || m.TranslationGroupID == nil || *m.TranslationGroupID != *posts[i].TranslationGroupID {
+				continue
+			}
+			posts[i].Translations = append(posts[i].Translations, entity.PostTranslation{
+				ID: m.ID, Locale: m.Locale, Slug: m.Slug, Title: m.Title, Status: m.Status,
+			})
+		}
+	}
+	return nil
+}
+
+// LinkAdminPostTranslation adds translationID to the translation group of post id, starting a
+// group when the post has none. A post can only join one group, and a group holds at most
+// one post per locale; a translation that belonged to another group leaves it first.
+func (s *PostService) LinkAdminPostTranslation(ctx context.Context, id uint, translationID uint, actorRole string) error {
+	if err := s.authorizePostAction(ctx, actorRole, core.PostPermissionUpdateAnyPost); err != nil {
+		return err
+	}
+	if id == translationID {
+		return fmt.Errorf("%w: a post cannot be its own translation", core.ErrInvalidInput)
+	}
+	post, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("post.translation.link.load", "load post failed", err)
+	}
+	translation, err := s.repo.GetByID(ctx, translationID)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("post.translation.link.load", "load translation failed", err)
+	}
+	if post.Locale == translation.Locale {
+		return fmt.Errorf("%w: both posts are in locale %s", core.ErrConflict, post.Locale)
+	}
+
+	groupID := post.ID
+	if post.TranslationGroupID != nil {
+		groupID = *post.TranslationGroupID
+	}
+	if translation.TranslationGroupID != nil && *translation.TranslationGroupID == groupID {
+		return nil
+	}
+	post.TranslationGroupID = &groupID
+	if err := s.checkGroupLocaleFree(ctx, post, translation.Locale); err != nil {
+		return err
+	}
+	if err := s.leaveTranslationGroup(ctx, translation); err != nil {
+		return err
+	}
+
+	ids := []uint{translation.ID}
+	if groupID == post.ID {
+		ids = append(ids, post.ID)
+	}
+	if err := s.repo.SetTranslationGroup(ctx, ids, &groupID); err != nil {
+		return normalizeServiceErrorWithOpMsg("post.translation.link", "link post translation failed", err)
+	}
+	return nil
+}
+
+// UnlinkAdminPostTranslation takes post id out of its translation group.
+func (s *PostService) UnlinkAdminPostTranslation(ctx context.Context, id uint, actorRole string) error {
+	if err := s.authorizePostAction(ctx, actorRole, core.PostPermissionUpdateAnyPost); err != nil {
+		return err
+	}
+	post, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("post.translation.unlink.load", "load post failed", err)
+	}
+	if post.TranslationGroupID == nil {
+		return fmt.Errorf("%w: post has no translations", core.ErrConflict)
+	}
+	return s.leaveTranslationGroup(ctx, post)
+}
+
+// leaveTranslationGroup detaches post from its group. A group left with a single post is
+// dissolved, and a group keyed by the leaving post is re-keyed so the ID can start a new one.
+func (s *PostService) leaveTranslationGroup(ctx context.Context, post entity.Post) error {
+	if post.TranslationGroupID == nil {
+		return nil
+	}
+	groupID := *post.TranslationGroupID
+	members, err := s.repo.GetTranslations(ctx, []uint{groupID}, false)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("post.translation.leave.list", "list post translations failed", err)
+	}
+	remaining := make([]uint, 0, len(members))
+	for _, m := range members {
+		if m.ID != post.ID {
+			remaining = append(remaining, m.ID)
+		}
+	}
+
+	if err := s.repo.SetTranslationGroup(ctx, []uint{post.ID}, nil); err != nil {
+		return normalizeServiceErrorWithOpMsg("post.translation.leave", "unlink post translation failed", err)
+	}
+	switch {
+	case len(remaining) == 1:
+		err = s.repo.SetTranslationGroup(ctx, remaining, nil)
+	case len(remaining) > 1 && groupID == post.ID:
+		newGroupID := slices.Min(remaining)
+		err = s.repo.SetTranslationGroup(ctx, remaining, &newGroupID)
+	}
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("post.translation.leave.regroup", "regroup remaining translations failed", err)
+	}
+	return nil
+}
+
+// checkGroupLocaleFree rejects a locale another member of post's translation group already uses.
+func (s *PostService) checkGroupLocaleFree(ctx context.Context, post entity.Post, locale string) error {
+	if post.TranslationGroupID == nil {
+		return nil
+	}
+	members, err := s.repo.GetTranslations(ctx, []uint{*post.TranslationGroupID}, false)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("post.translation.check_locale", "list post translations failed", err)
+	}
+	for _, m := range members {
+		if m.ID != post.ID && m.Locale == locale {
+			return fmt.Errorf("%w: translation group already has a %s post (ID %d)", core.ErrConflict, locale, m.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// translationRepo backs the translation-related repository calls with an in-memory slice.
+func translationRepo(posts *[]entity.Post) *fakePostRepo {
+	find := func(id uint) (int, bool) {
+		i := slices.IndexFunc(*posts, func(p entity.Post) bool { return p.ID == id })
+		return i, i >= 0
+	}
+	return &fakePostRepo{
+		getByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+			if i, ok := find(id); ok {
+				return (*posts)[i], nil
+			}
+			return entity.Post{}, core.ErrNotFound
+		},
+		getPublishedByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+			if i, ok := find(id); ok && (*posts)[i].Status == entity.StatusPublished {
+				return (*posts)[i], nil
+			}
+			return entity.Post{}, core.ErrNotFound
+		},
+		getTranslationsFn: func(ctx context.Context, groupIDs []uint, publishedOnly bool) ([]entity.Post, error) {
+			var out []entity.Post
+			for _, p := range *posts {
+				if p.TranslationGroupID == nil || !slices.Contains(groupIDs, *p.TranslationGroupID) {
+					continue
+				}
+				if publishedOnly && p.Status != entity.StatusPublished {
+					continue
+				}
+				out = append(out, p)
+			}
+			return out, nil
+		},
+		setTranslationGroupFn: func(ctx context.Context, ids []uint, groupID *uint) error {
+			for _, id := range ids {
+				i, ok := find(id)
+				if !ok {
+					return core.ErrNotFound
+				}
+				if groupID == nil {
+					(*posts)[i].TranslationGroupID = nil
+				} else {
+					g := *groupID
+					(*posts)[i].TranslationGroupID = &g
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func groupOf(posts []entity.Post, id uint) *uint {
+	for _, p := range posts {
+		if p.ID == id {
+			return p.TranslationGroupID
+		}
+	}
+	return nil
+}
+
+func TestPostService_LinkAdminPostTranslation(t *testing.T) {
+	ctx := context.Background()
+	posts := []entity.Post{
+		{ID: 1, Locale: "zh-CN"},
+		{ID: 2, Locale: "en"},
+		{ID: 3, Locale: "ja"},
+		{ID: 4, Locale: "en"},
+	}
+	svc := NewPostService(translationRepo(&posts), allowAll())
+
+	if err := svc.LinkAdminPostTranslation(ctx, 1, 2, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if g1, g2 := groupOf(posts, 1), groupOf(posts, 2); g1 == nil || g2 == nil || *g1 != 1 || *g2 != 1 {
+		t.Fatalf("want both in group 1, got %v %v", g1, g2)
+	}
+	// Linking through any member joins the same group.
+	if err := svc.LinkAdminPostTranslation(ctx, 2, 3, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if g := groupOf(posts, 3); g == nil || *g != 1 {
+		t.Fatalf("want post 3 in group 1, got %v", g)
+	}
+
+	if err := svc.LinkAdminPostTranslation(ctx, 1, 4, "admin"); !errors.Is(err, core.ErrConflict) {
+		t.Fatalf("second en post: want ErrConflict, got %v", err)
+	}
+	if err := svc.LinkAdminPostTranslation(ctx, 2, 4, "admin"); !errors.Is(err, core.ErrConflict) {
+		t.Fatalf("same locale: want ErrConflict, got %v", err)
+	}
+	if err := svc.LinkAdminPostTranslation(ctx, 1, 1, "admin"); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("self link: want ErrInvalidInput, got %v", err)
+	}
+
+	editor := &fakeAuthorizer{allow: map[core.PostPermission]bool{core.PostPermissionUpdateOwnDraft: true}}
+	if err := NewPostService(translationRepo(&posts), editor).LinkAdminPostTranslation(ctx, 1, 4, "user"); !errors.Is(err, core.ErrPermission) {
+		t.Fatalf("want ErrPermission, got %v", err)
+	}
+}
+
+func TestPostService_UnlinkAdminPostTranslation(t *testing.T) {
+	ctx := context.Background()
+	group := func(id uint) *uint { return &id }
+	posts := []entity.Post{
+		{ID: 1, Locale: "zh-CN", TranslationGroupID: group(1)},
+		{ID: 2, Locale: "en", TranslationGroupID: group(1)},
+		{ID: 3, Locale: "ja", TranslationGroupID: group(1)},
+	}
+	svc := NewPostService(translationRepo(&posts), allowAll())
+
+	// The group is keyed by post 1, so it is re-keyed when post 1 leaves.
+	if err := svc.UnlinkAdminPostTranslation(ctx, 1, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if g := groupOf(posts, 1); g != nil {
+		t.Fatalf("post 1 still grouped: %v", *g)
+	}
+	if g2, g3 := groupOf(posts, 2), groupOf(posts, 3); g2 == nil || g3 == nil || *g2 != 2 || *g3 != 2 {
+		t.Fatalf("want remaining posts re-keyed to 2, got %v %v", g2, g3)
+	}
+
+	// A group left with a single post is dissolved.
+	if err := svc.UnlinkAdminPostTranslation(ctx, 3, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if g := groupOf(posts, 2); g != nil {
+		t.Fatalf("single post should leave no group, got %v", *g)
+	}
+	if err := svc.UnlinkAdminPostTranslation(ctx, 2, "admin"); !errors.Is(err, core.ErrConflict) {
+		t.Fatalf("ungrouped post: want ErrConflict, got %v", err)
+	}
+}
+
+func TestPostService_GetPublicPostByID_LocaleChain(t *testing.T) {
+	ctx := context.Background()
+	group := uint(1)
+	posts := []entity.Post{
+		{ID: 1, Locale: "zh-CN", Slug: "ni-hao", Status: entity.StatusPublished, TranslationGroupID: &group},
+		{ID: 2, Locale: "en", Slug: "hello", Status: entity.StatusPublished, TranslationGroupID: &group},
+		{ID: 3, Locale: "ja", Slug: "konnichiwa", Status: entity.StatusDraft, TranslationGroupID: &group},
+	}
+	svc := NewPostService(translationRepo(&posts), allowAll())
+
+	got, err := svc.GetPublicPostByID(ctx, 1, []string{"ja", "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != 2 {
+		t.Fatalf("want the en translation (ja is unpublished), got post %d", got.ID)
+	}
+	if len(got.Translations) != 1 || got.Translations[0].ID != 1 || got.Translations[0].Locale != "zh-CN" {
+		t.Fatalf("want only the published zh-CN translation, got %+v", got.Translations)
+	}
+
+	got, err = svc.GetPublicPostByID(ctx, 2, []string{"fr"})
+	if err != nil || got.ID != 2 {
+		t.Fatalf("no locale match should keep the requested post, got %d %v", got.ID, err)
+	}
+}
+
+func TestPostService_CreateAdminPost_Locale(t *testing.T) {
+	ctx := context.Background()
+	var checked []string
+	repo := &fakePostRepo{
+		isSlugExistsFn: func(ctx context.Context, locale string, slug string) (bool, error) {
+			checked = append(checked, locale+"/"+slug)
+			return false, nil
+		},
+		createFn: func(ctx context.Context, p entity.Post) (entity.Post, error) { return p, nil },
+	}
+	svc := NewPostService(repo, allowAll())
+
+	got, err := svc.CreateAdminPost(ctx, 5, "admin", entity.Post{Title: "Hello"})
+	if err != nil || got.Locale != entity.DefaultLocale {
+		t.Fatalf("want default locale, got %q %v", got.Locale, err)
+	}
+	got, err = svc.CreateAdminPost(ctx, 5, "admin", entity.Post{Title: "Hello", Locale: "EN-us"})
+	if err != nil || got.Locale != "en-US" {
+		t.Fatalf("want normalized locale, got %q %v", got.Locale, err)
+	}
+	if !slices.Equal(checked, []string{"zh-CN/hello", "en-US/hello"}) {
+		t.Fatalf("slug uniqueness not checked per locale: %v", checked)
+	}
+	if _, err := svc.CreateAdminPost(ctx, 5, "admin", entity.Post{Title: "Hello", Locale: "english"}); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("want ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestPostService_UpdateAdminPost_Locale(t *testing.T) {
+	ctx := context.Background()
+	group := uint(1)
+	posts := []entity.Post{
+		{ID: 1, Title: "t", Slug: "hello", Locale: "zh-CN", TranslationGroupID: &group},
+		{ID: 2, Title: "t", Slug: "hello", Locale: "en", TranslationGroupID: &group},
+	}
+	repo := translationRepo(&posts)
+	var checked string
+	repo.isSlugExistsFn = func(ctx context.Context, locale string, slug string) (bool, error) {
+		checked = locale + "/" + slug
+		return false, nil
+	}
+	var updated entity.Post
+	repo.updateFn = func(ctx context.Context, p entity.Post) error {
+		updated = p
+		return nil
+	}
+	svc := NewPostService(repo, allowAll())
+
+	en := "en"
+	if err := svc.UpdateAdminPost(ctx, 1, entity.PostPatch{Locale: &en}, 0, 9, "admin"); !errors.Is(err, core.ErrConflict) {
+		t.Fatalf("locale taken in group: want ErrConflict, got %v", err)
+	}
+	fr := "fr"
+	if err := svc.UpdateAdminPost(ctx, 1, entity.PostPatch{Locale: &fr}, 0, 9, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Locale != "fr" || checked != "fr/hello" {
+		t.Fatalf("slug not re-checked in the new locale: %q %q", updated.Locale, checked)
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// trashPurgeBatch bounds how many expired posts one cleanup tick purges.
+const trashPurgeBatch = 50
+
+// ListTrashedPosts returns posts in the trash, most recently deleted first.
+// Anyone who may delete posts may also inspect and restore what they deleted.
+func (s *PostService) ListTrashedPosts(ctx context.Context, actorRole string) ([]entity.Post, error) {
+	if err := s.authorizePostAction(ctx, actorRole, core.PostPermissionDeletePost); err != nil {
+		return nil, err
+	}
+	posts, err := s.repo.GetTrashed(ctx)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("post.trash.list", "list trashed posts failed", err)
+	}
+	return posts, nil
+}
+
+// RestoreAdminPost takes a post out of the trash with the status it had when it was deleted.
+func (s *PostService) RestoreAdminPost(ctx context.Context, id uint, actorRole string) error {
+	if err := s.authorizePostAction(ctx, actorRole, core.PostPermissionDeletePost); err != nil {
+		return err
+	}
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return normalizeServiceErrorWithOpMsg("post.trash.restore", "restore trashed post failed", err)
+	}
+	s.InvalidateRelated()
+	return nil
+}
+
+// PurgeAdminPost permanently removes a trashed post, releasing its slug and media references.
+func (s *PostService) PurgeAdminPost(ctx context.Context, id uint, actorRole string) error {
+	if err := s.authorizePostAction(ctx, actorRole, core.PostPermissionPurgePost); err != nil {
+		return err
+	}
+	if err := s.repo.Purge(ctx, id); err != nil {
+		return normalizeServiceErrorWithOpMsg("post.trash.purge", "purge trashed post failed", err)
+	}
+	return nil
+}
+
+// PurgeExpiredTrash permanently removes posts that were trashed before cutoff.
+// It is driven by a background ticker configured with the trash retention period.
+func (s *PostService) PurgeExpiredTrash(ctx context.Context, cutoff time.Time) error {
+	expired, err := s.repo.GetTrashedBefore(ctx, cutoff, trashPurgeBatch)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("post.trash.list_expired", "list expired trashed posts failed", err)
+	}
+	for _, post := range expired {
+		if err := s.repo.Purge(ctx, post.ID); err != nil {
+			log.Printf("[WARN] Trashed post (ID: %d) could not be purged: %v", post.ID, err)
+		}
+	}
+	return nil
+}
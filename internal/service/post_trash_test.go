@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+func TestPostService_ListTrashedPosts(t *testing.T) {
+	ctx := context.Background()
+	deletedAt := time.Now()
+	repo := &fakePostRepo{getTrashedFn: func(ctx context.Context) ([]entity.Post, error) {
+		return []entity.Post{{ID: 1, DeletedAt: &deletedAt}}, nil
+	}}
+
+	got, err := NewPostService(repo, allowAll()).ListTrashedPosts(ctx, "admin")
+	if err != nil || len(got) != 1 {
+		t.Fatalf("unexpected: %+v %v", got, err)
+	}
+	if _, err := NewPostService(repo, authorScope()).ListTrashedPosts(ctx, "user"); !errors.Is(err, core.ErrPermission) {
+		t.Fatalf("author listing trash: want ErrPermission, got %v", err)
+	}
+}
+
+func TestPostService_RestoreAdminPost_NotTrashed(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakePostRepo{restoreFn: func(ctx context.Context, id uint) error { return core.ErrNotFound }}
+
+	if err := NewPostService(repo, allowAll()).RestoreAdminPost(ctx, 1, "admin"); !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("want ErrNotFound, got %v", err)
+	}
+}
+
+func TestPostService_PurgeAdminPost_RequiresPurgePermission(t *testing.T) {
+	ctx := context.Background()
+	var purged []uint
+	repo := &fakePostRepo{purgeFn: func(ctx context.Context, id uint) error {
+		purged = append(purged, id)
+		return nil
+	}}
+
+	deleteOnly := &fakeAuthorizer{allow: map[core.PostPermission]bool{core.PostPermissionDeletePost: true}}
+	if err := NewPostService(repo, deleteOnly).PurgeAdminPost(ctx, 1, "admin"); !errors.Is(err, core.ErrPermission) {
+		t.Fatalf("want ErrPermission, got %v", err)
+	}
+	if err := NewPostService(repo, allowAll()).PurgeAdminPost(ctx, 1, "admin"); err != nil {
+		t.Fatalf("PurgeAdminPost: %v", err)
+	}
+	if len(purged) != 1 || purged[0] != 1 {
+		t.Fatalf("purged: %v", purged)
+	}
+}
+
+func TestPostService_PurgeExpiredTrash(t *testing.T) {
+	ctx := context.Background()
+	cutoff := time.Now().AddDate(0, 0, -30)
+	var purged []uint
+	repo := &fakePostRepo{
+		getTrashedBeforeFn: func(ctx context.Context, got time.Time, limit int) ([]entity.Post, error) {
+			if !got.Equal(cutoff) || limit != trashPurgeBatch {
+				t.Fatalf("cutoff=%v limit=%d", got, limit)
+			}
+			return []entity.Post{{ID: 1}, {ID: 2}, {ID: 3}}, nil
+		},
+		purgeFn: func(ctx context.Context, id uint) error {
+			if id == 2 {
+				return errors.New("db down")
+			}
+			purged = append(purged, id)
+			return nil
+		},
+	}
+
+	// One failing purge must not stop the rest of the batch.
+	if err := NewPostService(repo, nil).PurgeExpiredTrash(ctx, cutoff); err != nil {
+		t.Fatalf("PurgeExpiredTrash: %v", err)
+	}
+	if len(purged) != 2 || purged[0] != 1 || purged[1] != 3 {
+		t.Fatalf("purged: %v", purged)
+	}
+}
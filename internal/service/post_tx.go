@@ -0,0 +1,45 @@
+package service
+
+import "context"
+
+// postTxKey marks a ctx that runs inside (*PostService).withinTx.
+type postTxKey struct{}
+
+// postTx collects the work to do once the transaction has committed.
+type postTx struct {
+	afterCommit []func()
+}
+
+// withinTx runs fn in one transaction when a Transactor is set, and directly otherwise.
+// A call made inside another withinTx joins the outer transaction. Callbacks registered with
+// afterCommit run once the outermost transaction has committed and are dropped on rollback.
+func (s *PostService) withinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.tx == nil || inTransaction(ctx) {
+		return fn(ctx)
+	}
+	state := &postTx{}
+	if err := s.tx.WithinTransaction(context.WithValue(ctx, postTxKey{}, state), fn); err != nil {
+		return err
+	}
+	for _, f := range state.afterCommit {
+		f()
+	}
+	return nil
+}
+
+// inTransaction reports whether ctx runs inside withinTx with a Transactor set.
+func inTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(postTxKey{}).(*postTx)
+	return ok
+}
+
+// afterCommit runs f once the transaction carried by ctx has committed, or right away when
+// ctx carries none. Cache invalidation goes through it so a concurrent reader cannot cache
+// the old state again before the new one is visible.
+func afterCommit(ctx context.Context, f func()) {
+	if state, ok := ctx.Value(postTxKey{}).(*postTx); ok {
+		state.afterCommit = append(state.afterCommit, f)
+		return
+	}
+	f()
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// ErrPostVersionConflict is returned when a write was based on an outdated version of a post.
+var ErrPostVersionConflict = fmt.Errorf("%w: post was changed by someone else", core.ErrConflict)
+
+// PostVersionConflictError carries the version currently stored, so an editor can reload
+// the post and merge their changes. It unwraps to ErrPostVersionConflict.
+type PostVersionConflictError struct {
+	CurrentVersion uint
+}
+
+func (e *PostVersionConflictError) Error() string {
+	return fmt.Sprintf("%v (current version %d)", ErrPostVersionConflict, e.CurrentVersion)
+}
+
+func (e *PostVersionConflictError) Unwrap() error { return ErrPostVersionConflict }
+
+// checkPostVersion enforces a client precondition such as If-Match.
+// An ifVersion of 0 means the caller did not send one.
+func checkPostVersion(post entity.Post, ifVersion uint) error {
+	if ifVersion != 0 && ifVersion != post.Version {
+		return &PostVersionConflictError{CurrentVersion: post.Version}
+	}
+	return nil
+}
+
+// versionConflict reports a write the repository rejected because the post changed after it
+// was loaded, reloading the post to tell the caller which version won.
+func (s *PostService) versionConflict(ctx context.Context, id uint) error {
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return normalizeServiceErrorWithOpMsg("post.version_conflict.reload", "reload post after version conflict failed", err)
+	}
+	return &PostVersionConflictError{CurrentVersion: current.Version}
+}
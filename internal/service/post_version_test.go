@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+func TestPostService_UpdateAdminPost_StaleIfVersion(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "old", Slug: "old", AuthorID: 9, Version: 3}
+	svc := NewPostService(statefulPostRepo(post), allowAll())
+
+	title := "new"
+	err := svc.UpdateAdminPost(ctx, 1, entity.PostPatch{Title: &title}, 2, 9, "admin")
+	var conflict *PostVersionConflictError
+	if !errors.As(err, &conflict) || conflict.CurrentVersion != 3 {
+		t.Fatalf("want PostVersionConflictError{3}, got %v", err)
+	}
+	if !errors.Is(err, core.ErrConflict) {
+		t.Fatalf("version conflict should unwrap to ErrConflict: %v", err)
+	}
+	if post.Title != "old" {
+		t.Fatalf("stale write was applied: %q", post.Title)
+	}
+
+	if err := svc.UpdateAdminPost(ctx, 1, entity.PostPatch{Title: &title}, 3, 9, "admin"); err != nil {
+		t.Fatalf("matching version: %v", err)
+	}
+}
+
+func TestPostService_PublishAdminPost_ConcurrentWriteReportsCurrentVersion(t *testing.T) {
+	ctx := context.Background()
+	loads := 0
+	repo := &fakePostRepo{
+		getByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+			loads++
+			// The second load happens after another editor's save bumped the version.
+			return entity.Post{ID: id, Title: "t", Slug: "t", Content: "c", AuthorID: 9, Version: uint(loads)}, nil
+		},
+		updateFn: func(ctx context.Context, p entity.Post) error {
+			return fmt.Errorf("%w: post %d is no longer at version %d", core.ErrConflict, p.ID, p.Version)
+		},
+	}
+
+	err := NewPostService(repo, allowAll()).PublishAdminPost(ctx, 1, 0, 9, "admin")
+	var conflict *PostVersionConflictError
+	if !errors.As(err, &conflict) || conflict.CurrentVersion != 2 {
+		t.Fatalf("want PostVersionConflictError{2}, got %v", err)
+	}
+}
+
+func TestPostService_MovePostToDraft_StaleIfVersion(t *testing.T) {
+	ctx := context.Background()
+	post := &entity.Post{ID: 1, Title: "t", Slug: "t", AuthorID: 9, Status: entity.StatusPublished, Version: 5}
+
+	err := NewPostService(statefulPostRepo(post), allowAll()).MovePostToDraft(ctx, 1, 4, 9, "admin")
+	if !errors.Is(err, ErrPostVersionConflict) {
+		t.Fatalf("want ErrPostVersionConflict, got %v", err)
+	}
+	if post.Status != entity.StatusPublished {
+		t.Fatalf("status changed despite conflict: %v", post.Status)
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gosimple/slug"
+)
+
+// seriesService implements core.SeriesService.
+type seriesService struct {
+	repo core.SeriesRepository
+}
+
+// NewSeriesService creates a SeriesService.
+func NewSeriesService(repo core.SeriesRepository) core.SeriesService {
+	return &seriesService{repo: repo}
+}
+
+// Create creates a new series. The slug is derived from the title when not provided
+// and suffixed with a counter if it is already taken.
+func (s *seriesService) Create(ctx context.Context, series entity.Series) (entity.Series, error) {
+	series.Title = strings.TrimSpace(series.Title)
+	if series.Title == "" {
+		return entity.Series{}, core.ErrInvalidInput
+	}
+	series.Description = strings.TrimSpace(series.Description)
+
+	base := series.Title
+	if strings.TrimSpace(series.Slug) != "" {
+		base = series.Slug
+	}
+	baseSlug := slug.Make(base)
+	if baseSlug == "" {
+		return entity.Series{}, fmt.Errorf("%w: cannot generate a valid slug", core.ErrInvalidInput)
+	}
+	var err error
+	series.Slug, err = s.uniqueSlug(ctx, baseSlug)
+	if err != nil {
+		return entity.Series{}, err
+	}
+
+	created, err := s.repo.Create(ctx, series)
+	if err != nil {
+		return entity.Series{}, normalizeServiceErrorWithOpMsg("series.create", "create series failed", err)
+	}
+	return created, nil
+}
+
+func (s *seriesService) uniqueSlug(ctx context.Context, base string) (string, error) {
+	candidate := base
+	for i := 1; i <= 100; i++ {
+		_, err := s.repo.GetBySlug(ctx, candidate)
+		if errors.Is(err, core.ErrNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", normalizeServiceErrorWithOpMsg("series.unique_slug", "check series slug uniqueness failed", err)
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+	return "", fmt.Errorf("%w: unable to generate unique series slug", core.ErrConflict)
+}
+
+// List returns all series with their published part counts.
+func (s *seriesService) List(ctx context.Context) ([]entity.SeriesWithPartCount, error) {
+	series, err := s.repo.ListWithPartCounts(ctx)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("series.list", "list series failed", err)
+	}
+	return series, nil
+}
+
+// GetBySlug returns a series by slug.
+func (s *seriesService) GetBySlug(ctx context.Context, slugValue string) (entity.Series, error) {
+	if slugValue == "" {
+		return entity.Series{}, core.ErrInvalidInput
+	}
+	series, err := s.repo.GetBySlug(ctx, slugValue)
+	if err != nil {
+		return entity.Series{}, normalizeServiceErrorWithOpMsg("series.get_by_slug", "get series by slug failed", err)
+	}
+	return series, nil
+}
+
+// ListPublishedParts returns the series with the given slug and its published posts in order.
+func (s *seriesService) ListPublishedParts(ctx context.Context, slugValue string) (entity.Series, []entity.SeriesPart, error) {
+	series, err := s.GetBySlug(ctx, slugValue)
+	if err != nil {
+		return entity.Series{}, nil, err
+	}
+	parts, err := s.repo.ListParts(ctx, series.ID, true)
+	if err != nil {
+		return entity.Series{}, nil, normalizeServiceErrorWithOpMsg("series.list_parts", "list series parts failed", err)
+	}
+	return series, parts, nil
+}
+
+// Update changes the title, slug and/or description of a series. Changing the title alone
+// keeps the slug so existing series URLs stay valid.
+func (s *seriesService) Update(ctx context.Context, id uint, patch entity.SeriesPatch) (entity.Series, error) {
+	if id == 0 {
+		return entity.Series{}, core.ErrInvalidInput
+	}
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return entity.Series{}, normalizeServiceErrorWithOpMsg("series.update.load", "load series failed", err)
+	}
+
+	if patch.Title != nil {
+		title := strings.TrimSpace(*patch.Title)
+		if title == "" {
+			return entity.Series{}, core.ErrInvalidInput
+		}
+		existing.Title = title
+	}
+	if patch.Description != nil {
+		existing.Description = strings.TrimSpace(*patch.Description)
+	}
+	if patch.Slug != nil {
+		newSlug := slug.Make(*patch.Slug)
+		if newSlug == "" {
+			return entity.Series{}, fmt.Errorf("%w: slug cannot be empty", core.ErrInvalidInput)
+		}
+		if newSlug != existing.Slug {
+			other, err := s.repo.GetBySlug(ctx, newSlug)
+			switch {
+			case err == nil && other.ID != existing.ID:
+				return entity.Series{}, fmt.Errorf("%w: slug is already in use", core.ErrDuplicate)
+			case err != nil && !errors.Is(err, core.ErrNotFound):
+				return entity.Series{}, normalizeServiceErrorWithOpMsg("series.update.check_slug", "check series slug uniqueness failed", err)
+			}
+			existing.Slug = newSlug
+		}
+	}
+
+	updated, err := s.repo.Update(ctx, existing)
+	if err != nil {
+		return entity.Series{}, normalizeServiceErrorWithOpMsg("series.update", "update series failed", err)
+	}
+	return updated, nil
+}
+
+// Delete removes a series; its posts stay published and just leave the series.
+func (s *seriesService) Delete(ctx context.Context, id uint) error {
+	if id == 0 {
+		return core.ErrInvalidInput
+	}
+	return normalizeServiceErrorWithOpMsg("series.delete", "delete series failed", s.repo.Delete(ctx, id))
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// memSeriesRepo keeps series and their parts in memory. Parts are expected in position order.
+type memSeriesRepo struct {
+	series []entity.Series
+	parts  map[uint][]entity.SeriesPart
+}
+
+func (m *memSeriesRepo) Create(ctx context.Context, s entity.Series) (entity.Series, error) {
+	s.ID = uint(len(m.series) + 1)
+	m.series = append(m.series, s)
+	return s, nil
+}
+func (m *memSeriesRepo) GetByID(ctx context.Context, id uint) (entity.Series, error) {
+	for _, s := range m.series {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return entity.Series{}, core.ErrNotFound
+}
+func (m *memSeriesRepo) GetBySlug(ctx context.Context, slug string) (entity.Series, error) {
+	for _, s := range m.series {
+		if s.Slug == slug {
+			return s, nil
+		}
+	}
+	return entity.Series{}, core.ErrNotFound
+}
+func (m *memSeriesRepo) ListWithPartCounts(ctx context.Context) ([]entity.SeriesWithPartCount, error) {
+	out := make([]entity.SeriesWithPartCount, len(m.series))
+	for i, s := range m.series {
+		out[i] = entity.SeriesWithPartCount{Series: s, PartCount: int64(len(m.parts[s.ID]))}
+	}
+	return out, nil
+}
+func (m *memSeriesRepo) Update(ctx context.Context, s entity.Series) (entity.Series, error) {
+	for i := range m.series {
+		if m.series[i].ID == s.ID {
+			m.series[i] = s
+			return s, nil
+		}
+	}
+	return entity.Series{}, core.ErrNotFound
+}
+func (m *memSeriesRepo) Delete(ctx context.Context, id uint) error {
+	for i := range m.series {
+		if m.series[i].ID == id {
+			m.series = append(m.series[:i], m.series[i+1:]...)
+			delete(m.parts, id)
+			return nil
+		}
+	}
+	return core.ErrNotFound
+}
+func (m *memSeriesRepo) ListParts(ctx context.Context, seriesID uint, publishedOnly bool) ([]entity.SeriesPart, error) {
+	var out []entity.SeriesPart
+	for _, p := range m.parts[seriesID] {
+		if publishedOnly && p.Status != entity.StatusPublished {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func TestSeriesService_Create(t *testing.T) {
+	ctx := context.Background()
+	repo := &memSeriesRepo{series: []entity.Series{{ID: 1, Title: "Go Basics", Slug: "go-basics"}}}
+	svc := NewSeriesService(repo)
+
+	if _, err := svc.Create(ctx, entity.Series{Title: "  "}); !errors.Is(err, core.ErrInvalidInput) {
+		t.Fatalf("want ErrInvalidInput, got %v", err)
+	}
+
+	created, err := svc.Create(ctx, entity.Series{Title: " Go Basics ", Description: " part two "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Slug != "go-basics-1" || created.Title != "Go Basics" || created.Description != "part two" {
+		t.Fatalf("unexpected series: %+v", created)
+	}
+}
+
+func TestSeriesService_Update(t *testing.T) {
+	ctx := context.Background()
+	repo := &memSeriesRepo{series: []entity.Series{
+		{ID: 1, Title: "Go", Slug: "go", Description: "old"},
+		{ID: 2, Title: "Rust", Slug: "rust"},
+	}}
+	svc := NewSeriesService(repo)
+
+	taken := "rust"
+	if _, err := svc.Update(ctx, 1, entity.SeriesPatch{Slug: &taken}); !errors.Is(err, core.ErrDuplicate) {
+		t.Fatalf("want ErrDuplicate, got %v", err)
+	}
+
+	title, empty := "Go in Depth", ""
+	updated, err := svc.Update(ctx, 1, entity.SeriesPatch{Title: &title, Description: &empty})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Title != title || updated.Slug != "go" || updated.Description != "" {
+		t.Fatalf("unexpected series: %+v", updated)
+	}
+}
+
+func TestSeriesService_ListPublishedParts(t *testing.T) {
+	ctx := context.Background()
+	repo := &memSeriesRepo{
+		series: []entity.Series{{ID: 1, Title: "Go", Slug: "go"}},
+		parts: map[uint][]entity.SeriesPart{1: {
+			{ID: 10, Position: 1, Status: entity.StatusPublished},
+			{ID: 11, Position: 2, Status: entity.StatusDraft},
+			{ID: 12, Position: 3, Status: entity.StatusPublished},
+		}},
+	}
+	svc := NewSeriesService(repo)
+
+	series, parts, err := svc.ListPublishedParts(ctx, "go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if series.ID != 1 || len(parts) != 2 || parts[0].ID != 10 || parts[1].ID != 12 {
+		t.Fatalf("unexpected parts: %+v", parts)
+	}
+	if _, _, err := svc.ListPublishedParts(ctx, "missing"); !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("want ErrNotFound, got %v", err)
+	}
+}
+
+func TestPostService_UpdateAdminPost_Series(t *testing.T) {
+	ctx := context.Background()
+	seriesID := uint(1)
+	series := &memSeriesRepo{
+		series: []entity.Series{{ID: 1, Title: "Go", Slug: "go"}},
+		parts: map[uint][]entity.SeriesPart{1: {
+			{ID: 10, Position: 1},
+			{ID: 11, Position: 2},
+		}},
+	}
+	var updated entity.Post
+	repo := &fakePostRepo{
+		getByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+			return entity.Post{ID: id, Title: "part"}, nil
+		},
+		updateFn: func(ctx context.Context, p entity.Post) error {
+			updated = p
+			return nil
+		},
+	}
+	svc := NewPostService(repo, allowAll())
+	svc.SetSeriesRepository(series)
+
+	t.Run("joining appends at the end", func(t *testing.T) {
+		if err := svc.UpdateAdminPost(ctx, 12, entity.PostPatch{SeriesID: &seriesID}, 0, 9, "admin"); err != nil {
+			t.Fatal(err)
+		}
+		if updated.SeriesID == nil || *updated.SeriesID != 1 || updated.SeriesPosition != 3 {
+			t.Fatalf("unexpected placement: %v %d", updated.SeriesID, updated.SeriesPosition)
+		}
+	})
+
+	t.Run("taken position conflicts", func(t *testing.T) {
+		pos := 2
+		err := svc.UpdateAdminPost(ctx, 12, entity.PostPatch{SeriesID: &seriesID, SeriesPosition: &pos}, 0, 9, "admin")
+		if !errors.Is(err, core.ErrConflict) {
+			t.Fatalf("want ErrConflict, got %v", err)
+		}
+	})
+
+	t.Run("unknown series rejected", func(t *testing.T) {
+		missing := uint(7)
+		err := svc.UpdateAdminPost(ctx, 12, entity.PostPatch{SeriesID: &missing}, 0, 9, "admin")
+		if !errors.Is(err, core.ErrInvalidInput) {
+			t.Fatalf("want ErrInvalidInput, got %v", err)
+		}
+	})
+
+	t.Run("zero leaves the series", func(t *testing.T) {
+		zero := uint(0)
+		if err := svc.UpdateAdminPost(ctx, 11, entity.PostPatch{SeriesID: &zero}, 0, 9, "admin"); err != nil {
+			t.Fatal(err)
+		}
+		if updated.SeriesID != nil || updated.SeriesPosition != 0 {
+			t.Fatalf("post still in series: %v %d", updated.SeriesID, updated.SeriesPosition)
+		}
+	})
+}
+
+func TestPostService_GetPublicPostByID_SeriesNavigation(t *testing.T) {
+	ctx := context.Background()
+	seriesID := uint(1)
+	series := &memSeriesRepo{
+		series: []entity.Series{{ID: 1, Title: "Go", Slug: "go"}},
+		parts: map[uint][]entity.SeriesPart{1: {
+			{ID: 10, Position: 1, Status: entity.StatusPublished},
+			{ID: 11, Position: 2, Status: entity.StatusDraft},
+			{ID: 12, Position: 3, Status: entity.StatusPublished},
+			{ID: 13, Position: 4, Status: entity.StatusPublished},
+		}},
+	}
+	repo := &fakePostRepo{getPublishedByIDFn: func(ctx context.Context, id uint) (entity.Post, error) {
+		return entity.Post{ID: id, Status: entity.StatusPublished, SeriesID: &seriesID, SeriesPosition: 3}, nil
+	}}
+	svc := NewPostService(repo, allowAll())
+	svc.SetSeriesRepository(series)
+
+	got, err := svc.GetPublicPostByID(ctx, 12, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nav := got.Series
+	if nav == nil || nav.Series.Slug != "go" || nav.Total != 3 || nav.Position != 3 {
+		t.Fatalf("unexpected series navigation: %+v", nav)
+	}
+	// The unpublished part 2 is skipped: the previous published part is part 1.
+	if nav.Prev == nil || nav.Prev.ID != 10 || nav.Next == nil || nav.Next.ID != 13 {
+		t.Fatalf("unexpected neighbours: prev=%+v next=%+v", nav.Prev, nav.Next)
+	}
+}
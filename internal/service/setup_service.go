@@ -163,7 +163,7 @@ func (s *SetupService) Install(cfg SetupConfig) error {
 	}
 
 	// 迁移表结构
-	if err := db.AutoMigrate(&model.User{}, &model.Category{}, &model.Tag{}, &model.Post{}, &model.SystemSetting{}, &model.MediaAsset{}, &model.PostAsset{}); err != nil {
+	if err := db.AutoMigrate(&model.User{}, &model.Category{}, &model.Tag{}, &model.Post{}, &model.SystemSetting{}, &model.MediaAsset{}, &model.PostAsset{}, &model.PostSlugHistory{}, &model.PostRevision{}); err != nil {
 		return normalizeServiceErrorWithOpMsg("setup.install.migrate", "schema migration failed", err)
 	}
 
@@ -220,12 +220,46 @@ func (s *SetupService) Install(cfg SetupConfig) error {
 			{"admin", "/api/v1/admin/posts/:id", "PUT"},
 			{"admin", "/api/v1/admin/posts/:id/publish", "POST"},
 			{"admin", "/api/v1/admin/posts/:id/draft", "POST"},
+			{"admin", "/api/v1/admin/posts/:id/schedule", "POST"},
+			{"admin", "/api/v1/admin/posts/review", "GET"},
+			{"admin", "/api/v1/admin/posts/:id/approve", "POST"},
+			{"admin", "/api/v1/admin/posts/:id/reject", "POST"},
+			{"admin", "/api/v1/admin/posts/trash", "GET"},
+			{"admin", "/api/v1/admin/posts/:id/restore", "POST"},
+			{"admin", "/api/v1/admin/posts/:id/translations", "POST"},
+			{"admin", "/api/v1/admin/posts/:id/translations", "DELETE"},
+			{"admin", "/api/v1/admin/posts/:id/previews", "GET"},
+			{"admin", "/api/v1/admin/posts/:id/previews", "POST"},
+			{"admin", "/api/v1/admin/posts/:id/previews/:preview", "DELETE"},
+			{"admin", "/api/v1/admin/posts/bulk", "POST"},
+			{"admin", "/api/v1/admin/comments", "GET"},
+			{"admin", "/api/v1/admin/comments/:id/approve", "POST"},
+			{"admin", "/api/v1/admin/comments/:id/reject", "POST"},
+			{"admin", "/api/v1/admin/comments/:id/spam", "POST"},
+			{"admin", "/api/v1/admin/content-types", "GET"},
+			{"admin", "/api/v1/admin/content-types", "POST"},
+			{"admin", "/api/v1/admin/content-types/:type", "GET"},
+			{"admin", "/api/v1/admin/content-types/:type", "PUT"},
+			{"admin", "/api/v1/admin/content/:type/:id/publish", "POST"},
+			{"admin", "/api/v1/admin/content/:type/:id/draft", "POST"},
+			{"admin", "/api/v1/admin/pages/:id/publish", "POST"},
+			{"admin", "/api/v1/admin/pages/:id/draft", "POST"},
+			{"admin", "/api/v1/admin/pages/reorder", "POST"},
+			{"admin", "/api/v1/series", "POST"},
+			{"admin", "/api/v1/series/:id", "PUT"},
+			{"admin", "/api/v1/admin/analytics/views", "GET"},
+			{"admin", "/api/v1/admin/analytics/posts/:id/views", "GET"},
+			{"admin", "/api/v1/admin/analytics/top-posts", "GET"},
+			{"admin", "/api/v1/admin/import", "POST"},
+			{"admin", "/api/v1/admin/export", "GET"},
 			{"admin", "post", "list:any"},
 			{"admin", "post", "read:any"},
 			{"admin", "post", "update:any"},
 			{"admin", "post", "publish"},
 			{"admin", "post", "unpublish"},
 			{"admin", "post", "delete"},
+			{"admin", "post", "review"},
+			{"admin", "post", "purge"},
 			{"admin", "/api/v1/media", "POST"},
 			{"admin", "/api/v1/tags", "POST"},
 			{"admin", "/api/v1/tags/:id", "PUT"},
@@ -237,9 +271,14 @@ func (s *SetupService) Install(cfg SetupConfig) error {
 
 		if cfg.AdminCanDelete {
 			enforcer.AddPolicy("admin", "/api/v1/admin/posts/:id", "DELETE")
+			enforcer.AddPolicy("admin", "/api/v1/admin/posts/:id/purge", "DELETE")
 			enforcer.AddPolicy("admin", "/api/v1/media/:id", "DELETE")
 			enforcer.AddPolicy("admin", "/api/v1/tags/:id", "DELETE")
 			enforcer.AddPolicy("admin", "/api/v1/categories/:id", "DELETE")
+			enforcer.AddPolicy("admin", "/api/v1/admin/content-types/:type", "DELETE")
+			enforcer.AddPolicy("admin", "/api/v1/admin/content/:type/:id", "DELETE")
+			enforcer.AddPolicy("admin", "/api/v1/admin/pages/:id", "DELETE")
+			enforcer.AddPolicy("admin", "/api/v1/series/:id", "DELETE")
 		}
 
 		// 3. [Role: user] - 普通注册用户
@@ -247,14 +286,64 @@ func (s *SetupService) Install(cfg SetupConfig) error {
 		userRules := [][]string{
 			{"user", "/api/v1/posts", "GET"},
 			{"user", "/api/v1/posts/:id", "GET"},
+			{"user", "/api/v1/posts/slug/:slug", "GET"},
+			{"user", "/api/v1/posts/search", "GET"},
+			{"user", "/api/v1/posts/:id/related", "GET"},
+			{"user", "/api/v1/categories", "GET"},
+			{"user", "/api/v1/categories/slug/:slug", "GET"},
+			{"user", "/api/v1/categories/slug/:slug/posts", "GET"},
+			{"user", "/api/v1/tags", "GET"},
+			{"user", "/api/v1/tags/:slug", "GET"},
+			{"user", "/api/v1/tags/:slug/posts", "GET"},
+			{"user", "/api/v1/posts/:id/comments", "GET"},
+			{"user", "/api/v1/posts/:id/comments", "POST"},
+			{"user", "/feed.xml", "GET"},
+			{"user", "/atom.xml", "GET"},
+			{"user", "/feed.json", "GET"},
+			{"user", "/tags/:slug/feed.xml", "GET"},
+			{"user", "/tags/:slug/atom.xml", "GET"},
+			{"user", "/tags/:slug/feed.json", "GET"},
+			{"user", "/categories/:slug/feed.xml", "GET"},
+			{"user", "/categories/:slug/atom.xml", "GET"},
+			{"user", "/categories/:slug/feed.json", "GET"},
+			{"user", "/sitemap.xml", "GET"},
 			{"user", "/api/v1/admin/posts", "GET"},
 			{"user", "/api/v1/admin/posts", "POST"},
 			{"user", "/api/v1/admin/posts/:id", "GET"},
 			{"user", "/api/v1/admin/posts/:id", "PUT"},
+			{"user", "/api/v1/admin/posts/:id/submit", "POST"},
+			{"user", "/api/v1/admin/posts/:id/revisions", "GET"},
+			{"user", "/api/v1/admin/posts/:id/revisions/diff", "GET"},
+			{"user", "/api/v1/admin/posts/:id/revisions/:rev", "GET"},
+			{"user", "/api/v1/admin/posts/:id/revisions/:rev/restore", "POST"},
+			{"user", "/api/v1/admin/posts/:id/autosave", "GET"},
+			{"user", "/api/v1/admin/posts/:id/autosave", "PUT"},
+			{"user", "/api/v1/admin/posts/:id/autosave", "DELETE"},
+			{"user", "/api/v1/admin/posts/:id/autosave/promote", "POST"},
+			{"user", "/api/v1/admin/posts/:id/previews", "GET"},
+			{"user", "/api/v1/admin/posts/:id/previews", "POST"},
+			{"user", "/api/v1/admin/posts/:id/previews/:preview", "DELETE"},
+			{"user", "/api/v1/admin/posts/bulk", "POST"},
+			{"user", "/api/v1/content/:type", "GET"},
+			{"user", "/api/v1/content/:type/:slug", "GET"},
+			{"user", "/api/v1/admin/content/:type", "GET"},
+			{"user", "/api/v1/admin/content/:type", "POST"},
+			{"user", "/api/v1/admin/content/:type/:id", "GET"},
+			{"user", "/api/v1/admin/content/:type/:id", "PUT"},
+			{"user", "/api/v1/pages", "GET"},
+			{"user", "/api/v1/pages/*path", "GET"},
+			{"user", "/api/v1/admin/pages", "GET"},
+			{"user", "/api/v1/admin/pages", "POST"},
+			{"user", "/api/v1/admin/pages/:id", "GET"},
+			{"user", "/api/v1/admin/pages/:id", "PUT"},
+			{"user", "/api/v1/series", "GET"},
+			{"user", "/api/v1/series/:slug", "GET"},
+			{"user", "/api/v1/series/:slug/posts", "GET"},
 			{"user", "post:draft", "create"},
 			{"user", "post:draft", "list:own"},
 			{"user", "post:draft", "read:own"},
 			{"user", "post:draft", "update:own"},
+			{"user", "post:draft", "submit:own"},
 			{"user", "/api/v1/media", "GET"},
 		}
 		enforcer.AddPolicies(userRules)
@@ -267,6 +356,34 @@ func (s *SetupService) Install(cfg SetupConfig) error {
 		if cfg.AllowAnonymousRead {
 			enforcer.AddPolicy("anonymous", "/api/v1/posts", "GET")
 			enforcer.AddPolicy("anonymous", "/api/v1/posts/:id", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/posts/slug/:slug", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/posts/search", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/posts/:id/related", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/categories", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/categories/slug/:slug", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/categories/slug/:slug/posts", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/tags", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/tags/:slug", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/tags/:slug/posts", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/posts/:id/comments", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/posts/:id/comments", "POST")
+			enforcer.AddPolicy("anonymous", "/feed.xml", "GET")
+			enforcer.AddPolicy("anonymous", "/atom.xml", "GET")
+			enforcer.AddPolicy("anonymous", "/feed.json", "GET")
+			enforcer.AddPolicy("anonymous", "/tags/:slug/feed.xml", "GET")
+			enforcer.AddPolicy("anonymous", "/tags/:slug/atom.xml", "GET")
+			enforcer.AddPolicy("anonymous", "/tags/:slug/feed.json", "GET")
+			enforcer.AddPolicy("anonymous", "/categories/:slug/feed.xml", "GET")
+			enforcer.AddPolicy("anonymous", "/categories/:slug/atom.xml", "GET")
+			enforcer.AddPolicy("anonymous", "/categories/:slug/feed.json", "GET")
+			enforcer.AddPolicy("anonymous", "/sitemap.xml", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/content/:type", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/content/:type/:slug", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/pages", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/pages/*path", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/series", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/series/:slug", "GET")
+			enforcer.AddPolicy("anonymous", "/api/v1/series/:slug/posts", "GET")
 		}
 
 		// 4. [Inheritance] - 角色继承
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MaxSitemapURLs is the sitemaps.org limit of URLs per sitemap file. Larger sites get a
+// sitemap index whose pages each hold at most this many URLs.
+const MaxSitemapURLs = 50000
+
+// SitemapConfig controls the public URLs in the sitemap and the robots.txt rules.
+type SitemapConfig struct {
+	// SiteURL is the public site origin. It must be configured for the sitemap; without it
+	// robots.txt leaves out the Sitemap line.
+	SiteURL string
+	// RobotsDisallow lists path prefixes crawlers are asked to skip. Empty allows everything.
+	RobotsDisallow []string
+	// PageSize overrides MaxSitemapURLs; only tests should need it.
+	PageSize int
+}
+
+// SitemapService builds the XML sitemap and robots.txt.
+type SitemapService struct {
+	repo core.SitemapRepository
+	cfg  SitemapConfig
+}
+
+func NewSitemapService(repo core.SitemapRepository, cfg SitemapConfig) *SitemapService {
+	if cfg.PageSize <= 0 || cfg.PageSize > MaxSitemapURLs {
+		cfg.PageSize = MaxSitemapURLs
+	}
+	cfg.SiteURL = strings.TrimRight(cfg.SiteURL, "/")
+	return &SitemapService{repo: repo, cfg: cfg}
+}
+
+// Build returns the sitemap. Page 0 is /sitemap.xml itself: the full URL set when it fits in
+// one file, otherwise an index of pages 1..n. Pages past the end are ErrNotFound.
+func (s *SitemapService) Build(ctx context.Context, page int) (entity.Sitemap, error) {
+	if page < 0 {
+		return entity.Sitemap{}, core.ErrInvalidInput
+	}
+	siteURL := s.cfg.SiteURL
+
+	total, err := s.repo.CountEntries(ctx)
+	if err != nil {
+		return entity.Sitemap{}, normalizeServiceErrorWithOpMsg("sitemap.count", "count sitemap entries failed", err)
+	}
+	pages := int((total + int64(s.cfg.PageSize) - 1) / int64(s.cfg.PageSize))
+	if pages == 0 {
+		pages = 1 // an empty site still has a (empty) first page
+	}
+
+	switch {
+	case page == 0 && pages > 1:
+		index := entity.Sitemap{Pages: make([]entity.SitemapURL, pages)}
+		for i := range index.Pages {
+			index.Pages[i] = entity.SitemapURL{Loc: siteURL + "/sitemap.xml?page=" + strconv.Itoa(i+1)}
+		}
+		return index, nil
+	case page == 0:
+		page = 1
+	case page > pages:
+		return entity.Sitemap{}, fmt.Errorf("%w: sitemap page %d does not exist", core.ErrNotFound, page)
+	}
+
+	entries, err := s.repo.ListEntries(ctx, (page-1)*s.cfg.PageSize, s.cfg.PageSize)
+	if err != nil {
+		return entity.Sitemap{}, normalizeServiceErrorWithOpMsg("sitemap.list", "list sitemap entries failed", err)
+	}
+	alternates, err := s.postAlternates(ctx, siteURL, entries)
+	if err != nil {
+		return entity.Sitemap{}, err
+	}
+	sitemap := entity.Sitemap{URLs: make([]entity.SitemapURL, 0, len(entries))}
+	for _, e := range entries {
+		u := entity.SitemapURL{Loc: sitemapLoc(siteURL, e), LastMod: e.LastMod}
+		if e.Kind == entity.SitemapEntryPost && e.GroupID != nil {
+			u.Alternates = alternates[*e.GroupID]
+		}
+		sitemap.URLs = append(sitemap.URLs, u)
+	}
+	return sitemap, nil
+}
+
+// postAlternates returns the hreflang alternates of every translation group on the page,
+// keyed by group. Each set lists all language versions, the page itself included, plus an
+// x-default pointing at the DefaultLocale version when there is one. Groups with a single
+// listed post get no alternates.
+func (s *SitemapService) postAlternates(ctx context.Context, siteURL string, entries []entity.SitemapEntry) (map[uint][]entity.LocaleAlternate, error) {
+	var groupIDs []uint
+	for _, e := range entries {
+		if e.Kind == entity.SitemapEntryPost && e.GroupID != nil && !slices.Contains(groupIDs, *e.GroupID) {
+			groupIDs = append(groupIDs, *e.GroupID)
+		}
+	}
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+	members, err := s.repo.ListPostAlternates(ctx, groupIDs)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("sitemap.alternates", "list post translations failed", err)
+	}
+
+	byGroup := make(map[uint][]entity.LocaleAlternate, len(groupIDs))
+	for _, m := range members {
+		if m.GroupID == nil {
+			continue
+		}
+		byGroup[*m.GroupID] = append(byGroup[*m.GroupID], entity.LocaleAlternate{
+			Locale: m.Locale,
+			URL:    localizedPostURL(siteURL, m.Locale, m.Slug),
+		})
+	}
+	for id, alts := range byGroup {
+		if len(alts) < 2 {
+			delete(byGroup, id)
+			continue
+		}
+		for _, a := range alts {
+			if a.Locale == entity.DefaultLocale {
+				byGroup[id] = append(alts, entity.LocaleAlternate{Locale: "x-default", URL: a.URL})
+				break
+			}
+		}
+	}
+	return byGroup, nil
+}
+
+func sitemapLoc(siteURL string, e entity.SitemapEntry) string {
+	switch e.Kind {
+	case entity.SitemapEntryCategory:
+		return siteURL + "/categories/" + url.PathEscape(e.Slug)
+	case entity.SitemapEntryTag:
+		return siteURL + "/tags/" + url.PathEscape(e.Slug)
+	default:
+		return localizedPostURL(siteURL, e.Locale, e.Slug)
+	}
+}
+
+// RobotsTxt renders robots.txt for all user agents, pointing crawlers at the sitemap when the
+// site URL is configured.
+func (s *SitemapService) RobotsTxt() string {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	if len(s.cfg.RobotsDisallow) == 0 {
+		b.WriteString("Disallow:\n")
+	}
+	for _, path := range s.cfg.RobotsDisallow {
+		b.WriteString("Disallow: " + path + "\n")
+	}
+	if s.cfg.SiteURL != "" {
+		b.WriteString("\nSitemap: " + s.cfg.SiteURL + "/sitemap.xml\n")
+	}
+	return b.String()
+}
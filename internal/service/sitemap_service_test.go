@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"KaldalisCMS/internal/core"
+	"KaldalisCMS/internal/core/entity"
+)
+
+// fakeSitemapRepo serves a fixed, already ordered entry list.
+type fakeSitemapRepo struct {
+	entries []entity.SitemapEntry
+	// alternates are the translation group members returned by ListPostAlternates.
+	alternates []entity.SitemapEntry
+}
+
+func (f *fakeSitemapRepo) CountEntries(ctx context.Context) (int64, error) {
+	return int64(len(f.entries)), nil
+}
+
+func (f *fakeSitemapRepo) ListEntries(ctx context.Context, offset int, limit int) ([]entity.SitemapEntry, error) {
+	if offset >= len(f.entries) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(f.entries) {
+		end = len(f.entries)
+	}
+	return f.entries[offset:end], nil
+}
+
+func (f *fakeSitemapRepo) ListPostAlternates(ctx context.Context, groupIDs []uint) ([]entity.SitemapEntry, error) {
+	var out []entity.SitemapEntry
+	for _, a := range f.alternates {
+		if a.GroupID != nil && slices.Contains(groupIDs, *a.GroupID) {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func TestSitemapService_Build_URLSet(t *testing.T) {
+	at := time.Unix(100, 0)
+	repo := &fakeSitemapRepo{entries: []entity.SitemapEntry{
+		{Kind: entity.SitemapEntryPost, Slug: "hello", LastMod: at},
+		{Kind: entity.SitemapEntryCategory, Slug: "go", LastMod: at},
+		{Kind: entity.SitemapEntryTag, Slug: "gin", LastMod: at},
+	}}
+	svc := NewSitemapService(repo, SitemapConfig{SiteURL: "https://blog.example.com/"})
+
+	got, err := svc.Build(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.IsIndex() {
+		t.Fatal("small site should not get a sitemap index")
+	}
+	want := []string{
+		"https://blog.example.com/posts/hello",
+		"https://blog.example.com/categories/go",
+		"https://blog.example.com/tags/gin",
+	}
+	if len(got.URLs) != len(want) {
+		t.Fatalf("want %d urls, got %d", len(want), len(got.URLs))
+	}
+	for i, u := range got.URLs {
+		if u.Loc != want[i] || !u.LastMod.Equal(at) {
+			t.Fatalf("url %d = %+v, want %s", i, u, want[i])
+		}
+	}
+}
+
+func TestSitemapService_Build_Alternates(t *testing.T) {
+	g1, g2 := uint(1), uint(5)
+	members := []entity.SitemapEntry{
+		{Kind: entity.SitemapEntryPost, Slug: "ni-hao", Locale: "zh-CN", GroupID: &g1},
+		{Kind: entity.SitemapEntryPost, Slug: "hello", Locale: "en", GroupID: &g1},
+		// The other member of group 5 is unpublished, so it has nothing to alternate with.
+		{Kind: entity.SitemapEntryPost, Slug: "solo", Locale: "en", GroupID: &g2},
+	}
+	repo := &fakeSitemapRepo{
+		entries:    append(members, entity.SitemapEntry{Kind: entity.SitemapEntryPost, Slug: "plain", Locale: "zh-CN"}),
+		alternates: members,
+	}
+	svc := NewSitemapService(repo, SitemapConfig{SiteURL: "https://blog.example.com"})
+
+	got, err := svc.Build(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.URLs[1].Loc != "https://blog.example.com/en/posts/hello" {
+		t.Fatalf("non-default locale should be prefixed: %s", got.URLs[1].Loc)
+	}
+	want := []entity.LocaleAlternate{
+		{Locale: "zh-CN", URL: "https://blog.example.com/posts/ni-hao"},
+		{Locale: "en", URL: "https://blog.example.com/en/posts/hello"},
+		{Locale: "x-default", URL: "https://blog.example.com/posts/ni-hao"},
+	}
+	for i := 0; i < 2; i++ {
+		if !slices.Equal(got.URLs[i].Alternates, want) {
+			t.Fatalf("url %d alternates = %+v, want %+v", i, got.URLs[i].Alternates, want)
+		}
+	}
+	if got.URLs[2].Alternates != nil || got.URLs[3].Alternates != nil {
+		t.Fatalf("untranslated posts should have no alternates: %+v %+v", got.URLs[2], got.URLs[3])
+	}
+}
+
+func TestSitemapService_Build_IndexAndPages(t *testing.T) {
+	repo := &fakeSitemapRepo{}
+	for _, s := range []string{"a", "b", "c", "d", "e"} {
+		repo.entries = append(repo.entries, entity.SitemapEntry{Kind: entity.SitemapEntryPost, Slug: s})
+	}
+	svc := NewSitemapService(repo, SitemapConfig{SiteURL: "http://localhost:8080", PageSize: 2})
+	ctx := context.Background()
+
+	index, err := svc.Build(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Pages) != 3 || index.Pages[2].Loc != "http://localhost:8080/sitemap.xml?page=3" {
+		t.Fatalf("unexpected index: %+v", index)
+	}
+
+	last, err := svc.Build(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(last.URLs) != 1 || last.URLs[0].Loc != "http://localhost:8080/posts/e" {
+		t.Fatalf("unexpected last page: %+v", last)
+	}
+
+	if _, err := svc.Build(ctx, 4); !errors.Is(err, core.ErrNotFound) {
+		t.Fatalf("want ErrNotFound past the last page, got %v", err)
+	}
+}
+
+func TestSitemapService_Build_EmptySite(t *testing.T) {
+	svc := NewSitemapService(&fakeSitemapRepo{}, SitemapConfig{})
+	got, err := svc.Build(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.IsIndex() || len(got.URLs) != 0 {
+		t.Fatalf("want empty url set, got %+v", got)
+	}
+}
+
+func TestSitemapService_RobotsTxt(t *testing.T) {
+	svc := NewSitemapService(&fakeSitemapRepo{}, SitemapConfig{SiteURL: "https://blog.example.com/", RobotsDisallow: []string{"/api/", "/admin/"}})
+	got := svc.RobotsTxt()
+	for _, want := range []string{"User-agent: *\n", "Disallow: /api/\n", "Disallow: /admin/\n", "Sitemap: https://blog.example.com/sitemap.xml\n"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("robots.txt missing %q:\n%s", want, got)
+		}
+	}
+
+	open := NewSitemapService(&fakeSitemapRepo{}, SitemapConfig{}).RobotsTxt()
+	if open != "User-agent: *\nDisallow:\n" {
+		t.Fatalf("robots.txt without a site URL must not name a sitemap:\n%s", open)
+	}
+}
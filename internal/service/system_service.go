@@ -44,6 +44,19 @@ func (s *SystemService) Status(ctx context.Context) (SystemStatus, error) {
 	return SystemStatus{Installed: set.Installed, SiteName: siteName}, nil
 }
 
+// ExportSettings returns the settings a site export carries; a site that is not installed
+// has none.
+func (s *SystemService) ExportSettings(ctx context.Context) (entity.ExportSettings, error) {
+	set, err := s.systemRepo.Get(ctx)
+	if errors.Is(err, repository.ErrSystemSettingNotFound) {
+		return entity.ExportSettings{}, nil
+	}
+	if err != nil {
+		return entity.ExportSettings{}, normalizeServiceErrorWithOpMsg("system.export_settings", "load system settings failed", err)
+	}
+	return entity.ExportSettings{SiteName: set.SiteName, InstalledAt: set.InstalledAt}, nil
+}
+
 // CheckDatabase verifies database connectivity for readiness probes.
 func (s *SystemService) CheckDatabase(ctx context.Context) error {
 	sqlDB, err := s.db.DB()
@@ -5,7 +5,10 @@ import (
 	"KaldalisCMS/internal/core/entity"
 	"context"
 	"errors"
+	"fmt"
 	"strings"
+
+	"github.com/gosimple/slug"
 )
 
 // tagService implements core.TagService.
@@ -18,7 +21,8 @@ func NewTagService(repo core.TagRepository) core.TagService {
 	return &tagService{repo: repo}
 }
 
-// Create creates a new tag.
+// Create creates a new tag. The slug is derived from the name when not provided
+// and suffixed with a counter if it is already taken.
 func (s *tagService) Create(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
 	tag.Name = strings.TrimSpace(tag.Name)
 	if tag.Name == "" {
@@ -42,6 +46,19 @@ func (s *tagService) Create(ctx context.Context, tag entity.Tag) (entity.Tag, er
 		return entity.Tag{}, normalizeServiceErrorWithOpMsg("tag.create.lookup", "check existing tag by name failed", err)
 	}
 
+	base := tag.Name
+	if strings.TrimSpace(tag.Slug) != "" {
+		base = tag.Slug
+	}
+	baseSlug := slug.Make(base)
+	if baseSlug == "" {
+		return entity.Tag{}, fmt.Errorf("%w: cannot generate a valid slug", core.ErrInvalidInput)
+	}
+	tag.Slug, err = s.uniqueSlug(ctx, baseSlug)
+	if err != nil {
+		return entity.Tag{}, err
+	}
+
 	created, err := s.repo.Create(ctx, tag)
 	if err != nil {
 		return entity.Tag{}, normalizeServiceErrorWithOpMsg("tag.create", "create tag failed", err)
@@ -49,6 +66,53 @@ func (s *tagService) Create(ctx context.Context, tag entity.Tag) (entity.Tag, er
 	return created, nil
 }
 
+func (s *tagService) uniqueSlug(ctx context.Context, base string) (string, error) {
+	candidate := base
+	for i := 1; i <= 100; i++ {
+		_, err := s.repo.GetBySlug(ctx, candidate)
+		if errors.Is(err, core.ErrNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", normalizeServiceErrorWithOpMsg("tag.unique_slug", "check tag slug uniqueness failed", err)
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+	return "", fmt.Errorf("%w: unable to generate unique tag slug", core.ErrConflict)
+}
+
+// ResolveNames returns the tags with the given names in input order, creating missing ones.
+// Names are trimmed and de-duplicated; a concurrent create of the same name is tolerated
+// by re-reading the tag that won.
+func (s *tagService) ResolveNames(ctx context.Context, names []string) ([]entity.Tag, error) {
+	tags := make([]entity.Tag, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, raw := range names {
+		name := strings.TrimSpace(raw)
+		if name == "" {
+			return nil, fmt.Errorf("%w: tag name cannot be empty", core.ErrInvalidInput)
+		}
+		key := strings.ToLower(name)
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		tag, err := s.repo.GetByName(ctx, name)
+		if errors.Is(err, core.ErrNotFound) {
+			tag, err = s.Create(ctx, entity.Tag{Name: name})
+			if errors.Is(err, core.ErrDuplicate) {
+				tag, err = s.repo.GetByName(ctx, name)
+			}
+		}
+		if err != nil {
+			return nil, normalizeServiceErrorWithOpMsg("tag.resolve_names", "resolve tag by name failed", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
 // GetAll returns all tags.
 func (s *tagService) GetAll(ctx context.Context) ([]entity.Tag, error) {
 	// No business-specific validation here; just delegate to repository.
@@ -59,6 +123,27 @@ func (s *tagService) GetAll(ctx context.Context) ([]entity.Tag, error) {
 	return tags, nil
 }
 
+// List returns all tags with their published post counts.
+func (s *tagService) List(ctx context.Context) ([]entity.TagWithPostCount, error) {
+	tags, err := s.repo.ListWithPostCounts(ctx)
+	if err != nil {
+		return nil, normalizeServiceErrorWithOpMsg("tag.list_with_counts", "list tags failed", err)
+	}
+	return tags, nil
+}
+
+// GetBySlug returns a tag by slug.
+func (s *tagService) GetBySlug(ctx context.Context, slugValue string) (entity.Tag, error) {
+	if slugValue == "" {
+		return entity.Tag{}, core.ErrInvalidInput
+	}
+	tag, err := s.repo.GetBySlug(ctx, slugValue)
+	if err != nil {
+		return entity.Tag{}, normalizeServiceErrorWithOpMsg("tag.get_by_slug", "get tag by slug failed", err)
+	}
+	return tag, nil
+}
+
 // GetByID returns a tag by ID.
 func (s *tagService) GetByID(ctx context.Context, id uint) (entity.Tag, error) {
 	if id == 0 {
@@ -84,7 +169,8 @@ func (s *tagService) GetByName(ctx context.Context, name string) (entity.Tag, er
 	return tag, nil
 }
 
-// Update updates a tag.
+// Update renames a tag and/or changes its slug. Empty fields are left unchanged;
+// renaming alone keeps the slug so existing tag URLs stay valid.
 func (s *tagService) Update(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
 	if tag.ID == 0 {
 		return entity.Tag{}, core.ErrInvalidInput
@@ -95,6 +181,19 @@ func (s *tagService) Update(ctx context.Context, tag entity.Tag) (entity.Tag, er
 			return entity.Tag{}, core.ErrInvalidInput
 		}
 	}
+	if tag.Slug != "" {
+		tag.Slug = slug.Make(tag.Slug)
+		if tag.Slug == "" {
+			return entity.Tag{}, fmt.Errorf("%w: slug cannot be empty", core.ErrInvalidInput)
+		}
+		other, err := s.repo.GetBySlug(ctx, tag.Slug)
+		switch {
+		case err == nil && other.ID != tag.ID:
+			return entity.Tag{}, fmt.Errorf("%w: slug is already in use", core.ErrDuplicate)
+		case err != nil && !errors.Is(err, core.ErrNotFound):
+			return entity.Tag{}, normalizeServiceErrorWithOpMsg("tag.update.check_slug", "check tag slug uniqueness failed", err)
+		}
+	}
 
 	updated, err := s.repo.Update(ctx, tag)
 	if err != nil {
@@ -14,6 +14,8 @@ type fakeTagRepo struct {
 	getAllFn    func(ctx context.Context) ([]entity.Tag, error)
 	getByIDFn   func(ctx context.Context, id uint) (entity.Tag, error)
 	getByNameFn func(ctx context.Context, name string) (entity.Tag, error)
+	getBySlugFn func(ctx context.Context, slug string) (entity.Tag, error)
+	listFn      func(ctx context.Context) ([]entity.TagWithPostCount, error)
 	updateFn    func(ctx context.Context, tag entity.Tag) (entity.Tag, error)
 	deleteFn    func(ctx context.Context, id uint) error
 }
@@ -28,6 +30,12 @@ func (f *fakeTagRepo) GetByID(ctx context.Context, id uint) (entity.Tag, error)
 func (f *fakeTagRepo) GetByName(ctx context.Context, name string) (entity.Tag, error) {
 	return f.getByNameFn(ctx, name)
 }
+func (f *fakeTagRepo) GetBySlug(ctx context.Context, slug string) (entity.Tag, error) {
+	return f.getBySlugFn(ctx, slug)
+}
+func (f *fakeTagRepo) ListWithPostCounts(ctx context.Context) ([]entity.TagWithPostCount, error) {
+	return f.listFn(ctx)
+}
 func (f *fakeTagRepo) Update(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
 	return f.updateFn(ctx, tag)
 }
@@ -63,6 +71,9 @@ func TestTagService_Create(t *testing.T) {
 			getByNameFn: func(ctx context.Context, name string) (entity.Tag, error) {
 				return entity.Tag{}, core.ErrNotFound
 			},
+			getBySlugFn: func(ctx context.Context, slug string) (entity.Tag, error) {
+				return entity.Tag{}, core.ErrNotFound
+			},
 			createFn: func(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
 				called = true
 				tag.ID = 1
@@ -83,6 +94,31 @@ func TestTagService_Create(t *testing.T) {
 		if got.ID != 1 {
 			t.Fatalf("want ID 1, got %d", got.ID)
 		}
+		if got.Slug != "go" {
+			t.Fatalf("want slug go, got %q", got.Slug)
+		}
+	})
+
+	t.Run("slug suffixed when taken", func(t *testing.T) {
+		repo := &fakeTagRepo{
+			getByNameFn: func(ctx context.Context, name string) (entity.Tag, error) {
+				return entity.Tag{}, core.ErrNotFound
+			},
+			getBySlugFn: func(ctx context.Context, slug string) (entity.Tag, error) {
+				if slug == "go-lang" {
+					return entity.Tag{ID: 2, Slug: slug}, nil
+				}
+				return entity.Tag{}, core.ErrNotFound
+			},
+			createFn: func(ctx context.Context, tag entity.Tag) (entity.Tag, error) { return tag, nil },
+		}
+		got, err := NewTagService(repo).Create(ctx, entity.Tag{Name: "Go Lang"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Slug != "go-lang-1" {
+			t.Fatalf("want slug go-lang-1, got %q", got.Slug)
+		}
 	})
 
 	t.Run("lookup repo error normalized", func(t *testing.T) {
@@ -203,3 +239,80 @@ func TestTagService_GetAll(t *testing.T) {
 		t.Fatalf("unexpected: %+v %v", got, err)
 	}
 }
+
+func TestTagService_Update_SlugTaken(t *testing.T) {
+	repo := &fakeTagRepo{getBySlugFn: func(ctx context.Context, slug string) (entity.Tag, error) {
+		return entity.Tag{ID: 9, Slug: slug}, nil
+	}}
+	_, err := NewTagService(repo).Update(context.Background(), entity.Tag{ID: 1, Slug: "Go"})
+	if !errors.Is(err, core.ErrDuplicate) {
+		t.Fatalf("want ErrDuplicate, got %v", err)
+	}
+}
+
+func TestTagService_ResolveNames(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reuses existing and creates missing", func(t *testing.T) {
+		var created []string
+		repo := &fakeTagRepo{
+			getByNameFn: func(ctx context.Context, name string) (entity.Tag, error) {
+				if name == "go" {
+					return entity.Tag{ID: 1, Name: "go", Slug: "go"}, nil
+				}
+				return entity.Tag{}, core.ErrNotFound
+			},
+			getBySlugFn: func(ctx context.Context, slug string) (entity.Tag, error) {
+				return entity.Tag{}, core.ErrNotFound
+			},
+			createFn: func(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
+				created = append(created, tag.Name)
+				tag.ID = 2
+				return tag, nil
+			},
+		}
+		got, err := NewTagService(repo).ResolveNames(ctx, []string{" go ", "Rust", "rust"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 || got[0].ID != 1 || got[1].ID != 2 {
+			t.Fatalf("unexpected tags: %+v", got)
+		}
+		if len(created) != 1 || created[0] != "Rust" {
+			t.Fatalf("want only Rust created, got %v", created)
+		}
+	})
+
+	t.Run("empty name rejected", func(t *testing.T) {
+		_, err := NewTagService(&fakeTagRepo{}).ResolveNames(ctx, []string{"  "})
+		if !errors.Is(err, core.ErrInvalidInput) {
+			t.Fatalf("want ErrInvalidInput, got %v", err)
+		}
+	})
+
+	t.Run("concurrent create falls back to lookup", func(t *testing.T) {
+		lookups := 0
+		repo := &fakeTagRepo{
+			getByNameFn: func(ctx context.Context, name string) (entity.Tag, error) {
+				lookups++
+				if lookups <= 2 {
+					return entity.Tag{}, core.ErrNotFound
+				}
+				return entity.Tag{ID: 5, Name: name}, nil
+			},
+			getBySlugFn: func(ctx context.Context, slug string) (entity.Tag, error) {
+				return entity.Tag{}, core.ErrNotFound
+			},
+			createFn: func(ctx context.Context, tag entity.Tag) (entity.Tag, error) {
+				return entity.Tag{}, core.ErrDuplicate
+			},
+		}
+		got, err := NewTagService(repo).ResolveNames(ctx, []string{"go"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || got[0].ID != 5 {
+			t.Fatalf("unexpected tags: %+v", got)
+		}
+	})
+}
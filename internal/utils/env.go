@@ -1,6 +1,9 @@
 package utils
 
-import "strconv"
+import (
+	"strconv"
+	"strings"
+)
 
 // ParseInt64 parses base-10 int64; returns 0 on empty/invalid.
 func ParseInt64(s string) int64 {
@@ -25,3 +28,20 @@ func ParseInt(s string) int {
 	}
 	return v
 }
+
+// ParseList splits a comma-separated value, trimming spaces and dropping empty items.
+func ParseList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// ParseBool parses a boolean such as "true" or "1"; returns false on empty/invalid.
+func ParseBool(s string) bool {
+	v, err := strconv.ParseBool(strings.TrimSpace(s))
+	return err == nil && v
+}
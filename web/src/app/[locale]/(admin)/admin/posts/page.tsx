@@ -27,7 +27,7 @@ import {
   Calendar
 } from "lucide-react";
 import { useAdminPosts, useDeletePost } from "@/services/post-service";
-import { PostStatus } from "@/lib/types";
+import { Post, PostStatus } from "@/lib/types";
 import { Input } from "@/components/ui/input";
 import { Badge } from "@/components/ui/badge";
 import { cn } from "@/lib/utils";
@@ -75,9 +75,9 @@ export default function PostsPage() {
     return d;
   };
 
-  const handleDelete = async (id: number) => {
+  const handleDelete = async (post: Post) => {
     if (!confirm("Are you sure you want to delete this post?")) return;
-    deletePostMutation.mutate(id);
+    deletePostMutation.mutate({ id: post.id, version: post.version });
   };
 
   const filteredPosts = posts.filter(post => {
@@ -228,7 +228,7 @@ export default function PostsPage() {
                                               </DropdownMenuItem>
                                           </Link>
                                           <DropdownMenuItem 
-                                            onClick={() => handleDelete(post.id)} 
+                                            onClick={() => handleDelete(post)} 
                                             disabled={deletePostMutation.isPending}
                                             className="text-accent focus:text-white focus:bg-accent cursor-pointer rounded-xl py-2.5"
                                           >
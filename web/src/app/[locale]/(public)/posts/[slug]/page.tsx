@@ -1,9 +1,8 @@
 "use client";
 
 import { useEffect, useState, use } from "react";
-import { useParams } from "next/navigation";
-import { useRouter } from "@/i18n/routing";
-import { useTranslations, useFormatter } from 'next-intl';
+import { routing, useRouter } from "@/i18n/routing";
+import { useTranslations, useFormatter, useLocale } from 'next-intl';
 import { Link } from '@/i18n/routing';
 import api from "@/lib/api";
 import { Post } from "@/lib/types";
@@ -12,11 +11,14 @@ import { Avatar, AvatarFallback, AvatarImage } from "@/components/ui/avatar";
 import { Badge } from "@/components/ui/badge";
 import { Calendar, ArrowLeft, Clock } from "lucide-react";
 
-export default function PostDetailPage({ params }: { params: Promise<{ id: string }> }) {
+// Post URLs carry the slug, as in feeds and the sitemap. The API resolves it in the page's
+// locale first, falls back to the default locale, and follows renamed slugs.
+export default function PostDetailPage({ params }: { params: Promise<{ slug: string }> }) {
   const resolvedParams = use(params);
-  const id = resolvedParams.id;
+  const slug = decodeURIComponent(resolvedParams.slug);
   
   const router = useRouter();
+  const locale = useLocale();
   const t = useTranslations();
   const format = useFormatter();
   const [post, setPost] = useState<Post | null>(null);
@@ -24,12 +26,19 @@ export default function PostDetailPage({ params }: { params: Promise<{ id: strin
   const [error, setError] = useState(false);
 
   useEffect(() => {
-    if (!id) return;
+    if (!slug) return;
 
     const fetchPost = async () => {
       try {
-        const data = await api.get<Post>(`/posts/${id}`);
-        setPost(data as unknown as Post);
+        const locales = Array.from(new Set([locale, routing.defaultLocale])).join(",");
+        const data = (await api.get<Post>(`/posts/slug/${encodeURIComponent(slug)}`, {
+          params: { locale: locales },
+        })) as unknown as Post;
+        setPost(data);
+        // An old slug is answered with the post under its current one; move the URL along.
+        if (data.slug && data.slug !== slug) {
+          router.replace(`/posts/${encodeURIComponent(data.slug)}`);
+        }
       } catch (err) {
         console.error("Failed to fetch post:", err);
         setError(true);
@@ -39,7 +48,7 @@ export default function PostDetailPage({ params }: { params: Promise<{ id: strin
     };
 
     fetchPost();
-  }, [id]);
+  }, [slug, locale, router]);
 
   if (loading) {
     return (
@@ -4,21 +4,21 @@ import { useEffect, useState } from "react";
 import { useTranslations, useFormatter } from 'next-intl';
 import { Link } from '@/i18n/routing';
 import api from "@/lib/api";
-import { Post } from "@/lib/types";
+import { PostListResponse, PostSummary } from "@/lib/types";
 import { Calendar, ArrowRight, Image as ImageIcon } from "lucide-react";
 import { motion } from "framer-motion";
 
 export default function PostsPage() {
   const t = useTranslations('posts');
   const format = useFormatter();
-  const [posts, setPosts] = useState<Post[]>([]);
+  const [posts, setPosts] = useState<PostSummary[]>([]);
   const [loading, setLoading] = useState(true);
 
   useEffect(() => {
     const fetchPosts = async () => {
       try {
-        const data = await api.get<Post[]>("/posts");
-        setPosts(data as unknown as Post[]);
+        const data = await api.get<PostListResponse>("/posts");
+        setPosts((data as unknown as PostListResponse).items);
       } catch (error) {
         console.error("Failed to fetch posts:", error);
       } finally {
@@ -70,7 +70,7 @@ export default function PostsPage() {
                 transition={{ duration: 0.4, delay: index * 0.05 }}
               >
                 <Link
-                  href={`/posts/${post.id}`}
+                  href={`/posts/${encodeURIComponent(post.slug)}`}
                   className="group flex gap-6 py-6 border-b border-border/50 hover:bg-muted/30 -mx-4 px-4 rounded-xl transition-colors"
                 >
                   {/* Cover */}
@@ -100,7 +100,7 @@ export default function PostsPage() {
                       <span>{post.author?.username || "Admin"}</span>
                     </div>
                     <p className="text-muted-foreground text-sm line-clamp-2 hidden md:block">
-                      {post.content?.slice(0, 150) || "No preview available..."}
+                      {post.excerpt || "No preview available..."}
                     </p>
                   </div>
 
@@ -48,6 +48,8 @@ export function PostEditor({ initialData, mode }: PostEditorProps) {
     ),
     excerpt: "",
   });
+  // Version the next write is conditioned on; each successful write bumps it by one.
+  const [version, setVersion] = useState(initialData?.version ?? 0);
 
   const createPost = useCreatePost();
   const updatePost = useUpdatePost(initialData?.id || 0);
@@ -98,7 +100,7 @@ export function PostEditor({ initialData, mode }: PostEditorProps) {
             if (newPost?.id) {
               // If the user clicked "Publish" on a new post, publish it after creation
               if (finalStatus === PostStatus.PUBLISHED) {
-                publishPost.mutate(newPost.id, {
+                publishPost.mutate({ id: newPost.id, version: newPost.version }, {
                   onSuccess: () => {
                     router.push(`/admin/posts/${newPost.id}/edit`);
                   },
@@ -114,18 +116,22 @@ export function PostEditor({ initialData, mode }: PostEditorProps) {
       } else {
         const postId = initialData?.id || 0;
         // Always save content changes first
-        updatePost.mutate(submissionData, {
+        updatePost.mutate({ data: submissionData, version }, {
           onSuccess: () => {
+            const saved = version + 1;
+            setVersion(saved);
             // Then handle status transitions via dedicated endpoints
             if (finalStatus === PostStatus.PUBLISHED && formData.status !== PostStatus.PUBLISHED) {
-              publishPost.mutate(postId, {
+              publishPost.mutate({ id: postId, version: saved }, {
                 onSuccess: () => {
+                  setVersion(saved + 1);
                   setFormData((prev) => ({ ...prev, status: PostStatus.PUBLISHED }));
                 },
               });
             } else if (finalStatus === PostStatus.DRAFT && formData.status === PostStatus.PUBLISHED) {
-              draftPost.mutate(postId, {
+              draftPost.mutate({ id: postId, version: saved }, {
                 onSuccess: () => {
+                  setVersion(saved + 1);
                   setFormData((prev) => ({ ...prev, status: PostStatus.DRAFT }));
                 },
               });
@@ -134,7 +140,7 @@ export function PostEditor({ initialData, mode }: PostEditorProps) {
         });
       }
     },
-    [formData, mode, createPost, updatePost, publishPost, draftPost, router, initialData?.id]
+    [formData, version, mode, createPost, updatePost, publishPost, draftPost, router, initialData?.id]
   );
 
   const handleCoverUpload = (e: React.ChangeEvent<HTMLInputElement>) => {
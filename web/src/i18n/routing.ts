@@ -6,7 +6,10 @@ export const routing = defineRouting({
   locales: ['zh-CN', 'en'],
  
   // Used when no locale matches
-  defaultLocale: 'zh-CN'
+  defaultLocale: 'zh-CN',
+
+  // The default locale is served without a prefix; feed and sitemap links rely on this
+  localePrefix: 'as-needed'
 });
  
 // Lightweight wrappers around Next.js' navigation APIs
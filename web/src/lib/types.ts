@@ -40,10 +40,26 @@ export interface Post {
   category_id?: number;
   category?: Category;
   tags?: Tag[];
+  // Optimistic-lock version; admin writes send it back as `If-Match: "v{version}"`.
+  version: number;
   created_at: string;
   updated_at: string;
 }
 
+// A post as returned by listings: a plain-text excerpt instead of the full content.
+export interface PostSummary extends Omit<Post, 'content'> {
+  excerpt: string;
+  locale: string;
+}
+
+// One page of the public post list (`GET /posts`).
+export interface PostListResponse {
+  items: PostSummary[];
+  total: number;
+  page: number;
+  page_size: number;
+}
+
 export interface CreatePostDTO {
   title: string;
   content: string;
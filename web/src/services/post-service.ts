@@ -1,5 +1,5 @@
 import api from "@/lib/api";
-import { Post, CreatePostDTO, UpdatePostDTO } from "@/lib/types";
+import { Post, PostListResponse, CreatePostDTO, UpdatePostDTO } from "@/lib/types";
 import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 import { toast } from "sonner";
 
@@ -15,31 +15,46 @@ export const postKeys = {
   adminDetail: (id: string | number) => ["admin-post", id] as const,
 };
 
+/**
+ * A post as seen at a given version. Admin writes are conditional: the server answers 428
+ * without If-Match and 409 when the post changed after that version was read.
+ */
+export interface PostVersionRef {
+  id: number;
+  version: number;
+}
+
+const ifMatch = (version: number) => ({ headers: { "If-Match": `"v${version}"` } });
+
 // ============================================================================
 // Public Hooks - For guest/public access (published posts only)
 // ============================================================================
 
 /**
- * Fetch published posts for public display
+ * Fetch the first page of published posts for public display
  */
 export const usePublicPosts = () => {
   return useQuery({
     queryKey: postKeys.publicAll,
     queryFn: async () => {
       const response = await api.get("/posts");
-      return response as unknown as Post[];
+      return response as unknown as PostListResponse;
     },
   });
 };
 
 /**
- * Fetch a single published post by ID or slug
+ * Fetch a single published post by ID or slug. `/posts/:id` only accepts numeric IDs;
+ * slugs go through `/posts/slug/:slug`, which also resolves renamed slugs.
  */
 export const usePublicPost = (idOrSlug: string | number) => {
   return useQuery({
     queryKey: postKeys.publicDetail(idOrSlug),
     queryFn: async () => {
-      const response = await api.get(`/posts/${idOrSlug}`);
+      const path = typeof idOrSlug === "number"
+        ? `/posts/${idOrSlug}`
+        : `/posts/slug/${encodeURIComponent(idOrSlug)}`;
+      const response = await api.get(path);
       return response as unknown as Post;
     },
     enabled: !!idOrSlug,
@@ -95,14 +110,13 @@ export const useCreatePost = () => {
 };
 
 /**
- * Update an existing post
+ * Update an existing post. A successful write bumps the post version by one.
  */
 export const useUpdatePost = (id: number) => {
   const queryClient = useQueryClient();
   return useMutation({
-    mutationFn: async (data: UpdatePostDTO) => {
-      const response = await api.put(`/admin/posts/${id}`, data);
-      return response as unknown as Post;
+    mutationFn: async ({ data, version }: { data: UpdatePostDTO; version: number }) => {
+      return await api.put(`/admin/posts/${id}`, data, ifMatch(version));
     },
     onSuccess: () => {
       queryClient.invalidateQueries({ queryKey: postKeys.adminAll });
@@ -114,13 +128,13 @@ export const useUpdatePost = (id: number) => {
 };
 
 /**
- * Delete a post
+ * Move a post to the trash
  */
 export const useDeletePost = () => {
   const queryClient = useQueryClient();
   return useMutation({
-    mutationFn: async (id: number) => {
-      return await api.delete(`/admin/posts/${id}`);
+    mutationFn: async ({ id, version }: PostVersionRef) => {
+      return await api.delete(`/admin/posts/${id}`, ifMatch(version));
     },
     onSuccess: () => {
       queryClient.invalidateQueries({ queryKey: postKeys.adminAll });
@@ -131,15 +145,15 @@ export const useDeletePost = () => {
 };
 
 /**
- * Publish a draft post
+ * Publish a draft post. A successful write bumps the post version by one.
  */
 export const usePublishPost = () => {
   const queryClient = useQueryClient();
   return useMutation({
-    mutationFn: async (id: number) => {
-      return await api.post(`/admin/posts/${id}/publish`);
+    mutationFn: async ({ id, version }: PostVersionRef) => {
+      return await api.post(`/admin/posts/${id}/publish`, undefined, ifMatch(version));
     },
-    onSuccess: (_, id) => {
+    onSuccess: (_, { id }) => {
       queryClient.invalidateQueries({ queryKey: postKeys.adminAll });
       queryClient.invalidateQueries({ queryKey: postKeys.adminDetail(id) });
       queryClient.invalidateQueries({ queryKey: postKeys.publicAll });
@@ -149,15 +163,15 @@ export const usePublishPost = () => {
 };
 
 /**
- * Move a published post back to draft
+ * Move a published post back to draft. A successful write bumps the post version by one.
  */
 export const useDraftPost = () => {
   const queryClient = useQueryClient();
   return useMutation({
-    mutationFn: async (id: number) => {
-      return await api.post(`/admin/posts/${id}/draft`);
+    mutationFn: async ({ id, version }: PostVersionRef) => {
+      return await api.post(`/admin/posts/${id}/draft`, undefined, ifMatch(version));
     },
-    onSuccess: (_, id) => {
+    onSuccess: (_, { id }) => {
       queryClient.invalidateQueries({ queryKey: postKeys.adminAll });
       queryClient.invalidateQueries({ queryKey: postKeys.adminDetail(id) });
       queryClient.invalidateQueries({ queryKey: postKeys.publicAll });